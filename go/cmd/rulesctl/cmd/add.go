@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -22,6 +23,12 @@ var (
 	addOptQueryRE           string
 	addOptLeadingCommentRE  string
 	addOptTrailingCommentRE string
+	addOptQueryDigest       string
+	addOptCommentTags       map[string]string
+	addOptMinEstimatedRows  int64
+	addOptMaxQPS            float64
+	addOptMaxConcurrency    int
+	addOptDelay             time.Duration
 	// TODO: other stuff, bind vars etc
 )
 
@@ -30,6 +37,7 @@ func runAdd(cmd *cobra.Command, args []string) {
 	ruleAction := mkAction()
 
 	rule := vtrules.NewQueryRule(addOptDescription, addOptName, ruleAction)
+	setActionOptions(rule)
 	for _, pt := range rulePlans {
 		rule.AddPlanCond(pt)
 	}
@@ -53,6 +61,19 @@ func runAdd(cmd *cobra.Command, args []string) {
 			log.Fatalf("Trailing comment condition invalid '%v': %v", addOptTrailingCommentRE, err)
 		}
 	}
+	if addOptQueryDigest != "" {
+		if err := rule.SetQueryDigestCond(addOptQueryDigest); err != nil {
+			log.Fatalf("Query digest condition invalid '%v': %v", addOptQueryDigest, err)
+		}
+	}
+	for k, v := range addOptCommentTags {
+		rule.AddCommentTagCond(k, v)
+	}
+	if addOptMinEstimatedRows != 0 {
+		if err := rule.SetMinEstimatedRowsCond(addOptMinEstimatedRows); err != nil {
+			log.Fatalf("Min estimated rows condition invalid '%v': %v", addOptMinEstimatedRows, err)
+		}
+	}
 
 	var rules *vtrules.Rules
 	_, err := os.Stat(configFile)
@@ -105,6 +126,12 @@ func mkAction() vtrules.Action {
 		return vtrules.QRFailRetry
 	case "continue":
 		return vtrules.QRContinue
+	case "throttle":
+		return vtrules.QRThrottle
+	case "delay":
+		return vtrules.QRDelay
+	case "log_only":
+		return vtrules.QRLogOnly
 	default:
 		log.Fatalf("Unknown action '%v'", addOptAction)
 	}
@@ -112,6 +139,32 @@ func mkAction() vtrules.Action {
 	panic("Nope")
 }
 
+func setActionOptions(rule *vtrules.Rule) {
+	switch rule.Action() {
+	case vtrules.QRThrottle:
+		if addOptDelay != 0 {
+			log.Fatalf("--delay can only be used with the delay action")
+		}
+		if err := rule.SetThrottle(addOptMaxQPS, addOptMaxConcurrency); err != nil {
+			log.Fatalf("Throttle invalid: %v", err)
+		}
+	case vtrules.QRDelay:
+		if addOptMaxQPS != 0 || addOptMaxConcurrency != 0 {
+			log.Fatalf("--max-qps and --max-concurrency can only be used with the throttle action")
+		}
+		if err := rule.SetDelay(addOptDelay); err != nil {
+			log.Fatalf("Delay invalid: %v", err)
+		}
+	default:
+		if addOptMaxQPS != 0 || addOptMaxConcurrency != 0 {
+			log.Fatalf("--max-qps and --max-concurrency can only be used with the throttle action")
+		}
+		if addOptDelay != 0 {
+			log.Fatalf("--delay can only be used with the delay action")
+		}
+	}
+}
+
 func Add() *cobra.Command {
 	addCmd := &cobra.Command{
 		Use:   "add-rule",
@@ -139,7 +192,7 @@ func Add() *cobra.Command {
 		&addOptAction,
 		"action", "a",
 		"",
-		"What action should be taken when this rule is matched {continue, fail, fail-retry} (required)")
+		"What action should be taken when this rule is matched {continue, fail, fail_retry, throttle, delay, log_only}; see \"explain actions\" for details (required)")
 	addCmd.Flags().StringSliceVarP(
 		&addOptPlans,
 		"plan", "p",
@@ -165,6 +218,36 @@ func Add() *cobra.Command {
 		"trailing-comment", "r",
 		"",
 		"A regexp that will be applied to comments after a SQL statement")
+	addCmd.Flags().StringVar(
+		&addOptQueryDigest,
+		"query-digest",
+		"",
+		"The digest of the normalized query, as shown in the Digest column of queryz")
+	addCmd.Flags().StringToStringVar(
+		&addOptCommentTags,
+		"comment-tag",
+		nil,
+		"A key=value tag that the sqlcommenter comments of a query must have, e.g. route=/orders; may be specified multiple times")
+	addCmd.Flags().Int64Var(
+		&addOptMinEstimatedRows,
+		"min-estimated-rows",
+		0,
+		"Queries will only match if MySQL estimates that they examine at least this many rows, according to their EXPLAIN")
+	addCmd.Flags().Float64Var(
+		&addOptMaxQPS,
+		"max-qps",
+		0,
+		"The maximum rate of the queries that match a throttle rule, in queries per second")
+	addCmd.Flags().IntVar(
+		&addOptMaxConcurrency,
+		"max-concurrency",
+		0,
+		"The maximum number of concurrent queries that match a throttle rule")
+	addCmd.Flags().DurationVar(
+		&addOptDelay,
+		"delay",
+		0,
+		"How long the queries that match a delay rule are delayed")
 
 	for _, f := range []string{"name", "action"} {
 		addCmd.MarkFlagRequired(f)
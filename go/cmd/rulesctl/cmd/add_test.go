@@ -131,3 +131,143 @@ func TestAdd(t *testing.T) {
 		})
 	}
 }
+
+func TestAddThrottleAndDelay(t *testing.T) {
+	configFile = "./testdata/rules.json"
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedOutput string
+	}{
+		{
+			name: "Action throttle",
+			args: []string{"--dry-run=true", "--name=Rule", "--action=throttle", "--max-qps=10", "--max-concurrency=2", "--table=Temp"},
+			expectedOutput: `[
+  {
+    "Description": "Some value",
+    "Name": "Name",
+    "Action": "FAIL"
+  },
+  {
+    "Description": "",
+    "Name": "Rule",
+    "TableNames": [
+      "Temp"
+    ],
+    "Action": "THROTTLE",
+    "MaxQPS": 10,
+    "MaxConcurrency": 2
+  }
+]
+`,
+		},
+		{
+			name: "Action delay",
+			args: []string{"--dry-run=true", "--name=Rule", "--action=delay", "--delay=1.5s", "--table=Temp"},
+			expectedOutput: `[
+  {
+    "Description": "Some value",
+    "Name": "Name",
+    "Action": "FAIL"
+  },
+  {
+    "Description": "",
+    "Name": "Rule",
+    "TableNames": [
+      "Temp"
+    ],
+    "Action": "DELAY",
+    "Delay": "1.5s"
+  }
+]
+`,
+		},
+		{
+			name: "Action log_only",
+			args: []string{"--dry-run=true", "--name=Rule", "--action=log_only", "--table=Temp"},
+			expectedOutput: `[
+  {
+    "Description": "Some value",
+    "Name": "Name",
+    "Action": "FAIL"
+  },
+  {
+    "Description": "",
+    "Name": "Rule",
+    "TableNames": [
+      "Temp"
+    ],
+    "Action": "LOG_ONLY"
+  }
+]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each command starts from the default values of the flags.
+			cmd := Add()
+			cmd.SetArgs(tt.args)
+
+			originalStdOut := os.Stdout
+			defer func() {
+				os.Stdout = originalStdOut
+			}()
+			// Redirect stdout to a buffer
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+
+			err = w.Close()
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.EqualValues(t, tt.expectedOutput, string(got))
+		})
+	}
+}
+
+func TestAddQueryProperties(t *testing.T) {
+	configFile = "./testdata/rules.json"
+
+	cmd := Add()
+	cmd.SetArgs([]string{"--dry-run=true", "--name=Rule", "--action=fail", "--query-digest=0123456789abcdef", "--comment-tag=application=shop,route=/orders", "--min-estimated-rows=1000"})
+
+	originalStdOut := os.Stdout
+	defer func() {
+		os.Stdout = originalStdOut
+	}()
+	// Redirect stdout to a buffer
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	err = w.Close()
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.EqualValues(t, `[
+  {
+    "Description": "Some value",
+    "Name": "Name",
+    "Action": "FAIL"
+  },
+  {
+    "Description": "",
+    "Name": "Rule",
+    "QueryDigest": "0123456789abcdef",
+    "CommentTags": {
+      "application": "shop",
+      "route": "/orders"
+    },
+    "MinEstimatedRows": 1000,
+    "Action": "FAIL"
+  }
+]
+`, string(got))
+}
@@ -11,7 +11,7 @@ import (
 func Explain() *cobra.Command {
 	explain := &cobra.Command{
 		Use:   "explain [concept]",
-		Short: "Explains a concept, valid options are: query-plans",
+		Short: "Explains a concept, valid options are: query-plans, actions",
 		Args:  cobra.ExactArgs(1),
 		Run:   runExplain,
 	}
@@ -21,6 +21,7 @@ func Explain() *cobra.Command {
 func runExplain(cmd *cobra.Command, args []string) {
 	lookup := map[string]func(){
 		"query-plans": helpQueryPlans,
+		"actions":     helpActions,
 	}
 
 	if fn, ok := lookup[args[0]]; ok {
@@ -44,3 +45,25 @@ The list of valid plan types that can be used follows:
 		fmt.Printf("  - %v\n", planbuilder.PlanType(i).String())
 	}
 }
+
+func helpActions() {
+	fmt.Printf(`Actions!
+
+The action of a rule is what the Tablet does with the queries that match the
+rule. The rules are evaluated in order, and the first rule that matches a query
+decides what happens to it, except for the log_only rules.
+
+The list of valid actions follows:
+  - continue: the query runs as if the rule did not exist
+  - fail: the query fails
+  - fail_retry: the query fails with an error that tells the client to retry it
+  - throttle: the queries are limited to --max-qps queries per second, and to
+    --max-concurrency concurrent queries; the queries wait until they are
+    allowed to run, and fail if their deadline expires first
+  - delay: the query waits for --delay before it runs
+  - log_only: the query is logged, and the next rules are evaluated
+
+The number of queries that matched each rule is exported in the QueryRuleHits
+counter of the Tablet.
+`)
+}
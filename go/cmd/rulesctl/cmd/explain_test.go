@@ -75,3 +75,26 @@ func TestExplainWithRandomArguement(t *testing.T) {
 	expected := "I don't know anything about"
 	require.Contains(t, string(got), expected)
 }
+
+func TestExplainWithActionsArgument(t *testing.T) {
+	explainCmd := Explain()
+
+	originalStdOut := os.Stdout
+	defer func() {
+		os.Stdout = originalStdOut
+	}()
+	// Redirect stdout to a buffer
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	explainCmd.Run(&cobra.Command{}, []string{"actions"})
+
+	err := w.Close()
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	for _, expected := range []string{"Actions!", "throttle", "delay", "log_only"} {
+		require.Contains(t, string(got), expected)
+	}
+}
@@ -18,16 +18,24 @@ package lookupvindex
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	topoprotopb "vitess.io/vitess/go/vt/topo/topoproto"
 )
 
@@ -83,6 +91,15 @@ var (
 		Keyspace string
 	}{}
 
+	verifyOptions = struct {
+		Keyspace            string
+		TabletTypes         []topodatapb.TabletType
+		MaxReportSampleRows int64
+		OnlyPKs             bool
+		Wait                bool
+		WaitUpdateInterval  time.Duration
+	}{}
+
 	parseAndValidateCreate = func(cmd *cobra.Command, args []string) error {
 		if createOptions.TableName == "" { // Use vindex name
 			createOptions.TableName = baseOptions.Name
@@ -200,6 +217,19 @@ var (
 		RunE:                  commandInternalize,
 	}
 
+	// repair makes a VDiffCreate call to a vtctld, which repairs the differences
+	// that are found.
+	repair = &cobra.Command{
+		Use:                   "repair",
+		Short:                 "Compare the lookup table to the owner table using a VDiff, and fix the lookup table rows that are missing, extra or mismatched. The Vindex must have been externalized and have an owner.",
+		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer repair`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Repair"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandRepair,
+	}
+
 	// show makes a GetWorkflows call to a vtctld.
 	show = &cobra.Command{
 		Use:                   "show",
@@ -211,6 +241,18 @@ var (
 		Args:                  cobra.NoArgs,
 		RunE:                  commandShow,
 	}
+
+	// verify makes a VDiffCreate call to a vtctld.
+	verify = &cobra.Command{
+		Use:                   "verify",
+		Short:                 "Compare the lookup table to the owner table using a VDiff, and report the lookup table rows that are missing, extra or mismatched. The Vindex must have been externalized and have an owner.",
+		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer verify`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Verify"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandVerify,
+	}
 )
 
 func commandCancel(cmd *cobra.Command, args []string) error {
@@ -358,6 +400,85 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandRepair(cmd *cobra.Command, args []string) error {
+	return runVDiff(cmd, true)
+}
+
+func commandVerify(cmd *cobra.Command, args []string) error {
+	return runVDiff(cmd, false)
+}
+
+// runVDiff runs a VDiff of the Lookup Vindex, which compares the lookup table to
+// what its Vindex definition generates from the owner table, and prints its
+// summary. The VDiff does not use the VReplication workflow that backfilled the
+// lookup table, as it is frozen or deleted once the Vindex is externalized.
+func runVDiff(cmd *cobra.Command, repair bool) error {
+	tsp := common.GetTabletSelectionPreference(cmd)
+	if verifyOptions.Keyspace == "" {
+		verifyOptions.Keyspace = baseOptions.TableKeyspace
+	}
+	cli.FinishedParsing(cmd)
+
+	ctx := common.GetCommandCtx()
+	client := common.GetClient()
+	uuidStr := uuid.New().String()
+	resp, err := client.VDiffCreate(ctx, &vtctldatapb.VDiffCreateRequest{
+		Workflow:                    baseOptions.Name,
+		TargetKeyspace:              baseOptions.TableKeyspace,
+		Uuid:                        uuidStr,
+		TabletTypes:                 verifyOptions.TabletTypes,
+		TabletSelectionPreference:   tsp,
+		Limit:                       math.MaxInt64,
+		FilteredReplicationWaitTime: protoutil.DurationToProto(workflow.DefaultTimeout),
+		OnlyPKs:                     verifyOptions.OnlyPKs,
+		MaxReportSampleRows:         verifyOptions.MaxReportSampleRows,
+		WaitUpdateInterval:          protoutil.DurationToProto(verifyOptions.WaitUpdateInterval),
+		AutoRetry:                   true,
+		Repair:                      repair,
+		LookupVindexKeyspace:        verifyOptions.Keyspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !verifyOptions.Wait {
+		fmt.Printf("VDiff %s scheduled for the %s Lookup Vindex, use `vtctldclient VDiff --workflow %s --target-keyspace %s show %s` to view the report\n",
+			resp.UUID, baseOptions.Name, baseOptions.Name, baseOptions.TableKeyspace, resp.UUID)
+		return nil
+	}
+
+	tkr := time.NewTicker(verifyOptions.WaitUpdateInterval)
+	defer tkr.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
+		case <-tkr.C:
+			showResp, err := client.VDiffShow(ctx, &vtctldatapb.VDiffShowRequest{
+				Workflow:       baseOptions.Name,
+				TargetKeyspace: baseOptions.TableKeyspace,
+				Arg:            uuidStr,
+			})
+			if err != nil {
+				return err
+			}
+			summary, err := workflow.BuildSummary(baseOptions.TableKeyspace, baseOptions.Name, uuidStr, showResp, true)
+			if err != nil {
+				return err
+			}
+			if summary == nil || summary.State != vdiff.CompletedState {
+				continue
+			}
+			data, err := cli.MarshalJSONPretty(summary)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", data)
+			return nil
+		}
+	}
+}
+
 func registerCommands(root *cobra.Command) {
 	base.PersistentFlags().StringVar(&baseOptions.Name, "name", "", "The name of the Lookup Vindex to create. This will also be the name of the VReplication workflow created to backfill the Lookup Vindex.")
 	base.MarkPersistentFlagRequired("name")
@@ -406,6 +527,19 @@ func registerCommands(root *cobra.Command) {
 	// to backfill the lookup vindex. It ends up making a
 	// WorkflowDelete VtctldServer call.
 	base.AddCommand(cancel)
+
+	// The verify and repair commands run a VDiff of the lookup table
+	// against the owner table of the externalized lookup vindex. The
+	// repair command also fixes the rows of the lookup table that differ.
+	for _, cmd := range []*cobra.Command{verify, repair} {
+		cmd.Flags().StringVar(&verifyOptions.Keyspace, "keyspace", "", "The keyspace containing the Lookup Vindex. If no value is specified then the table-keyspace will be used.")
+		cmd.Flags().Var((*topoprotopb.TabletTypeListFlag)(&verifyOptions.TabletTypes), "tablet-types", "Tablet types to use on the source and target.")
+		cmd.Flags().Int64Var(&verifyOptions.MaxReportSampleRows, "max-report-sample-rows", 10, "Maximum number of row differences to report (0 for all differences).")
+		cmd.Flags().BoolVar(&verifyOptions.OnlyPKs, "only-pks", false, "When reporting row differences, only show primary keys in the report.")
+		cmd.Flags().BoolVar(&verifyOptions.Wait, "wait", true, "Wait for the VDiff to finish and show its report before exiting.")
+		cmd.Flags().DurationVar(&verifyOptions.WaitUpdateInterval, "wait-update-interval", 10*time.Second, "When waiting on the VDiff to finish, check its status this often.")
+		base.AddCommand(cmd)
+	}
 }
 
 func init() {
@@ -404,6 +404,7 @@ type tableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
+	RepairedRows    int64  `json:"RepairedRows,omitempty"`
 	LastUpdated     string `json:"LastUpdated,omitempty"`
 }
 
@@ -446,6 +447,7 @@ Table {{$table.TableName}}:
 {{if $table.MismatchedRows}}	MismatchedRows:   {{$table.MismatchedRows}}{{end}}
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
+{{if $table.RepairedRows}}	RepairedRows:     {{$table.RepairedRows}}{{end}}
 {{end}}
  
 Use "--format=json" for more detailed output.
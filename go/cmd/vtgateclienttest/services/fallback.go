@@ -26,6 +26,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 // fallbackClient implements vtgateservice.VTGateService, and always passes
@@ -67,6 +68,10 @@ func (c fallbackClient) CloseSession(ctx context.Context, session *vtgatepb.Sess
 	return c.fallback.CloseSession(ctx, session)
 }
 
+func (c fallbackClient) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	return c.fallback.MessageAck(ctx, keyspace, name, ids, visibilityTimeout)
+}
+
 func (c fallbackClient) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
 	return c.fallback.VStream(ctx, tabletType, vgtid, filter, flags, send)
 }
@@ -31,6 +31,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 var errTerminal = errors.New("vtgate test client, errTerminal")
@@ -78,6 +79,10 @@ func (c *terminalClient) CloseSession(ctx context.Context, session *vtgatepb.Ses
 	return errTerminal
 }
 
+func (c *terminalClient) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	return 0, errTerminal
+}
+
 func (c *terminalClient) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
 	return errTerminal
 }
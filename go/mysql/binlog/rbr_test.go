@@ -21,6 +21,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/geometry"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
@@ -534,6 +537,16 @@ func TestCellLengthAndData(t *testing.T) {
 		data:     []byte{0x3, 0x00, 0x00, 0x00, 'a', 'b', 'c'},
 		out: sqltypes.MakeTrusted(querypb.Type_GEOMETRY,
 			[]byte("abc")),
+	}, {
+		// POINT(1 -1) in SRID 0, in MySQL's internal geometry format
+		typ:      TypeGeometry,
+		metadata: 4,
+		data: []byte{0x19, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0xbf},
+		out: sqltypes.MakeTrusted(querypb.Type_GEOMETRY,
+			[]byte("\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\xbf")),
 	}}
 
 	for _, tcase := range testcases {
@@ -557,3 +570,24 @@ func TestCellLengthAndData(t *testing.T) {
 		}
 	}
 }
+
+// TestCellValueGeographicGeometry checks that a geometry in WGS 84 is decoded in the
+// format that MySQL stores it in, with the longitude first, so that it's evaluated
+// the same way as a value read from the column.
+func TestCellValueGeographicGeometry(t *testing.T) {
+	// POINT(40.7501 -73.9949) in SRID 4326, which has a latitude of 40.7501
+	internal := []byte("\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x96\xb2\x0c\x71\xac\x7f\x52\xc0\x64\x5d\xdc\x46\x03\x60\x44\x40")
+	data := append([]byte{byte(len(internal)), 0x00, 0x00, 0x00}, internal...)
+
+	out, l, err := CellValue(data, 0, TypeGeometry, 4, &querypb.Field{Type: querypb.Type_GEOMETRY}, false)
+	require.NoError(t, err)
+	require.Equal(t, len(data), l)
+	require.Equal(t, sqltypes.MakeTrusted(querypb.Type_GEOMETRY, internal), out)
+
+	g, err := geometry.ParseInternal(out.Raw())
+	require.NoError(t, err)
+	require.EqualValues(t, geometry.WGS84, g.SRID)
+	require.Equal(t, []geometry.Coord{{X: -73.9949, Y: 40.7501}}, g.Points)
+	g.SwapXY()
+	require.Equal(t, "POINT(40.7501 -73.9949)", g.String())
+}
@@ -0,0 +1,465 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import "time"
+
+var (
+	longMonthNames = []string{
+		"January",
+		"February",
+		"March",
+		"April",
+		"May",
+		"June",
+		"July",
+		"August",
+		"September",
+		"October",
+		"November",
+		"December",
+	}
+
+	// longDayNames and shortDayNamesMonday start on Monday, which is how
+	// MySQL numbers the weekdays when parsing.
+	longDayNames = []string{
+		"Monday",
+		"Tuesday",
+		"Wednesday",
+		"Thursday",
+		"Friday",
+		"Saturday",
+		"Sunday",
+	}
+
+	shortDayNamesMonday = []string{
+		"Mon",
+		"Tue",
+		"Wed",
+		"Thu",
+		"Fri",
+		"Sat",
+		"Sun",
+	}
+)
+
+// StrToDateFormat describes the kind of temporal value that STR_TO_DATE returns for a format string.
+type StrToDateFormat struct {
+	Date bool
+	Time bool
+	Frac bool
+}
+
+// ParseStrToDateFormat returns which kind of temporal value STR_TO_DATE returns for
+// the format p: a date, a time or a datetime, with or without fractional seconds.
+func ParseStrToDateFormat(p string) (f StrToDateFormat) {
+	for i := 0; i < len(p)-1; i++ {
+		if p[i] != '%' {
+			continue
+		}
+		i++
+		switch p[i] {
+		case 'd', 'e', 'c', 'm', 'y', 'Y', 'a', 'b', 'D', 'j', 'M', 'U', 'u', 'V', 'v', 'W', 'w', 'X', 'x':
+			f.Date = true
+		case 'f':
+			f.Frac = true
+			f.Time = true
+		case 'H', 'h', 'I', 'i', 'k', 'l', 'p', 'r', 's', 'S', 'T':
+			f.Time = true
+		}
+	}
+	return
+}
+
+type strToDateParser struct {
+	year, month, day int
+	hour, min, sec   int
+	usec             int
+
+	weekday, yearday, daypart int
+	week                      int
+	weekYear                  int
+	usaTime                   bool
+	sundayFirst               bool
+	strictWeek                bool
+	strictWeekYearType        bool
+}
+
+// StrToDate parses s using the MySQL format string p, the same way MySQL's STR_TO_DATE does.
+// Dates with zero parts are only accepted when allowZero is set. Trailing characters in s that
+// are not matched by the format are ignored.
+func StrToDate(p, s string, allowZero bool) (DateTime, bool) {
+	var sp strToDateParser
+	if !sp.parseDate(p, s) {
+		return DateTime{}, false
+	}
+
+	nonZero := sp.year != 0 || sp.month != 0 || sp.day != 0
+	switch {
+	case nonZero && !allowZero && (sp.month == 0 || sp.day == 0):
+		return DateTime{}, false
+	case nonZero && sp.month != 0 && sp.day > daysIn(time.Month(sp.month), sp.year):
+		return DateTime{}, false
+	case !nonZero && !allowZero:
+		return DateTime{}, false
+	}
+	return sp.dateTime(), true
+}
+
+// StrToTime parses s using the MySQL format string p, the same way MySQL's STR_TO_DATE does
+// when the format only contains time specifiers. Any days in s are added to the hours.
+func StrToTime(p, s string) (Time, bool) {
+	var sp strToDateParser
+	if !sp.parseDate(p, s) {
+		return Time{}, false
+	}
+	if sp.year != 0 || sp.month != 0 || sp.day != 0 {
+		if sp.month == 0 || sp.day == 0 || sp.day > daysIn(time.Month(sp.month), sp.year) {
+			return Time{}, false
+		}
+	}
+	return Time{
+		hour:       uint16(sp.hour + sp.day*24),
+		minute:     uint8(sp.min),
+		second:     uint8(sp.sec),
+		nanosecond: uint32(sp.usec * 1000),
+	}, true
+}
+
+func (sp *strToDateParser) dateTime() DateTime {
+	return DateTime{
+		Date: Date{
+			year:  uint16(sp.year),
+			month: uint8(sp.month),
+			day:   uint8(sp.day),
+		},
+		Time: Time{
+			hour:       uint16(sp.hour),
+			minute:     uint8(sp.min),
+			second:     uint8(sp.sec),
+			nanosecond: uint32(sp.usec * 1000),
+		},
+	}
+}
+
+func (sp *strToDateParser) parseDate(p, s string) bool {
+	sp.week = -1
+	sp.weekYear = -1
+
+	if _, ok := sp.parse(p, s); !ok {
+		return false
+	}
+
+	if sp.yearday > 0 {
+		if !sp.setFromDayNumber(MysqlDayNumber(sp.year, 1, 1) + sp.yearday - 1) {
+			return false
+		}
+	}
+
+	if sp.week >= 0 && sp.weekday != 0 {
+		// %V and %v require %X and %x respectively, while %U and %u cannot be used with them
+		if sp.strictWeek && (sp.weekYear < 0 || sp.strictWeekYearType != sp.sundayFirst) {
+			return false
+		}
+		if !sp.strictWeek && sp.weekYear >= 0 {
+			return false
+		}
+
+		year := sp.year
+		if sp.strictWeek {
+			year = sp.weekYear
+		}
+		days := MysqlDayNumber(year, 1, 1)
+		first := mysqlWeekday(days, sp.sundayFirst)
+		if sp.sundayFirst {
+			if first != 0 {
+				days += 7
+			}
+			days += (sp.week-1)*7 - first + sp.weekday%7
+		} else {
+			if first > 3 {
+				days += 7
+			}
+			days += (sp.week-1)*7 - first + sp.weekday - 1
+		}
+		if !sp.setFromDayNumber(days) {
+			return false
+		}
+	}
+
+	return sp.month <= 12 && sp.day <= 31 && sp.hour <= 23 && sp.min <= 59 && sp.sec <= 59
+}
+
+func (sp *strToDateParser) setFromDayNumber(daynr int) bool {
+	if daynr <= 365 || daynr >= 3652500 {
+		return false
+	}
+	y, m, d := mysqlDateFromDayNumber(daynr)
+	sp.year, sp.month, sp.day = int(y), int(m), int(d)
+	return true
+}
+
+// mysqlWeekday returns the weekday for a day number returned by MysqlDayNumber,
+// where 0 is Monday, or Sunday when sundayFirst is set.
+func mysqlWeekday(daynr int, sundayFirst bool) int {
+	if sundayFirst {
+		return (daynr + 6) % 7
+	}
+	return (daynr + 5) % 7
+}
+
+// parse matches s against the format p and returns the part of s that was not consumed.
+func (sp *strToDateParser) parse(p, s string) (string, bool) {
+	var ok bool
+	for len(p) > 0 && len(s) > 0 {
+		s = skipSpaces(s)
+		if len(s) == 0 {
+			break
+		}
+
+		if p[0] != '%' || len(p) == 1 {
+			if !isSpace(p[0]) {
+				if s[0] != p[0] {
+					return "", false
+				}
+				s = s[1:]
+			}
+			p = p[1:]
+			continue
+		}
+
+		spec := p[1]
+		p = p[2:]
+
+		switch spec {
+		case 'Y':
+			var n, l int
+			if n, l, s, ok = strToDateNum(s, 4); !ok {
+				return "", false
+			}
+			sp.year = n
+			if l <= 2 {
+				sp.year = year2000(n)
+			}
+		case 'y':
+			if sp.year, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+			sp.year = year2000(sp.year)
+		case 'm', 'c':
+			if sp.month, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+		case 'M':
+			if sp.month, s, ok = strToDateWord(longMonthNames, s); !ok {
+				return "", false
+			}
+		case 'b':
+			if sp.month, s, ok = strToDateWord(shortMonthNames, s); !ok {
+				return "", false
+			}
+		case 'd', 'e':
+			if sp.day, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+		case 'D':
+			if sp.day, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+			// skip the suffix: 'st', 'nd', 'th'...
+			s = s[min(len(s), 2):]
+		case 'h', 'I', 'l':
+			sp.usaTime = true
+			if sp.hour, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+		case 'k', 'H':
+			if sp.hour, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+		case 'i':
+			if sp.min, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+		case 's', 'S':
+			if sp.sec, _, s, ok = strToDateNum(s, 2); !ok {
+				return "", false
+			}
+		case 'f':
+			var l int
+			if sp.usec, l, s, ok = strToDateNum(s, 6); !ok {
+				return "", false
+			}
+			for ; l < 6; l++ {
+				sp.usec *= 10
+			}
+		case 'p':
+			if len(s) < 2 || !sp.usaTime {
+				return "", false
+			}
+			switch {
+			case match(s[:2], "PM"):
+				sp.daypart = 12
+			case match(s[:2], "AM"):
+			default:
+				return "", false
+			}
+			s = s[2:]
+		case 'W':
+			if sp.weekday, s, ok = strToDateWord(longDayNames, s); !ok {
+				return "", false
+			}
+		case 'a':
+			if sp.weekday, s, ok = strToDateWord(shortDayNamesMonday, s); !ok {
+				return "", false
+			}
+		case 'w':
+			if sp.weekday, _, s, ok = strToDateNum(s, 1); !ok || sp.weekday >= 7 {
+				return "", false
+			}
+			// use the same 1-7 scale as %W
+			if sp.weekday == 0 {
+				sp.weekday = 7
+			}
+		case 'j':
+			if sp.yearday, _, s, ok = strToDateNum(s, 3); !ok {
+				return "", false
+			}
+		case 'V', 'U', 'v', 'u':
+			sp.sundayFirst = spec == 'U' || spec == 'V'
+			sp.strictWeek = spec == 'V' || spec == 'v'
+			if sp.week, _, s, ok = strToDateNum(s, 2); !ok || (sp.strictWeek && sp.week == 0) || sp.week > 53 {
+				return "", false
+			}
+		case 'X', 'x':
+			sp.strictWeekYearType = spec == 'X'
+			if sp.weekYear, _, s, ok = strToDateNum(s, 4); !ok {
+				return "", false
+			}
+		case 'r':
+			if s, ok = sp.parseSub("%I:%i:%S %p", s); !ok {
+				return "", false
+			}
+		case 'T':
+			if s, ok = sp.parseSub("%H:%i:%S", s); !ok {
+				return "", false
+			}
+		case '.':
+			for len(s) > 0 && isSeparator(s[0]) {
+				s = s[1:]
+			}
+		case '@':
+			for len(s) > 0 && isAlpha(s[0]) {
+				s = s[1:]
+			}
+		case '#':
+			for len(s) > 0 && isDigit(s, 0) {
+				s = s[1:]
+			}
+		default:
+			return "", false
+		}
+	}
+
+	if sp.usaTime {
+		if sp.hour > 12 || sp.hour < 1 {
+			return "", false
+		}
+		sp.hour = sp.hour%12 + sp.daypart
+	}
+	return s, true
+}
+
+// parseSub parses the time in s with one of the formats for %r and %T. The 12-hour clock
+// of %r only applies to the sub format, like in MySQL.
+func (sp *strToDateParser) parseSub(p, s string) (string, bool) {
+	usaTime, daypart := sp.usaTime, sp.daypart
+	sp.usaTime, sp.daypart = false, 0
+	s, ok := sp.parse(p, s)
+	sp.usaTime, sp.daypart = usaTime, daypart
+	return s, ok
+}
+
+// strToDateNum parses an unsigned number of at most l characters from s. It returns the
+// number, how many characters were used and the rest of s.
+func strToDateNum(s string, l int) (int, int, string, bool) {
+	end := min(len(s), l)
+	i := 0
+	for i < end && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	if i < end && s[i] == '+' {
+		i++
+	}
+	start := i
+	n := 0
+	for i < end && isDigit(s, i) {
+		n = n*10 + int(s[i]-'0')
+		i++
+	}
+	if i == start {
+		return 0, 0, s, false
+	}
+	return n, i, s[i:], true
+}
+
+// strToDateWord matches the word at the start of s with one of the names in tab, allowing
+// for unique prefixes. It returns the 1-based position of the name and the rest of s.
+func strToDateWord(tab []string, s string) (int, string, bool) {
+	end := 0
+	for end < len(s) && isAlpha(s[end]) {
+		end++
+	}
+	word := s[:end]
+
+	found, count := 0, 0
+	for i, name := range tab {
+		if len(word) > len(name) || !match(word, name[:len(word)]) {
+			continue
+		}
+		if len(word) == len(name) {
+			return i + 1, s[end:], true
+		}
+		found = i + 1
+		count++
+	}
+	if count != 1 {
+		return 0, s, false
+	}
+	return found, s[end:], true
+}
+
+func year2000(year int) int {
+	if year < 70 {
+		return year + 2000
+	}
+	if year < 100 {
+		return year + 1900
+	}
+	return year
+}
+
+func skipSpaces(s string) string {
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
+
+func isAlpha(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrToDate(t *testing.T) {
+	testCases := []struct {
+		in        string
+		format    string
+		allowZero bool
+		want      string
+	}{
+		{"01,5,2013", "%d,%m,%Y", false, "2013-05-01 00:00:00"},
+		{"May 1, 2013", "%M %d,%Y", false, "2013-05-01 00:00:00"},
+		{"dec 31 99", "%b %e %y", false, "1999-12-31 00:00:00"},
+		{"2013-05-01 10:11:12.5", "%Y-%m-%d %T.%f", false, "2013-05-01 10:11:12.500000"},
+		{"2013-05-01 10:11:12 pm", "%Y-%m-%d %r", false, "2013-05-01 22:11:12"},
+		{"2013-05-01 12:11:12 AM", "%Y-%m-%d %h:%i:%s %p", false, "2013-05-01 00:11:12"},
+		{"2013-05-01 13:11:12 AM", "%Y-%m-%d %h:%i:%s %p", false, ""},
+		{"200442 Monday", "%X%V %W", false, "2004-10-18 00:00:00"},
+		{"2004 042", "%Y %j", false, "2004-02-11 00:00:00"},
+		{"3rd March 2021", "%D %M %Y", false, "2021-03-03 00:00:00"},
+		{"2021-02-30", "%Y-%m-%d", false, ""},
+		{"2021-13-01", "%Y-%m-%d", false, ""},
+		{"2021-05-01 extra", "%Y-%m-%d", false, "2021-05-01 00:00:00"},
+		{"abc", "abc", false, ""},
+		{"abc", "abc", true, "0000-00-00 00:00:00"},
+		{"9", "%m", false, ""},
+		{"9", "%m", true, "0000-09-00 00:00:00"},
+		{"Ju 1 2021", "%b %d %Y", false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			dt, ok := StrToDate(tc.format, tc.in, tc.allowZero)
+			if tc.want == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			prec := uint8(0)
+			if ParseStrToDateFormat(tc.format).Frac {
+				prec = DefaultPrecision
+			}
+			assert.Equal(t, tc.want, string(dt.Format(prec)))
+		})
+	}
+}
+
+func TestStrToTime(t *testing.T) {
+	testCases := []struct {
+		in     string
+		format string
+		want   string
+	}{
+		{"a09:30:17", "a%h:%i:%s", "09:30:17"},
+		{"a09:30:17", "%h:%i:%s", ""},
+		{"09:30:17a", "%h:%i:%s", "09:30:17"},
+		{"9", "%s", "00:00:09"},
+		{"25:00:00", "%H:%i:%s", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			tt, ok := StrToTime(tc.format, tc.in)
+			if tc.want == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tc.want, string(tt.Format(0)))
+		})
+	}
+}
+
+func TestParseStrToDateFormat(t *testing.T) {
+	assert.Equal(t, StrToDateFormat{Date: true}, ParseStrToDateFormat("%Y-%m-%d"))
+	assert.Equal(t, StrToDateFormat{Time: true}, ParseStrToDateFormat("%H:%i:%s"))
+	assert.Equal(t, StrToDateFormat{Date: true, Time: true, Frac: true}, ParseStrToDateFormat("%Y-%m-%d %T.%f"))
+	assert.Equal(t, StrToDateFormat{}, ParseStrToDateFormat("abc %"))
+}
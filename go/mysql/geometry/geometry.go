@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package geometry implements the MySQL spatial data types: parsing and formatting
+// them as WKT, WKB and MySQL's internal storage format, and the spatial relations
+// and measurements that are evaluated on them.
+package geometry
+
+import (
+	"errors"
+)
+
+// Type is the kind of a Geometry, using the type codes of the WKB format
+type Type uint32
+
+const (
+	// Any is not a valid geometry type; it is used to accept geometries of every type
+	Any                Type = 0
+	Point              Type = 1
+	LineString         Type = 2
+	Polygon            Type = 3
+	MultiPoint         Type = 4
+	MultiLineString    Type = 5
+	MultiPolygon       Type = 6
+	GeometryCollection Type = 7
+)
+
+var typeNames = [...]string{
+	Any:                "GEOMETRY",
+	Point:              "POINT",
+	LineString:         "LINESTRING",
+	Polygon:            "POLYGON",
+	MultiPoint:         "MULTIPOINT",
+	MultiLineString:    "MULTILINESTRING",
+	MultiPolygon:       "MULTIPOLYGON",
+	GeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+// String returns the name of the type as used by MySQL in WKT
+func (t Type) String() string {
+	if int(t) < len(typeNames) {
+		return typeNames[t]
+	}
+	return "UNKNOWN"
+}
+
+// member returns the type of the elements of a multi-geometry type
+func (t Type) member() Type {
+	switch t {
+	case MultiPoint:
+		return Point
+	case MultiLineString:
+		return LineString
+	case MultiPolygon:
+		return Polygon
+	default:
+		return Any
+	}
+}
+
+// ErrInvalidData is returned when a geometry cannot be parsed or is not well-formed
+var ErrInvalidData = errors.New("invalid GIS data")
+
+// WGS84 is the SRID of the World Geodetic System 1984, the geographic spatial reference system
+// used by GPS. MySQL stores its coordinates with the longitude in X and the latitude in Y, but
+// following the definition of the SRS, its WKT, WKB, ST_X and ST_Y use latitude-longitude order.
+const WGS84 = 4326
+
+// Coord is a coordinate in a Cartesian plane
+type Coord struct {
+	X, Y float64
+}
+
+// Geometry is a parsed spatial value, with its coordinates in the order MySQL stores them. Points and line strings store their coordinates in Points;
+// a polygon stores each one of its rings as a LineString in Parts, the exterior ring first; and
+// the multi-geometries and geometry collections store their members in Parts.
+type Geometry struct {
+	SRID   uint32
+	Type   Type
+	Points []Coord
+	Parts  []*Geometry
+}
+
+// NewPoint returns a new Point geometry
+func NewPoint(srid uint32, x, y float64) *Geometry {
+	return &Geometry{SRID: srid, Type: Point, Points: []Coord{{X: x, Y: y}}}
+}
+
+// SwapXY swaps the X and Y coordinates of all the points of the geometry, which converts the
+// coordinates of a geometry in WGS 84 between the storage order and the order of its WKT and WKB
+func (g *Geometry) SwapXY() {
+	for i, p := range g.Points {
+		g.Points[i] = Coord{X: p.Y, Y: p.X}
+	}
+	for _, part := range g.Parts {
+		part.SwapXY()
+	}
+}
+
+// IsEmpty returns whether the geometry has no points at all, which is only possible
+// for an empty geometry collection
+func (g *Geometry) IsEmpty() bool {
+	if len(g.Points) > 0 {
+		return false
+	}
+	for _, p := range g.Parts {
+		if !p.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Dimension returns the topological dimension of the geometry: 0 for points, 1 for lines
+// and 2 for polygons. The dimension of a geometry collection is the largest of its members'
+// dimensions, or -1 when it is empty.
+func (g *Geometry) Dimension() int {
+	switch g.Type {
+	case Point, MultiPoint:
+		return 0
+	case LineString, MultiLineString:
+		return 1
+	case Polygon, MultiPolygon:
+		return 2
+	default:
+		dim := -1
+		for _, p := range g.Parts {
+			dim = max(dim, p.Dimension())
+		}
+		return dim
+	}
+}
+
+// validate checks the constraints that MySQL imposes on well-formed geometries
+func (g *Geometry) validate() error {
+	for _, p := range g.Points {
+		if !isFinite(p.X) || !isFinite(p.Y) {
+			return ErrInvalidData
+		}
+	}
+	switch g.Type {
+	case Point:
+		if len(g.Points) != 1 {
+			return ErrInvalidData
+		}
+	case LineString:
+		if len(g.Points) < 2 {
+			return ErrInvalidData
+		}
+	case Polygon:
+		if len(g.Parts) == 0 {
+			return ErrInvalidData
+		}
+		for _, ring := range g.Parts {
+			if ring.Type != LineString || len(ring.Points) < 4 || ring.Points[0] != ring.Points[len(ring.Points)-1] {
+				return ErrInvalidData
+			}
+			if err := ring.validate(); err != nil {
+				return err
+			}
+		}
+	case MultiPoint, MultiLineString, MultiPolygon:
+		if len(g.Parts) == 0 {
+			return ErrInvalidData
+		}
+		for _, part := range g.Parts {
+			if part.Type != g.Type.member() {
+				return ErrInvalidData
+			}
+			if err := part.validate(); err != nil {
+				return err
+			}
+		}
+	case GeometryCollection:
+		for _, part := range g.Parts {
+			if err := part.validate(); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrInvalidData
+	}
+	return nil
+}
+
+// eachPoint calls f for every point of the geometry, including the points of its rings and members
+func (g *Geometry) eachPoint(f func(p Coord)) {
+	for _, p := range g.Points {
+		f(p)
+	}
+	for _, part := range g.Parts {
+		part.eachPoint(f)
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geometry
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWKT(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5   2e3 ) ", "POINT(-1.5 2000)"},
+		{"POINT(0.1 1e30)", "POINT(0.1 1e30)"},
+		{"LINESTRING(0 0, 1 1, 2 2)", "LINESTRING(0 0,1 1,2 2)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(5 5,7 5,7 7,5 7,5 5))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(5 5,7 5,7 7,5 7,5 5))"},
+		{"MULTIPOINT(0 0, 20 20, 60 60)", "MULTIPOINT((0 0),(20 20),(60 60))"},
+		{"MULTIPOINT((0 0),(1 1))", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTILINESTRING((10 10, 20 20), (15 15, 30 15))", "MULTILINESTRING((10 10,20 20),(15 15,30 15))"},
+		{"MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((5 5,7 5,7 7,5 7,5 5)))", "MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((5 5,7 5,7 7,5 7,5 5)))"},
+		{"GEOMETRYCOLLECTION(POINT(10 10), POINT(30 30), LINESTRING(15 15, 20 20))", "GEOMETRYCOLLECTION(POINT(10 10),POINT(30 30),LINESTRING(15 15,20 20))"},
+		{"GEOMCOLLECTION(GEOMETRYCOLLECTION(POINT(1 1)))", "GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1 1)))"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+
+		{"POINT(1)", ""},
+		{"POINT(1 2 3)", ""},
+		{"POINT(1 2), POINT(3 4)", ""},
+		{"POINT EMPTY", ""},
+		{"LINESTRING(0 0)", ""},
+		{"POLYGON((0 0,10 0,10 10,0 0.5))", ""},
+		{"POLYGON((0 0,10 0,0 0))", ""},
+		{"MULTIPOINT()", ""},
+		{"CIRCLE(0 0, 1)", ""},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			g, err := ParseWKT(0, tc.in)
+			if tc.want == "" {
+				assert.ErrorIs(t, err, ErrInvalidData)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, g.String())
+		})
+	}
+}
+
+func TestWKB(t *testing.T) {
+	testCases := []string{
+		"POINT(1 -1)",
+		"LINESTRING(0 0,1 1,2 2)",
+		"POLYGON((0 0,10 0,10 10,0 10,0 0),(5 5,7 5,7 7,5 7,5 5))",
+		"MULTIPOINT((0 0),(1 1))",
+		"MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((5 5,7 5,7 7,5 7,5 5)))",
+		"GEOMETRYCOLLECTION(POINT(10 10),LINESTRING(15 15,20 20))",
+		"GEOMETRYCOLLECTION EMPTY",
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			g, err := ParseWKT(4326, tc)
+			require.NoError(t, err)
+
+			internal := g.MarshalInternal()
+			parsed, err := ParseInternal(internal)
+			require.NoError(t, err)
+			assert.Equal(t, g, parsed)
+			assert.EqualValues(t, 4326, parsed.SRID)
+
+			// truncated values are rejected instead of being partially parsed
+			for i := range len(internal) {
+				_, err = ParseInternal(internal[:i])
+				assert.ErrorIs(t, err, ErrInvalidData)
+			}
+		})
+	}
+
+	// MySQL's internal format for POINT(1 -1) with SRID 0
+	point, err := hex.DecodeString("000000000101000000000000000000F03F000000000000F0BF")
+	require.NoError(t, err)
+	g, err := ParseInternal(point)
+	require.NoError(t, err)
+	assert.Equal(t, "POINT(1 -1)", g.String())
+	assert.Equal(t, point, g.MarshalInternal())
+
+	// big-endian WKB
+	point, err = hex.DecodeString("00000000013FF0000000000000BFF0000000000000")
+	require.NoError(t, err)
+	g, err = ParseWKB(0, point)
+	require.NoError(t, err)
+	assert.Equal(t, "POINT(1 -1)", g.String())
+
+	// the members of a multipoint must be points
+	multi, err := hex.DecodeString("010400000001000000010200000002000000000000000000000000000000000000000000000000000000000000000000F03F000000000000F03F")
+	require.NoError(t, err)
+	_, err = ParseWKB(0, multi)
+	assert.ErrorIs(t, err, ErrInvalidData)
+}
+
+func TestRelations(t *testing.T) {
+	const (
+		square = "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+		donut  = "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))"
+		hole   = "POLYGON((4 4,6 4,6 6,4 6,4 4))"
+		concav = "POLYGON((0 0,10 0,10 10,5 2,0 10,0 0))"
+	)
+
+	testCases := []struct {
+		rel  Relation
+		a, b string
+		want bool
+	}{
+		{Contains, square, "POINT(5 5)", true},
+		{Contains, square, "POINT(10 5)", false},
+		{Contains, square, "POINT(11 5)", false},
+		{Contains, square, "LINESTRING(0 0,10 0)", false},
+		{Contains, square, "LINESTRING(0 0,10 10)", true},
+		{Contains, square, square, true},
+		{Contains, square, "POLYGON((1 1,2 1,2 2,1 1))", true},
+		{Contains, square, "POLYGON((1 1,20 1,2 2,1 1))", false},
+		{Contains, donut, "POINT(5 5)", false},
+		{Contains, donut, "POINT(2 2)", true},
+		{Contains, donut, hole, false},
+		{Contains, donut, "POLYGON((1 1,9 1,9 9,1 9,1 1))", false},
+		{Contains, concav, "LINESTRING(1 5,9 5)", false},
+		{Contains, concav, "MULTIPOINT((1 5),(9 5))", true},
+		{Contains, "POINT(1 1)", square, false},
+		{Contains, "LINESTRING(0 0,10 10)", "POINT(5 5)", true},
+		{Contains, "LINESTRING(0 0,10 10)", "POINT(0 0)", false},
+		{Contains, "LINESTRING(0 0,10 10)", "LINESTRING(2 2,3 3)", true},
+		{Contains, "MULTIPOINT((0 0),(1 1))", "POINT(1 1)", true},
+		{Contains, square, "GEOMETRYCOLLECTION EMPTY", false},
+		{Within, "POINT(5 5)", square, true},
+		{Within, "POINT(5 5)", donut, false},
+
+		{MBRContains, square, "POINT(5 5)", true},
+		{MBRContains, square, "POINT(0 5)", false},
+		{MBRCovers, square, "POINT(0 5)", true},
+		{MBRCoveredBy, "POINT(0 5)", square, true},
+		{MBRContains, concav, "LINESTRING(1 5,9 5)", true},
+		{MBRWithin, "POINT(5 5)", square, true},
+		{MBRWithin, "POINT(1 1)", "POINT(1 1)", true},
+		{MBRIntersects, square, "POINT(10 10)", true},
+		{MBRIntersects, square, "POINT(10 11)", false},
+		{MBRDisjoint, square, "POINT(10 11)", true},
+		{MBRDisjoint, square, "GEOMETRYCOLLECTION EMPTY", true},
+		{MBREquals, square, concav, true},
+		{MBREquals, square, donut, true},
+		{MBREquals, square, hole, false},
+		{MBRTouches, square, "POINT(10 10)", true},
+		{MBRTouches, square, "POLYGON((10 0,20 0,20 10,10 10,10 0))", true},
+		{MBRTouches, square, "POINT(5 5)", false},
+		{MBRTouches, "POINT(1 1)", "POINT(1 1)", false},
+		{MBROverlaps, square, "POLYGON((5 5,15 5,15 15,5 15,5 5))", true},
+		{MBROverlaps, square, hole, false},
+		{MBROverlaps, square, "LINESTRING(5 5,15 5)", false},
+	}
+
+	for _, tc := range testCases {
+		a, err := ParseWKT(0, tc.a)
+		require.NoError(t, err)
+		b, err := ParseWKT(0, tc.b)
+		require.NoError(t, err)
+		assert.Equalf(t, tc.want, tc.rel.Relate(a, b), "relation %d between %s and %s", tc.rel, tc.a, tc.b)
+	}
+}
+
+func TestDistanceSphere(t *testing.T) {
+	testCases := []struct {
+		a, b   string
+		radius float64
+		want   float64
+	}{
+		{"POINT(0 0)", "POINT(0 0)", DefaultSphereRadius, 0},
+		{"POINT(0 0)", "POINT(180 0)", 1, 3.141592653589793},
+		{"POINT(-73.9949 40.7501)", "POINT(-73.9706 40.7528)", DefaultSphereRadius, 2068.8194},
+		{"POINT(0 0)", "MULTIPOINT((90 0),(0 45),(10 10))", 1, 0.2461969},
+	}
+
+	for _, tc := range testCases {
+		a, err := ParseWKT(0, tc.a)
+		require.NoError(t, err)
+		b, err := ParseWKT(0, tc.b)
+		require.NoError(t, err)
+		assert.InDelta(t, tc.want, DistanceSphere(a, b, tc.radius), 1e-4)
+	}
+
+	// The WKT of WGS 84 is in latitude-longitude order, but its points are stored
+	// with the longitude first
+	a, err := ParseWKT(WGS84, "POINT(40.7501 -73.9949)")
+	require.NoError(t, err)
+	a.SwapXY()
+	b, err := ParseWKT(WGS84, "MULTIPOINT((40.7528 -73.9706),(0 0))")
+	require.NoError(t, err)
+	b.SwapXY()
+	assert.Equal(t, []Coord{{X: -73.9706, Y: 40.7528}}, b.Parts[0].Points)
+	assert.InDelta(t, 2068.8194, DistanceSphere(a, b, DefaultSphereRadius), 1e-4)
+	assert.Equal(t, "POINT(-73.9949 40.7501)", a.String())
+}
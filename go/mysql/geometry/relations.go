@@ -0,0 +1,433 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geometry
+
+import (
+	"math"
+	"slices"
+)
+
+// Box is the minimum bounding rectangle (MBR) of a geometry. The box of a point has no area,
+// and neither does the box of a horizontal or vertical line.
+type Box struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Envelope returns the minimum bounding rectangle of the geometry. It returns false if the
+// geometry is empty and has no bounding rectangle.
+func (g *Geometry) Envelope() (Box, bool) {
+	b := Box{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	g.eachPoint(func(p Coord) {
+		b.MinX = min(b.MinX, p.X)
+		b.MinY = min(b.MinY, p.Y)
+		b.MaxX = max(b.MaxX, p.X)
+		b.MaxY = max(b.MaxY, p.Y)
+	})
+	return b, b.MinX <= b.MaxX
+}
+
+func (b Box) dimension() int {
+	dim := 0
+	if b.MinX < b.MaxX {
+		dim++
+	}
+	if b.MinY < b.MaxY {
+		dim++
+	}
+	return dim
+}
+
+func (b Box) covers(o Box) bool {
+	return b.MinX <= o.MinX && o.MaxX <= b.MaxX && b.MinY <= o.MinY && o.MaxY <= b.MaxY
+}
+
+func (b Box) intersects(o Box) bool {
+	return b.MinX <= o.MaxX && o.MinX <= b.MaxX && b.MinY <= o.MaxY && o.MinY <= b.MaxY
+}
+
+// interiorsIntersect returns whether the interiors of both boxes intersect. The interior of
+// a box without area is its relative interior: a point is its own interior, and the interior
+// of a line does not include its end points.
+func (b Box) interiorsIntersect(o Box) bool {
+	return intervalInteriorsIntersect(b.MinX, b.MaxX, o.MinX, o.MaxX) &&
+		intervalInteriorsIntersect(b.MinY, b.MaxY, o.MinY, o.MaxY)
+}
+
+func intervalInteriorsIntersect(a0, a1, b0, b1 float64) bool {
+	switch {
+	case a0 == a1 && b0 == b1:
+		return a0 == b0
+	case a0 == a1:
+		return b0 < a0 && a0 < b1
+	case b0 == b1:
+		return a0 < b0 && b0 < a1
+	default:
+		return max(a0, b0) < min(a1, b1)
+	}
+}
+
+// Relation is a spatial relation between two geometries
+type Relation uint8
+
+const (
+	MBRContains Relation = iota
+	MBRCoveredBy
+	MBRCovers
+	MBRDisjoint
+	MBREquals
+	MBRIntersects
+	MBROverlaps
+	MBRTouches
+	MBRWithin
+	Contains
+	Within
+)
+
+// Relate returns whether the relation holds between the geometries a and b. The MBR relations
+// only compare the bounding rectangles of the geometries, while Contains and Within compare the
+// geometries themselves. Empty geometries are disjoint from every other geometry.
+func (r Relation) Relate(a, b *Geometry) bool {
+	switch r {
+	case Contains:
+		return contains(a, b)
+	case Within:
+		return contains(b, a)
+	}
+
+	ba, okA := a.Envelope()
+	bb, okB := b.Envelope()
+	if !okA || !okB {
+		return r == MBRDisjoint
+	}
+
+	switch r {
+	case MBRContains:
+		return ba.covers(bb) && ba.interiorsIntersect(bb)
+	case MBRWithin:
+		return bb.covers(ba) && ba.interiorsIntersect(bb)
+	case MBRCovers:
+		return ba.covers(bb)
+	case MBRCoveredBy:
+		return bb.covers(ba)
+	case MBRDisjoint:
+		return !ba.intersects(bb)
+	case MBREquals:
+		return ba == bb
+	case MBRIntersects:
+		return ba.intersects(bb)
+	case MBRTouches:
+		return ba.intersects(bb) && !ba.interiorsIntersect(bb)
+	case MBROverlaps:
+		return ba.dimension() == bb.dimension() && ba.interiorsIntersect(bb) && !ba.covers(bb) && !bb.covers(ba)
+	default:
+		return false
+	}
+}
+
+type location int8
+
+const (
+	exterior location = iota
+	boundary
+	interior
+)
+
+type segment struct {
+	a, b Coord
+}
+
+// segments returns all the segments of the lines and polygon rings in the geometry
+func (g *Geometry) segments(segs []segment) []segment {
+	if g.Type == LineString {
+		for i := 1; i < len(g.Points); i++ {
+			segs = append(segs, segment{g.Points[i-1], g.Points[i]})
+		}
+	}
+	for _, part := range g.Parts {
+		segs = part.segments(segs)
+	}
+	return segs
+}
+
+// polygons appends all the polygons in the geometry to polys
+func (g *Geometry) polygons(polys []*Geometry) []*Geometry {
+	switch g.Type {
+	case Polygon:
+		return append(polys, g)
+	case MultiPolygon, GeometryCollection:
+		for _, part := range g.Parts {
+			polys = part.polygons(polys)
+		}
+	}
+	return polys
+}
+
+// lines appends all the line strings and polygon rings in the geometry to lines
+func (g *Geometry) lines(lines []*Geometry) []*Geometry {
+	if g.Type == LineString {
+		return append(lines, g)
+	}
+	for _, part := range g.Parts {
+		lines = part.lines(lines)
+	}
+	return lines
+}
+
+// points appends the coordinates of all the points and multipoints in the geometry to points
+func (g *Geometry) points(points []Coord) []Coord {
+	switch g.Type {
+	case Point:
+		return append(points, g.Points[0])
+	case MultiPoint, GeometryCollection:
+		for _, part := range g.Parts {
+			points = part.points(points)
+		}
+	}
+	return points
+}
+
+// locate returns whether p lies in the interior, on the boundary or in the exterior of the geometry
+func (g *Geometry) locate(p Coord) location {
+	switch g.Type {
+	case Point:
+		if g.Points[0] == p {
+			return interior
+		}
+		return exterior
+	case LineString:
+		first, last := g.Points[0], g.Points[len(g.Points)-1]
+		if first != last && (p == first || p == last) {
+			return boundary
+		}
+		for i := 1; i < len(g.Points); i++ {
+			if onSegment(p, g.Points[i-1], g.Points[i]) {
+				return interior
+			}
+		}
+		return exterior
+	case Polygon:
+		for _, ring := range g.Parts {
+			for i := 1; i < len(ring.Points); i++ {
+				if onSegment(p, ring.Points[i-1], ring.Points[i]) {
+					return boundary
+				}
+			}
+		}
+		if !inRing(p, g.Parts[0].Points) {
+			return exterior
+		}
+		for _, hole := range g.Parts[1:] {
+			if inRing(p, hole.Points) {
+				return exterior
+			}
+		}
+		return interior
+	default:
+		loc := exterior
+		for _, part := range g.Parts {
+			switch part.locate(p) {
+			case interior:
+				return interior
+			case boundary:
+				loc = boundary
+			}
+		}
+		return loc
+	}
+}
+
+// contains returns whether no point of b lies in the exterior of a, and at least one
+// point of the interior of b lies in the interior of a
+func contains(a, b *Geometry) bool {
+	ea, okA := a.Envelope()
+	eb, okB := b.Envelope()
+	if !okA || !okB || !ea.covers(eb) || b.Dimension() > a.Dimension() {
+		return false
+	}
+
+	var inside bool
+	check := func(p Coord) bool {
+		switch a.locate(p) {
+		case exterior:
+			return false
+		case interior:
+			inside = true
+		}
+		return true
+	}
+
+	for _, p := range b.points(nil) {
+		if !check(p) {
+			return false
+		}
+	}
+
+	// The lines of b can leave a between their vertices, so split every segment of b where
+	// it meets a segment of a, and check one point in each one of the resulting pieces.
+	edges := a.segments(nil)
+	for _, line := range b.lines(nil) {
+		for i := 1; i < len(line.Points); i++ {
+			p0, p1 := line.Points[i-1], line.Points[i]
+			if !check(p0) || !check(p1) {
+				return false
+			}
+			cuts := []float64{0, 1}
+			for _, e := range edges {
+				cuts = appendIntersections(cuts, p0, p1, e)
+			}
+			slices.Sort(cuts)
+			for j := 1; j < len(cuts); j++ {
+				if cuts[j] == cuts[j-1] {
+					continue
+				}
+				t := (cuts[j-1] + cuts[j]) / 2
+				if !check(Coord{X: p0.X + t*(p1.X-p0.X), Y: p0.Y + t*(p1.Y-p0.Y)}) {
+					return false
+				}
+			}
+		}
+	}
+
+	for _, poly := range b.polygons(nil) {
+		// the boundary of a cannot enter the interior of a polygon that it contains
+		for _, e := range edges {
+			if poly.locate(e.a) == interior {
+				return false
+			}
+		}
+		if p, ok := interiorPoint(poly); ok && !check(p) {
+			return false
+		}
+	}
+	return inside
+}
+
+// appendIntersections appends to cuts the positions along the segment p0-p1 where it meets the segment e
+func appendIntersections(cuts []float64, p0, p1 Coord, e segment) []float64 {
+	r := Coord{X: p1.X - p0.X, Y: p1.Y - p0.Y}
+	s := Coord{X: e.b.X - e.a.X, Y: e.b.Y - e.a.Y}
+	q := Coord{X: e.a.X - p0.X, Y: e.a.Y - p0.Y}
+
+	denom := cross(r, s)
+	if denom == 0 {
+		if cross(q, r) != 0 {
+			return cuts
+		}
+		// collinear segments: cut at the end points of e
+		rr := r.X*r.X + r.Y*r.Y
+		for _, v := range []Coord{e.a, e.b} {
+			t := ((v.X-p0.X)*r.X + (v.Y-p0.Y)*r.Y) / rr
+			if t > 0 && t < 1 {
+				cuts = append(cuts, t)
+			}
+		}
+		return cuts
+	}
+
+	t := cross(q, s) / denom
+	u := cross(q, r) / denom
+	if t > 0 && t < 1 && u >= 0 && u <= 1 {
+		cuts = append(cuts, t)
+	}
+	return cuts
+}
+
+func cross(a, b Coord) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+// onSegment returns whether p lies on the segment a-b, allowing for floating point rounding errors
+func onSegment(p, a, b Coord) bool {
+	if p.X < min(a.X, b.X) || p.X > max(a.X, b.X) || p.Y < min(a.Y, b.Y) || p.Y > max(a.Y, b.Y) {
+		return false
+	}
+	d := Coord{X: b.X - a.X, Y: b.Y - a.Y}
+	v := Coord{X: p.X - a.X, Y: p.Y - a.Y}
+	tolerance := 1e-12 * (math.Abs(d.X) + math.Abs(d.Y)) * (math.Abs(v.X) + math.Abs(v.Y))
+	return math.Abs(cross(d, v)) <= tolerance
+}
+
+// inRing returns whether p lies inside the closed ring, using the even-odd rule.
+// Points on the ring itself must be handled by the caller.
+func inRing(p Coord, ring []Coord) bool {
+	var in bool
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// interiorPoint returns a point in the interior of the polygon. It intersects the polygon
+// with a horizontal line between its two lowest vertices and picks the middle of the
+// first piece of that line that lies inside the polygon.
+func interiorPoint(poly *Geometry) (Coord, bool) {
+	var ys []float64
+	poly.eachPoint(func(p Coord) {
+		ys = append(ys, p.Y)
+	})
+	slices.Sort(ys)
+	ys = slices.Compact(ys)
+	if len(ys) < 2 {
+		return Coord{}, false
+	}
+	y := (ys[0] + ys[1]) / 2
+
+	var xs []float64
+	for _, ring := range poly.Parts {
+		for i := 1; i < len(ring.Points); i++ {
+			a, b := ring.Points[i-1], ring.Points[i]
+			if (a.Y > y) != (b.Y > y) {
+				xs = append(xs, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+			}
+		}
+	}
+	slices.Sort(xs)
+	if len(xs) < 2 || xs[0] == xs[1] {
+		return Coord{}, false
+	}
+	return Coord{X: (xs[0] + xs[1]) / 2, Y: y}, true
+}
+
+// DefaultSphereRadius is the radius of the Earth in meters, used by
+// ST_Distance_Sphere when no radius is given
+const DefaultSphereRadius = 6370986
+
+// DistanceSphere returns the minimum great-circle distance between the points of a and b,
+// which must be points or multipoints with their longitude in X and their latitude in Y,
+// both in degrees, on a sphere with the given radius. This is how MySQL stores the
+// coordinates of geometries in WGS 84.
+func DistanceSphere(a, b *Geometry, radius float64) float64 {
+	distance := math.Inf(1)
+	for _, p := range a.points(nil) {
+		for _, q := range b.points(nil) {
+			distance = min(distance, haversine(p, q, radius))
+		}
+	}
+	return distance
+}
+
+func haversine(p, q Coord, radius float64) float64 {
+	const rad = math.Pi / 180
+	lat1, lat2 := p.Y*rad, q.Y*rad
+	dlat := math.Sin((lat2 - lat1) / 2)
+	dlon := math.Sin((q.X - p.X) * rad / 2)
+	h := dlat*dlat + math.Cos(lat1)*math.Cos(lat2)*dlon*dlon
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
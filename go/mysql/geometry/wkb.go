@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geometry
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+
+	// maxNesting is the deepest nesting of geometry collections that we accept
+	maxNesting = 64
+)
+
+// ParseInternal parses a geometry in the format MySQL uses to store geometry values:
+// a little-endian 4-byte SRID followed by the WKB representation of the geometry.
+// This is the format of geometry columns in query results and in the binary log.
+func ParseInternal(data []byte) (*Geometry, error) {
+	if len(data) < 4 {
+		return nil, ErrInvalidData
+	}
+	return ParseWKB(binary.LittleEndian.Uint32(data), data[4:])
+}
+
+// ParseWKB parses a geometry in the Well-Known Binary format with the given SRID
+func ParseWKB(srid uint32, data []byte) (*Geometry, error) {
+	p := wkbParser{data: data}
+	g, err := p.geometry(srid, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.data) > 0 {
+		return nil, ErrInvalidData
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type wkbParser struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (p *wkbParser) uint32() (uint32, bool) {
+	if len(p.data) < 4 {
+		return 0, false
+	}
+	n := p.order.Uint32(p.data)
+	p.data = p.data[4:]
+	return n, true
+}
+
+// count reads the number of elements that follow, making sure they can fit in
+// the remaining data so that corrupt input cannot trigger huge allocations
+func (p *wkbParser) count(minSize int) (int, bool) {
+	n, ok := p.uint32()
+	if !ok || uint64(n)*uint64(minSize) > uint64(len(p.data)) {
+		return 0, false
+	}
+	return int(n), true
+}
+
+func (p *wkbParser) point() (Coord, bool) {
+	if len(p.data) < 16 {
+		return Coord{}, false
+	}
+	x := math.Float64frombits(p.order.Uint64(p.data))
+	y := math.Float64frombits(p.order.Uint64(p.data[8:]))
+	p.data = p.data[16:]
+	return Coord{X: x, Y: y}, true
+}
+
+func (p *wkbParser) points() ([]Coord, bool) {
+	n, ok := p.count(16)
+	if !ok {
+		return nil, false
+	}
+	points := make([]Coord, 0, n)
+	for range n {
+		pt, _ := p.point()
+		points = append(points, pt)
+	}
+	return points, true
+}
+
+func (p *wkbParser) geometry(srid uint32, depth int) (*Geometry, error) {
+	if len(p.data) < 5 || depth > maxNesting {
+		return nil, ErrInvalidData
+	}
+	switch p.data[0] {
+	case wkbBigEndian:
+		p.order = binary.BigEndian
+	case wkbLittleEndian:
+		p.order = binary.LittleEndian
+	default:
+		return nil, ErrInvalidData
+	}
+	p.data = p.data[1:]
+
+	typ, _ := p.uint32()
+	g := &Geometry{SRID: srid, Type: Type(typ)}
+
+	var ok bool
+	switch g.Type {
+	case Point:
+		var pt Coord
+		pt, ok = p.point()
+		g.Points = []Coord{pt}
+	case LineString:
+		g.Points, ok = p.points()
+	case Polygon:
+		var n int
+		n, ok = p.count(4)
+		for i := 0; ok && i < n; i++ {
+			ring := &Geometry{SRID: srid, Type: LineString}
+			ring.Points, ok = p.points()
+			g.Parts = append(g.Parts, ring)
+		}
+	case MultiPoint, MultiLineString, MultiPolygon, GeometryCollection:
+		var n int
+		n, ok = p.count(5)
+		for i := 0; ok && i < n; i++ {
+			part, err := p.geometry(srid, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			g.Parts = append(g.Parts, part)
+		}
+	}
+	if !ok {
+		return nil, ErrInvalidData
+	}
+	return g, nil
+}
+
+// MarshalInternal returns the geometry in the format MySQL uses to store geometry values
+func (g *Geometry) MarshalInternal() []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, g.SRID)
+	return g.AppendWKB(buf)
+}
+
+// AppendWKB appends the little-endian Well-Known Binary representation of the geometry to buf
+func (g *Geometry) AppendWKB(buf []byte) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Type))
+
+	switch g.Type {
+	case Point:
+		buf = appendWKBPoint(buf, g.Points[0])
+	case LineString:
+		buf = appendWKBPoints(buf, g.Points)
+	case Polygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Parts)))
+		for _, ring := range g.Parts {
+			buf = appendWKBPoints(buf, ring.Points)
+		}
+	default:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Parts)))
+		for _, part := range g.Parts {
+			buf = part.AppendWKB(buf)
+		}
+	}
+	return buf
+}
+
+func appendWKBPoint(buf []byte, p Coord) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(buf []byte, points []Coord) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
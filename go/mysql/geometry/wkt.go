@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geometry
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// ParseWKT parses a geometry in the Well-Known Text format with the given SRID.
+// Like MySQL, keywords are case-insensitive and MULTIPOINT accepts its points
+// both with and without surrounding parentheses.
+func ParseWKT(srid uint32, text string) (*Geometry, error) {
+	p := wktParser{text: text, srid: srid}
+	g, ok := p.geometry(0)
+	if !ok {
+		return nil, ErrInvalidData
+	}
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return nil, ErrInvalidData
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	text string
+	pos  int
+	srid uint32
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.text) {
+		switch p.text[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.text) && p.text[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) {
+		c := p.text[p.pos] | 0x20
+		if c < 'a' || c > 'z' {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToUpper(p.text[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, bool) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		if (c < '0' || c > '9') && c != '.' && c != '-' && c != '+' && c != 'e' && c != 'E' {
+			break
+		}
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+	return f, err == nil
+}
+
+func (p *wktParser) point() (Coord, bool) {
+	x, ok := p.number()
+	if !ok {
+		return Coord{}, false
+	}
+	y, ok := p.number()
+	return Coord{X: x, Y: y}, ok
+}
+
+// points parses a parenthesized list of coordinates
+func (p *wktParser) points() ([]Coord, bool) {
+	if !p.consume('(') {
+		return nil, false
+	}
+	var points []Coord
+	for {
+		pt, ok := p.point()
+		if !ok {
+			return nil, false
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return points, p.consume(')')
+}
+
+// list parses a parenthesized list of elements, each one of them parsed by f
+func (p *wktParser) list(f func() (*Geometry, bool)) ([]*Geometry, bool) {
+	if !p.consume('(') {
+		return nil, false
+	}
+	var parts []*Geometry
+	for {
+		part, ok := f()
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, part)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return parts, p.consume(')')
+}
+
+func (p *wktParser) lineString() (*Geometry, bool) {
+	points, ok := p.points()
+	return &Geometry{SRID: p.srid, Type: LineString, Points: points}, ok
+}
+
+func (p *wktParser) polygon() (*Geometry, bool) {
+	rings, ok := p.list(p.lineString)
+	return &Geometry{SRID: p.srid, Type: Polygon, Parts: rings}, ok
+}
+
+func (p *wktParser) multiPointMember() (*Geometry, bool) {
+	parens := p.consume('(')
+	pt, ok := p.point()
+	if parens && ok {
+		ok = p.consume(')')
+	}
+	return &Geometry{SRID: p.srid, Type: Point, Points: []Coord{pt}}, ok
+}
+
+func (p *wktParser) geometry(depth int) (*Geometry, bool) {
+	if depth > maxNesting {
+		return nil, false
+	}
+	g := &Geometry{SRID: p.srid}
+	var ok bool
+	switch p.word() {
+	case "POINT":
+		g.Type = Point
+		var points []Coord
+		points, ok = p.points()
+		if ok && len(points) != 1 {
+			return nil, false
+		}
+		g.Points = points
+	case "LINESTRING":
+		g.Type = LineString
+		g.Points, ok = p.points()
+	case "POLYGON":
+		g.Type = Polygon
+		g.Parts, ok = p.list(p.lineString)
+	case "MULTIPOINT":
+		g.Type = MultiPoint
+		g.Parts, ok = p.list(p.multiPointMember)
+	case "MULTILINESTRING":
+		g.Type = MultiLineString
+		g.Parts, ok = p.list(p.lineString)
+	case "MULTIPOLYGON":
+		g.Type = MultiPolygon
+		g.Parts, ok = p.list(p.polygon)
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g.Type = GeometryCollection
+		switch {
+		case p.word() == "EMPTY":
+			ok = true
+		case p.consume('('):
+			if p.consume(')') {
+				return g, true
+			}
+			for {
+				var part *Geometry
+				if part, ok = p.geometry(depth + 1); !ok {
+					return nil, false
+				}
+				g.Parts = append(g.Parts, part)
+				if !p.consume(',') {
+					break
+				}
+			}
+			ok = p.consume(')')
+		}
+	}
+	return g, ok
+}
+
+// AppendWKT appends the Well-Known Text representation of the geometry to buf,
+// formatted the same way as MySQL's ST_AsText
+func (g *Geometry) AppendWKT(buf []byte) []byte {
+	buf = append(buf, g.Type.String()...)
+	return g.appendWKTBody(buf)
+}
+
+func (g *Geometry) appendWKTBody(buf []byte) []byte {
+	switch g.Type {
+	case Point, LineString:
+		return appendWKTPoints(buf, g.Points)
+	case Polygon:
+		return appendWKTParts(buf, g.Parts, func(buf []byte, ring *Geometry) []byte {
+			return appendWKTPoints(buf, ring.Points)
+		})
+	case MultiPoint, MultiLineString, MultiPolygon:
+		return appendWKTParts(buf, g.Parts, func(buf []byte, part *Geometry) []byte {
+			return part.appendWKTBody(buf)
+		})
+	default:
+		if len(g.Parts) == 0 {
+			return append(buf, " EMPTY"...)
+		}
+		return appendWKTParts(buf, g.Parts, func(buf []byte, part *Geometry) []byte {
+			return part.AppendWKT(buf)
+		})
+	}
+}
+
+// String returns the Well-Known Text representation of the geometry
+func (g *Geometry) String() string {
+	return string(g.AppendWKT(nil))
+}
+
+func appendWKTParts(buf []byte, parts []*Geometry, appendPart func([]byte, *Geometry) []byte) []byte {
+	buf = append(buf, '(')
+	for i, part := range parts {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendPart(buf, part)
+	}
+	return append(buf, ')')
+}
+
+func appendWKTPoints(buf []byte, points []Coord) []byte {
+	buf = append(buf, '(')
+	for i, p := range points {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendWKTNumber(buf, p.X)
+		buf = append(buf, ' ')
+		buf = appendWKTNumber(buf, p.Y)
+	}
+	return append(buf, ')')
+}
+
+func appendWKTNumber(buf []byte, f float64) []byte {
+	start := len(buf)
+	buf = strconv.AppendFloat(buf, f, 'g', -1, 64)
+	if i := bytes.IndexByte(buf[start:], '+'); i >= 0 {
+		buf = append(buf[:start+i], buf[start+i+1:]...)
+	}
+	return buf
+}
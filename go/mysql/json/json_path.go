@@ -217,20 +217,37 @@ func (jp *Path) Match(doc *Value, wrap bool, match func(value *Value)) {
 	m.value(jp, doc)
 }
 
-func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
+// IsDocumentRoot returns true if the path points to the whole document, i.e. `$`
+func (jp *Path) IsDocumentRoot() bool {
+	return jp.kind == jpDocumentRoot && jp.next == nil
+}
+
+// IsArrayCell returns true if the last leg of the path is an array location
+func (jp *Path) IsArrayCell() bool {
+	for jp.next != nil {
+		jp = jp.next
+	}
+	return jp.kind == jpArrayLocation
+}
+
+// transform walks the path until its last leg and calls t with that leg, the value
+// the leg applies to, and a function that replaces that value inside of its parent.
+func (jp *Path) transform(v *Value, replace func(*Value), t func(pp *Path, vv *Value, replace func(*Value))) {
 	if v == nil {
 		return
 	}
 	if jp.next == nil {
-		t(jp, v)
+		t(jp, v, replace)
 		return
 	}
 	switch jp.kind {
 	case jpDocumentRoot:
-		jp.next.transform(v, t)
+		jp.next.transform(v, replace, t)
 	case jpMember:
 		if obj, ok := v.Object(); ok {
-			jp.next.transform(obj.Get(jp.name), t)
+			if i, ok := obj.find(jp.name); ok {
+				jp.next.transform(obj.kvs[i].v, func(nv *Value) { obj.kvs[i].v = nv }, t)
+			}
 		}
 	case jpArrayLocation:
 		if ary, ok := v.Array(); ok {
@@ -239,7 +256,7 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				panic("range in transformation path expression")
 			}
 			if from >= 0 && from < len(ary) {
-				jp.next.transform(ary[from], t)
+				jp.next.transform(ary[from], func(nv *Value) { ary[from] = nv }, t)
 			}
 		} else if jp.offset0 == 0 || jp.offset0 == -1 {
 			/*
@@ -247,7 +264,7 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				the result of the evaluation is the same as if the value had been
 				wrapped in a single-element array:
 			*/
-			jp.next.transform(v, t)
+			jp.next.transform(v, replace, t)
 		}
 	case jpMemberAny, jpArrayLocationAny, jpAny:
 		panic("wildcard in transformation path expression")
@@ -261,40 +278,124 @@ const (
 	Insert
 	Replace
 	Remove
+	ArrayAppend
+	ArrayInsert
 )
 
-func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) error {
+// ApplyTransform applies the transformation to doc for every one of the given paths, using
+// the value with the same index, and returns the resulting document. The arrays and objects
+// in doc are modified in place, so doc must not be shared with any other expression.
+// The paths cannot contain wildcards or ranges.
+func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) (*Value, error) {
 	if t != Remove && len(paths) != len(values) {
 		panic("missing Values for transformation")
 	}
 	for i, p := range paths {
-		transform := func(pp *Path, vv *Value) {
+		transform := func(pp *Path, vv *Value, replace func(*Value)) {
 			switch pp.kind {
+			case jpDocumentRoot:
+				switch t {
+				case Set, Replace:
+					replace(values[i])
+				case ArrayAppend:
+					vv.appendArrayItem(values[i], replace)
+				}
 			case jpArrayLocation:
-				if ary, ok := vv.Array(); ok {
-					from, to := pp.arrayOffsets(ary)
-					if from != to {
-						return
-					}
-					if t == Remove {
-						vv.DelArrayItem(from)
-					} else {
-						vv.SetArrayItem(from, values[i], t)
+				ary, ok := vv.Array()
+				if !ok {
+					vv.transformScalarArrayItem(pp, values, i, t, replace)
+					return
+				}
+				from, to := pp.arrayOffsets(ary)
+				if from != to {
+					return
+				}
+				switch t {
+				case Remove:
+					vv.DelArrayItem(from)
+				case ArrayAppend:
+					if from >= 0 && from < len(ary) {
+						ary[from].appendArrayItem(values[i], func(nv *Value) { ary[from] = nv })
 					}
+				case ArrayInsert:
+					vv.InsArrayItem(from, values[i])
+				default:
+					vv.SetArrayItem(from, values[i], t)
 				}
 			case jpMember:
 				if obj, ok := vv.Object(); ok {
-					if t == Remove {
+					switch t {
+					case Remove:
 						obj.Del(pp.name)
-					} else {
+					case ArrayAppend:
+						if idx, ok := obj.find(pp.name); ok {
+							obj.kvs[idx].v.appendArrayItem(values[i], func(nv *Value) { obj.kvs[idx].v = nv })
+						}
+					case ArrayInsert:
+					default:
 						obj.Set(pp.name, values[i], t)
 					}
 				}
 			}
 		}
-		p.transform(doc, transform)
+		p.transform(doc, func(nv *Value) { doc = nv }, transform)
+	}
+	return doc, nil
+}
+
+// transformScalarArrayItem applies the transformation for an array location to a value that is
+// not an array. The value behaves as if it were wrapped in a single-element array.
+func (v *Value) transformScalarArrayItem(pp *Path, values []*Value, i int, t Transformation, replace func(*Value)) {
+	self := pp.offset0 == 0 || pp.offset0 == -1
+	switch t {
+	case Set:
+		if self {
+			replace(values[i])
+		} else if pp.offset0 > 0 {
+			replace(NewArray([]*Value{v, values[i]}))
+		}
+	case Insert:
+		if pp.offset0 > 0 {
+			replace(NewArray([]*Value{v, values[i]}))
+		}
+	case Replace:
+		if self {
+			replace(values[i])
+		}
+	case ArrayAppend:
+		if self {
+			v.appendArrayItem(values[i], replace)
+		}
+	}
+}
+
+// Walk calls f for v and for every value nested inside of it, in document order, together
+// with the path expression that points to each value. When f returns false, the values nested
+// inside the current value are skipped. The path is only valid during the call to f.
+func (v *Value) Walk(f func(path []byte, v *Value) bool) {
+	v.walk([]byte{'$'}, f)
+}
+
+func (v *Value) walk(path []byte, f func(path []byte, v *Value) bool) {
+	if !f(path, v) {
+		return
+	}
+	switch v.t {
+	case TypeArray:
+		for i, item := range v.a {
+			item.walk(fmt.Appendf(path, "[%d]", i), f)
+		}
+	case TypeObject:
+		for _, item := range v.o.kvs {
+			p := append(path, '.')
+			if jpIsIdentifier(item.k) {
+				p = append(p, item.k...)
+			} else {
+				p = escapeString(p, item.k)
+			}
+			item.v.walk(p, f)
+		}
 	}
-	return nil
 }
 
 func MatchPath(rawJSON, rawPath []byte, match func(value *Value)) error {
@@ -171,10 +171,39 @@ func TestTransformations(t *testing.T) {
 			Paths:    []string{`$[2]`, `$[1].b[1]`, `$[1].b[1]`},
 			Expected: `["a", {"b": [true]}]`,
 		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$[0][1]`, `$[2][5]`, `$[1].c`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[["a", 1], {"b": [true, false], "c": 3}, [10, 20, 2]]`,
+		},
+		{
+			T:        Replace,
+			Document: Document1,
+			Paths:    []string{`$[0][0]`, `$[1].c`, `$`},
+			Values:   []string{"1", "2", "[3]"},
+			Expected: `[3]`,
+		},
+		{
+			T:        ArrayAppend,
+			Document: Document1,
+			Paths:    []string{`$[0]`, `$[1].b`, `$`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[["a", 1], {"b": [true, false, 2]}, [10, 20], 3]`,
+		},
+		{
+			T:        ArrayInsert,
+			Document: Document1,
+			Paths:    []string{`$[1].b[0]`, `$[2][10]`, `$[0][0]`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `["a", {"b": [1, true, false]}, [10, 20, 2]]`,
+		},
 	}
 
 	for _, tc := range cases {
 		doc := json(t, tc.Document)
+		original := doc.Clone()
 
 		var paths []*Path
 		for _, p := range tc.Paths {
@@ -186,7 +215,7 @@ func TestTransformations(t *testing.T) {
 			values = append(values, json(t, v))
 		}
 
-		err := ApplyTransform(tc.T, doc, paths, values)
+		doc, err := ApplyTransform(tc.T, doc, paths, values)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -195,5 +224,23 @@ func TestTransformations(t *testing.T) {
 		if result != tc.Expected {
 			t.Errorf("bad transformation (%v)\nwant: %s\ngot:  %s", tc.T, tc.Expected, result)
 		}
+		if unchanged := string(original.MarshalTo(nil)); tc.Document != unchanged {
+			t.Errorf("clone was modified by transformation (%v): %s", tc.T, unchanged)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	doc := json(t, `{"a": [1, {"b c": "d"}], "e": {"f": true}}`)
+
+	var paths []string
+	doc.Walk(func(path []byte, v *Value) bool {
+		paths = append(paths, string(path))
+		return string(path) != "$.e"
+	})
+
+	expected := []string{`$`, `$.a`, `$.a[0]`, `$.a[1]`, `$.a[1]."b c"`, `$.e`}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("bad walk\nwant: %v\ngot:  %v", expected, paths)
 	}
 }
@@ -78,8 +78,10 @@ func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 		}
 		fallthrough
 	case Set:
-		for idx >= len(v.a) {
-			v.a = append(v.a, ValueNull)
+		// positions past the end of the array append the value at the end
+		if idx >= len(v.a) {
+			v.a = append(v.a, value)
+			return
 		}
 	}
 	if idx < len(v.a) {
@@ -87,6 +89,29 @@ func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 	}
 }
 
+// InsArrayItem inserts the value in the array v at idx position, shifting
+// the following items to the right. Positions past the end of the array
+// append the value at the end.
+func (v *Value) InsArrayItem(idx int, value *Value) {
+	if v == nil || v.t != TypeArray || idx < 0 {
+		return
+	}
+	if idx > len(v.a) {
+		idx = len(v.a)
+	}
+	v.a = slices.Insert(v.a, idx, value)
+}
+
+// appendArrayItem appends the value to the array v. If v is not an array,
+// it is replaced by an array containing both v and the value.
+func (v *Value) appendArrayItem(value *Value, replace func(*Value)) {
+	if v.t == TypeArray {
+		v.a = append(v.a, value)
+		return
+	}
+	replace(NewArray([]*Value{v, value}))
+}
+
 func (v *Value) DelArrayItem(n int) {
 	if v == nil || v.t != TypeArray {
 		return
@@ -96,3 +121,51 @@ func (v *Value) DelArrayItem(n int) {
 	}
 	v.a = append(v.a[:n], v.a[n+1:]...)
 }
+
+// Clone returns a copy of v that can be transformed without modifying v.
+// Arrays and objects are copied recursively; scalar values are never
+// modified in place, so they are shared with v.
+func (v *Value) Clone() *Value {
+	if v == nil {
+		return nil
+	}
+	switch v.t {
+	case TypeArray:
+		a := make([]*Value, 0, len(v.a))
+		for _, item := range v.a {
+			a = append(a, item.Clone())
+		}
+		return NewArray(a)
+	case TypeObject:
+		var obj Object
+		obj.kvs = make([]kv, 0, len(v.o.kvs))
+		for _, item := range v.o.kvs {
+			obj.kvs = append(obj.kvs, kv{item.k, item.v.Clone()})
+		}
+		return NewObject(obj)
+	default:
+		return v
+	}
+}
+
+// MergePatch merges patch into target as described in RFC 7396 and returns the result.
+// The objects in target are modified in place, so target must not be shared with any
+// other expression. A nil target is treated as a missing value.
+func MergePatch(target, patch *Value) *Value {
+	po, ok := patch.Object()
+	if !ok {
+		return patch
+	}
+	if target == nil || target.t != TypeObject {
+		target = NewObject(Object{})
+	}
+	to, _ := target.Object()
+	po.Visit(func(key string, value *Value) {
+		if value.t == TypeNull {
+			to.Del(key)
+			return
+		}
+		to.Set(key, MergePatch(to.Get(key), value), Set)
+	})
+	return target
+}
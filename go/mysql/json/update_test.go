@@ -106,3 +106,29 @@ func TestValueDelSet(t *testing.T) {
 		t.Fatalf("unexpected number of items left in the array; got %d; want %d", len(a), 2)
 	}
 }
+
+func TestMergePatch(t *testing.T) {
+	cases := []struct {
+		target, patch, expected string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`["a", "b"]`, `["c", "d"]`, `["c", "d"]`},
+		{`{"a": "b"}`, `["c"]`, `["c"]`},
+		{`{"e": null}`, `{"a": 1}`, `{"a": 1, "e": null}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	}
+
+	for _, tc := range cases {
+		result := MergePatch(MustParse(tc.target), MustParse(tc.patch))
+		if got := string(result.MarshalTo(nil)); got != tc.expected {
+			t.Errorf("bad merge patch of %s with %s\nwant: %s\ngot:  %s", tc.target, tc.patch, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Copyright (C) 1995-2022 Jean-loup Gailly and Mark Adler
+Copyright 2025 The Vitess Authors.
+
+This file contains code derived from the zlib compression library.
+License & terms of use for the original code: https://zlib.net/zlib_license.html
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package zlib implements the zlib compression format exactly like the reference
+// C implementation that MySQL links against. The deflate format allows many
+// different encodings for the same input, and Go's compress/flate does not produce
+// the same ones as zlib at any level: it has its own match finder and block
+// splitting, stores short inputs in uncompressed blocks, and ends its output with an
+// extra empty block. For instance, it compresses "hello" into 18 bytes
+// where zlib uses 13, and the bytes differ for almost every input.
+//
+// The output of COMPRESS() is stored in columns, compared and hashed as a binary
+// string, so a value compressed by vtgate would not match the same value compressed
+// by MySQL. This package is a port of zlib's default compression level, so that
+// COMPRESS() can be evaluated in Vitess with results that are byte-identical to
+// MySQL's. Decompression uses compress/zlib, as any valid stream decodes the same.
+package zlib
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"hash/adler32"
+	"io"
+)
+
+const (
+	minMatch = 3
+	maxMatch = 258
+
+	// wBits and memLevel are the defaults used by zlib's compress()
+	wBits    = 15
+	memLevel = 8
+
+	wSize        = 1 << wBits
+	wMask        = wSize - 1
+	windowSize   = 2 * wSize
+	hashBits     = memLevel + 7
+	hashSize     = 1 << hashBits
+	hashMask     = hashSize - 1
+	hashShift    = (hashBits + minMatch - 1) / minMatch
+	litBufSize   = 1 << (memLevel + 6)
+	minLookahead = maxMatch + minMatch + 1
+	maxDist      = wSize - minLookahead
+	winInit      = maxMatch
+
+	// tooFar discards matches of length 3 that are too distant to be worth it
+	tooFar = 4096
+
+	// these are the parameters of zlib's default compression level (6)
+	goodLength = 8
+	maxLazy    = 16
+	niceLength = 128
+	maxChain   = 128
+)
+
+// ErrCorrupt is returned when decompressing data that is not a valid zlib stream,
+// or that decompresses to more data than expected
+var ErrCorrupt = errors.New("zlib: invalid compressed data")
+
+type deflateState struct {
+	window     [windowSize + maxMatch]byte
+	prev       [wSize]uint16
+	head       [hashSize]uint16
+	insH       uint32
+	highWater  int
+	blockStart int
+	strstart   int
+	lookahead  int
+	insert     int
+
+	matchStart     int
+	matchLength    int
+	prevLength     int
+	prevMatch      int
+	matchAvailable bool
+
+	input []byte
+
+	trees
+}
+
+// Compress appends to dst the zlib stream that zlib's compress() function
+// generates for src with the default compression level
+func Compress(dst, src []byte) []byte {
+	s := &deflateState{input: src}
+	s.trees.out = dst
+	s.matchLength = minMatch - 1
+	s.prevLength = minMatch - 1
+	s.initBlock()
+
+	// the zlib header for the default compression level and window size
+	s.out = append(s.out, 0x78, 0x9c)
+	s.deflateSlow()
+	return binary.BigEndian.AppendUint32(s.out, adler32.Checksum(src))
+}
+
+// Uncompress decompresses a zlib stream. Like zlib's uncompress(), it fails if the
+// stream decompresses to more than maxSize bytes and ignores any data after its end.
+func Uncompress(src []byte, maxSize int) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil || len(out) > maxSize {
+		return nil, ErrCorrupt
+	}
+	return out, nil
+}
+
+func (s *deflateState) updateHash(c byte) {
+	s.insH = ((s.insH << hashShift) ^ uint32(c)) & hashMask
+}
+
+// insertString inserts the string starting at str in the hash table and returns
+// the previous head of its hash chain
+func (s *deflateState) insertString(str int) int {
+	s.updateHash(s.window[str+minMatch-1])
+	head := s.head[s.insH]
+	s.prev[str&wMask] = head
+	s.head[s.insH] = uint16(str)
+	return int(head)
+}
+
+func (s *deflateState) slideHash() {
+	for i, m := range s.head {
+		if int(m) >= wSize {
+			s.head[i] = m - wSize
+		} else {
+			s.head[i] = 0
+		}
+	}
+	for i, m := range s.prev {
+		if int(m) >= wSize {
+			s.prev[i] = m - wSize
+		} else {
+			s.prev[i] = 0
+		}
+	}
+}
+
+// fillWindow reads new data from the input when the lookahead becomes insufficient,
+// sliding the window down when the current position reaches its upper half
+func (s *deflateState) fillWindow() {
+	for {
+		more := windowSize - s.lookahead - s.strstart
+
+		if s.strstart >= wSize+maxDist {
+			copy(s.window[:wSize-more], s.window[wSize:])
+			s.matchStart -= wSize
+			s.strstart -= wSize
+			s.blockStart -= wSize
+			if s.insert > s.strstart {
+				s.insert = s.strstart
+			}
+			s.slideHash()
+			more += wSize
+		}
+		if len(s.input) == 0 {
+			break
+		}
+
+		n := copy(s.window[s.strstart+s.lookahead:s.strstart+s.lookahead+more], s.input)
+		s.input = s.input[n:]
+		s.lookahead += n
+
+		// initialize the hash value now that we have some input
+		if s.lookahead+s.insert >= minMatch {
+			str := s.strstart - s.insert
+			s.insH = uint32(s.window[str])
+			s.updateHash(s.window[str+1])
+			for s.insert > 0 {
+				s.updateHash(s.window[str+minMatch-1])
+				s.prev[str&wMask] = s.head[s.insH]
+				s.head[s.insH] = uint16(str)
+				str++
+				s.insert--
+				if s.lookahead+s.insert < minMatch {
+					break
+				}
+			}
+		}
+		if s.lookahead >= minLookahead || len(s.input) == 0 {
+			break
+		}
+	}
+
+	// zlib zeroes the bytes past the end of the current input, and the match
+	// finder can see them, so the window must be initialized the same way
+	if s.highWater < windowSize {
+		curr := s.strstart + s.lookahead
+		if s.highWater < curr {
+			init := min(windowSize-curr, winInit)
+			clear(s.window[curr : curr+init])
+			s.highWater = curr + init
+		} else if s.highWater < curr+winInit {
+			init := min(curr+winInit-s.highWater, windowSize-s.highWater)
+			clear(s.window[s.highWater : s.highWater+init])
+			s.highWater += init
+		}
+	}
+}
+
+// longestMatch returns the length of the longest match starting at curMatch,
+// following the hash chain, and sets matchStart to its position
+func (s *deflateState) longestMatch(curMatch int) int {
+	chainLength := maxChain
+	scan := s.strstart
+	bestLen := s.prevLength
+	niceMatch := niceLength
+	limit := 0
+	if s.strstart > maxDist {
+		limit = s.strstart - maxDist
+	}
+	scanEnd1 := s.window[scan+bestLen-1]
+	scanEnd := s.window[scan+bestLen]
+
+	if s.prevLength >= goodLength {
+		chainLength >>= 2
+	}
+	niceMatch = min(niceMatch, s.lookahead)
+
+	for {
+		match := curMatch
+		if s.window[match+bestLen] == scanEnd &&
+			s.window[match+bestLen-1] == scanEnd1 &&
+			s.window[match] == s.window[scan] &&
+			s.window[match+1] == s.window[scan+1] {
+			n := 2
+			for n < maxMatch && s.window[scan+n] == s.window[match+n] {
+				n++
+			}
+			if n > bestLen {
+				s.matchStart = curMatch
+				bestLen = n
+				if n >= niceMatch {
+					break
+				}
+				scanEnd1 = s.window[scan+bestLen-1]
+				scanEnd = s.window[scan+bestLen]
+			}
+		}
+
+		curMatch = int(s.prev[curMatch&wMask])
+		chainLength--
+		if curMatch <= limit || chainLength == 0 {
+			break
+		}
+	}
+	return min(bestLen, s.lookahead)
+}
+
+// flushBlock emits the current block, which ends at the current position
+func (s *deflateState) flushBlock(last bool) {
+	var buf []byte
+	if s.blockStart >= 0 {
+		buf = s.window[s.blockStart:s.strstart]
+	}
+	s.flushTrees(buf, last)
+	s.blockStart = s.strstart
+}
+
+// deflateSlow is zlib's compression strategy for the default level: a match is
+// only emitted if there is no better match at the next position (lazy evaluation)
+func (s *deflateState) deflateSlow() {
+	for {
+		if s.lookahead < minLookahead {
+			s.fillWindow()
+			if s.lookahead == 0 {
+				break
+			}
+		}
+
+		hashHead := 0
+		if s.lookahead >= minMatch {
+			hashHead = s.insertString(s.strstart)
+		}
+
+		s.prevLength = s.matchLength
+		s.prevMatch = s.matchStart
+		s.matchLength = minMatch - 1
+
+		if hashHead != 0 && s.prevLength < maxLazy && s.strstart-hashHead <= maxDist {
+			s.matchLength = s.longestMatch(hashHead)
+			if s.matchLength == minMatch && s.strstart-s.matchStart > tooFar {
+				s.matchLength = minMatch - 1
+			}
+		}
+
+		switch {
+		case s.prevLength >= minMatch && s.matchLength <= s.prevLength:
+			// the match at the previous position is better than the current one
+			maxInsert := s.strstart + s.lookahead - minMatch
+			flush := s.tallyDist(s.strstart-1-s.prevMatch, s.prevLength-minMatch)
+
+			s.lookahead -= s.prevLength - 1
+			for s.prevLength -= 2; s.prevLength != 0; s.prevLength-- {
+				s.strstart++
+				if s.strstart <= maxInsert {
+					s.insertString(s.strstart)
+				}
+			}
+			s.matchAvailable = false
+			s.matchLength = minMatch - 1
+			s.strstart++
+			if flush {
+				s.flushBlock(false)
+			}
+		case s.matchAvailable:
+			// there is no better match at the current position, so emit the previous byte
+			if s.tallyLit(s.window[s.strstart-1]) {
+				s.flushBlock(false)
+			}
+			s.strstart++
+			s.lookahead--
+		default:
+			// wait for the next step to decide between a literal and a match
+			s.matchAvailable = true
+			s.strstart++
+			s.lookahead--
+		}
+	}
+
+	if s.matchAvailable {
+		s.tallyLit(s.window[s.strstart-1])
+		s.matchAvailable = false
+	}
+	s.flushBlock(true)
+}
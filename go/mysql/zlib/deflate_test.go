@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zlib
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompress(t *testing.T) {
+	// the expected outputs have been generated with zlib's compress()
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"", "789C030000000001"},
+		{"hello", "789CCB48CDC9C90700062C0215"},
+		{strings.Repeat("a", 1000), "789C4B4C1C05A360140C770000F9D87AF8"},
+		{"The quick brown fox jumps over the lazy dog", "789C0BC94855282CCD4CCE56482ACA2FCF5348CBAF50C82ACD2D2856C82F4B2D5228014AE72456552AA4E4A703005BDC0FDA"},
+	}
+
+	for _, tc := range testCases {
+		compressed := Compress(nil, []byte(tc.in))
+		assert.Equal(t, tc.want, strings.ToUpper(hex.EncodeToString(compressed)))
+
+		uncompressed, err := Uncompress(compressed, len(tc.in))
+		require.NoError(t, err)
+		assert.Equal(t, tc.in, string(uncompressed))
+	}
+}
+
+func TestStdlibCompressDiffers(t *testing.T) {
+	// compress/zlib does not produce the same bytes as zlib's compress(), which
+	// is why COMPRESS() can't be evaluated with it
+	for _, in := range []string{"hello", strings.Repeat("a", 1000), "The quick brown fox jumps over the lazy dog"} {
+		var buf bytes.Buffer
+		w, err := zlib.NewWriterLevel(&buf, zlib.DefaultCompression)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(in))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		assert.NotEqual(t, Compress(nil, []byte(in)), buf.Bytes())
+	}
+}
+
+func TestCompressLarge(t *testing.T) {
+	// large enough to use several blocks and to slide the window many times
+	var in []byte
+	for i := range 200000 {
+		if i > 0 {
+			in = append(in, ' ')
+		}
+		in = strconv.AppendInt(in, int64(i*i%9973), 10)
+	}
+
+	compressed := Compress(nil, in)
+	assert.Len(t, compressed, 421888)
+	sum := sha256.Sum256(compressed)
+	assert.Equal(t, "c4115c2e0770f741d6ff4ff0028fa42f9c49ace0e0b711706e9fbb746fda9e75", hex.EncodeToString(sum[:]))
+
+	uncompressed, err := Uncompress(compressed, len(in))
+	require.NoError(t, err)
+	assert.Equal(t, in, uncompressed)
+}
+
+func TestUncompress(t *testing.T) {
+	compressed := Compress(nil, []byte("hello"))
+
+	out, err := Uncompress(compressed, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(out))
+
+	out, err = Uncompress(append(compressed, '.'), 100)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(out))
+
+	_, err = Uncompress(compressed, 4)
+	assert.ErrorIs(t, err, ErrCorrupt)
+	_, err = Uncompress(compressed[:len(compressed)-1], 5)
+	assert.ErrorIs(t, err, ErrCorrupt)
+	_, err = Uncompress([]byte("hello"), 5)
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
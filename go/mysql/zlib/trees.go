@@ -0,0 +1,622 @@
+/*
+Copyright (C) 1995-2022 Jean-loup Gailly and Mark Adler
+Copyright 2025 The Vitess Authors.
+
+This file contains code derived from the zlib compression library.
+License & terms of use for the original code: https://zlib.net/zlib_license.html
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zlib
+
+const (
+	maxBits   = 15
+	maxBLBits = 7
+
+	lengthCodes = 29
+	literals    = 256
+	lCodes      = literals + 1 + lengthCodes
+	dCodes      = 30
+	blCodes     = 19
+	heapSize    = 2*lCodes + 1
+	endBlock    = 256
+
+	// rep3to6 repeats the previous bit length 3-6 times, repz3to10 repeats a zero
+	// bit length 3-10 times and repz11to138 repeats a zero bit length 11-138 times
+	rep3to6     = 16
+	repz3to10   = 17
+	repz11to138 = 18
+
+	storedBlock = 0
+	staticTrees = 1
+	dynTrees    = 2
+)
+
+var (
+	extraLBits  = [lengthCodes]int{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5, 0}
+	extraDBits  = [dCodes]int{0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8, 9, 9, 10, 10, 11, 11, 12, 12, 13, 13}
+	extraBLBits = [blCodes]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 3, 7}
+
+	// blOrder is the order in which the bit lengths of the bit length codes are sent
+	blOrder = [blCodes]int{16, 17, 18, 0, 8, 7, 9, 6, 10, 5, 11, 4, 12, 3, 13, 2, 14, 1, 15}
+)
+
+var (
+	staticLTree [lCodes + 2]treeNode
+	staticDTree [dCodes]treeNode
+	lengthCode  [maxMatch - minMatch + 1]uint8
+	distCode    [512]uint8
+	baseLength  [lengthCodes]int
+	baseDist    [dCodes]int
+)
+
+func init() {
+	length := 0
+	code := 0
+	for ; code < lengthCodes-1; code++ {
+		baseLength[code] = length
+		for n := 0; n < 1<<extraLBits[code]; n++ {
+			lengthCode[length] = uint8(code)
+			length++
+		}
+	}
+	// the length 258 is represented by its own code instead of using the
+	// 5 extra bits of the previous code
+	lengthCode[length-1] = uint8(code)
+
+	dist := 0
+	for code = 0; code < 16; code++ {
+		baseDist[code] = dist
+		for n := 0; n < 1<<extraDBits[code]; n++ {
+			distCode[dist] = uint8(code)
+			dist++
+		}
+	}
+	dist >>= 7
+	for ; code < dCodes; code++ {
+		baseDist[code] = dist << 7
+		for n := 0; n < 1<<(extraDBits[code]-7); n++ {
+			distCode[256+dist] = uint8(code)
+			dist++
+		}
+	}
+
+	var blCount [maxBits + 1]int
+	n := 0
+	for ; n <= 143; n++ {
+		staticLTree[n].len = 8
+	}
+	for ; n <= 255; n++ {
+		staticLTree[n].len = 9
+	}
+	for ; n <= 279; n++ {
+		staticLTree[n].len = 7
+	}
+	for ; n <= 287; n++ {
+		staticLTree[n].len = 8
+	}
+	blCount[7] = 24
+	blCount[8] = 144 + 8
+	blCount[9] = 112
+	genCodes(staticLTree[:], lCodes+1, &blCount)
+
+	for n = 0; n < dCodes; n++ {
+		staticDTree[n].len = 5
+		staticDTree[n].code = uint16(bitReverse(n, 5))
+	}
+}
+
+func dCode(dist int) uint8 {
+	if dist < 256 {
+		return distCode[dist]
+	}
+	return distCode[256+(dist>>7)]
+}
+
+// treeNode is a node of a Huffman tree. zlib stores the frequency and the code,
+// and the parent and the bit length, in unions; they are kept separate here
+type treeNode struct {
+	freq int
+	code uint16
+	dad  int
+	len  int
+}
+
+type treeDesc struct {
+	dynTree    []treeNode
+	maxCode    int
+	staticTree []treeNode
+	extraBits  []int
+	extraBase  int
+	elems      int
+	maxLength  int
+}
+
+type trees struct {
+	dynLTree [heapSize]treeNode
+	dynDTree [2*dCodes + 1]treeNode
+	blTree   [2*blCodes + 1]treeNode
+
+	lDesc, dDesc, blDesc treeDesc
+
+	blCount [maxBits + 1]int
+	heap    [heapSize]int
+	heapLen int
+	heapMax int
+	depth   [heapSize]uint8
+
+	// symbols are stored as (distance, length or literal) pairs; a zero
+	// distance means that the symbol is a literal
+	syms    []symbol
+	opt     int
+	static  int
+	initted bool
+
+	out   []byte
+	bits  uint64
+	nbits uint
+}
+
+type symbol struct {
+	dist uint16
+	lc   uint8
+}
+
+func (t *trees) initBlock() {
+	if !t.initted {
+		t.lDesc = treeDesc{dynTree: t.dynLTree[:], staticTree: staticLTree[:], extraBits: extraLBits[:], extraBase: literals + 1, elems: lCodes, maxLength: maxBits}
+		t.dDesc = treeDesc{dynTree: t.dynDTree[:], staticTree: staticDTree[:], extraBits: extraDBits[:], extraBase: 0, elems: dCodes, maxLength: maxBits}
+		t.blDesc = treeDesc{dynTree: t.blTree[:], extraBits: extraBLBits[:], extraBase: 0, elems: blCodes, maxLength: maxBLBits}
+		t.syms = make([]symbol, 0, litBufSize-1)
+		t.initted = true
+	}
+	for n := 0; n < lCodes; n++ {
+		t.dynLTree[n].freq = 0
+	}
+	for n := 0; n < dCodes; n++ {
+		t.dynDTree[n].freq = 0
+	}
+	for n := 0; n < blCodes; n++ {
+		t.blTree[n].freq = 0
+	}
+	t.dynLTree[endBlock].freq = 1
+	t.opt = 0
+	t.static = 0
+	t.syms = t.syms[:0]
+}
+
+// tallyLit records a literal and returns whether the current block must be flushed
+func (t *trees) tallyLit(c byte) bool {
+	t.syms = append(t.syms, symbol{lc: c})
+	t.dynLTree[c].freq++
+	return len(t.syms) == litBufSize-1
+}
+
+// tallyDist records a match and returns whether the current block must be flushed
+func (t *trees) tallyDist(dist, length int) bool {
+	t.syms = append(t.syms, symbol{dist: uint16(dist), lc: uint8(length)})
+	dist--
+	t.dynLTree[int(lengthCode[length])+literals+1].freq++
+	t.dynDTree[dCode(dist)].freq++
+	return len(t.syms) == litBufSize-1
+}
+
+func (t *trees) sendBits(value int, length uint) {
+	t.bits |= uint64(value) << t.nbits
+	t.nbits += length
+	for t.nbits >= 16 {
+		t.out = append(t.out, byte(t.bits), byte(t.bits>>8))
+		t.bits >>= 16
+		t.nbits -= 16
+	}
+}
+
+func (t *trees) sendCode(c int, tree []treeNode) {
+	t.sendBits(int(tree[c].code), uint(tree[c].len))
+}
+
+// windup flushes the remaining bits, aligning the output on a byte boundary
+func (t *trees) windup() {
+	if t.nbits > 8 {
+		t.out = append(t.out, byte(t.bits), byte(t.bits>>8))
+	} else if t.nbits > 0 {
+		t.out = append(t.out, byte(t.bits))
+	}
+	t.bits = 0
+	t.nbits = 0
+}
+
+// pqDownHeap restores the heap property by moving down the tree starting at node k
+func (t *trees) pqDownHeap(tree []treeNode, k int) {
+	v := t.heap[k]
+	j := k << 1
+	for j <= t.heapLen {
+		if j < t.heapLen && smaller(tree, t.heap[j+1], t.heap[j], &t.depth) {
+			j++
+		}
+		if smaller(tree, v, t.heap[j], &t.depth) {
+			break
+		}
+		t.heap[k] = t.heap[j]
+		k = j
+		j <<= 1
+	}
+	t.heap[k] = v
+}
+
+func smaller(tree []treeNode, n, m int, depth *[heapSize]uint8) bool {
+	return tree[n].freq < tree[m].freq || (tree[n].freq == tree[m].freq && depth[n] <= depth[m])
+}
+
+// genBitLen computes the optimal bit lengths for a tree, limiting them to the
+// maximum length of the tree, and updates the total bit length of the block
+func (t *trees) genBitLen(desc *treeDesc) {
+	tree := desc.dynTree
+	stree := desc.staticTree
+	overflow := 0
+
+	for bits := range t.blCount {
+		t.blCount[bits] = 0
+	}
+	// the root of the heap has a zero length
+	tree[t.heap[t.heapMax]].len = 0
+
+	h := t.heapMax + 1
+	for ; h < heapSize; h++ {
+		n := t.heap[h]
+		bits := tree[tree[n].dad].len + 1
+		if bits > desc.maxLength {
+			bits = desc.maxLength
+			overflow++
+		}
+		tree[n].len = bits
+		if n > desc.maxCode {
+			// not a leaf node
+			continue
+		}
+		t.blCount[bits]++
+		xbits := 0
+		if n >= desc.extraBase {
+			xbits = desc.extraBits[n-desc.extraBase]
+		}
+		f := tree[n].freq
+		t.opt += f * (bits + xbits)
+		if stree != nil {
+			t.static += f * (stree[n].len + xbits)
+		}
+	}
+	if overflow == 0 {
+		return
+	}
+
+	// find the first bit length which could increase
+	for overflow > 0 {
+		bits := desc.maxLength - 1
+		for t.blCount[bits] == 0 {
+			bits--
+		}
+		t.blCount[bits]--
+		t.blCount[bits+1] += 2
+		t.blCount[desc.maxLength]--
+		overflow -= 2
+	}
+
+	// recompute all bit lengths, scanning in increasing frequency
+	for bits := desc.maxLength; bits != 0; bits-- {
+		n := t.blCount[bits]
+		for n != 0 {
+			h--
+			m := t.heap[h]
+			if m > desc.maxCode {
+				continue
+			}
+			if tree[m].len != bits {
+				t.opt += (bits - tree[m].len) * tree[m].freq
+				tree[m].len = bits
+			}
+			n--
+		}
+	}
+}
+
+// genCodes generates the codes for a tree given its bit lengths
+func genCodes(tree []treeNode, maxCode int, blCount *[maxBits + 1]int) {
+	var nextCode [maxBits + 1]int
+	code := 0
+	for bits := 1; bits <= maxBits; bits++ {
+		code = (code + blCount[bits-1]) << 1
+		nextCode[bits] = code
+	}
+	for n := 0; n <= maxCode; n++ {
+		l := tree[n].len
+		if l == 0 {
+			continue
+		}
+		tree[n].code = uint16(bitReverse(nextCode[l], l))
+		nextCode[l]++
+	}
+}
+
+func bitReverse(code, length int) int {
+	res := 0
+	for {
+		res |= code & 1
+		code >>= 1
+		res <<= 1
+		length--
+		if length <= 0 {
+			break
+		}
+	}
+	return res >> 1
+}
+
+// buildTree builds the Huffman tree of a block and computes its bit lengths and codes
+func (t *trees) buildTree(desc *treeDesc) {
+	tree := desc.dynTree
+	stree := desc.staticTree
+	maxCode := -1
+
+	t.heapLen = 0
+	t.heapMax = heapSize
+	for n := 0; n < desc.elems; n++ {
+		if tree[n].freq != 0 {
+			t.heapLen++
+			t.heap[t.heapLen] = n
+			maxCode = n
+			t.depth[n] = 0
+		} else {
+			tree[n].len = 0
+		}
+	}
+
+	// the deflate format requires at least one distance code, and at least
+	// two codes of non-zero frequency in every tree
+	for t.heapLen < 2 {
+		node := 0
+		if maxCode < 2 {
+			maxCode++
+			node = maxCode
+		}
+		t.heapLen++
+		t.heap[t.heapLen] = node
+		tree[node].freq = 1
+		t.depth[node] = 0
+		t.opt--
+		if stree != nil {
+			t.static -= stree[node].len
+		}
+	}
+	desc.maxCode = maxCode
+
+	for n := t.heapLen / 2; n >= 1; n-- {
+		t.pqDownHeap(tree, n)
+	}
+
+	// combine the two least frequent nodes until there is only one left
+	node := desc.elems
+	for {
+		n := t.heap[1]
+		t.heap[1] = t.heap[t.heapLen]
+		t.heapLen--
+		t.pqDownHeap(tree, 1)
+		m := t.heap[1]
+
+		t.heapMax--
+		t.heap[t.heapMax] = n
+		t.heapMax--
+		t.heap[t.heapMax] = m
+
+		tree[node].freq = tree[n].freq + tree[m].freq
+		t.depth[node] = max(t.depth[n], t.depth[m]) + 1
+		tree[n].dad = node
+		tree[m].dad = node
+
+		t.heap[1] = node
+		node++
+		t.pqDownHeap(tree, 1)
+
+		if t.heapLen < 2 {
+			break
+		}
+	}
+	t.heapMax--
+	t.heap[t.heapMax] = t.heap[1]
+
+	t.genBitLen(desc)
+	genCodes(tree, maxCode, &t.blCount)
+}
+
+// scanTree computes the frequencies of the bit length codes needed to send a tree
+func (t *trees) scanTree(tree []treeNode, maxCode int) {
+	prevLen := -1
+	nextLen := tree[0].len
+	count := 0
+	maxCount, minCount := 7, 4
+	if nextLen == 0 {
+		maxCount, minCount = 138, 3
+	}
+	// guard
+	tree[maxCode+1].len = 0xffff
+
+	for n := 0; n <= maxCode; n++ {
+		curLen := nextLen
+		nextLen = tree[n+1].len
+		count++
+		if count < maxCount && curLen == nextLen {
+			continue
+		}
+		switch {
+		case count < minCount:
+			t.blTree[curLen].freq += count
+		case curLen != 0:
+			if curLen != prevLen {
+				t.blTree[curLen].freq++
+			}
+			t.blTree[rep3to6].freq++
+		case count <= 10:
+			t.blTree[repz3to10].freq++
+		default:
+			t.blTree[repz11to138].freq++
+		}
+		count = 0
+		prevLen = curLen
+		switch {
+		case nextLen == 0:
+			maxCount, minCount = 138, 3
+		case curLen == nextLen:
+			maxCount, minCount = 6, 3
+		default:
+			maxCount, minCount = 7, 4
+		}
+	}
+}
+
+// sendTree sends a tree in compressed form using the bit length codes
+func (t *trees) sendTree(tree []treeNode, maxCode int) {
+	prevLen := -1
+	nextLen := tree[0].len
+	count := 0
+	maxCount, minCount := 7, 4
+	if nextLen == 0 {
+		maxCount, minCount = 138, 3
+	}
+
+	for n := 0; n <= maxCode; n++ {
+		curLen := nextLen
+		nextLen = tree[n+1].len
+		count++
+		if count < maxCount && curLen == nextLen {
+			continue
+		}
+		switch {
+		case count < minCount:
+			for ; count != 0; count-- {
+				t.sendCode(curLen, t.blTree[:])
+			}
+		case curLen != 0:
+			if curLen != prevLen {
+				t.sendCode(curLen, t.blTree[:])
+				count--
+			}
+			t.sendCode(rep3to6, t.blTree[:])
+			t.sendBits(count-3, 2)
+		case count <= 10:
+			t.sendCode(repz3to10, t.blTree[:])
+			t.sendBits(count-3, 3)
+		default:
+			t.sendCode(repz11to138, t.blTree[:])
+			t.sendBits(count-11, 7)
+		}
+		count = 0
+		prevLen = curLen
+		switch {
+		case nextLen == 0:
+			maxCount, minCount = 138, 3
+		case curLen == nextLen:
+			maxCount, minCount = 6, 3
+		default:
+			maxCount, minCount = 7, 4
+		}
+	}
+}
+
+// buildBLTree builds the tree for the bit lengths and returns the index in
+// blOrder of the last bit length code to send
+func (t *trees) buildBLTree() int {
+	t.scanTree(t.dynLTree[:], t.lDesc.maxCode)
+	t.scanTree(t.dynDTree[:], t.dDesc.maxCode)
+	t.buildTree(&t.blDesc)
+
+	maxBLIndex := blCodes - 1
+	for ; maxBLIndex >= 3; maxBLIndex-- {
+		if t.blTree[blOrder[maxBLIndex]].len != 0 {
+			break
+		}
+	}
+	t.opt += 3*(maxBLIndex+1) + 5 + 5 + 4
+	return maxBLIndex
+}
+
+func (t *trees) sendAllTrees(lcodes, dcodes, blcodes int) {
+	t.sendBits(lcodes-257, 5)
+	t.sendBits(dcodes-1, 5)
+	t.sendBits(blcodes-4, 4)
+	for rank := 0; rank < blcodes; rank++ {
+		t.sendBits(t.blTree[blOrder[rank]].len, 3)
+	}
+	t.sendTree(t.dynLTree[:], lcodes-1)
+	t.sendTree(t.dynDTree[:], dcodes-1)
+}
+
+func (t *trees) compressBlock(ltree, dtree []treeNode) {
+	for _, sym := range t.syms {
+		if sym.dist == 0 {
+			t.sendCode(int(sym.lc), ltree)
+			continue
+		}
+		lc := int(sym.lc)
+		code := int(lengthCode[lc])
+		t.sendCode(code+literals+1, ltree)
+		if extra := extraLBits[code]; extra != 0 {
+			t.sendBits(lc-baseLength[code], uint(extra))
+		}
+		dist := int(sym.dist) - 1
+		code = int(dCode(dist))
+		t.sendCode(code, dtree)
+		if extra := extraDBits[code]; extra != 0 {
+			t.sendBits(dist-baseDist[code], uint(extra))
+		}
+	}
+	t.sendCode(endBlock, ltree)
+}
+
+// flushTrees emits the current block using the cheapest of the stored, static and
+// dynamic encodings. buf contains the uncompressed data of the block, or is nil if
+// it is no longer available in the window.
+func (t *trees) flushTrees(buf []byte, last bool) {
+	t.buildTree(&t.lDesc)
+	t.buildTree(&t.dDesc)
+	maxBLIndex := t.buildBLTree()
+
+	optLenB := (t.opt + 3 + 7) >> 3
+	staticLenB := (t.static + 3 + 7) >> 3
+	if staticLenB <= optLenB {
+		optLenB = staticLenB
+	}
+
+	lastBit := 0
+	if last {
+		lastBit = 1
+	}
+
+	switch {
+	case buf != nil && len(buf)+4 <= optLenB:
+		t.sendBits(storedBlock<<1+lastBit, 3)
+		t.windup()
+		t.out = append(t.out, byte(len(buf)), byte(len(buf)>>8), ^byte(len(buf)), ^byte(len(buf)>>8))
+		t.out = append(t.out, buf...)
+	case staticLenB == optLenB:
+		t.sendBits(staticTrees<<1+lastBit, 3)
+		t.compressBlock(staticLTree[:], staticDTree[:])
+	default:
+		t.sendBits(dynTrees<<1+lastBit, 3)
+		t.sendAllTrees(t.lDesc.maxCode+1, t.dDesc.maxCode+1, maxBLIndex+1)
+		t.compressBlock(t.dynLTree[:], t.dynDTree[:])
+	}
+	t.initBlock()
+	if last {
+		t.windup()
+	}
+}
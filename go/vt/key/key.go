@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"strings"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -86,6 +87,19 @@ func Empty(id []byte) bool {
 	return len(Normalize(id)) == 0
 }
 
+// PrefixEnd returns the smallest keyspace ID that is greater than id and than all the keyspace IDs that start with
+// id, or nil if there is no such keyspace ID. It can be used as the exclusive End of a KeyRange that includes id.
+func PrefixEnd(id []byte) []byte {
+	for i := len(id) - 1; i >= 0; i-- {
+		if id[i] != 0xff {
+			end := bytes.Clone(id[:i+1])
+			end[i]++
+			return end
+		}
+	}
+	return nil
+}
+
 //
 // KeyRange helper methods
 //
@@ -118,6 +132,33 @@ func KeyRangeAdd(a, b *topodatapb.KeyRange) (*topodatapb.KeyRange, bool) {
 	return nil, false
 }
 
+// KeyRangeUnion merges the given KeyRange values into the smallest list of KeyRange values that covers the same
+// keyspace IDs, i.e. overlapping and contiguous values are merged together. The result is sorted by Start.
+func KeyRangeUnion(keyRanges []*topodatapb.KeyRange) []*topodatapb.KeyRange {
+	if len(keyRanges) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(keyRanges)
+	slices.SortFunc(sorted, KeyRangeStartCompare)
+
+	out := []*topodatapb.KeyRange{NewKeyRange(sorted[0].GetStart(), sorted[0].GetEnd())}
+	for _, kr := range sorted[1:] {
+		last := out[len(out)-1]
+		if Empty(last.End) {
+			// the last KeyRange goes up to the maximum keyspace ID, so it covers all the remaining ones
+			break
+		}
+		if Less(last.End, kr.GetStart()) {
+			out = append(out, NewKeyRange(kr.GetStart(), kr.GetEnd()))
+			continue
+		}
+		if KeyRangeEndCompare(kr, last) > 0 {
+			last.End = kr.GetEnd()
+		}
+	}
+	return out
+}
+
 // KeyRangeContains returns true if the provided id is in the keyrange.
 func KeyRangeContains(keyRange *topodatapb.KeyRange, id []byte) bool {
 	if KeyRangeIsComplete(keyRange) {
@@ -435,6 +435,64 @@ func TestKeyRangeAdd(t *testing.T) {
 	}
 }
 
+func TestKeyRangeUnion(t *testing.T) {
+	testcases := []struct {
+		in  []string
+		out []string
+	}{{
+		in:  nil,
+		out: nil,
+	}, {
+		in:  []string{"40-80"},
+		out: []string{"40-80"},
+	}, {
+		in:  []string{"80-c0", "-40"},
+		out: []string{"-40", "80-c0"},
+	}, {
+		in:  []string{"40-80", "-40", "c0-"},
+		out: []string{"-80", "c0-"},
+	}, {
+		in:  []string{"40-a0", "20-60", "80-90"},
+		out: []string{"20-a0"},
+	}, {
+		in:  []string{"40-", "60-80", "-10"},
+		out: []string{"-10", "40-"},
+	}, {
+		in:  []string{"-40", "20-", "80-c0"},
+		out: []string{"-"},
+	}, {
+		in:  []string{"4000-80", "8000-c000"},
+		out: []string{"4000-c000"},
+	}}
+	for _, tcase := range testcases {
+		var in []*topodatapb.KeyRange
+		for _, kr := range tcase.in {
+			in = append(in, stringToKeyRange(kr))
+		}
+		var out []string
+		for _, kr := range KeyRangeUnion(in) {
+			out = append(out, KeyRangeString(kr))
+		}
+		assert.Equal(t, tcase.out, out, "KeyRangeUnion(%v)", tcase.in)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	testcases := []struct {
+		in  []byte
+		out []byte
+	}{
+		{in: []byte{0x10}, out: []byte{0x11}},
+		{in: []byte{0x80, 0x00}, out: []byte{0x80, 0x01}},
+		{in: []byte{0x40, 0xff}, out: []byte{0x41}},
+		{in: []byte{0xff, 0xff}, out: nil},
+		{in: nil, out: nil},
+	}
+	for _, tcase := range testcases {
+		assert.Equal(t, tcase.out, PrefixEnd(tcase.in), "PrefixEnd(%x)", tcase.in)
+	}
+}
+
 func TestKeyRangeEndEqual(t *testing.T) {
 	testcases := []struct {
 		first  string
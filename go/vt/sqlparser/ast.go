@@ -3191,6 +3191,15 @@ type (
 		NewXML    Expr
 	}
 
+	// GetFormatType is an enum that get types of GetFormatExpr
+	GetFormatType int8
+
+	// GetFormatExpr represents the GET_FORMAT({DATE|TIME|DATETIME|TIMESTAMP}, format) function.
+	GetFormatExpr struct {
+		Type GetFormatType
+		Expr Expr
+	}
+
 	// LockingFuncType is an enum that get types of LockingFunc
 	LockingFuncType int8
 
@@ -3307,6 +3316,7 @@ func (*LagLeadExpr) IsExpr()                        {}
 func (*NamedWindow) IsExpr()                        {}
 func (*ExtractValueExpr) IsExpr()                   {}
 func (*UpdateXMLExpr) IsExpr()                      {}
+func (*GetFormatExpr) IsExpr()                      {}
 func (*LockingFunc) IsExpr()                        {}
 func (*PerformanceSchemaFuncExpr) IsExpr()          {}
 func (*GTIDFuncExpr) IsExpr()                       {}
@@ -3353,6 +3363,7 @@ func (*GeomFromGeoJSONExpr) IsExpr()                {}
 func (*FuncExpr) iCallable()                           {}
 func (*TimestampDiffExpr) iCallable()                  {}
 func (*ExtractFuncExpr) iCallable()                    {}
+func (*GetFormatExpr) iCallable()                      {}
 func (*WeightStringFuncExpr) iCallable()               {}
 func (*CurTimeFuncExpr) iCallable()                    {}
 func (*ValuesFuncExpr) iCallable()                     {}
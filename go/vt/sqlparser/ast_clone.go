@@ -205,6 +205,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfGeomFromWKBExpr(in)
 	case *GeomPropertyFuncExpr:
 		return CloneRefOfGeomPropertyFuncExpr(in)
+	case *GetFormatExpr:
+		return CloneRefOfGetFormatExpr(in)
 	case *GroupBy:
 		return CloneRefOfGroupBy(in)
 	case *GroupConcatExpr:
@@ -1551,6 +1553,16 @@ func CloneRefOfGeomPropertyFuncExpr(n *GeomPropertyFuncExpr) *GeomPropertyFuncEx
 	return &out
 }
 
+// CloneRefOfGetFormatExpr creates a deep clone of the input.
+func CloneRefOfGetFormatExpr(n *GetFormatExpr) *GetFormatExpr {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Expr = CloneExpr(n.Expr)
+	return &out
+}
+
 // CloneRefOfGroupBy creates a deep clone of the input.
 func CloneRefOfGroupBy(n *GroupBy) *GroupBy {
 	if n == nil {
@@ -3651,6 +3663,8 @@ func CloneCallable(in Callable) Callable {
 		return CloneRefOfGeomFromWKBExpr(in)
 	case *GeomPropertyFuncExpr:
 		return CloneRefOfGeomPropertyFuncExpr(in)
+	case *GetFormatExpr:
+		return CloneRefOfGetFormatExpr(in)
 	case *GroupConcatExpr:
 		return CloneRefOfGroupConcatExpr(in)
 	case *InsertExpr:
@@ -3947,6 +3961,8 @@ func CloneExpr(in Expr) Expr {
 		return CloneRefOfGeomFromWKBExpr(in)
 	case *GeomPropertyFuncExpr:
 		return CloneRefOfGeomPropertyFuncExpr(in)
+	case *GetFormatExpr:
+		return CloneRefOfGetFormatExpr(in)
 	case *GroupConcatExpr:
 		return CloneRefOfGroupConcatExpr(in)
 	case *InsertExpr:
@@ -572,6 +572,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfGeomPropertyFuncExpr(a, b)
+	case *GetFormatExpr:
+		b, ok := inB.(*GetFormatExpr)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfGetFormatExpr(a, b)
 	case *GroupBy:
 		b, ok := inB.(*GroupBy)
 		if !ok {
@@ -2817,6 +2823,18 @@ func (cmp *Comparator) RefOfGeomPropertyFuncExpr(a, b *GeomPropertyFuncExpr) boo
 		cmp.Expr(a.Geom, b.Geom)
 }
 
+// RefOfGetFormatExpr does deep equals between the two objects.
+func (cmp *Comparator) RefOfGetFormatExpr(a, b *GetFormatExpr) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Type == b.Type &&
+		cmp.Expr(a.Expr, b.Expr)
+}
+
 // RefOfGroupBy does deep equals between the two objects.
 func (cmp *Comparator) RefOfGroupBy(a, b *GroupBy) bool {
 	if a == b {
@@ -5466,6 +5484,12 @@ func (cmp *Comparator) Callable(inA, inB Callable) bool {
 			return false
 		}
 		return cmp.RefOfGeomPropertyFuncExpr(a, b)
+	case *GetFormatExpr:
+		b, ok := inB.(*GetFormatExpr)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfGetFormatExpr(a, b)
 	case *GroupConcatExpr:
 		b, ok := inB.(*GroupConcatExpr)
 		if !ok {
@@ -6228,6 +6252,12 @@ func (cmp *Comparator) Expr(inA, inB Expr) bool {
 			return false
 		}
 		return cmp.RefOfGeomPropertyFuncExpr(a, b)
+	case *GetFormatExpr:
+		b, ok := inB.(*GetFormatExpr)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfGetFormatExpr(a, b)
 	case *GroupConcatExpr:
 		b, ok := inB.(*GroupConcatExpr)
 		if !ok {
@@ -272,6 +272,14 @@ func (node *AlterVschema) Format(buf *TrackedBuffer) {
 		buf.astPrintf(node, "alter vschema create vindex %v %v", node.Table, node.VindexSpec)
 	case DropVindexDDLAction:
 		buf.astPrintf(node, "alter vschema drop vindex %v", node.Table)
+	case AlterVindexDDLAction:
+		buf.astPrintf(node, "alter vschema alter vindex %v with ", node.Table)
+		for i, p := range node.VindexSpec.Params {
+			if i != 0 {
+				buf.astPrintf(node, ", ")
+			}
+			buf.astPrintf(node, "%v", p)
+		}
 	case AddVschemaTableDDLAction:
 		buf.astPrintf(node, "alter vschema add table %v", node.Table)
 	case DropVschemaTableDDLAction:
@@ -2998,6 +3006,11 @@ func (node *Variance) Format(buf *TrackedBuffer) {
 	}
 }
 
+// Format formats the node.
+func (node *GetFormatExpr) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "get_format(%s, %v)", node.Type.ToString(), node.Expr)
+}
+
 // Format formats the node.
 func (node *LockingFunc) Format(buf *TrackedBuffer) {
 	buf.WriteString(node.Type.ToString() + "(")
@@ -379,6 +379,16 @@ func (node *AlterVschema) FormatFast(buf *TrackedBuffer) {
 	case DropVindexDDLAction:
 		buf.WriteString("alter vschema drop vindex ")
 		node.Table.FormatFast(buf)
+	case AlterVindexDDLAction:
+		buf.WriteString("alter vschema alter vindex ")
+		node.Table.FormatFast(buf)
+		buf.WriteString(" with ")
+		for i, p := range node.VindexSpec.Params {
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			p.FormatFast(buf)
+		}
 	case AddVschemaTableDDLAction:
 		buf.WriteString("alter vschema add table ")
 		node.Table.FormatFast(buf)
@@ -3939,6 +3949,15 @@ func (node *Variance) FormatFast(buf *TrackedBuffer) {
 	}
 }
 
+// FormatFast formats the node.
+func (node *GetFormatExpr) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("get_format(")
+	buf.WriteString(node.Type.ToString())
+	buf.WriteString(", ")
+	buf.printExpr(node, node.Expr, true)
+	buf.WriteByte(')')
+}
+
 // FormatFast formats the node.
 func (node *LockingFunc) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(node.Type.ToString() + "(")
@@ -1462,6 +1462,8 @@ func (action DDLAction) ToString() string {
 		return CreateVindexStr
 	case DropVindexDDLAction:
 		return DropVindexStr
+	case AlterVindexDDLAction:
+		return AlterVindexStr
 	case AddVschemaTableDDLAction:
 		return AddVschemaTableStr
 	case DropVschemaTableDDLAction:
@@ -2004,6 +2006,22 @@ func (ty JSONValueMergeType) ToString() string {
 	}
 }
 
+// ToString returns the type as a string
+func (ty GetFormatType) ToString() string {
+	switch ty {
+	case GetFormatDate:
+		return GetFormatDateStr
+	case GetFormatTime:
+		return GetFormatTimeStr
+	case GetFormatDatetime:
+		return GetFormatDatetimeStr
+	case GetFormatTimestamp:
+		return GetFormatTimestampStr
+	default:
+		return "Unknown GetFormatType"
+	}
+}
+
 // ToString returns the type as a string
 func (ty LockingFuncType) ToString() string {
 	switch ty {
@@ -2367,6 +2385,10 @@ func ContainsAggregation(e SQLNode) bool {
 			// so we don't need to worry about aggregation in the original
 			return false, nil
 		case AggrFunc:
+			if GetOverClause(node) != nil {
+				// aggregations over a window don't group rows
+				return true, nil
+			}
 			hasAggregates = true
 			return false, io.EOF
 		}
@@ -2376,6 +2398,80 @@ func ContainsAggregation(e SQLNode) bool {
 	return hasAggregates
 }
 
+// GetOverClause returns the OVER clause of a window function, or of an aggregate
+// function used as a window function. For any other node, nil is returned.
+func GetOverClause(node SQLNode) *OverClause {
+	switch node := node.(type) {
+	case *ArgumentLessWindowExpr:
+		return node.OverClause
+	case *FirstOrLastValueExpr:
+		return node.OverClause
+	case *NtileExpr:
+		return node.OverClause
+	case *NTHValueExpr:
+		return node.OverClause
+	case *LagLeadExpr:
+		return node.OverClause
+	case *Count:
+		return node.OverClause
+	case *CountStar:
+		return node.OverClause
+	case *Avg:
+		return node.OverClause
+	case *Max:
+		return node.OverClause
+	case *Min:
+		return node.OverClause
+	case *Sum:
+		return node.OverClause
+	case *BitAnd:
+		return node.OverClause
+	case *BitOr:
+		return node.OverClause
+	case *BitXor:
+		return node.OverClause
+	case *Std:
+		return node.OverClause
+	case *StdDev:
+		return node.OverClause
+	case *StdPop:
+		return node.OverClause
+	case *StdSamp:
+		return node.OverClause
+	case *VarPop:
+		return node.OverClause
+	case *VarSamp:
+		return node.OverClause
+	case *Variance:
+		return node.OverClause
+	case *JSONArrayAgg:
+		return node.OverClause
+	case *JSONObjectAgg:
+		return node.OverClause
+	}
+	return nil
+}
+
+// ContainsWindowFunction returns true if the expression contains a window function,
+// or an aggregate function used as a window function
+func ContainsWindowFunction(e SQLNode) bool {
+	hasWindow := false
+	_ = Walk(func(node SQLNode) (kontinue bool, err error) {
+		switch node.(type) {
+		case *Offset:
+			return false, nil
+		case *Subquery:
+			return false, nil
+		}
+		if GetOverClause(node) != nil {
+			hasWindow = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, e)
+	return hasWindow
+}
+
 // setFuncArgs sets the arguments for the aggregation function, while checking that there is only one argument
 func setFuncArgs(aggr AggrFunc, exprs []Expr, name string) error {
 	if len(exprs) != 1 {
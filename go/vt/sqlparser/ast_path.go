@@ -189,6 +189,7 @@ const (
 	RefOfGeomFromWKBExprSrid
 	RefOfGeomFromWKBExprAxisOrderOpt
 	RefOfGeomPropertyFuncExprGeom
+	RefOfGetFormatExprExpr
 	RefOfGroupByExprsOffset
 	RefOfGroupConcatExprExprsOffset
 	RefOfGroupConcatExprOrderBy
@@ -903,6 +904,8 @@ func (s ASTStep) DebugString() string {
 		return "(*GeomFromWKBExpr).AxisOrderOpt"
 	case RefOfGeomPropertyFuncExprGeom:
 		return "(*GeomPropertyFuncExpr).Geom"
+	case RefOfGetFormatExprExpr:
+		return "(*GetFormatExpr).Expr"
 	case RefOfGroupByExprsOffset:
 		return "(*GroupBy).ExprsOffset"
 	case RefOfGroupConcatExprExprsOffset:
@@ -2017,6 +2020,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*GeomFromWKBExpr).AxisOrderOpt
 		case RefOfGeomPropertyFuncExprGeom:
 			node = node.(*GeomPropertyFuncExpr).Geom
+		case RefOfGetFormatExprExpr:
+			node = node.(*GetFormatExpr).Expr
 		case RefOfGroupByExprsOffset:
 			idx, bytesRead := path.nextPathOffset()
 			path = path[bytesRead:]
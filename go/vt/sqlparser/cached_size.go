@@ -1698,6 +1698,20 @@ func (cached *GeomPropertyFuncExpr) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *GetFormatExpr) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(24)
+	}
+	// field Expr vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Expr.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *GroupBy) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
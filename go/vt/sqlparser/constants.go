@@ -69,6 +69,7 @@ const (
 	FlushStr            = "flush"
 	CreateVindexStr     = "create vindex"
 	DropVindexStr       = "drop vindex"
+	AlterVindexStr      = "alter vindex"
 	AddVschemaTableStr  = "add vschema table"
 	DropVschemaTableStr = "drop vschema table"
 	AddColVindexStr     = "on table add vindex"
@@ -393,6 +394,12 @@ const (
 	JSONMergePatchStr    = "json_merge_patch"
 	JSONMergePreserveStr = "json_merge_preserve"
 
+	// GetFormatType strings
+	GetFormatDateStr      = "date"
+	GetFormatTimeStr      = "time"
+	GetFormatDatetimeStr  = "datetime"
+	GetFormatTimestampStr = "timestamp"
+
 	// LockingFuncType strings
 	GetLockStr         = "get_lock"
 	IsFreeLockStr      = "is_free_lock"
@@ -511,6 +518,7 @@ const (
 	AddAutoIncDDLAction
 	DropAutoIncDDLAction
 	RevertDDLAction
+	AlterVindexDDLAction
 )
 
 // Constants for scope of variables
@@ -625,6 +633,14 @@ const (
 	JSONMergePreserveType
 )
 
+// Constants for Enum Type - GetFormatType
+const (
+	GetFormatDate GetFormatType = iota
+	GetFormatTime
+	GetFormatDatetime
+	GetFormatTimestamp
+)
+
 // Constants for Enum Type - LockingFuncType
 const (
 	GetLock LockingFuncType = iota
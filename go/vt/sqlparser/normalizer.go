@@ -89,6 +89,9 @@ const (
 	RowCountName = "__vtrcount"
 	// UserDefinedVariableName is the prefix for user-defined variable bind names.
 	UserDefinedVariableName = "__vtudv"
+	// TenantIDName is the bind variable name for the tenant id of the session, in
+	// the tenant predicates that are added to the queries on multi-tenant keyspaces.
+	TenantIDName = "__vttenantid"
 )
 
 // funcRewrites lists all functions that must be rewritten. we don't want these to make it down to mysql,
@@ -716,6 +719,7 @@ func (nz *normalizer) sysVarRewrite(cursor *Cursor, node *Variable) {
 		sysvars.SkipQueryPlanCache.Name,
 		sysvars.Socket.Name,
 		sysvars.SQLSelectLimit.Name,
+		sysvars.TenantID.Name,
 		sysvars.Version.Name,
 		sysvars.VersionComment.Name,
 		sysvars.QueryTimeout.Name,
@@ -1953,6 +1953,15 @@ var (
 		// Alter Vschema does not reach the vttablets, so we don't need to run the normalizer test
 		input:                "alter vschema create vindex xyz_vdx using xyz with param1=hello, param2='world', param3=123",
 		ignoreNormalizerTest: true,
+	}, {
+		// Alter Vschema does not reach the vttablets, so we don't need to run the normalizer test
+		input:                "alter vschema alter vindex range_vdx with split_points=`0=,100=80`",
+		output:               "alter vschema alter vindex range_vdx with split_points=0=,100=80",
+		ignoreNormalizerTest: true,
+	}, {
+		// Alter Vschema does not reach the vttablets, so we don't need to run the normalizer test
+		input:                "alter vschema alter vindex ks.range_vdx with type=uint",
+		ignoreNormalizerTest: true,
 	}, {
 		// Alter Vschema does not reach the vttablets, so we don't need to run the normalizer test
 		input:                "alter vschema drop vindex hash_vdx",
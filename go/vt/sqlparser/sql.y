@@ -179,6 +179,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
   explainType 	  ExplainType
   vexplainType 	  VExplainType
   intervalType	  IntervalType
+  getFormatType	  GetFormatType
   lockType LockType
   referenceDefinition *ReferenceDefinition
   txAccessModes []TxAccessMode
@@ -384,7 +385,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> COUNT AVG MAX MIN SUM GROUP_CONCAT BIT_AND BIT_OR BIT_XOR STD STDDEV STDDEV_POP STDDEV_SAMP VAR_POP VAR_SAMP VARIANCE ANY_VALUE
 %token <str> REGEXP_INSTR REGEXP_LIKE REGEXP_REPLACE REGEXP_SUBSTR
 %token <str> ExtractValue UpdateXML
-%token <str> GET_LOCK RELEASE_LOCK RELEASE_ALL_LOCKS IS_FREE_LOCK IS_USED_LOCK
+%token <str> GET_FORMAT GET_LOCK RELEASE_LOCK RELEASE_ALL_LOCKS IS_FREE_LOCK IS_USED_LOCK
 %token <str> LOCATE POSITION
 %token <str> ST_GeometryCollectionFromText ST_GeometryFromText ST_LineStringFromText ST_MultiLineStringFromText ST_MultiPointFromText ST_MultiPolygonFromText ST_PointFromText ST_PolygonFromText
 %token <str> ST_GeometryCollectionFromWKB ST_GeometryFromWKB ST_LineStringFromWKB ST_MultiLineStringFromWKB ST_MultiPointFromWKB ST_MultiPolygonFromWKB ST_PointFromWKB ST_PolygonFromWKB
@@ -486,6 +487,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <subPartitionDefinitions> subpartition_definition_list subpartition_definition_list_with_brackets
 %type <subPartitionDefinitionOptions> subpartition_definition_attribute_list_opt
 %type <intervalType> interval timestampadd_interval
+%type <getFormatType> get_format_type
 %type <str> cache_opt separator_opt flush_option for_channel_opt maxvalue
 %type <matchExprOption> match_option
 %type <boolean> distinct_opt union_op replace_opt local_opt
@@ -3255,6 +3257,17 @@ alter_statement:
         },
       }
   }
+| ALTER comment_opt VSCHEMA ALTER VINDEX table_name WITH vindex_param_list
+  {
+    $$ = &AlterVschema{
+        Action: AlterVindexDDLAction,
+        Table: $6,
+        VindexSpec: &VindexSpec{
+          Name: NewIdentifierCI($6.Name.String()),
+          Params: $8,
+        },
+      }
+  }
 | ALTER comment_opt VSCHEMA DROP VINDEX table_name
   {
     $$ = &AlterVschema{
@@ -6225,6 +6238,10 @@ UTC_DATE func_paren_opt
   {
     $$ = &LocateExpr{SubStr: $3, Str: $5}
   }
+| GET_FORMAT openb get_format_type ',' expression closeb
+  {
+    $$ = &GetFormatExpr{Type: $3, Expr: $5}
+  }
 | GET_LOCK openb expression ',' expression closeb
   {
     $$ = &LockingFunc{Type: GetLock, Name:$3, Timeout:$5}
@@ -7051,6 +7068,24 @@ interval:
     $$=IntervalYear
   }
 
+get_format_type:
+  DATE
+  {
+    $$=GetFormatDate
+  }
+| TIME
+  {
+    $$=GetFormatTime
+  }
+| DATETIME
+  {
+    $$=GetFormatDatetime
+  }
+| TIMESTAMP
+  {
+    $$=GetFormatTimestamp
+  }
+
 timestampadd_interval:
   DAY
   {
@@ -8421,6 +8456,7 @@ non_reserved_keyword:
 | GEOMCOLLECTION
 | GEOMETRY
 | GEOMETRYCOLLECTION
+| GET_FORMAT %prec FUNCTION_CALL_NON_KEYWORD
 | GET_LOCK %prec FUNCTION_CALL_NON_KEYWORD
 | GET_MASTER_PUBLIC_KEY
 | GLOBAL
@@ -17,6 +17,8 @@ limitations under the License.
 package sqlparser
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -24,6 +26,78 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
+// QueryDigest returns the fingerprint of a query, which is the hex encoding of
+// the first 8 bytes of the SHA-256 hash of its normalized text. The text is
+// normalized token by token: comments are dropped, keywords and identifiers are
+// lowercased and unquoted, the qualifiers of identifiers are dropped, and values
+// and bind variables are replaced by '?'. This way, a query planned by vtgate
+// and the query it sends to a tablet have the same digest, whatever their
+// formatting, the qualifiers that vtgate adds or removes, and the names of their
+// bind variables.
+func QueryDigest(query string) string {
+	// The comments are dropped, so the MySQL version of the parser that decides
+	// which version comments to parse does not matter.
+	tkn := (&Parser{}).NewStringTokenizer(query)
+	var b []byte
+	// qualifier is the length of b before the last token, if it is an
+	// identifier that a '.' can turn into a qualifier.
+	qualifier := -1
+	for {
+		typ, val := tkn.Scan()
+		if typ == 0 || typ == ';' {
+			break
+		}
+		ident := false
+		switch typ {
+		case COMMENT:
+			continue
+		case '.':
+			if qualifier >= 0 {
+				b = b[:qualifier]
+				qualifier = -1
+				continue
+			}
+			val = "."
+		case STRING, NCHAR_STRING, INTEGRAL, FLOAT, DECIMAL, HEXNUM, HEX, BIT_LITERAL, VALUE_ARG, LIST_ARG, OFFSET_ARG:
+			val = "?"
+		default:
+			if op, ok := digestOperators[typ]; ok {
+				val = op
+			} else if val == "" && typ < 256 {
+				val = string(rune(typ))
+			} else {
+				// Keywords can be used as unquoted identifiers.
+				ident = true
+			}
+		}
+		qualifier = -1
+		if ident {
+			qualifier = len(b)
+		}
+		if len(b) > 0 {
+			b = append(b, ' ')
+		}
+		b = append(b, strings.ToLower(val)...)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// digestOperators are the text of the operators that the tokenizer returns
+// without a value.
+var digestOperators = map[int]string{
+	AND:                     "and",
+	OR:                      "or",
+	NE:                      "!=",
+	LE:                      "<=",
+	GE:                      ">=",
+	NULL_SAFE_EQUAL:         "<=>",
+	SHIFT_LEFT:              "<<",
+	SHIFT_RIGHT:             ">>",
+	JSON_EXTRACT_OP:         "->",
+	JSON_UNQUOTE_EXTRACT_OP: "->>",
+}
+
 // QueryMatchesTemplates sees if the given query has the same fingerprint as one of the given templates
 // (one is enough)
 func (p *Parser) QueryMatchesTemplates(query string, queryTemplates []string) (match bool, err error) {
@@ -347,3 +347,25 @@ func TestReplaceTableQualifiersMultiQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryDigest(t *testing.T) {
+	digest := QueryDigest("select * from t where id = :id")
+	assert.Len(t, digest, 16)
+	assert.Equal(t, digest, QueryDigest("select * from t where id = :id"))
+	assert.NotEqual(t, digest, QueryDigest("select * from t where id > :id"))
+	assert.NotEqual(t, digest, QueryDigest("select * from t where id2 = :id"))
+
+	// The formatting, the comments, the values and the names of the bind
+	// variables do not change the digest.
+	for _, query := range []string{
+		"select * from t where id = :id2",
+		"select * from t where id = 1",
+		"select * from t where id = 'a'",
+		"SELECT *\n  FROM `t` WHERE id=:vtg1 /* INT64 */",
+		"/* leading */ select /*+ hint */ * from t where id = :id;",
+		"select * from ks.t where t.id = :id",
+	} {
+		assert.Equal(t, digest, QueryDigest(query), query)
+	}
+	assert.Equal(t, QueryDigest("select * from t where a != 1 and b <=> 2"), QueryDigest("select * from t where a <> :a && b <=> :b"))
+}
@@ -58,6 +58,8 @@ var (
 	utf8mb4 = "'utf8mb4'"
 
 	ForeignKeyChecks = "foreign_key_checks"
+	// BlockEncryptionMode is also used by vtgate to evaluate AES_ENCRYPT and AES_DECRYPT
+	BlockEncryptionMode = "block_encryption_mode"
 
 	Autocommit                  = SystemVariable{Name: "autocommit", IsBoolean: true, Default: on}
 	Charset                     = SystemVariable{Name: "charset", Default: utf8mb4, IdentifierAsString: true}
@@ -73,11 +75,15 @@ var (
 	TxReadOnly                  = SystemVariable{Name: "tx_read_only", IsBoolean: true, Default: off}
 	Workload                    = SystemVariable{Name: "workload", IdentifierAsString: true}
 	QueryTimeout                = SystemVariable{Name: "query_timeout"}
+	CTEMaxRecursionDepth        = SystemVariable{Name: "cte_max_recursion_depth", Default: "1000", SupportSetVar: true}
 
 	// Online DDL
 	DDLStrategy      = SystemVariable{Name: "ddl_strategy", IdentifierAsString: true}
 	MigrationContext = SystemVariable{Name: "migration_context", IdentifierAsString: true}
 
+	// Multi-tenancy
+	TenantID = SystemVariable{Name: "tenant_id", IdentifierAsString: true}
+
 	// Version
 	Version        = SystemVariable{Name: "version"}
 	VersionComment = SystemVariable{Name: "version_comment"}
@@ -104,11 +110,13 @@ var (
 		Names,
 		SessionUUID,
 		MigrationContext,
+		TenantID,
 		SessionEnableSystemSettings,
 		ReadAfterWriteGTID,
 		ReadAfterWriteTimeOut,
 		SessionTrackGTIDs,
 		QueryTimeout,
+		CTEMaxRecursionDepth,
 	}
 
 	ReadOnly = []SystemVariable{
@@ -187,6 +195,7 @@ var (
 		{Name: "transaction_write_set_extraction"},
 	}
 	UseReservedConn = []SystemVariable{
+		{Name: BlockEncryptionMode},
 		{Name: "default_week_format"},
 		{Name: "end_markers_in_json", IsBoolean: true, SupportSetVar: true},
 		{Name: "eq_range_index_dive_limit", SupportSetVar: true},
@@ -238,7 +247,6 @@ var (
 		// Until then, SET statements against these settings are allowed
 		// as long as they have the same value as the underlying database
 		{Name: "binlog_format"},
-		{Name: "block_encryption_mode"},
 		{Name: "character_set_client"},
 		{Name: "character_set_connection"},
 		{Name: "character_set_database"},
@@ -18,11 +18,14 @@ package topotools
 
 import (
 	"context"
+	"maps"
 	"reflect"
+	"time"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -103,6 +106,56 @@ func ApplyVSchemaDDL(ctx context.Context, ksName string, topoServer *topo.Server
 
 		return ksvs, nil
 
+	case sqlparser.AlterVindexDDLAction:
+		name := alterVschema.VindexSpec.Name.String()
+		vindex, ok := ksvs.Vindexes[name]
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "vindex %s does not exists in keyspace %s", name, ksName)
+		}
+
+		// The given params are merged into the existing ones, so that only the
+		// params being changed need to be specified. Changing a param would change
+		// the keyspace ids of the existing rows, so the only change allowed is
+		// adding split points after the last one of a range vindex. Even that
+		// changes the keyspace ids of the existing rows at or above a new split
+		// point, so the new split points must be beyond the existing data: they
+		// are only accepted for a datetime range vindex, and in the future.
+		owner, params := alterVschema.VindexSpec.ParseParams()
+		merged := maps.Clone(vindex.Params)
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		for k, v := range params {
+			if old, ok := vindex.Params[k]; ok && old == v {
+				continue
+			}
+			if vindex.Type != "range" || k != "split_points" {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "cannot change param %s of vindex %s in keyspace %s, as it would change the keyspace ids of existing rows", k, name, ksName)
+			}
+			merged[k] = v
+		}
+
+		newVindex, err := vindexes.CreateVindex(vindex.Type, name, merged)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid params for vindex %s in keyspace %s", name, ksName)
+		}
+		if newRange, ok := newVindex.(*vindexes.Range); ok {
+			oldVindex, err := vindexes.CreateVindex(vindex.Type, name, vindex.Params)
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "invalid params for vindex %s in keyspace %s", name, ksName)
+			}
+			if err := newRange.CheckAppendedSplitPoints(oldVindex.(*vindexes.Range), time.Now()); err != nil {
+				return nil, vterrors.Wrapf(err, "cannot change the split points of vindex %s in keyspace %s", name, ksName)
+			}
+		}
+
+		if owner != "" {
+			vindex.Owner = owner
+		}
+		vindex.Params = merged
+
+		return ksvs, nil
+
 	case sqlparser.AddVschemaTableDDLAction:
 		if ksvs.Sharded {
 			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "add vschema table: unsupported on sharded keyspace %s", ksName)
@@ -53,6 +53,7 @@ import (
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	vttestpb "vitess.io/vitess/go/vt/proto/vttest"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 // tablet contains all the data for an individual tablet.
@@ -610,8 +611,8 @@ func (itc *internalTabletConn) MessageStream(ctx context.Context, target *queryp
 }
 
 // MessageAck is part of queryservice.QueryService
-func (itc *internalTabletConn) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (int64, error) {
-	count, err := itc.tablet.qsc.QueryService().MessageAck(ctx, target, name, ids)
+func (itc *internalTabletConn) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	count, err := itc.tablet.qsc.QueryService().MessageAck(ctx, target, name, ids, visibilityTimeout)
 	return count, tabletconn.ErrorFromGRPC(vterrors.ToGRPC(err))
 }
 
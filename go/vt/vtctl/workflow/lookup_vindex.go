@@ -251,12 +251,21 @@ type vindexInfo struct {
 	fromCols        []string
 	toCol           string
 	ignoreNulls     bool
+	// coveringCols are the extra columns of a covering lookup table, which
+	// follow the from columns in the ColumnVindex.
+	coveringCols []string
 
 	// sourceTable is the supplied table info.
 	sourceTable     *vschemapb.Table
 	sourceTableName string
 }
 
+// lookupCols returns the columns of the lookup table that are copied from the
+// columns of the ColumnVindex: the from columns, followed by the covering columns.
+func (vInfo *vindexInfo) lookupCols() []string {
+	return append(slices.Clone(vInfo.fromCols), vInfo.coveringCols...)
+}
+
 // validateAndGetVindex validates and extracts vindex configuration
 func (lv *lookupVindex) validateAndGetVindex(specs *vschemapb.Keyspace) (*vschemapb.Vindex, *vindexInfo, error) {
 	if specs == nil {
@@ -296,10 +305,16 @@ func (lv *lookupVindex) validateAndGetVindex(specs *vschemapb.Keyspace) (*vschem
 	vindex.Params["write_only"] = "true"
 
 	// See if we can create the vindex without errors.
-	if _, err := vindexes.CreateVindex(vindex.Type, vindexName, vindex.Params); err != nil {
+	v, err := vindexes.CreateVindex(vindex.Type, vindexName, vindex.Params)
+	if err != nil {
 		return nil, nil, err
 	}
 
+	var vindexCoveringCols []string
+	if covering, ok := v.(vindexes.Covering); ok {
+		_, _, vindexCoveringCols = covering.CoveringTable()
+	}
+
 	ignoreNulls := false
 	if ignoreNullsStr, ok := vindex.Params["ignore_nulls"]; ok {
 		// This mirrors the behavior of vindexes.boolFromMap().
@@ -327,6 +342,7 @@ func (lv *lookupVindex) validateAndGetVindex(specs *vschemapb.Keyspace) (*vschem
 		fromCols:        vindexFromCols,
 		toCol:           vindexToCol,
 		ignoreNulls:     ignoreNulls,
+		coveringCols:    vindexCoveringCols,
 	}, nil
 }
 
@@ -401,8 +417,9 @@ func (lv *lookupVindex) generateCreateDDLStatement(tableSchema *tabletmanagerdat
 
 	var modified []string
 	modified = append(modified, strings.Replace(lines[0], vInfo.sourceTableName, vInfo.targetTableName, 1))
+	lookupCols := vInfo.lookupCols()
 	for i := range sourceVindexColumns {
-		line, err := generateColDef(lines, sourceVindexColumns[i], vInfo.fromCols[i])
+		line, err := generateColDef(lines, sourceVindexColumns[i], lookupCols[i])
 		if err != nil {
 			return "", err
 		}
@@ -438,10 +455,11 @@ func (lv *lookupVindex) generateCreateDDLStatement(tableSchema *tabletmanagerdat
 }
 
 func generateMaterializeQuery(vInfo *vindexInfo, vindex *vschemapb.Vindex, sourceVindexColumns []string) string {
+	lookupCols := vInfo.lookupCols()
 	buf := sqlparser.NewTrackedBuffer(nil)
 	buf.Myprintf("select ")
-	for i := range vInfo.fromCols {
-		buf.Myprintf("%s as %s, ", sqlparser.String(sqlparser.NewIdentifierCI(sourceVindexColumns[i])), sqlparser.String(sqlparser.NewIdentifierCI(vInfo.fromCols[i])))
+	for i := range lookupCols {
+		buf.Myprintf("%s as %s, ", sqlparser.String(sqlparser.NewIdentifierCI(sourceVindexColumns[i])), sqlparser.String(sqlparser.NewIdentifierCI(lookupCols[i])))
 	}
 	if strings.EqualFold(vInfo.toCol, "keyspace_id") || strings.EqualFold(vindex.Type, "consistent_lookup_unique") || strings.EqualFold(vindex.Type, "consistent_lookup") {
 		buf.Myprintf("keyspace_id() as %s ", sqlparser.String(sqlparser.NewIdentifierCI(vInfo.toCol)))
@@ -462,8 +480,8 @@ func generateMaterializeQuery(vInfo *vindexInfo, vindex *vschemapb.Vindex, sourc
 	if vindex.Owner != "" {
 		// Only backfill.
 		buf.Myprintf(" group by ")
-		for i := range vInfo.fromCols {
-			buf.Myprintf("%s, ", sqlparser.String(sqlparser.NewIdentifierCI(vInfo.fromCols[i])))
+		for i := range lookupCols {
+			buf.Myprintf("%s, ", sqlparser.String(sqlparser.NewIdentifierCI(lookupCols[i])))
 		}
 		buf.Myprintf("%s", sqlparser.String(sqlparser.NewIdentifierCI(vInfo.toCol)))
 	}
@@ -494,9 +512,9 @@ func validateSourceTableAndGetVindexColumns(vInfo *vindexInfo, vindex *vschemapb
 		}
 		sourceVindexColumns = []string{vInfo.sourceTable.ColumnVindexes[0].Column}
 	}
-	if len(sourceVindexColumns) != len(vInfo.fromCols) {
+	if len(sourceVindexColumns) != len(vInfo.lookupCols()) {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "length of table columns (%d) differs from length of vindex columns (%d)",
-			len(sourceVindexColumns), len(vInfo.fromCols))
+			len(sourceVindexColumns), len(vInfo.lookupCols()))
 	}
 
 	return sourceVindexColumns, nil
@@ -595,3 +613,70 @@ func (lv *lookupVindex) validateExternalized(ctx context.Context, vindex *vschem
 	}
 	return nil
 }
+
+// prepareVDiff returns the settings of a materialization of the lookup table
+// of the given Lookup Vindex from its owner table, as the Vindex is currently
+// defined in the VSchema. A VDiff of the Lookup Vindex compares the lookup
+// table to this materialization instead of using the VReplication workflow
+// that backfilled it, which is frozen or deleted once the Vindex has been
+// externalized.
+func (lv *lookupVindex) prepareVDiff(ctx context.Context, name, keyspace, tableKeyspace string) (*vtctldatapb.MaterializeSettings, error) {
+	vindex, vschema, err := getVindexAndVSchema(ctx, lv.ts, keyspace, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := lv.validateExternalizedVindex(vindex); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vindex %s has not been externalized yet: %v", name, err)
+	}
+	ownerTable := vschema.Tables[vindex.Owner]
+	if ownerTable == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "owner table %s of vindex %s not found in the %s keyspace",
+			vindex.Owner, name, keyspace)
+	}
+	var columnVindex *vschemapb.ColumnVindex
+	for _, cv := range ownerTable.ColumnVindexes {
+		if cv.Name == name {
+			columnVindex = cv
+			break
+		}
+	}
+	if columnVindex == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no ColumnVindex found for vindex %s in the owner table %s of the %s keyspace",
+			name, vindex.Owner, keyspace)
+	}
+
+	// Validate the Vindex the same way as when it's created.
+	specs := &vschemapb.Keyspace{
+		Vindexes: map[string]*vschemapb.Vindex{name: vindex.CloneVT()},
+		Tables: map[string]*vschemapb.Table{
+			vindex.Owner: {ColumnVindexes: []*vschemapb.ColumnVindex{columnVindex}},
+		},
+	}
+	specVindex, vInfo, err := lv.validateAndGetVindex(specs)
+	if err != nil {
+		return nil, err
+	}
+	if vInfo.targetKeyspace != tableKeyspace {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the lookup table of vindex %s is in the %s keyspace, not in %s",
+			name, vInfo.targetKeyspace, tableKeyspace)
+	}
+	vInfo.sourceTable, vInfo.sourceTableName, err = getSourceTable(specs, vInfo.targetTableName, vInfo.fromCols)
+	if err != nil {
+		return nil, err
+	}
+	sourceVindexColumns, err := validateSourceTableAndGetVindexColumns(vInfo, specVindex, keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.MaterializeSettings{
+		Workflow:              name,
+		MaterializationIntent: vtctldatapb.MaterializationIntent_CREATELOOKUPINDEX,
+		SourceKeyspace:        keyspace,
+		TargetKeyspace:        tableKeyspace,
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      vInfo.targetTableName,
+			SourceExpression: generateMaterializeQuery(vInfo, specVindex, sourceVindexColumns),
+		}},
+	}, nil
+}
@@ -2426,6 +2426,114 @@ func TestCreateLookupVindexIgnoreNulls(t *testing.T) {
 	require.Equal(t, wantQuery, ms.TableSettings[0].SourceExpression, "unexpected query")
 }
 
+func TestCreateLookupVindexCovering(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "ks",
+		TargetKeyspace: "ks",
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	specs := &vschemapb.Keyspace{
+		Vindexes: map[string]*vschemapb.Vindex{
+			"v": {
+				Type: "lookup_covering",
+				Params: map[string]string{
+					"table":    "ks.lkp",
+					"from":     "c1",
+					"to":       "keyspace_id",
+					"covering": "c2,c3",
+				},
+				Owner: "t1",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Name:    "v",
+					Columns: []string{"col2", "col3", "col4"},
+				}},
+			},
+		},
+	}
+	// Dummy sourceSchema
+	sourceSchema := "CREATE TABLE `t1` (\n" +
+		"  `col1` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `col2` int(11) NOT NULL,\n" +
+		"  `col3` varchar(64) DEFAULT NULL,\n" +
+		"  `col4` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=latin1"
+
+	vschema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"xxhash": {
+				Type: "xxhash",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Name:   "xxhash",
+					Column: "col1",
+				}},
+			},
+		},
+	}
+
+	wantDDL := "CREATE TABLE `lkp` (\n" +
+		"  `c1` int(11) NOT NULL,\n" +
+		"  `c2` varchar(64),\n" +
+		"  `c3` int(11),\n" +
+		"  `keyspace_id` varbinary(128),\n" +
+		"  PRIMARY KEY (`c1`)\n" +
+		")"
+	wantQuery := "select col2 as c1, col3 as c2, col4 as c3, keyspace_id() as keyspace_id from t1 group by c1, c2, c3, keyspace_id"
+
+	env.tmc.schema[ms.SourceKeyspace+".t1"] = &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Fields: []*querypb.Field{{
+				Name: "col1",
+				Type: querypb.Type_INT64,
+			}, {
+				Name: "col2",
+				Type: querypb.Type_INT64,
+			}, {
+				Name: "col3",
+				Type: querypb.Type_VARCHAR,
+			}, {
+				Name: "col4",
+				Type: querypb.Type_INT64,
+			}},
+			Schema: sourceSchema,
+		}},
+	}
+	if err := env.topoServ.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     ms.TargetKeyspace,
+		Keyspace: vschema,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	lv := newLookupVindex(env.ws)
+	ms, ks, _, _, err := lv.prepareCreate(ctx, "workflow", ms.TargetKeyspace, specs, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"col2", "col3", "col4"}, ks.Tables["t1"].ColumnVindexes[1].Columns)
+	require.NotNil(t, ms)
+	require.GreaterOrEqual(t, len(ms.TableSettings), 1)
+	require.Equal(t, wantDDL, ms.TableSettings[0].CreateDdl, "unexpected DDL")
+	require.Equal(t, wantQuery, ms.TableSettings[0].SourceExpression, "unexpected query")
+
+	// The ColumnVindex needs a column for each one of the covering columns.
+	specs.Tables["t1"].ColumnVindexes[0].Columns = []string{"col2", "col3"}
+	_, _, _, _, err = lv.prepareCreate(ctx, "workflow", ms.TargetKeyspace, specs, false)
+	require.EqualError(t, err, "length of table columns (2) differs from length of vindex columns (3)")
+}
+
 func TestStopAfterCopyFlag(t *testing.T) {
 	ms := &vtctldatapb.MaterializeSettings{
 		SourceKeyspace: "ks",
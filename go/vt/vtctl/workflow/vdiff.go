@@ -19,6 +19,7 @@ package workflow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math"
 	"sort"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 
@@ -48,6 +50,7 @@ type TableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
+	RepairedRows    int64  `json:"RepairedRows,omitempty"`
 	LastUpdated     string `json:"LastUpdated,omitempty"`
 }
 
@@ -204,6 +207,7 @@ func BuildSummary(keyspace, workflow, uuid string, resp *vtctldatapb.VDiffShowRe
 						ts.MatchingRows += dr.MatchingRows
 						ts.ExtraRowsTarget += dr.ExtraRowsTarget
 						ts.ExtraRowsSource += dr.ExtraRowsSource
+						ts.RepairedRows += dr.RepairedRows
 					}
 					if _, ok := reports[table]; !ok {
 						reports[table] = make(map[string]vdiff.DiffReport)
@@ -305,10 +309,16 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 	span.Annotate("tables", req.Tables)
 	span.Annotate("auto_retry", req.AutoRetry)
 	span.Annotate("max_diff_duration", req.MaxDiffDuration)
+	span.Annotate("repair", req.Repair)
+	span.Annotate("lookup_vindex_keyspace", req.LookupVindexKeyspace)
 	if req.AutoStart != nil {
 		span.Annotate("auto_start", req.GetAutoStart())
 	}
 
+	if req.Repair && req.LookupVindexKeyspace == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "repair is only supported for a Lookup Vindex VDiff")
+	}
+
 	var err error
 	req.Uuid = strings.TrimSpace(req.Uuid)
 	if req.Uuid == "" { // Generate a UUID
@@ -362,6 +372,7 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 			UpdateTableStats:      req.UpdateTableStats,
 			MaxDiffSeconds:        req.MaxDiffDuration.Seconds,
 			AutoStart:             &autoStart,
+			Repair:                req.Repair,
 		},
 		ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{
 			OnlyPks:                 req.OnlyPKs,
@@ -379,6 +390,16 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 		VdiffUuid: req.Uuid,
 	}
 
+	if req.LookupVindexKeyspace != "" {
+		if err := s.createLookupVindexVDiff(ctx, req.LookupVindexKeyspace, tabletreq); err != nil {
+			s.Logger().Errorf("Error executing vdiff create action: %v", err)
+			return nil, err
+		}
+		return &vtctldatapb.VDiffCreateResponse{
+			UUID: req.Uuid,
+		}, nil
+	}
+
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
 		return nil, err
@@ -428,7 +449,7 @@ func (s *Server) VDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDeleteRe
 		ActionArg: req.Arg,
 	}
 
-	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
+	ts, err := s.buildVDiffTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
 		return nil, err
 	}
@@ -464,7 +485,7 @@ func (s *Server) VDiffResume(ctx context.Context, req *vtctldatapb.VDiffResumeRe
 		VdiffUuid: req.Uuid,
 	}
 
-	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
+	ts, err := s.buildVDiffTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
 		return nil, err
 	}
@@ -503,7 +524,7 @@ func (s *Server) VDiffShow(ctx context.Context, req *vtctldatapb.VDiffShowReques
 		ActionArg: req.Arg,
 	}
 
-	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
+	ts, err := s.buildVDiffTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
 		return nil, err
 	}
@@ -547,7 +568,7 @@ func (s *Server) VDiffStop(ctx context.Context, req *vtctldatapb.VDiffStopReques
 		VdiffUuid: req.Uuid,
 	}
 
-	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
+	ts, err := s.buildVDiffTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
 		return nil, err
 	}
@@ -569,3 +590,114 @@ func (s *Server) VDiffStop(ctx context.Context, req *vtctldatapb.VDiffStopReques
 
 	return &vtctldatapb.VDiffStopResponse{}, nil
 }
+
+// createLookupVindexVDiff creates a VDiff of the lookup table of the Lookup
+// Vindex that is named by the workflow of the request and defined in the given
+// keyspace. The streams of the diff are generated from the Vindex definition,
+// the same way as those of the workflow that backfills the lookup table, and
+// passed on to the primary tablets of the lookup table keyspace.
+func (s *Server) createLookupVindexVDiff(ctx context.Context, keyspace string, tabletreq *tabletmanagerdatapb.VDiffRequest) error {
+	ms, err := newLookupVindex(s).prepareVDiff(ctx, tabletreq.Workflow, keyspace, tabletreq.Keyspace)
+	if err != nil {
+		return err
+	}
+	mz := &materializer{
+		ctx:      ctx,
+		ts:       s.ts,
+		sourceTs: s.ts,
+		tmc:      s.tmc,
+		ms:       ms,
+		env:      s.env,
+	}
+	if err := mz.buildMaterializer(); err != nil {
+		return err
+	}
+	return forAllShards(mz.targetShards, func(target *topo.ShardInfo) error {
+		if !target.HasPrimary() {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no primary tablet found for target shard %s/%s",
+				target.Keyspace(), target.ShardName())
+		}
+		primary, err := s.ts.GetTablet(ctx, target.PrimaryAlias)
+		if err != nil {
+			return err
+		}
+		sources, err := mz.generateBinlogSources(ctx, target, mz.filterSourceShards(target), false)
+		if err != nil {
+			return err
+		}
+		req := tabletreq.CloneVT()
+		req.Options.CoreOptions.LookupVindexSources = sources
+		_, err = s.tmc.VDiff(ctx, primary.Tablet, req)
+		return err
+	})
+}
+
+// buildVDiffTrafficSwitcher builds the traffic switcher that is used to pass on
+// a VDiff action to the target primary tablets of the workflow. The VReplication
+// workflow of a Lookup Vindex no longer exists once the Vindex has been
+// externalized, so if there are no streams for the workflow and it names a
+// Lookup Vindex whose lookup table is in the target keyspace, the action is
+// passed on to all of the primary tablets of the keyspace instead.
+func (s *Server) buildVDiffTrafficSwitcher(ctx context.Context, targetKeyspace, workflow string) (*trafficSwitcher, error) {
+	ts, err := s.buildTrafficSwitcher(ctx, targetKeyspace, workflow)
+	if !errors.Is(err, ErrNoStreams) {
+		return ts, err
+	}
+	isLookupVindex, lerr := s.isLookupVindexInKeyspace(ctx, workflow, targetKeyspace)
+	if lerr != nil {
+		return nil, lerr
+	}
+	if !isLookupVindex {
+		return nil, err
+	}
+	shards, err := s.ts.GetServingShards(ctx, targetKeyspace)
+	if err != nil {
+		return nil, err
+	}
+	ts = &trafficSwitcher{
+		ws:             s,
+		logger:         s.Logger(),
+		workflow:       workflow,
+		targetKeyspace: targetKeyspace,
+		targets:        make(map[string]*MigrationTarget, len(shards)),
+	}
+	for _, si := range shards {
+		if !si.HasPrimary() {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no primary tablet found for target shard %s/%s",
+				targetKeyspace, si.ShardName())
+		}
+		primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return nil, err
+		}
+		ts.targets[si.ShardName()] = &MigrationTarget{si: si, primary: primary}
+	}
+	return ts, nil
+}
+
+// isLookupVindexInKeyspace returns whether a Lookup Vindex with the given name
+// is defined in one of the keyspaces and has its lookup table in tableKeyspace.
+func (s *Server) isLookupVindexInKeyspace(ctx context.Context, name, tableKeyspace string) (bool, error) {
+	keyspaces, err := s.ts.GetKeyspaces(ctx)
+	if err != nil {
+		return false, err
+	}
+	parser := s.SQLParser()
+	for _, keyspace := range keyspaces {
+		vschema, err := s.ts.GetVSchema(ctx, keyspace)
+		if err != nil {
+			if topo.IsErrType(err, topo.NoNode) {
+				continue
+			}
+			return false, err
+		}
+		vindex := vschema.Vindexes[name]
+		if vindex == nil || !strings.Contains(vindex.Type, "lookup") {
+			continue
+		}
+		if ks, _, err := parser.ParseTable(vindex.Params["table"]); err == nil && ks == tableKeyspace {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -26,11 +26,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -177,6 +180,15 @@ func TestVDiffCreate(t *testing.T) {
 				Workflow:       workflowName,
 			},
 		},
+		{
+			name: "repair without lookup vindex",
+			req: &vtctldatapb.VDiffCreateRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				Workflow:       workflowName,
+				Repair:         true,
+			},
+			wantErr: "repair is only supported for a Lookup Vindex VDiff",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -200,6 +212,156 @@ func TestVDiffCreate(t *testing.T) {
 	}
 }
 
+func TestVDiffCreateLookupVindex(t *testing.T) {
+	ctx := context.Background()
+	vindexName := "c1_lookup"
+	sourceKeyspace := &testKeyspace{
+		KeyspaceName: "source",
+		ShardNames:   []string{"0"},
+	}
+	targetKeyspace := &testKeyspace{
+		KeyspaceName: "target",
+		ShardNames:   []string{"-80", "80-"},
+	}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+	env.tmc.strict = true
+
+	lookupVindex := &vschemapb.Vindex{
+		Type: "consistent_lookup_unique",
+		Params: map[string]string{
+			"table": "target.lookup",
+			"from":  "c1",
+			"to":    "keyspace_id",
+		},
+		Owner: "t1",
+	}
+	err := env.ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: sourceKeyspace.KeyspaceName,
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"xxhash":   {Type: "xxhash"},
+				vindexName: lookupVindex,
+			},
+			Tables: map[string]*vschemapb.Table{
+				"t1": {
+					ColumnVindexes: []*vschemapb.ColumnVindex{
+						{Name: "xxhash", Column: "id"},
+						{Name: vindexName, Column: "c1"},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	err = env.ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: targetKeyspace.KeyspaceName,
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"xxhash": {Type: "xxhash"},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"lookup": {
+					ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "c1"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := &vtctldatapb.VDiffCreateRequest{
+		TargetKeyspace:       targetKeyspace.KeyspaceName,
+		Workflow:             vindexName,
+		Uuid:                 uuid.New().String(),
+		Repair:               true,
+		LookupVindexKeyspace: sourceKeyspace.KeyspaceName,
+	}
+	autoStart := true
+	tabletReq := func(keyRange string) *tabletmanagerdatapb.VDiffRequest {
+		return &tabletmanagerdatapb.VDiffRequest{
+			Keyspace:  targetKeyspace.KeyspaceName,
+			Workflow:  vindexName,
+			Action:    string(vdiff.CreateAction),
+			VdiffUuid: req.Uuid,
+			Options: &tabletmanagerdatapb.VDiffOptions{
+				PickerOptions: &tabletmanagerdatapb.VDiffPickerOptions{},
+				CoreOptions: &tabletmanagerdatapb.VDiffCoreOptions{
+					MaxRows:        math.MaxInt64,
+					TimeoutSeconds: int64(DefaultTimeout.Seconds()),
+					AutoStart:      &autoStart,
+					Repair:         true,
+					LookupVindexSources: []*binlogdatapb.BinlogSource{{
+						Keyspace: sourceKeyspace.KeyspaceName,
+						Shard:    "0",
+						Filter: &binlogdatapb.Filter{
+							Rules: []*binlogdatapb.Rule{{
+								Match: "lookup",
+								Filter: fmt.Sprintf("select c1 as c1, keyspace_id() as keyspace_id from t1 where in_keyrange(c1, '%s.xxhash', '%s') group by c1, keyspace_id",
+									targetKeyspace.KeyspaceName, keyRange),
+							}},
+						},
+					}},
+				},
+				ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{},
+			},
+		}
+	}
+	env.tmc.expectVDiffRequest(env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID], &vdiffRequestResponse{
+		req: tabletReq("-80"),
+	})
+	env.tmc.expectVDiffRequest(env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID+tabletUIDStep], &vdiffRequestResponse{
+		req: tabletReq("80-"),
+	})
+	got, err := env.ws.VDiffCreate(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, req.Uuid, got.UUID)
+	env.tmc.confirmVDiffRequests(t)
+
+	// The VReplication workflow of the vindex no longer exists, so the vdiff is
+	// shown from all of the target shards.
+	env.tmc.expectReadVReplicationWorkflowRequestOnTargetTablets(&readVReplicationWorkflowRequestResponse{
+		req: &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{Workflow: vindexName},
+		res: &tabletmanagerdatapb.ReadVReplicationWorkflowResponse{Workflow: vindexName},
+	})
+	showReq := &tabletmanagerdatapb.VDiffRequest{
+		Keyspace:  targetKeyspace.KeyspaceName,
+		Workflow:  vindexName,
+		Action:    string(vdiff.ShowAction),
+		ActionArg: req.Uuid,
+	}
+	for _, tablet := range env.tablets[targetKeyspace.KeyspaceName] {
+		env.tmc.expectVDiffRequest(tablet, &vdiffRequestResponse{
+			req: showReq,
+			res: &tabletmanagerdatapb.VDiffResponse{},
+		})
+	}
+	showRes, err := env.ws.VDiffShow(ctx, &vtctldatapb.VDiffShowRequest{
+		TargetKeyspace: targetKeyspace.KeyspaceName,
+		Workflow:       vindexName,
+		Arg:            req.Uuid,
+	})
+	require.NoError(t, err)
+	require.Len(t, showRes.TabletResponses, 2)
+	env.tmc.confirmVDiffRequests(t)
+
+	// The lookup table must be in the target keyspace.
+	req.TargetKeyspace = sourceKeyspace.KeyspaceName
+	_, err = env.ws.VDiffCreate(ctx, req)
+	require.ErrorContains(t, err, "the lookup table of vindex c1_lookup is in the target keyspace, not in source")
+
+	// The vindex must have been externalized.
+	req.TargetKeyspace = targetKeyspace.KeyspaceName
+	lookupVindex.Params["write_only"] = "true"
+	vs, err := env.ts.GetVSchema(ctx, sourceKeyspace.KeyspaceName)
+	require.NoError(t, err)
+	vs.Vindexes[vindexName] = lookupVindex
+	require.NoError(t, env.ts.SaveVSchema(ctx, vs))
+	_, err = env.ws.VDiffCreate(ctx, req)
+	require.ErrorContains(t, err, "vindex c1_lookup has not been externalized yet")
+}
+
 func TestVDiffResume(t *testing.T) {
 	ctx := context.Background()
 	sourceKeyspace := &testKeyspace{
@@ -101,8 +101,11 @@ var (
 	VT09027 = errorWithState("VT09027", vtrpcpb.Code_FAILED_PRECONDITION, CTERecursiveForbidsAggregation, "Recursive Common Table Expression '%s' can contain neither aggregation nor window functions in recursive query block", "")
 	VT09028 = errorWithState("VT09028", vtrpcpb.Code_FAILED_PRECONDITION, CTERecursiveForbiddenJoinOrder, "In recursive query block of Recursive Common Table Expression '%s', the recursive table must neither be in the right argument of a LEFT JOIN, nor be forced to be non-first with join order hints", "")
 	VT09029 = errorWithState("VT09029", vtrpcpb.Code_FAILED_PRECONDITION, CTERecursiveRequiresSingleReference, "In recursive query block of Recursive Common Table Expression %s, the recursive table must be referenced only once, and not in any subquery", "")
-	VT09030 = errorWithState("VT09030", vtrpcpb.Code_FAILED_PRECONDITION, CTEMaxRecursionDepth, "Recursive query aborted after 1000 iterations.", "")
+	VT09030 = errorWithState("VT09030", vtrpcpb.Code_FAILED_PRECONDITION, CTEMaxRecursionDepth, "Recursive query aborted after %d iterations. Try increasing @@cte_max_recursion_depth to a larger value.", "")
 	VT09031 = errorWithoutState("VT09031", vtrpcpb.Code_FAILED_PRECONDITION, "Primary demotion is stalled", "")
+	VT09032 = errorWithoutState("VT09032", vtrpcpb.Code_FAILED_PRECONDITION, "table '%s' is not scoped by its tenant column '%s'", "The keyspace requires the queries on this table to have a predicate on, or a value for, the tenant column.")
+	VT09033 = errorWithoutState("VT09033", vtrpcpb.Code_FAILED_PRECONDITION, "tenant_id is not set", "The query is scoped to the tenant of the session, which has to be set with SET @@tenant_id.")
+	VT09034 = errorWithoutState("VT09034", vtrpcpb.Code_FAILED_PRECONDITION, "tenant column value '%s' does not match the tenant_id '%s' of the session", "The query can only use the tenant of the session, which is set with SET @@tenant_id.")
 
 	VT10001 = errorWithoutState("VT10001", vtrpcpb.Code_ABORTED, "foreign key constraints are not allowed", "Foreign key constraints are not allowed, see https://vitess.io/blog/2021-06-15-online-ddl-why-no-fk/.")
 	VT10002 = errorWithoutState("VT10002", vtrpcpb.Code_ABORTED, "atomic distributed transaction not allowed: %s", "The distributed transaction cannot be committed. A rollback decision is taken.")
@@ -195,6 +198,9 @@ var (
 		VT09029,
 		VT09030,
 		VT09031,
+		VT09032,
+		VT09033,
+		VT09034,
 		VT10001,
 		VT10002,
 		VT12001,
@@ -19,6 +19,7 @@ package engine
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/slice"
@@ -29,6 +30,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vthash"
 )
 
 // AggregateParams specify the parameters for each aggregation.
@@ -42,6 +44,17 @@ type AggregateParams struct {
 	WCol   int
 	Type   evalengine.Type
 
+	// HashDistinct is set when the input is not sorted by the DISTINCT expressions, e.g. when
+	// there is more than one DISTINCT aggregation. The values already seen are then kept in a hash set.
+	HashDistinct bool
+	// DistinctCols are the columns of the remaining expressions of a
+	// multi-expression DISTINCT aggregation, such as COUNT(DISTINCT a, b).
+	// GROUP_CONCAT(DISTINCT a, b) concatenates their values after the first one.
+	DistinctCols []int
+
+	// OrderBy is used by GROUP_CONCAT with ORDER BY to sort the values of a group
+	OrderBy evalengine.Comparison
+
 	Alias    string
 	Func     sqlparser.AggrFunc
 	Original *sqlparser.AliasedExpr
@@ -79,6 +92,17 @@ func (ap *AggregateParams) String() string {
 	if sqltypes.IsText(ap.Type.Type()) && ap.CollationEnv.IsSupported(ap.Type.Collation()) {
 		keyCol += " COLLATE " + ap.CollationEnv.LookupName(ap.Type.Collation())
 	}
+	for _, col := range ap.DistinctCols {
+		keyCol += ", " + strconv.Itoa(col)
+	}
+	if len(ap.OrderBy) > 0 {
+		keyCol += " ORDER BY " + strings.Join(slice.Map(ap.OrderBy, func(from evalengine.OrderByParams) string {
+			return from.String()
+		}), ", ")
+	}
+	if ap.HashDistinct {
+		keyCol += " USING HASH"
+	}
 	dispOrigOp := ""
 	if ap.OrigOpcode != AggregateUnassigned && ap.OrigOpcode != ap.Opcode {
 		dispOrigOp = "_" + ap.OrigOpcode.String()
@@ -108,9 +132,73 @@ type aggregatorDistinct struct {
 	coll         collations.ID
 	collationEnv *collations.Environment
 	values       *evalengine.EnumSetValues
+
+	// hashed is used instead of comparing with the last value when the input is not sorted by the distinct columns
+	hashed *distinctHashSet
+}
+
+// distinctHashSet keeps the hash codes of all the values seen by a DISTINCT aggregation in the current group
+type distinctHashSet struct {
+	columns []int
+	colls   []collations.ID
+	types   []sqltypes.Type
+	seen    map[vthash.Hash]struct{}
+}
+
+func newDistinctHashSet(fields []*querypb.Field, columns []int, collationEnv *collations.Environment) *distinctHashSet {
+	if collationEnv == nil {
+		collationEnv = collations.MySQL8()
+	}
+	set := &distinctHashSet{
+		columns: columns,
+		seen:    make(map[vthash.Hash]struct{}),
+	}
+	for _, col := range columns {
+		coll := collations.ID(fields[col].Charset)
+		if sqltypes.IsText(fields[col].Type) && !collationEnv.IsSupported(coll) {
+			// the field does not tell us the collation, so we use the default one for the connection
+			coll = collationEnv.DefaultConnectionCharset()
+		}
+		set.colls = append(set.colls, coll)
+		set.types = append(set.types, fields[col].Type)
+	}
+	return set
+}
+
+// seenBefore adds the values of the row to the set, and returns true if they were already in it
+func (s *distinctHashSet) seenBefore(row []sqltypes.Value) (bool, error) {
+	hasher := vthash.New()
+	for i, col := range s.columns {
+		if err := evalengine.NullsafeHashcode128(&hasher, row[col], s.colls[i], s.types[i], 0, nil); err != nil {
+			return false, err
+		}
+	}
+	code := hasher.Sum128()
+	if _, found := s.seen[code]; found {
+		return true, nil
+	}
+	s.seen[code] = struct{}{}
+	return false, nil
+}
+
+// hasNull returns true if any of the distinct values in the row is NULL
+func (a *aggregatorDistinct) hasNull(row []sqltypes.Value) bool {
+	if a.hashed == nil {
+		return false
+	}
+	for _, col := range a.hashed.columns {
+		if row[col].IsNull() {
+			return true
+		}
+	}
+	return false
 }
 
 func (a *aggregatorDistinct) shouldReturn(row []sqltypes.Value) (bool, error) {
+	if a.hashed != nil {
+		seen, err := a.hashed.seenBefore(row)
+		return seen || err != nil, err
+	}
 	if a.column >= 0 {
 		last := a.last
 		next := row[a.column]
@@ -132,6 +220,9 @@ func (a *aggregatorDistinct) shouldReturn(row []sqltypes.Value) (bool, error) {
 
 func (a *aggregatorDistinct) reset() {
 	a.last = sqltypes.NULL
+	if a.hashed != nil {
+		a.hashed.seen = make(map[vthash.Hash]struct{})
+	}
 }
 
 type aggregatorCount struct {
@@ -141,7 +232,7 @@ type aggregatorCount struct {
 }
 
 func (a *aggregatorCount) add(row []sqltypes.Value) error {
-	if row[a.from].IsNull() {
+	if row[a.from].IsNull() || a.distinct.hasNull(row) {
 		return nil
 	}
 	if ret, err := a.distinct.shouldReturn(row); ret {
@@ -255,27 +346,62 @@ func (a *aggregatorScalar) reset() {
 }
 
 type aggregatorGroupConcat struct {
-	from      int
+	from int
+	// args are the columns of the remaining arguments, which are concatenated after the first one
+	args      []int
 	type_     sqltypes.Type
 	separator []byte
+	distinct  aggregatorDistinct
+
+	// orderBy is set for GROUP_CONCAT with ORDER BY. The rows of the
+	// group are kept, and concatenated in order when the group is finished.
+	orderBy evalengine.Comparison
+	rows    []sqltypes.Row
 
 	concat []byte
 	n      int
 }
 
 func (a *aggregatorGroupConcat) add(row []sqltypes.Value) error {
+	// like MySQL, the rows with a NULL argument are skipped
 	if row[a.from].IsNull() {
 		return nil
 	}
+	for _, col := range a.args {
+		if row[col].IsNull() {
+			return nil
+		}
+	}
+	if ret, err := a.distinct.shouldReturn(row); ret {
+		return err
+	}
+	if a.orderBy != nil {
+		a.rows = append(a.rows, row)
+		return nil
+	}
+	a.append(row)
+	return nil
+}
+
+func (a *aggregatorGroupConcat) append(row []sqltypes.Value) {
 	if a.n > 0 {
 		a.concat = append(a.concat, a.separator...)
 	}
 	a.concat = append(a.concat, row[a.from].Raw()...)
+	for _, col := range a.args {
+		a.concat = append(a.concat, row[col].Raw()...)
+	}
 	a.n++
-	return nil
 }
 
 func (a *aggregatorGroupConcat) finish() sqltypes.Value {
+	if a.orderBy != nil {
+		a.orderBy.Sort(a.rows)
+		for _, row := range a.rows {
+			a.append(row)
+		}
+		a.rows = nil
+	}
 	if a.n == 0 {
 		return sqltypes.NULL
 	}
@@ -285,6 +411,8 @@ func (a *aggregatorGroupConcat) finish() sqltypes.Value {
 func (a *aggregatorGroupConcat) reset() {
 	a.n = 0
 	a.concat = nil // not safe to reuse this byte slice as it's returned as MakeTrusted
+	a.rows = nil
+	a.distinct.reset()
 }
 
 type aggregatorGtid struct {
@@ -354,8 +482,8 @@ func isComparable(typ sqltypes.Type) bool {
 	return false
 }
 
-func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams) (aggregationState, []*querypb.Field, error) {
-	fields = slice.Map(fields, func(from *querypb.Field) *querypb.Field { return from.CloneVT() })
+func newAggregation(inputFields []*querypb.Field, aggregates []*AggregateParams) (aggregationState, []*querypb.Field, error) {
+	fields := slice.Map(inputFields, func(from *querypb.Field) *querypb.Field { return from.CloneVT() })
 
 	agstate := make([]aggregator, len(fields))
 	for _, aggr := range aggregates {
@@ -371,6 +499,11 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams) (agg
 				distinct = aggr.WCol
 			}
 		}
+		var hashed *distinctHashSet
+		if aggr.HashDistinct {
+			// the output fields are changed to the aggregation types, so we use the input fields here
+			hashed = newDistinctHashSet(inputFields, append([]int{aggr.Col}, aggr.DistinctCols...), aggr.CollationEnv)
+		}
 
 		if aggr.Opcode == AggregateMin || aggr.Opcode == AggregateMax {
 			if aggr.WAssigned() && !isComparable(sourceType) {
@@ -390,6 +523,7 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams) (agg
 					coll:         aggr.Type.Collation(),
 					collationEnv: aggr.CollationEnv,
 					values:       aggr.Type.Values(),
+					hashed:       hashed,
 				},
 			}
 
@@ -410,6 +544,7 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams) (agg
 					coll:         aggr.Type.Collation(),
 					collationEnv: aggr.CollationEnv,
 					values:       aggr.Type.Values(),
+					hashed:       hashed,
 				},
 			}
 
@@ -440,8 +575,17 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams) (agg
 			separator := []byte(gcFunc.Separator)
 			ag = &aggregatorGroupConcat{
 				from:      aggr.Col,
+				args:      aggr.DistinctCols,
 				type_:     targetType,
 				separator: separator,
+				distinct: aggregatorDistinct{
+					column:       distinct,
+					coll:         aggr.Type.Collation(),
+					collationEnv: aggr.CollationEnv,
+					values:       aggr.Type.Values(),
+					hashed:       hashed,
+				},
+				orderBy: aggr.OrderBy,
 			}
 
 		default:
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*CorrelatedSubquery)(nil)
+
+// CorrelatedSubquery executes the subquery once for every row of the outer primitive,
+// using values from the outer row as bind variables for the subquery.
+// The result of the subquery is made available to the row in one of two ways:
+//   - if there is a Predicate, it is evaluated using the subquery result as bind variables,
+//     and only the outer rows for which it evaluates to true are returned.
+//   - otherwise, the value of the subquery is added as the first column of the outer row.
+type CorrelatedSubquery struct {
+	Opcode PulloutOpcode
+
+	// SubqueryResult and HasValues are the bind variables that the subquery result is stored in
+	SubqueryResult string
+	HasValues      string
+
+	// Vars defines the bind variables that need to be built
+	// from the outer row before invoking the subquery.
+	Vars map[string]int
+
+	Predicate    evalengine.Expr
+	ASTPredicate sqlparser.Expr
+
+	Subquery Primitive
+	Outer    Primitive
+}
+
+// Inputs returns the input primitives for this subquery
+func (cs *CorrelatedSubquery) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{cs.Outer, cs.Subquery}, []map[string]any{{
+		inputName: "Outer",
+	}, {
+		inputName: "SubQuery",
+	}}
+}
+
+// RouteType returns a description of the query routing type used by the primitive
+func (cs *CorrelatedSubquery) RouteType() string {
+	return cs.Opcode.String()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (cs *CorrelatedSubquery) GetKeyspaceName() string {
+	return cs.Outer.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (cs *CorrelatedSubquery) GetTableName() string {
+	return cs.Outer.GetTableName()
+}
+
+// TryExecute satisfies the Primitive interface.
+func (cs *CorrelatedSubquery) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	outer, err := vcursor.ExecutePrimitive(ctx, cs.Outer, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	result := &sqltypes.Result{}
+	if wantfields {
+		result.Fields, err = cs.fields(ctx, vcursor, bindVars, outer.Fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Rows, err = cs.processRows(ctx, vcursor, bindVars, outer.Rows)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TryStreamExecute performs a streaming exec.
+func (cs *CorrelatedSubquery) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	return vcursor.StreamExecutePrimitive(ctx, cs.Outer, bindVars, wantfields, func(outer *sqltypes.Result) error {
+		result := &sqltypes.Result{}
+		if len(outer.Fields) > 0 {
+			fields, err := cs.fields(ctx, vcursor, bindVars, outer.Fields)
+			if err != nil {
+				return err
+			}
+			result.Fields = fields
+		}
+		rows, err := cs.processRows(ctx, vcursor, bindVars, outer.Rows)
+		if err != nil {
+			return err
+		}
+		result.Rows = rows
+		return callback(result)
+	})
+}
+
+// GetFields fetches the field info.
+func (cs *CorrelatedSubquery) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	outer, err := cs.Outer.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := cs.fields(ctx, vcursor, bindVars, outer.Fields)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: fields}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (cs *CorrelatedSubquery) NeedsTransaction() bool {
+	return cs.Subquery.NeedsTransaction() || cs.Outer.NeedsTransaction()
+}
+
+func (cs *CorrelatedSubquery) processRows(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, rows []sqltypes.Row) ([]sqltypes.Row, error) {
+	var out []sqltypes.Row
+	for _, row := range rows {
+		combinedVars, err := cs.execSubquery(ctx, vcursor, bindVars, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if cs.Predicate == nil {
+			value, err := sqltypes.BindVariableToValue(combinedVars[cs.valueName()])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, append(sqltypes.Row{value}, row...))
+			continue
+		}
+
+		env := evalengine.NewExpressionEnv(ctx, combinedVars, vcursor)
+		env.Row = row
+		evalResult, err := env.Evaluate(cs.Predicate)
+		if err != nil {
+			return nil, err
+		}
+		if evalResult.ToBoolean() {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+func (cs *CorrelatedSubquery) execSubquery(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, row sqltypes.Row) (map[string]*querypb.BindVariable, error) {
+	joinVars := make(map[string]*querypb.BindVariable, len(cs.Vars))
+	for k, col := range cs.Vars {
+		joinVars[k] = sqltypes.ValueBindVariable(row[col])
+	}
+	combinedVars := combineVars(bindVars, joinVars)
+	result, err := vcursor.ExecutePrimitive(ctx, cs.Subquery, combinedVars, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Rows) > 0 && len(result.Rows[0]) > 1 && cs.Opcode != PulloutExists {
+		return nil, errSqColumn
+	}
+	if err := addSubqueryBindVars(cs.Opcode, cs.SubqueryResult, cs.HasValues, result, combinedVars); err != nil {
+		return nil, err
+	}
+	return combinedVars, nil
+}
+
+// fields returns the fields of the rows produced by this primitive
+func (cs *CorrelatedSubquery) fields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, outer []*querypb.Field) ([]*querypb.Field, error) {
+	if cs.Predicate != nil || outer == nil {
+		return outer, nil
+	}
+	field := &querypb.Field{
+		Name:    cs.valueName(),
+		Type:    sqltypes.Int64,
+		Charset: collations.CollationBinaryID,
+		Flags:   uint32(querypb.MySqlFlag_BINARY_FLAG | querypb.MySqlFlag_NUM_FLAG),
+	}
+	if cs.Opcode == PulloutValue {
+		joinVars := make(map[string]*querypb.BindVariable, len(cs.Vars))
+		for k := range cs.Vars {
+			joinVars[k] = sqltypes.NullBindVariable
+		}
+		qr, err := cs.Subquery.GetFields(ctx, vcursor, combineVars(bindVars, joinVars))
+		if err != nil {
+			return nil, err
+		}
+		if len(qr.Fields) == 0 {
+			return nil, vterrors.VT13001("subquery did not return any fields")
+		}
+		field = qr.Fields[0].CloneVT()
+		field.Name = cs.valueName()
+		// the subquery returns NULL if it has no rows
+		field.Flags &^= uint32(querypb.MySqlFlag_NOT_NULL_FLAG)
+	}
+	return append([]*querypb.Field{field}, outer...), nil
+}
+
+func (cs *CorrelatedSubquery) valueName() string {
+	if cs.Opcode == PulloutExists {
+		return cs.HasValues
+	}
+	return cs.SubqueryResult
+}
+
+func (cs *CorrelatedSubquery) description() PrimitiveDescription {
+	other := map[string]any{}
+	var pulloutVars []string
+	if cs.HasValues != "" {
+		pulloutVars = append(pulloutVars, cs.HasValues)
+	}
+	if cs.SubqueryResult != "" {
+		pulloutVars = append(pulloutVars, cs.SubqueryResult)
+	}
+	if len(pulloutVars) > 0 {
+		other["PulloutVars"] = pulloutVars
+	}
+	if len(cs.Vars) > 0 {
+		other["JoinVars"] = orderedStringIntMap(cs.Vars)
+	}
+	if cs.Predicate != nil {
+		other["Predicate"] = sqlparser.String(cs.ASTPredicate)
+	}
+	return PrimitiveDescription{
+		OperatorType: "CorrelatedSubquery",
+		Variant:      cs.Opcode.String(),
+		Other:        other,
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func TestCorrelatedSubqueryIn(t *testing.T) {
+	outerResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|col",
+			"int64|int64",
+		),
+		"1|10",
+		"2|20",
+		"3|30",
+	)
+	sqFields := sqltypes.MakeTestFields("col", "int64")
+	sfp := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(sqFields, "10", "11"),
+			sqltypes.MakeTestResult(sqFields, "21"),
+			sqltypes.MakeTestResult(sqFields),
+		},
+	}
+
+	predicate, err := sqlparser.NewTestParser().ParseExpr(":__sq_has_values and col in ::__sq1")
+	require.NoError(t, err)
+	pred, err := evalengine.Translate(predicate, &evalengine.Config{
+		Collation:     collations.CollationBinaryID,
+		ResolveColumn: evalengine.FieldResolver(outerResult.Fields).Column,
+		Environment:   vtenv.NewTestEnv(),
+	})
+	require.NoError(t, err)
+
+	cs := &CorrelatedSubquery{
+		Opcode:         PulloutIn,
+		SubqueryResult: "__sq1",
+		HasValues:      "__sq_has_values",
+		Vars:           map[string]int{"id": 0},
+		Predicate:      pred,
+		ASTPredicate:   predicate,
+		Subquery:       sfp,
+		Outer:          &fakePrimitive{results: []*sqltypes.Result{outerResult}},
+	}
+
+	result, err := cs.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	sfp.ExpectLog(t, []string{
+		`Execute id: type:INT64 value:"1" false`,
+		`Execute id: type:INT64 value:"2" false`,
+		`Execute id: type:INT64 value:"3" false`,
+	})
+	expectResult(t, result, sqltypes.MakeTestResult(outerResult.Fields, "1|10"))
+}
+
+func TestCorrelatedSubqueryValue(t *testing.T) {
+	outerResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id",
+			"int64",
+		),
+		"1",
+		"2",
+	)
+	sqFields := sqltypes.MakeTestFields("count(*)", "int64")
+	sfp := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(sqFields),
+			sqltypes.MakeTestResult(sqFields, "4"),
+			sqltypes.MakeTestResult(sqFields),
+		},
+	}
+
+	cs := &CorrelatedSubquery{
+		Opcode:         PulloutValue,
+		SubqueryResult: "__sq1",
+		Vars:           map[string]int{"id": 0},
+		Subquery:       sfp,
+		Outer:          &fakePrimitive{results: []*sqltypes.Result{outerResult}},
+	}
+
+	result, err := cs.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	sfp.ExpectLog(t, []string{
+		`GetFields id: `,
+		`Execute id:  true`,
+		`Execute id: type:INT64 value:"1" false`,
+		`Execute id: type:INT64 value:"2" false`,
+	})
+	expectResult(t, result, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("__sq1|id", "int64|int64"),
+		"4|1",
+		"null|2",
+	))
+}
+
+func TestCorrelatedSubqueryTooManyColumns(t *testing.T) {
+	sfp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("col1|col2", "int64|int64"),
+			"1|2",
+		)},
+	}
+
+	cs := &CorrelatedSubquery{
+		Opcode:         PulloutValue,
+		SubqueryResult: "__sq1",
+		Vars:           map[string]int{"id": 0},
+		Subquery:       sfp,
+		Outer: &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("id", "int64"),
+			"1",
+		)}},
+	}
+
+	_, err := cs.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.EqualError(t, err, "subquery returned more than one column")
+}
@@ -171,6 +171,10 @@ func (t *noopVCursor) SQLMode() string {
 	return config.DefaultSQLMode
 }
 
+func (t *noopVCursor) BlockEncryptionMode() string {
+	return config.DefaultBlockEncryptionMode
+}
+
 func (t *noopVCursor) ExecutePrimitive(ctx context.Context, primitive Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
 	return primitive.TryExecute(ctx, t, bindVars, wantfields)
 }
@@ -223,6 +227,22 @@ func (t *noopVCursor) GetMigrationContext() string {
 	panic("implement me")
 }
 
+func (t *noopVCursor) SetTenantID(tenantID string) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) GetTenantID() string {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetCTEMaxRecursionDepth(int64) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) GetCTEMaxRecursionDepth() int64 {
+	return 1000
+}
+
 func (t *noopVCursor) GetSessionUUID() string {
 	panic("implement me")
 }
@@ -450,7 +470,9 @@ type loggingVCursor struct {
 	ksAvailable     bool
 	inReservedConn  bool
 	systemVariables map[string]string
-	disableSetVar   bool
+	// cteMaxRecursionDepth overrides the default cte_max_recursion_depth when set
+	cteMaxRecursionDepth int64
+	disableSetVar        bool
 
 	// map different shards to keyspaces in the test.
 	ksShardMap map[string][]string
@@ -511,6 +533,13 @@ func (f *loggingVCursor) GetSystemVariables(func(k string, v string)) {
 	panic("implement me")
 }
 
+func (f *loggingVCursor) GetCTEMaxRecursionDepth() int64 {
+	if f.cteMaxRecursionDepth == 0 {
+		return f.noopVCursor.GetCTEMaxRecursionDepth()
+	}
+	return f.cteMaxRecursionDepth
+}
+
 func (f *loggingVCursor) SetFoundRows(u uint64) {
 	panic("implement me")
 }
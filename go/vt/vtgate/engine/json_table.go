@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*JSONTable)(nil)
+
+// JSONTable evaluates a JSON_TABLE table expression in vtgate.
+// When the JSON_TABLE uses columns from other tables, they are provided as bind variables.
+type JSONTable struct {
+	noInputs
+	noTxNeeded
+
+	JSONTable *evalengine.JSONTable
+
+	// Cols are the offsets of the JSON_TABLE columns that are returned
+	Cols []int
+
+	// Doc and Path are the document expression and the row path, used for describing the primitive
+	Doc  string
+	Path string
+}
+
+// RouteType implements the Primitive interface
+func (jt *JSONTable) RouteType() string {
+	return "JSONTable"
+}
+
+// GetKeyspaceName implements the Primitive interface
+func (jt *JSONTable) GetKeyspaceName() string {
+	return ""
+}
+
+// GetTableName implements the Primitive interface
+func (jt *JSONTable) GetTableName() string {
+	return ""
+}
+
+// TryExecute implements the Primitive interface
+func (jt *JSONTable) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	rows, err := jt.JSONTable.Rows(env)
+	if err != nil {
+		return nil, err
+	}
+	result := &sqltypes.Result{}
+	if wantfields {
+		result.Fields = jt.fields()
+	}
+	for _, row := range rows {
+		out := make(sqltypes.Row, 0, len(jt.Cols))
+		for _, col := range jt.Cols {
+			out = append(out, row[col])
+		}
+		result.Rows = append(result.Rows, out)
+	}
+	return result, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (jt *JSONTable) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	result, err := jt.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(result)
+}
+
+// GetFields implements the Primitive interface
+func (jt *JSONTable) GetFields(context.Context, VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{Fields: jt.fields()}, nil
+}
+
+func (jt *JSONTable) fields() []*querypb.Field {
+	all := jt.JSONTable.Fields()
+	fields := make([]*querypb.Field, 0, len(jt.Cols))
+	for _, col := range jt.Cols {
+		fields = append(fields, all[col])
+	}
+	return fields
+}
+
+func (jt *JSONTable) description() PrimitiveDescription {
+	all := jt.JSONTable.Fields()
+	var columns []string
+	for _, col := range jt.Cols {
+		columns = append(columns, all[col].Name)
+	}
+	return PrimitiveDescription{
+		OperatorType: "JSONTable",
+		Other: map[string]any{
+			"Document": jt.Doc,
+			"Path":     jt.Path,
+			"Columns":  columns,
+		},
+	}
+}
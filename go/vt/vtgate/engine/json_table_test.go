@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func TestJSONTable(t *testing.T) {
+	venv := vtenv.NewTestEnv()
+	stmt, err := venv.Parser().Parse(`select * from json_table(:doc, '$[*]' columns(id for ordinality, name varchar(10) path '$.name')) as jt`)
+	require.NoError(t, err)
+	node := stmt.(*sqlparser.Select).From[0].(*sqlparser.JSONTableExpr)
+	ejt, err := evalengine.TranslateJSONTable(node, &evalengine.Config{
+		Collation:   collations.CollationUtf8mb4ID,
+		Environment: venv,
+	})
+	require.NoError(t, err)
+
+	jt := &JSONTable{
+		JSONTable: ejt,
+		Cols:      []int{1, 0},
+		Doc:       ":doc",
+		Path:      "'$[*]'",
+	}
+	bv := map[string]*querypb.BindVariable{
+		"doc": sqltypes.StringBindVariable(`[{"name": "a"}, {"name": "b"}]`),
+	}
+
+	result, err := jt.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+	expected := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("name|id", "varchar|uint32"),
+		"a|1",
+		"b|2",
+	)
+	require.Equal(t, expected.Rows, result.Rows)
+	require.Len(t, result.Fields, 2)
+	require.Equal(t, "name", result.Fields[0].Name)
+	require.Equal(t, sqltypes.Uint32, result.Fields[1].Type)
+
+	bv["doc"] = sqltypes.NullBindVariable
+	result, err = jt.TryExecute(context.Background(), &noopVCursor{}, bv, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Rows)
+}
@@ -200,3 +200,64 @@ func (code AggregateOpcode) IsDistinct() bool {
 		return false
 	}
 }
+
+// WindowOpcode is the opcode for window functions evaluated on vtgate.
+type WindowOpcode int
+
+// These constants list the window functions that can be evaluated on vtgate.
+const (
+	WindowRowNumber = WindowOpcode(iota)
+	WindowRank
+	WindowDenseRank
+	WindowLag
+	WindowLead
+	WindowSum
+	_NumOfWindowOpCodes // This line must be last of the opcodes!
+)
+
+var WindowName = map[WindowOpcode]string{
+	WindowRowNumber: "row_number",
+	WindowRank:      "rank",
+	WindowDenseRank: "dense_rank",
+	WindowLag:       "lag",
+	WindowLead:      "lead",
+	WindowSum:       "sum",
+}
+
+func (code WindowOpcode) String() string {
+	name := WindowName[code]
+	if name == "" {
+		name = "ERROR"
+	}
+	return name
+}
+
+// MarshalJSON serializes the WindowOpcode as a JSON string.
+// It's used for testing and diagnostics.
+func (code WindowOpcode) MarshalJSON() ([]byte, error) {
+	return ([]byte)(fmt.Sprintf("\"%s\"", code.String())), nil
+}
+
+// SQLType returns the type produced by the window function, given the type of its argument
+func (code WindowOpcode) SQLType(typ querypb.Type) querypb.Type {
+	switch code {
+	case WindowRowNumber, WindowRank, WindowDenseRank:
+		return sqltypes.Int64
+	case WindowLag, WindowLead:
+		return typ
+	case WindowSum:
+		return AggregateSum.SQLType(typ)
+	default:
+		panic(code.String()) // we have a unit test checking we never reach here
+	}
+}
+
+// UsesArgument returns true if the window function reads the value of its argument column
+func (code WindowOpcode) UsesArgument() bool {
+	switch code {
+	case WindowLag, WindowLead, WindowSum:
+		return true
+	default:
+		return false
+	}
+}
@@ -34,6 +34,35 @@ func TestCheckAllAggrOpCodes(t *testing.T) {
 	}
 }
 
+func TestCheckAllWindowOpCodes(t *testing.T) {
+	// This test is just checking that we never reach the panic when using SQLType() on valid opcodes
+	for i := WindowOpcode(0); i < _NumOfWindowOpCodes; i++ {
+		i.SQLType(sqltypes.Null)
+	}
+}
+
+func TestWindowType(t *testing.T) {
+	tt := []struct {
+		opcode WindowOpcode
+		typ    querypb.Type
+		out    querypb.Type
+	}{
+		{WindowRowNumber, sqltypes.VarChar, sqltypes.Int64},
+		{WindowRank, sqltypes.Null, sqltypes.Int64},
+		{WindowDenseRank, sqltypes.Float64, sqltypes.Int64},
+		{WindowLag, sqltypes.VarChar, sqltypes.VarChar},
+		{WindowLead, sqltypes.Datetime, sqltypes.Datetime},
+		{WindowSum, sqltypes.Int64, sqltypes.Decimal},
+		{WindowSum, sqltypes.Float32, sqltypes.Float64},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.opcode.String()+"_"+tc.typ.String(), func(t *testing.T) {
+			assert.Equal(t, tc.out, tc.opcode.SQLType(tc.typ))
+		})
+	}
+}
+
 func TestType(t *testing.T) {
 	tt := []struct {
 		opcode AggregateOpcode
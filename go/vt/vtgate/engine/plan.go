@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vthash"
 )
 
@@ -48,6 +49,8 @@ type (
 		TablesUsed   []string                // TablesUsed enumerates the tables this query accesses.
 		QueryHints   sqlparser.QueryHints    // QueryHints stores any SET_VAR hints that influenced plan generation.
 		ParamsCount  uint16                  // ParamsCount is the total number of bind parameters (?) in the query.
+		Cacheable    bool                    // Cacheable is set when the results of the query only depend on its bind vars and the rows it reads.
+		TenantValues []evalengine.Expr       // TenantValues are the values the query gives to tenant columns, which must match the tenant id of the session.
 
 		ExecCount    uint64 // ExecCount is how many times this plan has been executed.
 		ExecTime     uint64 // ExecTime is the total accumulated execution time in nanoseconds.
@@ -299,6 +302,7 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		RowsReturned uint64                `json:",omitempty"`
 		Errors       uint64                `json:",omitempty"`
 		TablesUsed   []string              `json:",omitempty"`
+		TenantValues []string              `json:",omitempty"`
 	}{
 		Type:         p.Type.String(),
 		QueryType:    p.QueryType.String(),
@@ -312,6 +316,9 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		Errors:       atomic.LoadUint64(&p.Errors),
 		TablesUsed:   p.TablesUsed,
 	}
+	for _, value := range p.TenantValues {
+		marshalPlan.TenantValues = append(marshalPlan.TenantValues, sqlparser.String(value))
+	}
 
 	b := new(bytes.Buffer)
 	enc := json.NewEncoder(b)
@@ -91,6 +91,7 @@ type (
 		Environment() *vtenv.Environment
 		TimeZone() *time.Location
 		SQLMode() string
+		BlockEncryptionMode() string
 
 		ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error)
 
@@ -189,6 +190,10 @@ type (
 		GetDDLStrategy() string
 		SetMigrationContext(string)
 		GetMigrationContext() string
+		SetTenantID(string)
+		GetTenantID() string
+		SetCTEMaxRecursionDepth(int64)
+		GetCTEMaxRecursionDepth() int64
 
 		GetSessionUUID() string
 
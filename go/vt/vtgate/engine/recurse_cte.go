@@ -33,21 +33,40 @@ type RecurseCTE struct {
 	Seed, Term Primitive
 
 	Vars map[string]int
+
+	// Distinct is set for UNION DISTINCT recursive CTEs. Rows that have already been produced
+	// are dropped and not used for further recursion, which also stops recursion over cycles.
+	Distinct bool
 }
 
 var _ Primitive = (*RecurseCTE)(nil)
 
 func (r *RecurseCTE) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
-	res, err := vcursor.ExecutePrimitive(ctx, r.Seed, bindVars, wantfields)
+	res, err := vcursor.ExecutePrimitive(ctx, r.Seed, bindVars, wantfields || r.Distinct)
 	if err != nil {
 		return nil, err
 	}
 
+	var seen *distinctHashSet
+	if r.Distinct {
+		seen = r.newSeenSet(vcursor, res.Fields)
+		if res.Rows, err = filterSeen(seen, res.Rows); err != nil {
+			return nil, err
+		}
+		if !wantfields {
+			res.Fields = nil
+		}
+	}
+
+	maxDepth := int(vcursor.Session().GetCTEMaxRecursionDepth())
+
 	// recurseRows contains the rows used in the next recursion
 	recurseRows := res.Rows
 	joinVars := make(map[string]*querypb.BindVariable)
-	loops := 0
-	for len(recurseRows) > 0 {
+	for depth := 1; len(recurseRows) > 0; depth++ {
+		if depth > maxDepth {
+			return nil, vterrors.VT09030(depth)
+		}
 		// copy over the results from the previous recursion
 		theseRows := recurseRows
 		recurseRows = nil
@@ -63,38 +82,68 @@ func (r *RecurseCTE) TryExecute(ctx context.Context, vcursor VCursor, bindVars m
 			if err != nil {
 				return nil, err
 			}
-			recurseRows = append(recurseRows, rresult.Rows...)
-			res.Rows = append(res.Rows, rresult.Rows...)
-			loops++
-			if loops > 1000 { // TODO: This should be controlled with a system variable setting
-				return nil, vterrors.VT09030("")
+			rows := rresult.Rows
+			if seen != nil {
+				if rows, err = filterSeen(seen, rows); err != nil {
+					return nil, err
+				}
 			}
+			recurseRows = append(recurseRows, rows...)
+			res.Rows = append(res.Rows, rows...)
 		}
 	}
 	return res, nil
 }
 
+func (r *RecurseCTE) newSeenSet(vcursor VCursor, fields []*querypb.Field) *distinctHashSet {
+	columns := make([]int, len(fields))
+	for i := range fields {
+		columns[i] = i
+	}
+	return newDistinctHashSet(fields, columns, vcursor.Environment().CollationEnv())
+}
+
+// filterSeen returns the rows that have not been seen before, and adds them to the seen set
+func filterSeen(seen *distinctHashSet, rows []sqltypes.Row) ([]sqltypes.Row, error) {
+	var result []sqltypes.Row
+	for _, row := range rows {
+		found, err := seen.seenBefore(row)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
 func (r *RecurseCTE) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
-	if vcursor.Session().InTransaction() {
+	if vcursor.Session().InTransaction() || r.Distinct {
+		// UNION DISTINCT needs to see all the rows produced so far, so we don't stream it
 		res, err := r.TryExecute(ctx, vcursor, bindVars, wantfields)
 		if err != nil {
 			return err
 		}
 		return callback(res)
 	}
+	maxDepth := int(vcursor.Session().GetCTEMaxRecursionDepth())
 	return vcursor.StreamExecutePrimitive(ctx, r.Seed, bindVars, wantfields, func(result *sqltypes.Result) error {
 		err := callback(result)
 		if err != nil {
 			return err
 		}
-		return r.recurse(ctx, vcursor, bindVars, result, callback)
+		return r.recurse(ctx, vcursor, bindVars, result, 1, maxDepth, callback)
 	})
 }
 
-func (r *RecurseCTE) recurse(ctx context.Context, vcursor VCursor, bindvars map[string]*querypb.BindVariable, result *sqltypes.Result, callback func(*sqltypes.Result) error) error {
+func (r *RecurseCTE) recurse(ctx context.Context, vcursor VCursor, bindvars map[string]*querypb.BindVariable, result *sqltypes.Result, depth, maxDepth int, callback func(*sqltypes.Result) error) error {
 	if len(result.Rows) == 0 {
 		return nil
 	}
+	if depth > maxDepth {
+		return vterrors.VT09030(depth)
+	}
 	joinVars := make(map[string]*querypb.BindVariable)
 	for _, row := range result.Rows {
 		for k, col := range r.Vars {
@@ -106,7 +155,7 @@ func (r *RecurseCTE) recurse(ctx context.Context, vcursor VCursor, bindvars map[
 			if err != nil {
 				return err
 			}
-			return r.recurse(ctx, vcursor, bindvars, result, callback)
+			return r.recurse(ctx, vcursor, bindvars, result, depth+1, maxDepth, callback)
 		})
 		if err != nil {
 			return err
@@ -146,6 +195,9 @@ func (r *RecurseCTE) description() PrimitiveDescription {
 	other := map[string]interface{}{
 		"JoinVars": orderedStringIntMap(r.Vars),
 	}
+	if r.Distinct {
+		other["Distinct"] = true
+	}
 
 	return PrimitiveDescription{
 		OperatorType: "RecurseCTE",
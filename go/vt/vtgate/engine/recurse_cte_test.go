@@ -127,3 +127,65 @@ func TestRecurseDualQuery(t *testing.T) {
 	expectResult(t, r, wantRes)
 
 }
+
+func TestRecurseCTEMaxRecursionDepth(t *testing.T) {
+	// WITH RECURSIVE cte AS (SELECT 1 as col1 UNION ALL SELECT col1+1 FROM cte) SELECT * FROM cte;
+	fields := sqltypes.MakeTestFields("col1", "int64")
+	seed := &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "1")}}
+	term := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "2"),
+			sqltypes.MakeTestResult(fields, "3"),
+			sqltypes.MakeTestResult(fields, "4"),
+		},
+	}
+	cte := &RecurseCTE{
+		Seed: seed,
+		Term: term,
+		Vars: map[string]int{"col1": 0},
+	}
+	vc := &loggingVCursor{cteMaxRecursionDepth: 2}
+
+	_, err := cte.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "VT09030: Recursive query aborted after 3 iterations. Try increasing @@cte_max_recursion_depth to a larger value.")
+
+	seed.rewind()
+	term.rewind()
+	_, err = wrapStreamExecute(cte, vc, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "VT09030: Recursive query aborted after 3 iterations. Try increasing @@cte_max_recursion_depth to a larger value.")
+}
+
+func TestRecurseCTEDistinct(t *testing.T) {
+	// The recursion follows a cycle 1 -> 2 -> 1, which UNION DISTINCT stops:
+	// WITH RECURSIVE cte AS (SELECT 1 as col1 UNION SELECT next FROM cte JOIN t ON t.id = cte.col1) SELECT * FROM cte;
+	fields := sqltypes.MakeTestFields("col1", "int64")
+	seed := &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "1", "1")}}
+	term := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "2"),
+			sqltypes.MakeTestResult(fields, "1", "3"),
+			sqltypes.MakeTestResult(fields),
+		},
+	}
+	cte := &RecurseCTE{
+		Seed:     seed,
+		Term:     term,
+		Vars:     map[string]int{"col1": 0},
+		Distinct: true,
+	}
+
+	r, err := cte.TryExecute(context.Background(), &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	term.ExpectLog(t, []string{
+		`Execute col1: type:INT64 value:"1" false`,
+		`Execute col1: type:INT64 value:"2" false`,
+		`Execute col1: type:INT64 value:"3" false`,
+	})
+	expectResult(t, r, sqltypes.MakeTestResult(fields, "1", "2", "3"))
+
+	seed.rewind()
+	term.rewind()
+	r, err = wrapStreamExecute(cte, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	expectResult(t, r, sqltypes.MakeTestResult(fields, "1", "2", "3"))
+}
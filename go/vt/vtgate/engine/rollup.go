@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vterrors"
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+)
+
+var _ Primitive = (*Rollup)(nil)
+
+// Rollup is a primitive that adds the super-aggregate rows of a GROUP BY ... WITH ROLLUP.
+// It expects the underlying primitive to produce a single row per group, sorted by the
+// GroupByKeys, which is what OrderedAggregate does. Every row of the input is passed through,
+// and whenever a prefix of the grouping keys changes, a super-aggregate row is produced for it,
+// with NULL for the grouping keys that are rolled up. A grand total row comes last.
+type Rollup struct {
+	// Aggregates are the aggregations that produced the input rows.
+	// The super-aggregate rows are calculated by combining these.
+	Aggregates []*AggregateParams
+
+	// GroupByKeys specifies the grouping keys, in the order of the GROUP BY clause.
+	GroupByKeys []*GroupByParams
+
+	// TruncateColumnCount specifies the number of columns to return
+	// in the final result. Rest of the columns are truncated
+	// from the result received. If 0, no truncation happens.
+	TruncateColumnCount int
+
+	// Input is the primitive that will feed into this Primitive.
+	Input Primitive
+}
+
+// RouteType returns a description of the query routing type used by the primitive
+func (r *Rollup) RouteType() string {
+	return r.Input.RouteType()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (r *Rollup) GetKeyspaceName() string {
+	return r.Input.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (r *Rollup) GetTableName() string {
+	return r.Input.GetTableName()
+}
+
+// TryExecute is a Primitive function.
+func (r *Rollup) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	result, err := vcursor.ExecutePrimitive(
+		ctx,
+		r.Input,
+		bindVars,
+		true, /*wantFields - we need the input fields types to correctly combine the aggregations*/
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.newRollupState(result.Fields)
+	if err != nil {
+		return nil, err
+	}
+	out := &sqltypes.Result{
+		Fields: result.Fields,
+		Rows:   make([]sqltypes.Row, 0, len(result.Rows)),
+	}
+	for _, row := range result.Rows {
+		rows, err := state.add(row)
+		if err != nil {
+			return nil, err
+		}
+		out.Rows = append(out.Rows, rows...)
+	}
+	out.Rows = append(out.Rows, state.flush()...)
+
+	return out.Truncate(r.TruncateColumnCount), nil
+}
+
+// TryStreamExecute is a Primitive function.
+func (r *Rollup) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	cb := func(qr *sqltypes.Result) error {
+		return callback(qr.Truncate(r.TruncateColumnCount))
+	}
+
+	var state *rollupState
+	visitor := func(qr *sqltypes.Result) error {
+		var err error
+		if state == nil && len(qr.Fields) != 0 {
+			state, err = r.newRollupState(qr.Fields)
+			if err != nil {
+				return err
+			}
+			if err = cb(&sqltypes.Result{Fields: qr.Fields}); err != nil {
+				return err
+			}
+		}
+
+		var out []sqltypes.Row
+		for _, row := range qr.Rows {
+			rows, err := state.add(row)
+			if err != nil {
+				return err
+			}
+			out = append(out, rows...)
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return cb(&sqltypes.Result{Rows: out})
+	}
+
+	/* we need the input fields types to correctly combine the aggregations */
+	err := vcursor.StreamExecutePrimitive(ctx, r.Input, bindVars, true, visitor)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		return nil
+	}
+	rows := state.flush()
+	if len(rows) == 0 {
+		return nil
+	}
+	return cb(&sqltypes.Result{Rows: rows})
+}
+
+// GetFields is a Primitive function.
+func (r *Rollup) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := r.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Truncate(r.TruncateColumnCount), nil
+}
+
+// Inputs returns the Primitive input for this rollup
+func (r *Rollup) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{r.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (r *Rollup) NeedsTransaction() bool {
+	return r.Input.NeedsTransaction()
+}
+
+func (r *Rollup) description() PrimitiveDescription {
+	other := map[string]any{
+		"Aggregates": GenericJoin(r.Aggregates, aggregateParamsToString),
+		"GroupBy":    GenericJoin(r.GroupByKeys, groupByParamsToString),
+	}
+	if r.TruncateColumnCount > 0 {
+		other["ResultColumns"] = r.TruncateColumnCount
+	}
+	return PrimitiveDescription{
+		OperatorType: "Rollup",
+		Other:        other,
+	}
+}
+
+// rollupAggregate returns the aggregation that combines the already aggregated values of the input
+func rollupAggregate(aggr *AggregateParams) (*AggregateParams, error) {
+	kopy := *aggr
+	switch aggr.Opcode {
+	case AggregateCount, AggregateCountStar:
+		// the super-aggregate of counts is the sum of them
+		kopy.Opcode, kopy.OrigOpcode = AggregateSum, aggr.Opcode
+	case AggregateCountDistinct, AggregateSumDistinct:
+		// the planner rejects DISTINCT aggregations with ROLLUP, so we should never get here
+		return nil, vterrors.VT13001("DISTINCT aggregation in a Rollup")
+	}
+	return &kopy, nil
+}
+
+type rollupState struct {
+	keys []*GroupByParams
+
+	// levels holds the aggregation state for the super-aggregate rows. The state at index i
+	// aggregates the groups that share the first i grouping keys, so index 0 holds the grand total.
+	levels  []aggregationState
+	lastRow sqltypes.Row
+}
+
+func (r *Rollup) newRollupState(fields []*querypb.Field) (*rollupState, error) {
+	var aggregates []*AggregateParams
+	for _, aggr := range r.Aggregates {
+		rollup, err := rollupAggregate(aggr)
+		if err != nil {
+			return nil, err
+		}
+		aggregates = append(aggregates, rollup)
+	}
+
+	state := &rollupState{keys: r.GroupByKeys}
+	for range r.GroupByKeys {
+		agg, _, err := newAggregation(fields, aggregates)
+		if err != nil {
+			return nil, err
+		}
+		state.levels = append(state.levels, agg)
+	}
+	return state, nil
+}
+
+// add returns the incoming row, preceded by the super-aggregate rows of the groups that ended before it
+func (rs *rollupState) add(row sqltypes.Row) ([]sqltypes.Row, error) {
+	var out []sqltypes.Row
+	if rs.lastRow != nil {
+		changed := len(rs.keys)
+		for i := range rs.keys {
+			same, err := sameKeys(rs.keys[i:i+1], rs.lastRow, row)
+			if err != nil {
+				return nil, err
+			}
+			if !same {
+				changed = i
+				break
+			}
+		}
+		// all the levels that include the changed key are done
+		for level := len(rs.levels) - 1; level > changed; level-- {
+			out = append(out, rs.finishLevel(level))
+		}
+	}
+
+	for _, agg := range rs.levels {
+		if err := agg.add(row); err != nil {
+			return nil, err
+		}
+	}
+	rs.lastRow = row
+	return append(out, row), nil
+}
+
+// flush returns the remaining super-aggregate rows, ending with the grand total
+func (rs *rollupState) flush() []sqltypes.Row {
+	if rs.lastRow == nil {
+		return nil
+	}
+	var out []sqltypes.Row
+	for level := len(rs.levels) - 1; level >= 0; level-- {
+		out = append(out, rs.finishLevel(level))
+	}
+	return out
+}
+
+func (rs *rollupState) finishLevel(level int) sqltypes.Row {
+	row := rs.levels[level].finish()
+	rs.levels[level].reset()
+	for _, key := range rs.keys[level:] {
+		row[key.KeyCol] = sqltypes.NULL
+		if key.WeightStringCol >= 0 {
+			row[key.WeightStringCol] = sqltypes.NULL
+		}
+	}
+	return row
+}
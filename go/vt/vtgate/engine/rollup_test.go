@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+)
+
+func TestRollupExecute(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"a|b|count(*)|max(c)",
+		"varbinary|int64|int64|int64",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"x|1|2|10",
+			"x|2|1|30",
+			"y|1|4|20",
+		)},
+	}
+
+	countParam := NewAggregateParam(AggregateSum, 2, "", nil)
+	countParam.OrigOpcode = AggregateCountStar
+	r := &Rollup{
+		Aggregates: []*AggregateParams{
+			countParam,
+			NewAggregateParam(AggregateMax, 3, "", nil),
+		},
+		GroupByKeys: []*GroupByParams{
+			{KeyCol: 0, WeightStringCol: -1},
+			{KeyCol: 1, WeightStringCol: -1},
+		},
+		Input: fp,
+	}
+
+	result, err := r.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		fields,
+		"x|1|2|10",
+		"x|2|1|30",
+		"x|null|3|30",
+		"y|1|4|20",
+		"y|null|4|20",
+		"null|null|7|30",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestRollupCountsFromVTGate(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"a|count(b)|weight_string(a)",
+		"varchar|int64|varbinary",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|2|A",
+			"b|3|B",
+		)},
+	}
+
+	r := &Rollup{
+		Aggregates:          []*AggregateParams{NewAggregateParam(AggregateCount, 1, "", nil)},
+		GroupByKeys:         []*GroupByParams{{KeyCol: 0, WeightStringCol: 2}},
+		TruncateColumnCount: 2,
+		Input:               fp,
+	}
+
+	results, err := wrapStreamExecute(r, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"a|count(b)",
+			"varchar|int64",
+		),
+		"a|2",
+		"b|3",
+		"null|5",
+	)
+	utils.MustMatch(t, wantResult, results)
+}
+
+func TestRollupNoRows(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"a|count(*)",
+		"varbinary|int64",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(fields)},
+	}
+
+	r := &Rollup{
+		Aggregates:  []*AggregateParams{NewAggregateParam(AggregateCountStar, 1, "", nil)},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		Input:       fp,
+	}
+
+	result, err := r.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(fields), result)
+}
+
+func TestRollupDistinctAggregation(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"a|count(distinct b)",
+				"varbinary|int64",
+			),
+			"x|1",
+		)},
+	}
+
+	r := &Rollup{
+		Aggregates:  []*AggregateParams{NewAggregateParam(AggregateCountDistinct, 1, "", nil)},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		Input:       fp,
+	}
+
+	_, err := r.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.EqualError(t, err, "VT13001: [BUG] DISTINCT aggregation in a Rollup")
+}
@@ -25,6 +25,7 @@ import (
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
@@ -52,7 +53,8 @@ const (
 	// Requires: A Vindex, and a multi Values.
 	IN
 	// Between is for routing a statement to a multi shard
-	// Requires: A Vindex, and start and end Value.
+	// Requires: A Sequential Vindex, and one or more tuples of start and
+	// end Value. A NULL start or end means that the range is open on that side.
 	Between
 	// MultiEqual is used for routing queries with IN with tuple clause
 	// Requires: A Vindex, and a multi Tuple Values.
@@ -410,15 +412,24 @@ func (rp *RoutingParameters) inMultiCol(ctx context.Context, vcursor VCursor, bi
 
 func (rp *RoutingParameters) between(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
-	value, err := env.Evaluate(rp.Values[0])
-	if err != nil {
-		return nil, nil, err
+	ranges := make([][]sqltypes.Value, 0, len(rp.Values))
+	for _, expr := range rp.Values {
+		value, err := env.Evaluate(expr)
+		if err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, value.TupleValues())
 	}
-	rss, values, err := resolveShardsBetween(ctx, vcursor, rp.Vindex.(vindexes.Sequential), rp.Keyspace, value.TupleValues())
+	rss, err := resolveShardsBetween(ctx, vcursor, rp.Vindex.(vindexes.Sequential), rp.Keyspace, ranges)
 	if err != nil {
 		return nil, nil, err
 	}
-	return rss, shardVars(bindVars, values), nil
+
+	multiBindVars := make([]map[string]*querypb.BindVariable, len(rss))
+	for i := range multiBindVars {
+		multiBindVars[i] = bindVars
+	}
+	return rss, multiBindVars, nil
 }
 
 func (rp *RoutingParameters) multiEqual(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
@@ -552,22 +563,36 @@ func buildMultiColumnVindexValues(shardsValues [][][]sqltypes.Value) [][][]*quer
 	return shardsIds
 }
 
-func resolveShardsBetween(ctx context.Context, vcursor VCursor, vindex vindexes.Sequential, keyspace *vindexes.Keyspace, vindexKeys []sqltypes.Value) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
-	// Convert vindexKeys to []*querypb.Value
-	ids := make([]*querypb.Value, len(vindexKeys))
-	for i, vik := range vindexKeys {
-		ids[i] = sqltypes.ValueToProto(vik)
+// resolveShardsBetween resolves the shards for the union of the given ranges of
+// vindex keys. The key ranges that the vindex maps them to are merged together
+// before being resolved, so that overlapping ranges are only resolved once.
+func resolveShardsBetween(ctx context.Context, vcursor VCursor, vindex vindexes.Sequential, keyspace *vindexes.Keyspace, ranges [][]sqltypes.Value) ([]*srvtopo.ResolvedShard, error) {
+	var destinations []key.ShardDestination
+	var keyRanges []*topodatapb.KeyRange
+	for _, vindexKeys := range ranges {
+		// RangeMap using the Vindex
+		dests, err := vindex.RangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+		if err != nil {
+			return nil, err
+		}
+		for _, dest := range dests {
+			switch dest := dest.(type) {
+			case *key.DestinationKeyRange:
+				keyRanges = append(keyRanges, dest.KeyRange)
+			case key.DestinationKeyRange:
+				keyRanges = append(keyRanges, dest.KeyRange)
+			default:
+				destinations = append(destinations, dest)
+			}
+		}
 	}
-
-	// RangeMap using the Vindex
-	destinations, err := vindex.RangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
-	if err != nil {
-		return nil, nil, err
-
+	for _, kr := range key.KeyRangeUnion(keyRanges) {
+		destinations = append(destinations, key.DestinationKeyRange{KeyRange: kr})
 	}
 
 	// And use the Resolver to map to ResolvedShards.
-	return vcursor.ResolveDestinations(ctx, keyspace.Name, ids, destinations)
+	rss, _, err := vcursor.ResolveDestinations(ctx, keyspace.Name, nil, destinations)
+	return rss, err
 }
 
 func shardVars(bv map[string]*querypb.BindVariable, mapVals [][]*querypb.Value) []map[string]*querypb.BindVariable {
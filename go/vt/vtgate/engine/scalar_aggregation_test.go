@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/sqlparser"
 	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 )
 
 func TestEmptyRows(outer *testing.T) {
@@ -418,3 +419,111 @@ func TestScalarGroupConcat(t *testing.T) {
 		})
 	}
 }
+
+// TestScalarHashDistinctAggr tests distinct aggregations on different columns, where the input is not sorted by them.
+func TestScalarHashDistinctAggr(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"a|b|a|a",
+		"int64|varchar|int64|int64",
+	)
+
+	fp := &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(
+		fields,
+		"1|x|1|1",
+		"2|y|2|2",
+		"1|y|1|1",
+		"null|x|null|null",
+		"2|null|2|2",
+		"1|x|1|1",
+	)}}
+
+	countA := NewAggregateParam(AggregateCountDistinct, 0, "count(distinct a)", collations.MySQL8())
+	countA.HashDistinct = true
+	countB := NewAggregateParam(AggregateCountDistinct, 1, "count(distinct b)", collations.MySQL8())
+	countB.HashDistinct = true
+	countAB := NewAggregateParam(AggregateCountDistinct, 2, "count(distinct a, b)", collations.MySQL8())
+	countAB.HashDistinct = true
+	countAB.DistinctCols = []int{1}
+	sumA := NewAggregateParam(AggregateSumDistinct, 3, "sum(distinct a)", collations.MySQL8())
+	sumA.HashDistinct = true
+	oa := &ScalarAggregate{
+		Aggregates: []*AggregateParams{countA, countB, countAB, sumA},
+		Input:      fp,
+	}
+	qr, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, `[[INT64(2) INT64(2) INT64(3) DECIMAL(3)]]`, fmt.Sprintf("%v", qr.Rows))
+}
+
+// TestScalarGroupConcatDistinctOrderBy tests group_concat with distinct and order by on engine.
+func TestScalarGroupConcatDistinctOrderBy(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c2|c2|c3",
+		"varchar|varchar|int64",
+	)
+
+	fp := &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(
+		fields,
+		"b|b|2",
+		"a|a|3",
+		"c|c|1",
+		"a|a|3",
+		"null|null|4",
+	)}}
+
+	oa := &ScalarAggregate{
+		Aggregates: []*AggregateParams{{
+			Opcode: AggregateGroupConcat,
+			Col:    0,
+			Func:   &sqlparser.GroupConcatExpr{Separator: ","},
+			OrderBy: evalengine.Comparison{{
+				Col:             2,
+				WeightStringCol: -1,
+				Desc:            true,
+				Type:            evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+			}},
+		}, {
+			Opcode:       AggregateGroupConcat,
+			Col:          1,
+			Func:         &sqlparser.GroupConcatExpr{Separator: ",", Distinct: true},
+			HashDistinct: true,
+		}},
+		TruncateColumnCount: 2,
+		Input:               fp,
+	}
+	qr, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, `[[TEXT("a,a,b,c") TEXT("b,a,c")]]`, fmt.Sprintf("%v", qr.Rows))
+}
+
+// TestScalarGroupConcatDistinctMultipleColumns tests group_concat with distinct on more than one column on engine.
+func TestScalarGroupConcatDistinctMultipleColumns(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varchar|int64",
+	)
+
+	fp := &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(
+		fields,
+		"a|1",
+		"a|2",
+		"a|1",
+		"b|null",
+		"b|1",
+	)}}
+
+	oa := &ScalarAggregate{
+		Aggregates: []*AggregateParams{{
+			Opcode:       AggregateGroupConcat,
+			Col:          0,
+			Func:         &sqlparser.GroupConcatExpr{Separator: ",", Distinct: true},
+			HashDistinct: true,
+			DistinctCols: []int{1},
+		}},
+		TruncateColumnCount: 1,
+		Input:               fp,
+	}
+	qr, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, `[[TEXT("a1,a2,b1")]]`, fmt.Sprintf("%v", qr.Rows))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -317,7 +318,7 @@ func (svs *SysVarReservedConn) execSetStatement(ctx context.Context, vcursor VCu
 
 func (svs *SysVarReservedConn) checkAndUpdateSysVar(ctx context.Context, vcursor VCursor, res *evalengine.ExpressionEnv) (bool, error) {
 	sysVarExprValidationQuery := fmt.Sprintf("select %s from dual where @@%s != %s", svs.Expr, svs.Name, svs.Expr)
-	if svs.Name == "sql_mode" {
+	if svs.Name == "sql_mode" || svs.Name == sysvars.BlockEncryptionMode {
 		sysVarExprValidationQuery = fmt.Sprintf("select @@%s orig, %s new", svs.Name, svs.Expr)
 	}
 	rss, _, err := vcursor.ResolveDestinations(ctx, svs.Keyspace.Name, nil, []key.ShardDestination{key.DestinationKeyspaceID{0}})
@@ -334,7 +335,8 @@ func (svs *SysVarReservedConn) checkAndUpdateSysVar(ctx context.Context, vcursor
 	}
 
 	var value sqltypes.Value
-	if svs.Name == "sql_mode" {
+	switch svs.Name {
+	case "sql_mode":
 		changed, value, err = sqlModeChangedValue(qr)
 		if err != nil {
 			return false, err
@@ -342,7 +344,20 @@ func (svs *SysVarReservedConn) checkAndUpdateSysVar(ctx context.Context, vcursor
 		if !changed {
 			return false, nil
 		}
-	} else {
+	case sysvars.BlockEncryptionMode:
+		// vtgate evaluates AES_ENCRYPT and AES_DECRYPT with the block_encryption_mode of the
+		// session, so the mode is kept in the session even when MySQL already uses it.
+		if len(qr.Fields) != 2 || len(qr.Rows[0]) != 2 {
+			return false, nil
+		}
+		value = qr.Rows[0][1]
+		if strings.EqualFold(qr.Rows[0][0].ToString(), value.ToString()) {
+			var buf strings.Builder
+			value.EncodeSQL(&buf)
+			vcursor.Session().SetSysVar(svs.Name, buf.String())
+			return false, nil
+		}
+	default:
 		value = qr.Rows[0][0]
 	}
 	var buf strings.Builder
@@ -485,12 +500,32 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid migration_context: %s", str)
 		}
 		vcursor.Session().SetMigrationContext(str)
+	case sysvars.TenantID.Name:
+		value, err := env.Evaluate(svss.Expr)
+		if err != nil {
+			return err
+		}
+		// Tenant ids are usually numbers, and NULL unsets the tenant.
+		v := value.Value(vcursor.ConnCollation())
+		if !v.IsIntegral() && !v.IsText() && !v.IsBinary() && !v.IsNull() {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongTypeForVar, "incorrect argument type to variable '%s': %s", svss.Name, v.Type().String())
+		}
+		vcursor.Session().SetTenantID(v.ToString())
 	case sysvars.QueryTimeout.Name:
 		queryTimeout, err := svss.evalAsInt64(env, vcursor)
 		if err != nil {
 			return err
 		}
 		vcursor.Session().SetQueryTimeout(queryTimeout)
+	case sysvars.CTEMaxRecursionDepth.Name:
+		depth, err := svss.evalAsInt64(env, vcursor)
+		if err != nil {
+			return err
+		}
+		if depth < 0 || depth > math.MaxUint32 {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "variable '%s' can't be set to the value of '%d'", svss.Name, depth)
+		}
+		vcursor.Session().SetCTEMaxRecursionDepth(depth)
 	case sysvars.SessionEnableSystemSettings.Name:
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetSessionEnableSystemSettings)
 	case sysvars.Charset.Name, sysvars.Names.Name:
@@ -418,6 +418,41 @@ func TestSetTable(t *testing.T) {
 		qr: []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("orig|new", "varchar|varchar"),
 			"|",
 		)},
+	}, {
+		testName: "block_encryption_mode no change - kept in the session",
+		setOps: []SetOp{
+			&SysVarReservedConn{
+				Name:     "block_encryption_mode",
+				Keyspace: &vindexes.Keyspace{Name: "ks", Sharded: true},
+				Expr:     "'aes-256-cbc'",
+			},
+		},
+		expectedQueryLog: []string{
+			`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(00)`,
+			`ExecuteMultiShard ks.-20: select @@block_encryption_mode orig, 'aes-256-cbc' new {} false false`,
+			"SysVar set with (block_encryption_mode,'aes-256-cbc')",
+		},
+		qr: []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("orig|new", "varchar|varchar"),
+			"aes-256-cbc|aes-256-cbc",
+		)},
+	}, {
+		testName: "block_encryption_mode change",
+		setOps: []SetOp{
+			&SysVarReservedConn{
+				Name:     "block_encryption_mode",
+				Keyspace: &vindexes.Keyspace{Name: "ks", Sharded: true},
+				Expr:     "'aes-256-cbc'",
+			},
+		},
+		expectedQueryLog: []string{
+			`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(00)`,
+			`ExecuteMultiShard ks.-20: select @@block_encryption_mode orig, 'aes-256-cbc' new {} false false`,
+			"SysVar set with (block_encryption_mode,'aes-256-cbc')",
+			"Needs Reserved Conn",
+		},
+		qr: []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("orig|new", "varchar|varchar"),
+			"aes-128-ecb|aes-256-cbc",
+		)},
 	}, {
 		testName:     "sql_mode change - empty orig - MySQL57",
 		mysqlVersion: "5.7.9",
@@ -20,11 +20,15 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -47,6 +51,30 @@ type Update struct {
 
 	// ChangedVindexValues contains values for updated Vindexes during an update statement.
 	ChangedVindexValues map[string]*VindexValues
+
+	// MoveRows is set when the update changes the primary vindex columns.
+	// The Vindexes of the DML then start with the primary vindex.
+	MoveRows *MoveRows
+}
+
+// MoveRows contains the information needed to move the rows that get a new keyspace id
+// to the shard of their new keyspace id.
+type MoveRows struct {
+	// Columns are the columns of the table that are written when a row is moved, which
+	// leaves out the generated columns. The OwnedVindexQuery returns the values they get
+	// from the update starting at Offset.
+	Columns []string
+	Offset  int
+
+	// PrimaryKey is used to delete the moved rows from their old shard.
+	PrimaryKey []string
+}
+
+// rowMove is a row that has to be moved to another shard after the update.
+type rowMove struct {
+	from, to *srvtopo.ResolvedShard
+	// row holds the values of the MoveRows columns after the update.
+	row sqltypes.Row
 }
 
 // TryExecute performs a non-streaming exec.
@@ -64,7 +92,22 @@ func (upd *Update) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 	case Unsharded:
 		return upd.execUnsharded(ctx, upd, vcursor, bindVars, rss)
 	case Equal, EqualUnique, IN, Scatter, ByDestination, SubShard, MultiEqual:
-		return upd.execMultiDestination(ctx, upd, vcursor, bindVars, rss, upd.updateVindexEntries, bvs)
+		var moves []*rowMove
+		updateVindexEntries := func(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, rss []*srvtopo.ResolvedShard) (err error) {
+			moves, err = upd.updateVindexEntries(ctx, vcursor, bindVars, rss)
+			return err
+		}
+		res, err := upd.execMultiDestination(ctx, upd, vcursor, bindVars, rss, updateVindexEntries, bvs)
+		if err != nil {
+			return nil, err
+		}
+		// The rows are moved once they have been updated, so that the update cannot change them again on their new shard.
+		for _, move := range moves {
+			if err := upd.moveRow(ctx, vcursor, move); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
 	default:
 		// Unreachable.
 		return nil, fmt.Errorf("unsupported opcode: %v", upd.Opcode)
@@ -87,9 +130,11 @@ func (upd *Update) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 // for DMLs to reuse existing transactions.
 // Note 2: While changes are being committed, the changing row could be
 // unreachable by either the new or old column values.
-func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, rss []*srvtopo.ResolvedShard) error {
+// The rows that have to be moved to another shard because their primary vindex
+// columns are changing are returned, so they can be moved after the update.
+func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, rss []*srvtopo.ResolvedShard) ([]*rowMove, error) {
 	if !upd.isVindexModified() {
-		return nil
+		return nil, nil
 	}
 	queries := make([]*querypb.BoundQuery, len(rss))
 	for i := range rss {
@@ -98,12 +143,12 @@ func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bin
 	subQueryResult, errors := vcursor.ExecuteMultiShard(ctx, upd, rss, queries, false /*rollbackOnError*/, false /*canAutocommit*/, upd.FetchLastInsertID)
 	for _, err := range errors {
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if len(subQueryResult.Rows) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	fieldColNumMap := make(map[string]int)
@@ -112,10 +157,22 @@ func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bin
 	}
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
 
+	var moves []*rowMove
 	for _, row := range subQueryResult.Rows {
 		ksid, err := resolveKeyspaceID(ctx, vcursor, upd.KsidVindex, row[0:upd.KsidLength])
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		if upd.MoveRows != nil && upd.isPrimaryVindexModified(row) {
+			move, err := upd.prepareMove(ctx, vcursor, subQueryResult.Fields, row, ksid)
+			if err != nil {
+				return nil, err
+			}
+			if move != nil {
+				moves = append(moves, move)
+			}
+			continue
 		}
 
 		for _, colVindex := range upd.Vindexes {
@@ -129,7 +186,7 @@ func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bin
 			if !row[offset].IsNull() {
 				val, err := row[offset].ToCastInt64()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				if val == int64(1) { // 1 means that the old and new value are same and vindex update is not required.
 					continue
@@ -145,7 +202,7 @@ func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bin
 				if colValue, exists := updColValues.EvalExprMap[vCol.String()]; exists {
 					resolvedVal, err := env.Evaluate(colValue)
 					if err != nil {
-						return err
+						return nil, err
 					}
 					vindexColumnKeys = append(vindexColumnKeys, resolvedVal.Value(vcursor.ConnCollation()))
 				} else {
@@ -156,7 +213,7 @@ func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bin
 
 			if colVindex.Owned {
 				if err := colVindex.Vindex.(vindexes.Lookup).Update(ctx, vcursor, fromIds, ksid, vindexColumnKeys); err != nil {
-					return err
+					return nil, err
 				}
 			} else {
 				allNulls := true
@@ -175,22 +232,203 @@ func (upd *Update) updateVindexEntries(ctx context.Context, vcursor VCursor, bin
 				// If values were supplied, we validate against keyspace id.
 				verified, err := vindexes.Verify(ctx, colVindex.Vindex, vcursor, [][]sqltypes.Value{vindexColumnKeys}, [][]byte{ksid})
 				if err != nil {
-					return err
+					return nil, err
 				}
 
 				if !verified[0] {
-					return fmt.Errorf("values %v for column %v does not map to keyspace ids", vindexColumnKeys, colVindex.Columns)
+					return nil, fmt.Errorf("values %v for column %v does not map to keyspace ids", vindexColumnKeys, colVindex.Columns)
 				}
 			}
 		}
 	}
-	return nil
+	return moves, nil
 }
 
 func (upd *Update) isVindexModified() bool {
 	return len(upd.ChangedVindexValues) != 0
 }
 
+func (upd *Update) isPrimaryVindexModified(row sqltypes.Row) bool {
+	values, ok := upd.ChangedVindexValues[upd.Vindexes[0].Name]
+	if !ok {
+		return false
+	}
+	if row[values.Offset].IsNull() {
+		return true
+	}
+	val, err := row[values.Offset].ToCastInt64()
+	return err != nil || val != 1
+}
+
+// prepareMove computes the new row and keyspace id of a row whose primary vindex columns are changing,
+// and moves its lookup vindex entries to the new keyspace id. If the row stays on the same shard,
+// no move is needed and nil is returned.
+func (upd *Update) prepareMove(ctx context.Context, vcursor VCursor, fields []*querypb.Field, row sqltypes.Row, ksid []byte) (*rowMove, error) {
+	newRow := row[upd.MoveRows.Offset : upd.MoveRows.Offset+len(upd.MoveRows.Columns)]
+
+	// The owned vindex query starts with the current values of the owned vindex columns.
+	oldValues := func(colVindex *vindexes.ColumnVindex) ([]sqltypes.Value, error) {
+		values := make([]sqltypes.Value, 0, len(colVindex.Columns))
+		for _, col := range colVindex.Columns {
+			idx := fieldIndex(fields[:upd.MoveRows.Offset], col.String())
+			if idx < 0 {
+				return nil, vterrors.VT13001(fmt.Sprintf("vindex column %s not found in the row to move", col.String()))
+			}
+			values = append(values, row[idx])
+		}
+		return values, nil
+	}
+	newValues := func(colVindex *vindexes.ColumnVindex) ([]sqltypes.Value, error) {
+		values := make([]sqltypes.Value, 0, len(colVindex.Columns))
+		for _, col := range colVindex.Columns {
+			idx := upd.MoveRows.columnIndex(col.String())
+			if idx < 0 {
+				return nil, vterrors.VT13001(fmt.Sprintf("vindex column %s not found in the row to move", col.String()))
+			}
+			values = append(values, newRow[idx])
+		}
+		return values, nil
+	}
+
+	primaryValues, err := newValues(upd.Vindexes[0])
+	if err != nil {
+		return nil, err
+	}
+	newKsid, err := resolveKeyspaceID(ctx, vcursor, upd.KsidVindex, primaryValues)
+	if err != nil {
+		return nil, err
+	}
+	if newKsid == nil {
+		return nil, vterrors.VT09023(primaryValues)
+	}
+
+	for _, colVindex := range upd.Vindexes[1:] {
+		toIDs, err := newValues(colVindex)
+		if err != nil {
+			return nil, err
+		}
+		allNulls := true
+		for _, id := range toIDs {
+			if !id.IsNull() {
+				allNulls = false
+				break
+			}
+		}
+		if !colVindex.Owned {
+			if allNulls {
+				continue
+			}
+			verified, err := vindexes.Verify(ctx, colVindex.Vindex, vcursor, [][]sqltypes.Value{toIDs}, [][]byte{newKsid})
+			if err != nil {
+				return nil, err
+			}
+			if !verified[0] {
+				return nil, fmt.Errorf("values %v for column %v does not map to keyspace ids", toIDs, colVindex.Columns)
+			}
+			continue
+		}
+		fromIDs, err := oldValues(colVindex)
+		if err != nil {
+			return nil, err
+		}
+		lookup := colVindex.Vindex.(vindexes.Lookup)
+		if err := lookup.Delete(ctx, vcursor, [][]sqltypes.Value{fromIDs}, ksid); err != nil {
+			return nil, err
+		}
+		if allNulls {
+			continue
+		}
+		if err := lookup.Create(ctx, vcursor, [][]sqltypes.Value{toIDs}, [][]byte{newKsid}, false /* ignoreMode */); err != nil {
+			return nil, err
+		}
+	}
+
+	from, err := upd.resolveKeyspaceIDShard(ctx, vcursor, ksid)
+	if err != nil {
+		return nil, err
+	}
+	to, err := upd.resolveKeyspaceIDShard(ctx, vcursor, newKsid)
+	if err != nil {
+		return nil, err
+	}
+	if from.Target.Shard == to.Target.Shard {
+		return nil, nil
+	}
+	return &rowMove{from: from, to: to, row: newRow}, nil
+}
+
+func (mr *MoveRows) columnIndex(name string) int {
+	for i, col := range mr.Columns {
+		if strings.EqualFold(col, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (upd *Update) resolveKeyspaceIDShard(ctx context.Context, vcursor VCursor, ksid []byte) (*srvtopo.ResolvedShard, error) {
+	rss, _, err := vcursor.ResolveDestinations(ctx, upd.Keyspace.Name, nil, []key.ShardDestination{key.DestinationKeyspaceID(ksid)})
+	if err != nil {
+		return nil, err
+	}
+	if len(rss) != 1 {
+		return nil, vterrors.VT13001(fmt.Sprintf("keyspace id %x resolved to %d shards", ksid, len(rss)))
+	}
+	return rss[0], nil
+}
+
+// moveRow deletes an updated row from its old shard, and inserts it on the shard of its new keyspace id.
+func (upd *Update) moveRow(ctx context.Context, vcursor VCursor, move *rowMove) error {
+	table := sqlparser.NewTableName(upd.TableNames[0])
+	bindVars := make(map[string]*querypb.BindVariable, len(move.row))
+
+	// The row already has its new primary key on its old shard.
+	var where []sqlparser.Expr
+	for _, col := range upd.MoveRows.PrimaryKey {
+		idx := upd.MoveRows.columnIndex(col)
+		if idx < 0 {
+			return vterrors.VT13001(fmt.Sprintf("primary key column %s not found in the row to move", col))
+		}
+		bvName := fmt.Sprintf("pk%d", len(where))
+		bindVars[bvName] = sqltypes.ValueBindVariable(move.row[idx])
+		where = append(where, sqlparser.NewComparisonExpr(sqlparser.EqualOp, sqlparser.NewColName(col), sqlparser.NewArgument(bvName), nil))
+	}
+	del := &sqlparser.Delete{
+		TableExprs: sqlparser.TableExprs{sqlparser.NewAliasedTableExpr(table, "")},
+		Where:      sqlparser.NewWhere(sqlparser.WhereClause, sqlparser.AndExpressions(where...)),
+	}
+
+	ins := &sqlparser.Insert{Table: sqlparser.NewAliasedTableExpr(table, "")}
+	var values sqlparser.ValTuple
+	for i, col := range upd.MoveRows.Columns {
+		bvName := fmt.Sprintf("v%d", i)
+		bindVars[bvName] = sqltypes.ValueBindVariable(move.row[i])
+		ins.Columns = append(ins.Columns, sqlparser.NewIdentifierCI(col))
+		values = append(values, sqlparser.NewArgument(bvName))
+	}
+	ins.Rows = sqlparser.Values{values}
+
+	if err := upd.execMovedRowQuery(ctx, vcursor, move.from, sqlparser.String(del), bindVars); err != nil {
+		return err
+	}
+	return upd.execMovedRowQuery(ctx, vcursor, move.to, sqlparser.String(ins), bindVars)
+}
+
+func (upd *Update) execMovedRowQuery(ctx context.Context, vcursor VCursor, rs *srvtopo.ResolvedShard, query string, bindVars map[string]*querypb.BindVariable) error {
+	queries := []*querypb.BoundQuery{{Sql: query, BindVariables: bindVars}}
+	_, errs := vcursor.ExecuteMultiShard(ctx, upd, []*srvtopo.ResolvedShard{rs}, queries, true /*rollbackOnError*/, false /*canAutocommit*/, false /*fetchLastInsertID*/)
+	return vterrors.Aggregate(errs)
+}
+
+func fieldIndex(fields []*querypb.Field, name string) int {
+	for i, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
 func (upd *Update) description() PrimitiveDescription {
 	other := map[string]any{
 		"Query":                upd.Query,
@@ -214,6 +452,13 @@ func (upd *Update) description() PrimitiveDescription {
 	if upd.FetchLastInsertID {
 		other["FetchLastInsertID"] = upd.FetchLastInsertID
 	}
+	if upd.MoveRows != nil {
+		var moved []string
+		for i, col := range upd.MoveRows.Columns {
+			moved = append(moved, fmt.Sprintf("%s:%d", col, upd.MoveRows.Offset+i))
+		}
+		other["MoveRows"] = moved
+	}
 
 	return PrimitiveDescription{
 		OperatorType: "Update",
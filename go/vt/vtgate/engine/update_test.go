@@ -353,6 +353,105 @@ func TestUpdateEqualChangedVindex(t *testing.T) {
 
 }
 
+func TestUpdateEqualMoveRows(t *testing.T) {
+	ks := buildTestVSchema().Keyspaces["sharded"]
+	upd := &Update{
+		DML: &DML{
+			RoutingParameters: &RoutingParameters{
+				Opcode:   Equal,
+				Keyspace: ks.Keyspace,
+				Vindex:   ks.Vindexes["hash"],
+				Values:   []evalengine.Expr{evalengine.NewLiteralInt(1)},
+			},
+			Query:            "dummy_update",
+			TableNames:       []string{ks.Tables["t1"].Name.String()},
+			Vindexes:         ks.Tables["t1"].ColumnVindexes,
+			OwnedVindexQuery: "dummy_subquery",
+			KsidVindex:       ks.Vindexes["hash"],
+			KsidLength:       1,
+		},
+		ChangedVindexValues: map[string]*VindexValues{
+			"hash": {
+				EvalExprMap: map[string]evalengine.Expr{
+					"id": evalengine.NewLiteralInt(2),
+				},
+				Offset: 4,
+			},
+		},
+		MoveRows: &MoveRows{
+			Columns:    []string{"id", "c1", "c2", "c3", "val"},
+			Offset:     5,
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	results := []*sqltypes.Result{sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|c1|c2|c3|hash|id|c1|c2|c3|val",
+			"int64|int64|int64|int64|int64|int64|int64|int64|int64|int64",
+		),
+		"1|4|5|6|0|2|4|5|7|11",
+	)}
+	vc := newTestVCursor("-20", "20-")
+	vc.shardForKsid = []string{"-20", "-20", "20-"}
+	vc.results = results
+
+	_, err := upd.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [type:INT64 value:"1"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: dummy_subquery {} false false`,
+		// The lookup vindex entries are moved to the new keyspace id, with the new values of their columns.
+		`Execute delete from lkp2 where from1 = :from1 and from2 = :from2 and toc = :toc from1: type:INT64 value:"4" from2: type:INT64 value:"5" toc: type:VARBINARY value:"\x16k@\xb4J\xbaK\xd6" true`,
+		`Execute insert into lkp2(from1, from2, toc) values(:from1_0, :from2_0, :toc_0) from1_0: type:INT64 value:"4" from2_0: type:INT64 value:"5" toc_0: type:VARBINARY value:"\x06\xe7\xea\"Βp\x8f" true`,
+		`Execute delete from lkp1 where from = :from and toc = :toc from: type:INT64 value:"6" toc: type:VARBINARY value:"\x16k@\xb4J\xbaK\xd6" true`,
+		`Execute insert into lkp1(from, toc) values(:from_0, :toc_0) from_0: type:INT64 value:"7" toc_0: type:VARBINARY value:"\x06\xe7\xea\"Βp\x8f" true`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(06e7ea22ce92708f)`,
+		// The row is updated in place first, and then moved to the shard of its new keyspace id.
+		`ExecuteMultiShard sharded.-20: dummy_update {} true true`,
+		`ExecuteMultiShard sharded.-20: delete from t1 where id = :pk0 {pk0: type:INT64 value:"2" v0: type:INT64 value:"2" v1: type:INT64 value:"4" v2: type:INT64 value:"5" v3: type:INT64 value:"7" v4: type:INT64 value:"11"} true false`,
+		`ExecuteMultiShard sharded.20-: insert into t1(id, c1, c2, c3, val) values (:v0, :v1, :v2, :v3, :v4) {pk0: type:INT64 value:"2" v0: type:INT64 value:"2" v1: type:INT64 value:"4" v2: type:INT64 value:"5" v3: type:INT64 value:"7" v4: type:INT64 value:"11"} true false`,
+	})
+
+	// The new keyspace id is on the same shard, so the row is only updated in place.
+	vc = newTestVCursor("-20", "20-")
+	vc.results = results
+
+	_, err = upd.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [type:INT64 value:"1"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: dummy_subquery {} false false`,
+		`Execute delete from lkp2 where from1 = :from1 and from2 = :from2 and toc = :toc from1: type:INT64 value:"4" from2: type:INT64 value:"5" toc: type:VARBINARY value:"\x16k@\xb4J\xbaK\xd6" true`,
+		`Execute insert into lkp2(from1, from2, toc) values(:from1_0, :from2_0, :toc_0) from1_0: type:INT64 value:"4" from2_0: type:INT64 value:"5" toc_0: type:VARBINARY value:"\x06\xe7\xea\"Βp\x8f" true`,
+		`Execute delete from lkp1 where from = :from and toc = :toc from: type:INT64 value:"6" toc: type:VARBINARY value:"\x16k@\xb4J\xbaK\xd6" true`,
+		`Execute insert into lkp1(from, toc) values(:from_0, :toc_0) from_0: type:INT64 value:"7" toc_0: type:VARBINARY value:"\x06\xe7\xea\"Βp\x8f" true`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(06e7ea22ce92708f)`,
+		`ExecuteMultiShard sharded.-20: dummy_update {} true true`,
+	})
+
+	// The primary vindex column does not change.
+	results = []*sqltypes.Result{sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|c1|c2|c3|hash|id|c1|c2|c3|val",
+			"int64|int64|int64|int64|int64|int64|int64|int64|int64|int64",
+		),
+		"1|4|5|6|1|1|4|5|6|11",
+	)}
+	vc = newTestVCursor("-20", "20-")
+	vc.results = results
+
+	_, err = upd.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [type:INT64 value:"1"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: dummy_subquery {} false false`,
+		`ExecuteMultiShard sharded.-20: dummy_update {} true true`,
+	})
+}
+
 func TestUpdateEqualMultiColChangedVindex(t *testing.T) {
 	ks := buildTestVSchema().Keyspaces["sharded"]
 	upd := &Update{
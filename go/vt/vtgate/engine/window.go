@@ -0,0 +1,417 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*Window)(nil)
+
+// Window is a primitive that evaluates window functions on vtgate.
+// It expects the underlying primitive to feed results sorted by the
+// PARTITION BY keys followed by the ORDER BY keys of the window.
+// The result of each window function is written to the column of the
+// function, replacing the value the input produced for it.
+type Window struct {
+	// Functions specifies the window functions to evaluate.
+	Functions []*WindowFuncParams
+
+	// PartitionBy specifies the input values that make up the window partitions.
+	PartitionBy []*GroupByParams
+
+	// OrderBy specifies the input values the window is ordered by.
+	// Rows with equal values for all of these are peers of each other.
+	OrderBy []*GroupByParams
+
+	// TruncateColumnCount specifies the number of columns to return
+	// in the final result. Rest of the columns are truncated
+	// from the result received. If 0, no truncation happens.
+	TruncateColumnCount int
+
+	// Input is the primitive that will feed into this Primitive.
+	Input Primitive
+}
+
+// WindowFuncParams specify the parameters for each window function.
+type WindowFuncParams struct {
+	Opcode opcode.WindowOpcode
+
+	// Col is the column the result is written to. For functions that take
+	// an argument, the input is expected to produce the argument in this column.
+	Col int
+
+	// Offset and Default are only used by LAG and LEAD.
+	Offset  int
+	Default evalengine.Expr
+
+	Alias string
+}
+
+// String returns a string. Used for plan descriptions
+func (wf *WindowFuncParams) String() string {
+	var out string
+	switch wf.Opcode {
+	case opcode.WindowLag, opcode.WindowLead:
+		out = fmt.Sprintf("%s(%d, %d", wf.Opcode.String(), wf.Col, wf.Offset)
+		if wf.Default != nil {
+			out += ", " + sqlparser.String(wf.Default)
+		}
+		out += ")"
+	default:
+		out = fmt.Sprintf("%s(%d)", wf.Opcode.String(), wf.Col)
+	}
+	if wf.Alias != "" {
+		out += " AS " + wf.Alias
+	}
+	return out
+}
+
+// RouteType returns a description of the query routing type used by the primitive
+func (w *Window) RouteType() string {
+	return w.Input.RouteType()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (w *Window) GetKeyspaceName() string {
+	return w.Input.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (w *Window) GetTableName() string {
+	return w.Input.GetTableName()
+}
+
+// TryExecute is a Primitive function.
+func (w *Window) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	result, err := vcursor.ExecutePrimitive(
+		ctx,
+		w.Input,
+		bindVars,
+		true, /*wantFields - we need the input fields types to correctly calculate the output types*/
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	state := w.newWindowState(evalengine.NewExpressionEnv(ctx, bindVars, vcursor), vcursor.ConnCollation(), result.Fields)
+	out := &sqltypes.Result{
+		Fields: w.fields(result.Fields),
+		Rows:   make([]sqltypes.Row, 0, len(result.Rows)),
+	}
+	for _, row := range result.Rows {
+		rows, err := state.add(row)
+		if err != nil {
+			return nil, err
+		}
+		out.Rows = append(out.Rows, rows...)
+	}
+	rows, err := state.flush()
+	if err != nil {
+		return nil, err
+	}
+	out.Rows = append(out.Rows, rows...)
+
+	return out.Truncate(w.TruncateColumnCount), nil
+}
+
+// TryStreamExecute is a Primitive function.
+func (w *Window) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	cb := func(qr *sqltypes.Result) error {
+		return callback(qr.Truncate(w.TruncateColumnCount))
+	}
+
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	var state *windowState
+
+	visitor := func(qr *sqltypes.Result) error {
+		if state == nil && len(qr.Fields) != 0 {
+			state = w.newWindowState(env, vcursor.ConnCollation(), qr.Fields)
+			if err := cb(&sqltypes.Result{Fields: w.fields(qr.Fields)}); err != nil {
+				return err
+			}
+		}
+
+		var out []sqltypes.Row
+		for _, row := range qr.Rows {
+			rows, err := state.add(row)
+			if err != nil {
+				return err
+			}
+			out = append(out, rows...)
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return cb(&sqltypes.Result{Rows: out})
+	}
+
+	/* we need the input fields types to correctly calculate the output types */
+	err := vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, true, visitor)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		return nil
+	}
+	rows, err := state.flush()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return cb(&sqltypes.Result{Rows: rows})
+}
+
+// GetFields is a Primitive function.
+func (w *Window) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := w.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+
+	qr = &sqltypes.Result{Fields: w.fields(qr.Fields)}
+	return qr.Truncate(w.TruncateColumnCount), nil
+}
+
+// Inputs returns the Primitive input for this window
+func (w *Window) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (w *Window) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+func (w *Window) fields(fields []*querypb.Field) []*querypb.Field {
+	fields = slice.Map(fields, func(from *querypb.Field) *querypb.Field { return from.CloneVT() })
+	for _, wf := range w.Functions {
+		fields[wf.Col].Type = wf.Opcode.SQLType(fields[wf.Col].Type)
+		if wf.Alias != "" {
+			fields[wf.Col].Name = wf.Alias
+		}
+	}
+	return fields
+}
+
+func (w *Window) description() PrimitiveDescription {
+	other := map[string]any{
+		"Functions": GenericJoin(w.Functions, func(in any) string {
+			return in.(*WindowFuncParams).String()
+		}),
+	}
+	if len(w.PartitionBy) > 0 {
+		other["PartitionBy"] = GenericJoin(w.PartitionBy, groupByParamsToString)
+	}
+	if len(w.OrderBy) > 0 {
+		other["OrderBy"] = GenericJoin(w.OrderBy, groupByParamsToString)
+	}
+	if w.TruncateColumnCount > 0 {
+		other["ResultColumns"] = w.TruncateColumnCount
+	}
+	return PrimitiveDescription{
+		OperatorType: "Window",
+		Other:        other,
+	}
+}
+
+// windowState buffers the rows of the current partition until the
+// partition is complete, and the window functions can be evaluated
+type windowState struct {
+	w      *Window
+	env    *evalengine.ExpressionEnv
+	coll   collations.ID
+	fields []*querypb.Field
+	rows   []sqltypes.Row
+}
+
+func (w *Window) newWindowState(env *evalengine.ExpressionEnv, coll collations.ID, fields []*querypb.Field) *windowState {
+	return &windowState{
+		w:      w,
+		env:    env,
+		coll:   coll,
+		fields: fields,
+	}
+}
+
+// add adds a row to the current partition. If the row starts a new partition,
+// the rows of the previous partition are evaluated and returned
+func (ws *windowState) add(row sqltypes.Row) ([]sqltypes.Row, error) {
+	if len(ws.rows) == 0 {
+		ws.rows = append(ws.rows, row)
+		return nil, nil
+	}
+
+	same, err := sameKeys(ws.w.PartitionBy, ws.rows[0], row)
+	if err != nil {
+		return nil, err
+	}
+	if same {
+		ws.rows = append(ws.rows, row)
+		return nil, nil
+	}
+
+	out, err := ws.flush()
+	if err != nil {
+		return nil, err
+	}
+	ws.rows = append(ws.rows, row)
+	return out, nil
+}
+
+// flush evaluates the window functions over the current partition and returns the resulting rows
+func (ws *windowState) flush() ([]sqltypes.Row, error) {
+	rows := ws.rows
+	ws.rows = nil
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	// peers[i] holds the index of the first row of the peer group of row i
+	peers := make([]int, len(rows))
+	for i := 1; i < len(rows); i++ {
+		same, err := sameKeys(ws.w.OrderBy, rows[peers[i-1]], rows[i])
+		if err != nil {
+			return nil, err
+		}
+		if same {
+			peers[i] = peers[i-1]
+		} else {
+			peers[i] = i
+		}
+	}
+
+	out := make([]sqltypes.Row, len(rows))
+	for i, row := range rows {
+		out[i] = slices.Clone(row)
+	}
+
+	for _, wf := range ws.w.Functions {
+		if err := ws.evaluate(wf, rows, peers, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (ws *windowState) evaluate(wf *WindowFuncParams, rows []sqltypes.Row, peers []int, out []sqltypes.Row) error {
+	switch wf.Opcode {
+	case opcode.WindowRowNumber:
+		for i := range rows {
+			out[i][wf.Col] = sqltypes.NewInt64(int64(i + 1))
+		}
+	case opcode.WindowRank:
+		for i := range rows {
+			out[i][wf.Col] = sqltypes.NewInt64(int64(peers[i] + 1))
+		}
+	case opcode.WindowDenseRank:
+		var rank int64
+		for i := range rows {
+			if peers[i] == i {
+				rank++
+			}
+			out[i][wf.Col] = sqltypes.NewInt64(rank)
+		}
+	case opcode.WindowLag, opcode.WindowLead:
+		for i := range rows {
+			idx := i + wf.Offset
+			if wf.Opcode == opcode.WindowLag {
+				idx = i - wf.Offset
+			}
+			if idx >= 0 && idx < len(rows) {
+				out[i][wf.Col] = rows[idx][wf.Col]
+				continue
+			}
+			if wf.Default == nil {
+				out[i][wf.Col] = sqltypes.NULL
+				continue
+			}
+			ws.env.Row = rows[i]
+			res, err := ws.env.Evaluate(wf.Default)
+			if err != nil {
+				return err
+			}
+			out[i][wf.Col] = res.Value(ws.coll)
+		}
+	case opcode.WindowSum:
+		// without a frame clause, the frame of a row goes from the start
+		// of the partition to the last peer of the row
+		sum := evalengine.NewAggregationSum(ws.fields[wf.Col].Type)
+		for start := 0; start < len(rows); {
+			end := start + 1
+			for end < len(rows) && peers[end] == start {
+				end++
+			}
+			for _, row := range rows[start:end] {
+				if row[wf.Col].IsNull() {
+					continue
+				}
+				if err := sum.Add(row[wf.Col]); err != nil {
+					return err
+				}
+			}
+			result := sum.Result()
+			for i := start; i < end; i++ {
+				out[i][wf.Col] = result
+			}
+			start = end
+		}
+	default:
+		return fmt.Errorf("BUG: unexpected window function opcode %s", wf.Opcode.String())
+	}
+	return nil
+}
+
+// sameKeys returns true if the two rows have the same values for all the given keys
+func sameKeys(keys []*GroupByParams, a, b sqltypes.Row) (bool, error) {
+	for _, key := range keys {
+		v1 := a[key.KeyCol]
+		v2 := b[key.KeyCol]
+		if v1.TinyWeightCmp(v2) != 0 {
+			return false, nil
+		}
+
+		cmp, err := evalengine.NullsafeCompare(v1, v2, key.CollationEnv, key.Type.Collation(), key.Type.Values())
+		if err != nil {
+			_, isCollationErr := err.(evalengine.UnsupportedCollationError)
+			if !isCollationErr || key.WeightStringCol == -1 {
+				return false, err
+			}
+			cmp, err = evalengine.NullsafeCompare(a[key.WeightStringCol], b[key.WeightStringCol], key.CollationEnv, key.Type.Collation(), key.Type.Values())
+			if err != nil {
+				return false, err
+			}
+		}
+		if cmp != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
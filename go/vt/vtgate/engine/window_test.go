@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func TestWindowRanking(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"dept|salary|rn|rnk|drnk",
+				"varbinary|int64|null|null|null",
+			),
+			"a|10|||",
+			"a|20|||",
+			"a|20|||",
+			"a|30|||",
+			"b|5|||",
+			"b|5|||",
+			"c|1|||",
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowFuncParams{
+			{Opcode: opcode.WindowRowNumber, Col: 2},
+			{Opcode: opcode.WindowRank, Col: 3},
+			{Opcode: opcode.WindowDenseRank, Col: 4},
+		},
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Input:       fp,
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"dept|salary|rn|rnk|drnk",
+			"varbinary|int64|int64|int64|int64",
+		),
+		"a|10|1|1|1",
+		"a|20|2|2|2",
+		"a|20|3|2|2",
+		"a|30|4|4|3",
+		"b|5|1|1|1",
+		"b|5|2|1|1",
+		"c|1|1|1|1",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowLagLead(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"dept|salary|prev|next",
+				"varbinary|int64|int64|int64",
+			),
+			"a|10|10|10",
+			"a|20|20|20",
+			"a|30|30|30",
+			"b|5|5|5",
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowFuncParams{
+			{Opcode: opcode.WindowLag, Col: 2, Offset: 1},
+			{Opcode: opcode.WindowLead, Col: 3, Offset: 2, Default: evalengine.NewLiteralInt(-1)},
+		},
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Input:       fp,
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"dept|salary|prev|next",
+			"varbinary|int64|int64|int64",
+		),
+		"a|10|null|30",
+		"a|20|10|-1",
+		"a|30|20|-1",
+		"b|5|null|-1",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowSum(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"dept|salary|running|total",
+		"varbinary|int64|int64|int64",
+	)
+	input := sqltypes.MakeTestResult(
+		fields,
+		"a|10|10|10",
+		"a|20|20|20",
+		"a|20|20|20",
+		"a|30|30|30",
+		"b|5|5|5",
+	)
+
+	t.Run("ordered window sums up to the last peer", func(t *testing.T) {
+		w := &Window{
+			Functions:   []*WindowFuncParams{{Opcode: opcode.WindowSum, Col: 2}},
+			PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+			OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+			Input:       &fakePrimitive{results: []*sqltypes.Result{input}},
+		}
+
+		result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+		require.NoError(t, err)
+
+		wantResult := sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"dept|salary|running|total",
+				"varbinary|int64|decimal|int64",
+			),
+			"a|10|10|10",
+			"a|20|50|20",
+			"a|20|50|20",
+			"a|30|80|30",
+			"b|5|5|5",
+		)
+		utils.MustMatch(t, wantResult, result)
+	})
+
+	t.Run("unordered window sums the whole partition", func(t *testing.T) {
+		w := &Window{
+			Functions:   []*WindowFuncParams{{Opcode: opcode.WindowSum, Col: 3}},
+			PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+			Input:       &fakePrimitive{results: []*sqltypes.Result{input}},
+		}
+
+		result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+		require.NoError(t, err)
+
+		wantResult := sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"dept|salary|running|total",
+				"varbinary|int64|int64|decimal",
+			),
+			"a|10|10|80",
+			"a|20|20|80",
+			"a|20|20|80",
+			"a|30|30|80",
+			"b|5|5|5",
+		)
+		utils.MustMatch(t, wantResult, result)
+	})
+}
+
+func TestWindowStreamExecute(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"dept|rn|weight_string(dept)",
+				"varchar|int64|varbinary",
+			),
+			"a|0|A",
+			"A|0|A",
+			"b|0|B",
+			"c|0|C",
+			"C|0|C",
+		)},
+	}
+
+	w := &Window{
+		Functions:           []*WindowFuncParams{{Opcode: opcode.WindowRowNumber, Col: 1, Alias: "rn"}},
+		PartitionBy:         []*GroupByParams{{KeyCol: 2, WeightStringCol: -1}},
+		TruncateColumnCount: 2,
+		Input:               fp,
+	}
+
+	results, err := wrapStreamExecute(w, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"dept|rn",
+			"varchar|int64",
+		),
+		"a|1",
+		"A|2",
+		"b|1",
+		"c|1",
+		"C|2",
+	)
+	utils.MustMatch(t, wantResult, results)
+}
+
+func TestWindowGetFields(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"col|sum(col)|null",
+				"int64|int64|null",
+			),
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowFuncParams{
+			{Opcode: opcode.WindowSum, Col: 1},
+			{Opcode: opcode.WindowRank, Col: 2, Alias: "rnk"},
+		},
+		Input: fp,
+	}
+
+	got, err := w.GetFields(context.Background(), nil, nil)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"col|sum(col)|rnk",
+		"int64|decimal|int64",
+	)), got)
+}
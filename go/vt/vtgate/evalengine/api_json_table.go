@@ -0,0 +1,350 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+type (
+	// JSONTable is the evaluable form of a JSON_TABLE table expression.
+	// It produces a row for every value that Path matches in the document,
+	// and every NESTED PATH column can turn such a row into several rows.
+	JSONTable struct {
+		Doc     Expr
+		Path    *json.Path
+		Columns []*JSONTableColumn
+
+		// Width is the number of columns in the produced rows
+		Width int
+	}
+
+	// JSONTableColumn is one of the column definitions of a JSON_TABLE.
+	// Nested columns are the columns of a NESTED PATH definition, which
+	// are evaluated against the values matched by Path.
+	JSONTableColumn struct {
+		Name   string
+		Type   Type
+		Offset int
+
+		Ordinality bool
+		Exists     bool
+		Path       *json.Path
+		OnEmpty    *JSONTableOnResponse
+		OnError    *JSONTableOnResponse
+
+		Nested []*JSONTableColumn
+	}
+
+	// JSONTableOnResponse is the ON EMPTY or ON ERROR clause of a JSON_TABLE column.
+	// Unless Error is set, Default is used as the value for the column.
+	JSONTableOnResponse struct {
+		Error   bool
+		Default sqltypes.Value
+	}
+)
+
+// JSONTableOrdinalityType is the type of the FOR ORDINALITY columns of a JSON_TABLE
+var JSONTableOrdinalityType = NewType(sqltypes.Uint32, collations.CollationBinaryID)
+
+// NewTypeFromColumnType returns the type of a column declared with the given column type
+func NewTypeFromColumnType(ct *sqlparser.ColumnType, collationEnv *collations.Environment) Type {
+	typ := ct.SQLType()
+	var size, scale int32
+	if ct.Length != nil {
+		size = int32(*ct.Length)
+	}
+	if ct.Scale != nil {
+		scale = int32(*ct.Scale)
+	}
+	return NewTypeEx(typ, collations.CollationForType(typ, collationEnv.DefaultConnectionCharset()), true, size, scale, nil)
+}
+
+// TranslateJSONTable translates a JSON_TABLE table expression into a JSONTable that can be evaluated
+func TranslateJSONTable(node *sqlparser.JSONTableExpr, cfg *Config) (*JSONTable, error) {
+	doc, err := Translate(node.Expr, cfg)
+	if err != nil {
+		return nil, err
+	}
+	path, err := translateJSONTablePath(node.Filter)
+	if err != nil {
+		return nil, err
+	}
+	jt := &JSONTable{Doc: doc, Path: path}
+	jt.Columns, err = jt.translateColumns(node.Columns, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return jt, nil
+}
+
+func translateJSONTablePath(expr sqlparser.Expr) (*json.Path, error) {
+	lit, ok := expr.(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.StrVal {
+		return nil, vterrors.VT12001("JSON_TABLE path that is not a string literal")
+	}
+	var p json.PathParser
+	return p.ParseBytes(lit.Bytes())
+}
+
+func (jt *JSONTable) translateColumns(defs []*sqlparser.JtColumnDefinition, cfg *Config) ([]*JSONTableColumn, error) {
+	var columns []*JSONTableColumn
+	for _, def := range defs {
+		var col *JSONTableColumn
+		var err error
+		switch {
+		case def.JtOrdinal != nil:
+			col = &JSONTableColumn{Name: def.JtOrdinal.Name.String(), Type: JSONTableOrdinalityType, Ordinality: true}
+		case def.JtPath != nil:
+			col, err = translateJSONTablePathColumn(def.JtPath, cfg)
+		case def.JtNestedPath != nil:
+			col = &JSONTableColumn{}
+			col.Path, err = translateJSONTablePath(def.JtNestedPath.Path)
+			if err == nil {
+				col.Nested, err = jt.translateColumns(def.JtNestedPath.Columns, cfg)
+			}
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, col)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		col.Offset = jt.Width
+		jt.Width++
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func translateJSONTablePathColumn(def *sqlparser.JtPathColDef, cfg *Config) (*JSONTableColumn, error) {
+	path, err := translateJSONTablePath(def.Path)
+	if err != nil {
+		return nil, err
+	}
+	col := &JSONTableColumn{
+		Name:   def.Name.String(),
+		Type:   NewTypeFromColumnType(def.Type, cfg.Environment.CollationEnv()),
+		Exists: def.JtColExists,
+		Path:   path,
+	}
+	col.OnEmpty, err = translateJSONTableOnResponse(def.EmptyOnResponse, cfg)
+	if err != nil {
+		return nil, err
+	}
+	col.OnError, err = translateJSONTableOnResponse(def.ErrorOnResponse, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func translateJSONTableOnResponse(resp *sqlparser.JtOnResponse, cfg *Config) (*JSONTableOnResponse, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	switch resp.ResponseType {
+	case sqlparser.ErrorJSONType:
+		return &JSONTableOnResponse{Error: true}, nil
+	case sqlparser.DefaultJSONType:
+		expr, err := Translate(resp.Expr, cfg)
+		if err != nil {
+			return nil, err
+		}
+		res, err := EmptyExpressionEnv(cfg.Environment).Evaluate(expr)
+		if err != nil {
+			return nil, err
+		}
+		return &JSONTableOnResponse{Default: res.Value(cfg.Collation)}, nil
+	default:
+		return &JSONTableOnResponse{}, nil
+	}
+}
+
+// Fields returns the fields of the rows produced by this JSON_TABLE
+func (jt *JSONTable) Fields() []*querypb.Field {
+	fields := make([]*querypb.Field, jt.Width)
+	jt.visitColumns(jt.Columns, func(col *JSONTableColumn) {
+		fields[col.Offset] = col.Type.ToField(col.Name)
+	})
+	return fields
+}
+
+func (jt *JSONTable) visitColumns(columns []*JSONTableColumn, f func(col *JSONTableColumn)) {
+	for _, col := range columns {
+		if col.Nested != nil {
+			jt.visitColumns(col.Nested, f)
+			continue
+		}
+		f(col)
+	}
+}
+
+// Rows evaluates the JSON_TABLE in the given environment and returns the rows it produces.
+// A NULL document produces no rows.
+func (jt *JSONTable) Rows(env *ExpressionEnv) ([]sqltypes.Row, error) {
+	res, err := env.Evaluate(jt.Doc)
+	if err != nil {
+		return nil, err
+	}
+	if res.v == nil {
+		return nil, nil
+	}
+	doc, err := intoJSON("JSON_TABLE", res.v)
+	if err != nil {
+		return nil, err
+	}
+	return jt.expand(env, doc, jt.Path, jt.Columns)
+}
+
+// expand produces the rows for all the values matched by path. Sibling NESTED PATH columns
+// produce rows of their own, with the columns of the other siblings set to NULL.
+// When none of them produce any rows, a single row with all the nested columns set to NULL is returned.
+func (jt *JSONTable) expand(env *ExpressionEnv, doc *json.Value, path *json.Path, columns []*JSONTableColumn) ([]sqltypes.Row, error) {
+	var matches []*json.Value
+	path.Match(doc, true, func(value *json.Value) {
+		matches = append(matches, value)
+	})
+
+	var out []sqltypes.Row
+	for idx, match := range matches {
+		row := make(sqltypes.Row, jt.Width)
+		var nested []sqltypes.Row
+		for _, col := range columns {
+			switch {
+			case col.Nested != nil:
+				rows, err := jt.expand(env, match, col.Path, col.Nested)
+				if err != nil {
+					return nil, err
+				}
+				nested = append(nested, rows...)
+			case col.Ordinality:
+				row[col.Offset] = sqltypes.NewUint32(uint32(idx + 1))
+			default:
+				value, err := col.value(env, match)
+				if err != nil {
+					return nil, err
+				}
+				row[col.Offset] = value
+			}
+		}
+		if len(nested) == 0 {
+			out = append(out, row)
+			continue
+		}
+		for _, nestedRow := range nested {
+			for _, col := range columns {
+				if col.Nested == nil {
+					nestedRow[col.Offset] = row[col.Offset]
+				}
+			}
+			out = append(out, nestedRow)
+		}
+	}
+	return out, nil
+}
+
+func (col *JSONTableColumn) value(env *ExpressionEnv, doc *json.Value) (sqltypes.Value, error) {
+	var matches []*json.Value
+	col.Path.Match(doc, true, func(value *json.Value) {
+		matches = append(matches, value)
+	})
+
+	if col.Exists {
+		exists := sqltypes.NewInt64(0)
+		if len(matches) > 0 {
+			exists = sqltypes.NewInt64(1)
+		}
+		return col.coerce(env, exists)
+	}
+
+	switch {
+	case len(matches) == 0:
+		return col.respond(env, col.OnEmpty, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Missing value for JSON_TABLE column '%s'", col.Name))
+	case len(matches) > 1:
+		return col.respond(env, col.OnError, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Subquery returns more than 1 row for JSON_TABLE column '%s'", col.Name))
+	}
+
+	match := matches[0]
+	if col.Type.Type() == sqltypes.TypeJSON {
+		return sqltypes.MakeTrusted(sqltypes.TypeJSON, match.MarshalTo(nil)), nil
+	}
+
+	var scalar sqltypes.Value
+	switch match.Type() {
+	case json.TypeNull:
+		return sqltypes.NULL, nil
+	case json.TypeObject, json.TypeArray:
+		return col.respond(env, col.OnError, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Can't store an array or an object in the scalar column '%s' of JSON_TABLE", col.Name))
+	case json.TypeNumber:
+		switch match.NumberType() {
+		case json.NumberTypeSigned:
+			scalar = sqltypes.MakeTrusted(sqltypes.Int64, []byte(match.Raw()))
+		case json.NumberTypeUnsigned:
+			scalar = sqltypes.MakeTrusted(sqltypes.Uint64, []byte(match.Raw()))
+		default:
+			scalar = sqltypes.MakeTrusted(sqltypes.Float64, []byte(match.Raw()))
+		}
+	case json.TypeBoolean:
+		scalar = sqltypes.NewInt64(0)
+		if match == json.ValueTrue {
+			scalar = sqltypes.NewInt64(1)
+		}
+	default:
+		str, ok := match.StringBytes()
+		if !ok {
+			str = []byte(match.Raw())
+		}
+		scalar = sqltypes.MakeTrusted(sqltypes.VarChar, str)
+	}
+
+	value, err := col.coerce(env, scalar)
+	if err != nil {
+		return col.respond(env, col.OnError, err)
+	}
+	return value, nil
+}
+
+// respond returns the value for the column when it is empty or when an error occurred
+func (col *JSONTableColumn) respond(env *ExpressionEnv, resp *JSONTableOnResponse, err error) (sqltypes.Value, error) {
+	if resp == nil {
+		return sqltypes.NULL, nil
+	}
+	if resp.Error {
+		return sqltypes.Value{}, err
+	}
+	return col.coerce(env, resp.Default)
+}
+
+func (col *JSONTableColumn) coerce(env *ExpressionEnv, value sqltypes.Value) (sqltypes.Value, error) {
+	if value.IsNull() {
+		return sqltypes.NULL, nil
+	}
+	e, err := valueToEvalCast(value, col.Type.Type(), col.Type.Collation(), col.Type.Values(), env.sqlmode)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	return evalToSQLValueWithType(e, col.Type), nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+)
+
+func TestJSONTable(t *testing.T) {
+	cases := []struct {
+		query  string
+		fields string
+		rows   string
+		err    string
+	}{{
+		query:  `select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}]', '$[*]' columns(id for ordinality, a int path '$.a', b varchar(10) path '$.b')) as jt`,
+		fields: "[id:UINT32 a:INT32 b:VARCHAR]",
+		rows:   `[[UINT32(1) INT32(1) VARCHAR("x")] [UINT32(2) INT32(2) NULL]]`,
+	}, {
+		query:  `select * from json_table('[{"c1": null}]', '$[*]' columns(c1 int path '$.c1' error on error)) as jt`,
+		fields: "[c1:INT32]",
+		rows:   `[[NULL]]`,
+	}, {
+		query:  `select * from json_table('[{"a": 1}, {}]', '$[*]' columns(a int path '$.a' default '42' on empty, e int exists path '$.a')) as jt`,
+		fields: "[a:INT32 e:INT32]",
+		rows:   `[[INT32(1) INT32(1)] [INT32(42) INT32(0)]]`,
+	}, {
+		query: `select * from json_table('[{"a": [1, 2]}]', '$[*]' columns(a int path '$.a' error on error)) as jt`,
+		err:   "Can't store an array or an object in the scalar column 'a' of JSON_TABLE",
+	}, {
+		query: `select * from json_table('[{"a": 1}]', '$[*]' columns(b int path '$.b' error on empty)) as jt`,
+		err:   "Missing value for JSON_TABLE column 'b'",
+	}, {
+		query:  `select * from json_table('[{"a": 1, "b": [1, 2], "c": [3]}, {"a": 2}]', '$[*]' columns(a int path '$.a', nested path '$.b[*]' columns(b int path '$'), nested path '$.c[*]' columns(c int path '$'))) as jt`,
+		fields: "[a:INT32 b:INT32 c:INT32]",
+		rows:   `[[INT32(1) INT32(1) NULL] [INT32(1) INT32(2) NULL] [INT32(1) NULL INT32(3)] [INT32(2) NULL NULL]]`,
+	}, {
+		query:  `select * from json_table('{"a": {"x": 1}}', '$' columns(a json path '$.a')) as jt`,
+		fields: "[a:JSON]",
+		rows:   `[[JSON("{\"x\": 1}")]]`,
+	}, {
+		query: `select * from json_table(null, '$[*]' columns(a int path '$.a')) as jt`,
+		rows:  `[]`,
+	}}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := venv.Parser().Parse(tc.query)
+			require.NoError(t, err)
+			node := stmt.(*sqlparser.Select).From[0].(*sqlparser.JSONTableExpr)
+
+			jt, err := TranslateJSONTable(node, &Config{
+				Collation:   collations.CollationUtf8mb4ID,
+				Environment: venv,
+			})
+			require.NoError(t, err)
+
+			rows, err := jt.Rows(EmptyExpressionEnv(venv))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.fields != "" {
+				var fields []string
+				for _, f := range jt.Fields() {
+					fields = append(fields, f.Name+":"+f.Type.String())
+				}
+				require.Equal(t, tc.fields, fmt.Sprintf("%v", fields))
+			}
+			require.Equal(t, tc.rows, fmt.Sprintf("%v", rows))
+		})
+	}
+}
@@ -1457,6 +1457,41 @@ func (asm *assembler) Fn_REVERSE() {
 	}, "FN REVERSE VARCHAR(SP-1)")
 }
 
+func (asm *assembler) Fn_FORMAT(locale bool, col collations.TypedCollation) {
+	if locale {
+		asm.adjustStack(-2)
+		asm.emit(func(env *ExpressionEnv) int {
+			num := env.vm.stack[env.vm.sp-3]
+			dec := env.vm.stack[env.vm.sp-2].(*evalInt64)
+			loc := numberLocaleFor(env.vm.stack[env.vm.sp-1])
+
+			env.vm.stack[env.vm.sp-3] = env.vm.arena.newEvalText(formatNumber(num, dec.i, loc), col)
+			env.vm.sp -= 2
+			return 1
+		}, "FN FORMAT NUMERIC(SP-3), INT64(SP-2), VARCHAR(SP-1)")
+	} else {
+		asm.adjustStack(-1)
+		asm.emit(func(env *ExpressionEnv) int {
+			num := env.vm.stack[env.vm.sp-2]
+			dec := env.vm.stack[env.vm.sp-1].(*evalInt64)
+
+			env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalText(formatNumber(num, dec.i, numberLocales[0]), col)
+			env.vm.sp--
+			return 1
+		}, "FN FORMAT NUMERIC(SP-2), INT64(SP-1)")
+	}
+}
+
+func (asm *assembler) Fn_SOUNDEX() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+
+		arg.tt = int16(sqltypes.VarChar)
+		arg.bytes = soundex(arg)
+		return 1
+	}, "FN SOUNDEX VARCHAR(SP-1)")
+}
+
 func (asm *assembler) Fn_SPACE(col collations.TypedCollation) {
 	asm.emit(func(env *ExpressionEnv) int {
 		arg := env.vm.stack[env.vm.sp-1].(*evalInt64).i
@@ -2294,6 +2329,45 @@ func (asm *assembler) Fn_JSON_KEYS(jp *json.Path) {
 	}
 }
 
+func (asm *assembler) Fn_JSON_MERGE_PATCH(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		docs := make([]*json.Value, 0, args)
+		for sp := env.vm.sp - args; sp < env.vm.sp; sp++ {
+			doc, _ := env.vm.stack[sp].(*evalJSON)
+			docs = append(docs, doc)
+		}
+		if result := jsonMergePatch(docs); result != nil {
+			env.vm.stack[env.vm.sp-args] = result
+		} else {
+			env.vm.stack[env.vm.sp-args] = nil
+		}
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_MERGE_PATCH (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_JSON_MODIFY(t json.Transformation, paths []*json.Path) {
+	values := len(paths)
+	if t == json.Remove {
+		values = 0
+	}
+	asm.adjustStack(-values)
+	asm.emit(func(env *ExpressionEnv) int {
+		doc := env.vm.stack[env.vm.sp-values-1].(*evalJSON)
+		var vals []*json.Value
+		if values > 0 {
+			vals = make([]*json.Value, 0, values)
+			for sp := env.vm.sp - values; sp < env.vm.sp; sp++ {
+				vals = append(vals, env.vm.stack[sp].(*evalJSON).Clone())
+			}
+		}
+		env.vm.stack[env.vm.sp-values-1], env.vm.err = json.ApplyTransform(t, doc.Clone(), paths, vals)
+		env.vm.sp -= values
+		return 1
+	}, "FN JSON_MODIFY (SP-%d)...(SP-1), %d, [static]", values+1, t)
+}
+
 func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	asm.adjustStack(-(args - 1))
 	asm.emit(func(env *ExpressionEnv) int {
@@ -2315,6 +2389,45 @@ func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	}, "FN JSON_ARRAY (SP-%d)...(SP-1)", args)
 }
 
+func (asm *assembler) Fn_JSON_OVERLAPS() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		a := env.vm.stack[env.vm.sp-2].(*evalJSON)
+		b := env.vm.stack[env.vm.sp-1].(*evalJSON)
+		var overlaps bool
+		overlaps, env.vm.err = jsonOverlaps(a, b)
+		env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalBool(overlaps)
+		env.vm.sp--
+		return 1
+	}, "FN JSON_OVERLAPS (SP-2), (SP-1)")
+}
+
+func (asm *assembler) Fn_SPATIAL(method string, args int, f func(args []eval) (eval, error)) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		sp := env.vm.sp - args
+		env.vm.stack[sp], env.vm.err = f(env.vm.stack[sp:env.vm.sp])
+		env.vm.sp = sp + 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", method, args)
+}
+
+func (asm *assembler) Fn_JSON_SEARCH(match jsonMatch, escape rune, paths []*json.Path) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		doc := env.vm.stack[env.vm.sp-2].(*evalJSON)
+		search := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		wc := colldata.Lookup(search.col.Collation).Wildcard(search.bytes, 0, 0, escape)
+		if result := jsonSearch(doc, wc, match, paths); result != nil {
+			env.vm.stack[env.vm.sp-2] = result
+		} else {
+			env.vm.stack[env.vm.sp-2] = nil
+		}
+		env.vm.sp--
+		return 1
+	}, "FN JSON_SEARCH (SP-2), VARCHAR(SP-1), '%s', [static]", match)
+}
+
 func (asm *assembler) Fn_JSON_UNQUOTE() {
 	asm.emit(func(env *ExpressionEnv) int {
 		j := env.vm.stack[env.vm.sp-1].(*evalJSON)
@@ -3501,8 +3614,10 @@ func (asm *assembler) PackTuple(tlen int) {
 func (asm *assembler) Parse_j(offset int) {
 	asm.emit(func(env *ExpressionEnv) int {
 		var p json.Parser
-		arg := env.vm.stack[env.vm.sp-offset].(*evalBytes)
-		env.vm.stack[env.vm.sp-offset], env.vm.err = p.ParseBytes(arg.bytes)
+		arg, ok := env.vm.stack[env.vm.sp-offset].(*evalBytes)
+		if ok {
+			env.vm.stack[env.vm.sp-offset], env.vm.err = p.ParseBytes(arg.bytes)
+		}
 		return 1
 	}, "PARSE_JSON VARCHAR(SP-%d)", offset)
 }
@@ -3778,6 +3893,45 @@ func (asm *assembler) Fn_RandomBytes() {
 	}, "FN RANDOM_BYTES INT64(SP-1)")
 }
 
+func (asm *assembler) Fn_AES(call *builtinAES, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		sp := env.vm.sp - args
+		env.vm.stack[sp], env.vm.err = call.aes(env, env.vm.stack[sp:env.vm.sp])
+		env.vm.sp = sp + 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", call.Method, args)
+}
+
+func (asm *assembler) Fn_COMPRESS() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBinary(compress(arg.bytes))
+		return 1
+	}, "FN COMPRESS VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_UNCOMPRESS() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		out, ok := uncompress(arg.bytes)
+		if !ok {
+			env.vm.stack[env.vm.sp-1] = nil
+			return 1
+		}
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalRaw(out, sqltypes.Blob, collationBinary)
+		return 1
+	}, "FN UNCOMPRESS VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_UNCOMPRESSED_LENGTH() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalInt64(uncompressedLength(arg.bytes))
+		return 1
+	}, "FN UNCOMPRESSED_LENGTH VARBINARY(SP-1)")
+}
+
 func (asm *assembler) Fn_DATE_FORMAT(col collations.TypedCollation) {
 	asm.adjustStack(-1)
 	asm.emit(func(env *ExpressionEnv) int {
@@ -3796,6 +3950,45 @@ func (asm *assembler) Fn_DATE_FORMAT(col collations.TypedCollation) {
 	}, "FN DATE_FORMAT DATETIME(SP-2), VARBINARY(SP-1)")
 }
 
+func (asm *assembler) Fn_STR_TO_DATE(tt sqltypes.Type, prec int, allowZero bool) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		format := env.vm.stack[env.vm.sp-1].(*evalBytes)
+
+		env.vm.stack[env.vm.sp-2] = strToDate(tt, prec, str.string(), format.string(), allowZero)
+		env.vm.sp--
+		return 1
+	}, "FN STR_TO_DATE VARBINARY(SP-2), VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_TIMESTAMPDIFF(unit datetime.IntervalType) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		t1 := evalToTimestampDiffArg(env.vm.stack[env.vm.sp-2], env.now)
+		t2 := evalToTimestampDiffArg(env.vm.stack[env.vm.sp-1], env.now)
+		if t1 == nil || t2 == nil {
+			env.vm.stack[env.vm.sp-2] = nil
+		} else {
+			env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalInt64(timestampDiff(unit, t1.dt, t2.dt))
+		}
+		env.vm.sp--
+		return 1
+	}, "FN TIMESTAMPDIFF DATETIME(SP-2), DATETIME(SP-1)")
+}
+
+func (asm *assembler) Fn_GET_FORMAT(tt sqltypes.Type, col collations.TypedCollation) {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		if f, ok := getFormat(tt, arg.string()); ok {
+			env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalText([]byte(f), col)
+		} else {
+			env.vm.stack[env.vm.sp-1] = nil
+		}
+		return 1
+	}, "FN GET_FORMAT VARBINARY(SP-1)")
+}
+
 func (asm *assembler) Fn_CONVERT_TZ() {
 	asm.adjustStack(-2)
 	asm.emit(func(env *ExpressionEnv) int {
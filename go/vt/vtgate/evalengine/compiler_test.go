@@ -761,6 +761,208 @@ func TestCompilerSingle(t *testing.T) {
 			expression: `WEEK(timestamp '2024-01-01 10:34:58', 1)`,
 			result:     `INT64(1)`,
 		},
+		{
+			expression: `STR_TO_DATE('May 1, 2013', '%M %d,%Y')`,
+			result:     `DATE("2013-05-01")`,
+		},
+		{
+			expression: `STR_TO_DATE('2013-05-01 10:11:12.5', '%Y-%m-%d %T.%f')`,
+			result:     `DATETIME("2013-05-01 10:11:12.500000")`,
+			typeWanted: evalengine.NewTypeEx(sqltypes.Datetime, collations.CollationBinaryID, true, 6, 0, nil),
+		},
+		{
+			expression: `STR_TO_DATE('a09:30:17', '%h:%i:%s')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `TIMESTAMPDIFF(MINUTE, '2003-02-01', '2003-05-01 12:05:55')`,
+			result:     `INT64(128885)`,
+		},
+		{
+			expression: `TIMESTAMPDIFF(MONTH, '2003-01-31 10:00:00', '2003-02-28 09:00:00')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `GET_FORMAT(DATETIME, 'eur')`,
+			result:     `VARCHAR("%Y-%m-%d %H.%i.%s")`,
+		},
+		{
+			expression: `FORMAT(12332.2, 2, 'de_DE')`,
+			result:     `VARCHAR("12.332,20")`,
+		},
+		{
+			expression: `FORMAT(-1234567.891e0, 1)`,
+			result:     `VARCHAR("-1,234,567.9")`,
+		},
+		{
+			expression: `SOUNDEX('Quadratically')`,
+			result:     `VARCHAR("Q36324")`,
+		},
+		{
+			expression: `SOUNDEX('Ashcraft')`,
+			result:     `VARCHAR("A2613")`,
+		},
+		{
+			expression: `SOUNDEX('Tymczak')`,
+			result:     `VARCHAR("T520")`,
+		},
+		{
+			expression: `FORMAT(12332.2, 2, NULL)`,
+			result:     `VARCHAR("12,332.20")`,
+		},
+		{
+			expression: `JSON_SET('{"a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_INSERT('{"a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 1, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_REPLACE('{"a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3]}")`,
+		},
+		{
+			expression: `JSON_REMOVE('["a", ["b", "c"], "d"]', '$[1]')`,
+			result:     `JSON("[\"a\", \"d\"]")`,
+		},
+		{
+			expression: `JSON_ARRAY_APPEND('["a", ["b", "c"], "d"]', '$[1]', 1, '$[0]', 2, '$[1][0]', 3)`,
+			result:     `JSON("[[\"a\", 2], [[\"b\", 3], \"c\", 1], \"d\"]")`,
+		},
+		{
+			expression: `JSON_ARRAY_INSERT('["a", {"b": [1, 2]}, [3, 4]]', '$[1]', 'x', '$[100]', 'y', '$[2].b[0]', 'z')`,
+			result:     `JSON("[\"a\", \"x\", {\"b\": [\"z\", 1, 2]}, [3, 4], \"y\"]")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`,
+			result:     `JSON("{\"a\": 5, \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x":"abc"}, {"y":"bcd"}]', 'all', '%b%')`,
+			result:     `JSON("[\"$[0]\", \"$[2].x\", \"$[3].y\"]")`,
+		},
+		{
+			expression: `JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x":"abc"}, {"y":"bcd"}]', 'one', '%b%', NULL, '$[3]')`,
+			result:     `JSON("\"$[3].y\"")`,
+		},
+		{
+			expression: `JSON_OVERLAPS('{"a": 1, "b": 10, "d": 10}', '{"c": 1, "e": 10, "f": 1, "d": 10}')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[[1, 2], [3, 4], 5]', '[1, [2, 3], [4, 5]]')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `ST_AsText(ST_GeomFromText('MULTIPOINT(0 0, 20 20, 60 60)'))`,
+			result:     `VARCHAR("MULTIPOINT((0 0),(20 20),(60 60))")`,
+		},
+		{
+			expression: `ST_X(POINT(56.7, 53.34))`,
+			result:     `FLOAT64(56.7)`,
+		},
+		{
+			expression: `ST_AsText(ST_Y(POINT(56.7, 53.34), 10.5))`,
+			result:     `VARCHAR("POINT(56.7 10.5)")`,
+		},
+		{
+			expression: `ST_Distance_Sphere(POINT(-73.9949, 40.7501), POINT(-73.9706, 40.7528))`,
+			result:     `FLOAT64(2068.819445659122)`,
+		},
+		{
+			expression: `ST_Distance_Sphere(ST_GeomFromText('POINT(40.7501 -73.9949)', 4326), ST_GeomFromText('POINT(40.7528 -73.9706)', 4326))`,
+			result:     `FLOAT64(2068.819445659122)`,
+		},
+		{
+			expression: `ST_X(ST_GeomFromText('POINT(40.7501 -73.9949)', 4326))`,
+			result:     `FLOAT64(40.7501)`,
+		},
+		{
+			expression: `HEX(ST_GeomFromText('POINT(40.7501 -73.9949)', 4326))`,
+			result:     `VARCHAR("E6100000010100000096B20C71AC7F52C0645DDC4603604440")`,
+		},
+		{
+			expression: `ST_AsText(column0)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x96\xb2\x0c\x71\xac\x7f\x52\xc0\x64\x5d\xdc\x46\x03\x60\x44\x40"))},
+			result:     `VARCHAR("POINT(40.7501 -73.9949)")`,
+		},
+		{
+			expression: `ST_Y(column0)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x96\xb2\x0c\x71\xac\x7f\x52\xc0\x64\x5d\xdc\x46\x03\x60\x44\x40"))},
+			result:     `FLOAT64(-73.9949)`,
+		},
+		{
+			expression: `ST_Distance_Sphere(column0, ST_GeomFromText('POINT(40.7528 -73.9706)', 4326))`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x96\xb2\x0c\x71\xac\x7f\x52\xc0\x64\x5d\xdc\x46\x03\x60\x44\x40"))},
+			result:     `FLOAT64(2068.819445659122)`,
+		},
+		{
+			expression: `ST_Contains(ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))'), POINT(5, 5))`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `MBRWithin(ST_GeomFromText('POINT(1 1)'), ST_GeomFromText('POLYGON((0 0,0 3,3 3,3 0,0 0))'))`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `ST_AsText(column0)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\xbf"))},
+			result:     `VARCHAR("POINT(1 -1)")`,
+		},
+		{
+			expression: `ST_Contains(ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0))'), column0)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\xbf"))},
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `HEX(AES_ENCRYPT('text', 'key'))`,
+			result:     `VARCHAR("15E36637363712FC2E699B9C95B75393")`,
+		},
+		{
+			expression: `AES_DECRYPT(UNHEX('15E36637363712FC2E699B9C95B75393'), 'key')`,
+			result:     `VARBINARY("text")`,
+		},
+		{
+			expression: `AES_DECRYPT(UNHEX('15E36637363712FC2E699B9C95B753'), 'key')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `AES_ENCRYPT('text', NULL)`,
+			result:     `NULL`,
+		},
+		{
+			expression: `HEX(COMPRESS('hello'))`,
+			result:     `VARCHAR("05000000789CCB48CDC9C90700062C0215")`,
+		},
+		{
+			expression: `HEX(COMPRESS('x599'))`,
+			result:     `VARCHAR("04000000789CAB30B5B40400032E01202E")`,
+		},
+		{
+			expression: `COMPRESS('')`,
+			result:     `VARBINARY("")`,
+		},
+		{
+			expression: `UNCOMPRESS(COMPRESS('hello'))`,
+			result:     `BLOB("hello")`,
+		},
+		{
+			expression: `UNCOMPRESS(UNHEX('04000000789CAB30B5B40400032E01202E'))`,
+			result:     `BLOB("x599")`,
+		},
+		{
+			expression: `UNCOMPRESS('hello')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `UNCOMPRESSED_LENGTH(COMPRESS(REPEAT('a', 1000)))`,
+			result:     `INT64(1000)`,
+		},
+		{
+			expression: `UNCOMPRESSED_LENGTH('abc')`,
+			result:     `INT64(0)`,
+		},
 		{
 			expression: `column0 + 1`,
 			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Enum, []byte("foo"))},
@@ -839,6 +1041,29 @@ func TestCompilerSingle(t *testing.T) {
 	}
 }
 
+func TestGeographicStorageOrder(t *testing.T) {
+	// POINT(0 180) in WGS 84, as stored by MySQL: its longitude is 0 and its latitude is 180
+	values := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x66\x40"))}
+
+	venv := vtenv.NewTestEnv()
+	expr, err := venv.Parser().ParseExpr(`ST_Distance_Sphere(column0, ST_GeomFromText('POINT(0 0)', 4326), 1)`)
+	require.NoError(t, err)
+	fields := evalengine.FieldResolver(makeFields(values))
+	cfg := &evalengine.Config{
+		ResolveColumn: fields.Column,
+		ResolveType:   fields.Type,
+		Collation:     collations.CollationUtf8mb4ID,
+		Environment:   venv,
+	}
+	converted, err := evalengine.Translate(expr, cfg)
+	require.NoError(t, err)
+
+	env := evalengine.NewExpressionEnv(context.Background(), nil, evalengine.NewEmptyVCursor(venv, time.UTC))
+	env.Row = values
+	_, err = env.Evaluate(converted)
+	require.ErrorContains(t, err, "Latitude 180.000000 is out of range in function st_distance_sphere")
+}
+
 func TestBindVarLiteral(t *testing.T) {
 	var testCases = []struct {
 		expression string
@@ -919,8 +1144,9 @@ func TestBindVarLiteral(t *testing.T) {
 }
 
 type testVcursor struct {
-	lastInsertID *uint64
-	env          *vtenv.Environment
+	lastInsertID        *uint64
+	env                 *vtenv.Environment
+	blockEncryptionMode string
 }
 
 func (t *testVcursor) TimeZone() *time.Location {
@@ -935,6 +1161,13 @@ func (t *testVcursor) SQLMode() string {
 	return "oltp"
 }
 
+func (t *testVcursor) BlockEncryptionMode() string {
+	if t.blockEncryptionMode == "" {
+		return "aes-128-ecb"
+	}
+	return t.blockEncryptionMode
+}
+
 func (t *testVcursor) Environment() *vtenv.Environment {
 	return t.env
 }
@@ -1011,6 +1244,78 @@ func runTest(t *testing.T, expr sqlparser.Expr, cfg *evalengine.Config, tc struc
 	}
 }
 
+func TestBlockEncryptionMode(t *testing.T) {
+	const (
+		plaintext = "'Vitess is a database clustering system'"
+		key       = "'a very long key that is folded into the buffer'"
+		iv        = "'1234567890123456'"
+	)
+
+	// the expected results have been generated with OpenSSL
+	var testCases = []struct {
+		mode   string
+		result string
+	}{
+		{"aes-128-ecb", "8D260BAFF61012630042541E76D3F7D25FE41E662552CE30383A5C535CD76A5F034CC649F77245AD12972C679C27D1B2"},
+		{"aes-128-cbc", "75CA504E611419E0E5079EEED3150C2ED455E196D11DA320749707F6D96C8D59AF86B77232ED131126F7B22EFBEFA0B9"},
+		{"aes-192-cfb1", "60DF503973EB79CF2897D01334AEA6DF2D5E701567189D36857939408A7EFB3B6E140289535A"},
+		{"aes-256-cfb8", "7484EA22ADB0F82512D93BABA7B520FB489A32D69B61A365E996915BC0B47A66C102F9971368"},
+		{"aes-256-cfb128", "74A89887B0E1F6593E2576B6604AB308E6B99A1044289D4EE00CA5601F3E4393D631448ED976"},
+		{"AES-128-OFB", "93AF419CAD2DDD7AD218AB8B86FD8D1B2B8BF38F3F45E8098339CFEED97898258AF280AE1805"},
+	}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			vc := &testVcursor{env: venv, blockEncryptionMode: tc.mode}
+			expressions := []struct {
+				expression string
+				result     string
+			}{
+				{fmt.Sprintf("HEX(AES_ENCRYPT(%s, %s, %s))", plaintext, key, iv), fmt.Sprintf(`VARCHAR("%s")`, tc.result)},
+				{fmt.Sprintf("AES_DECRYPT(UNHEX('%s'), %s, %s)", tc.result, key, iv), `VARBINARY("Vitess is a database clustering system")`},
+			}
+			if tc.mode != "aes-128-ecb" {
+				expressions = append(expressions, struct {
+					expression string
+					result     string
+				}{fmt.Sprintf("AES_ENCRYPT(%s, %s, NULL)", plaintext, key), `NULL`})
+			}
+
+			for _, ee := range expressions {
+				expr, err := venv.Parser().ParseExpr(ee.expression)
+				require.NoError(t, err)
+
+				for _, compiled := range []bool{false, true} {
+					converted, err := evalengine.Translate(expr, &evalengine.Config{
+						Collation:     collations.CollationUtf8mb4ID,
+						NoCompilation: !compiled,
+						Environment:   venv,
+					})
+					require.NoError(t, err)
+
+					env := evalengine.NewExpressionEnv(context.Background(), nil, vc)
+					res, err := env.Evaluate(converted)
+					require.NoError(t, err)
+					assert.Equal(t, ee.result, res.String(), "%s (compiled = %v)", ee.expression, compiled)
+				}
+			}
+
+			// all the modes but ECB require an initialization vector
+			expr, err := venv.Parser().ParseExpr(fmt.Sprintf("AES_ENCRYPT(%s, %s)", plaintext, key))
+			require.NoError(t, err)
+			converted, err := evalengine.Translate(expr, &evalengine.Config{Collation: collations.CollationUtf8mb4ID, Environment: venv})
+			require.NoError(t, err)
+			_, err = evalengine.NewExpressionEnv(context.Background(), nil, vc).Evaluate(converted)
+			if tc.mode == "aes-128-ecb" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "Incorrect parameter count in the call to native function 'aes_encrypt'")
+			}
+		})
+	}
+}
+
 func TestCompilerNonConstant(t *testing.T) {
 	var testCases = []struct {
 		expression string
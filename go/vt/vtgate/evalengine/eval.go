@@ -406,6 +406,8 @@ func valueToEval(value sqltypes.Value, collation collations.TypedCollation, valu
 		return newEvalSet(value.Raw(), values), nil
 	case tt == sqltypes.Vector:
 		return newEvalVector(value.Raw()), nil
+	case tt == sqltypes.Geometry:
+		return newEvalGeometry(value.Raw()), nil
 	case sqltypes.IsText(tt):
 		if tt == sqltypes.HexNum {
 			raw, err := parseHexNumber(value.Raw())
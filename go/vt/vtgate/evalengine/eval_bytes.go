@@ -65,6 +65,10 @@ func newEvalVector(raw []byte) *evalBytes {
 	return newEvalRaw(sqltypes.Vector, raw, collationBinary)
 }
 
+func newEvalGeometry(raw []byte) *evalBytes {
+	return newEvalRaw(sqltypes.Geometry, raw, collationBinary)
+}
+
 func evalToBinary(e eval) *evalBytes {
 	if e, ok := e.(*evalBytes); ok && e.isBinary() && !e.isHexOrBitLiteral() {
 		return e
@@ -155,6 +155,8 @@ func (column *Column) compile(c *compiler) (ctype, error) {
 		c.asm.PushColumn_time(column.Offset)
 	case tt == sqltypes.Vector:
 		c.asm.PushColumn_vector(column.Offset)
+	case tt == sqltypes.Geometry:
+		c.asm.PushColumn_geometry(column.Offset)
 	default:
 		return ctype{}, vterrors.Errorf(vtrpc.Code_UNIMPLEMENTED, "Type is not supported: %s", tt)
 	}
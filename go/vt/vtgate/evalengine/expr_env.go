@@ -34,6 +34,7 @@ type VCursor interface {
 	TimeZone() *time.Location
 	GetKeyspace() string
 	SQLMode() string
+	BlockEncryptionMode() string
 	Environment() *vtenv.Environment
 	SetLastInsertID(id uint64)
 }
@@ -87,6 +88,10 @@ func (env *ExpressionEnv) currentTimezone() *time.Location {
 	return env.vc.TimeZone()
 }
 
+func (env *ExpressionEnv) currentBlockEncryptionMode() string {
+	return env.vc.BlockEncryptionMode()
+}
+
 func (env *ExpressionEnv) Evaluate(expr Expr) (EvalResult, error) {
 	if p, ok := expr.(*CompiledExpr); ok {
 		return env.EvaluateVM(p)
@@ -141,6 +146,11 @@ func (e *emptyVCursor) GetKeyspace() string {
 func (e *emptyVCursor) SQLMode() string {
 	return config.DefaultSQLMode
 }
+
+func (e *emptyVCursor) BlockEncryptionMode() string {
+	return config.DefaultBlockEncryptionMode
+}
+
 func (e *emptyVCursor) SetLastInsertID(_ uint64) {}
 
 func NewEmptyVCursor(env *vtenv.Environment, tz *time.Location) VCursor {
@@ -17,15 +17,23 @@ limitations under the License.
 package evalengine
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/binary"
 	"encoding/hex"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/zlib"
 	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
 )
 
 type builtinMD5 struct {
@@ -246,3 +254,410 @@ func (call *builtinRandomBytes) compile(c *compiler) (ctype, error) {
 	c.asm.jumpDestination(skip)
 	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: nullableFlags(arg.Flag) | flagNullable}, nil
 }
+
+// aesMode is one of the modes of AES encryption that can be configured
+// in MySQL's block_encryption_mode
+type aesMode struct {
+	keySize int
+	mode    string
+}
+
+func parseAESMode(mode string) (aesMode, bool) {
+	var m aesMode
+	rest, ok := strings.CutPrefix(strings.ToLower(mode), "aes-")
+	if !ok {
+		return m, false
+	}
+	bits, opmode, _ := strings.Cut(rest, "-")
+	switch bits {
+	case "128":
+		m.keySize = 16
+	case "192":
+		m.keySize = 24
+	case "256":
+		m.keySize = 32
+	default:
+		return m, false
+	}
+	switch opmode {
+	case "ecb", "cbc", "cfb1", "cfb8", "cfb128", "ofb":
+		m.mode = opmode
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// aesKey folds a key of arbitrary length into a key of the given size
+// by XORing its bytes, which is how MySQL derives AES keys
+func aesKey(key []byte, size int) []byte {
+	rkey := make([]byte, size)
+	for i, b := range key {
+		rkey[i%size] ^= b
+	}
+	return rkey
+}
+
+// aesCrypt encrypts or decrypts src the same way as MySQL with OpenSSL. The ECB and
+// CBC modes use PKCS#7 padding, and decrypting with them fails if the padding is invalid.
+func aesCrypt(m aesMode, src, key, iv []byte, decrypt bool) ([]byte, bool) {
+	block, err := aes.NewCipher(aesKey(key, m.keySize))
+	if err != nil {
+		return nil, false
+	}
+
+	switch m.mode {
+	case "ecb", "cbc":
+		var bm cipher.BlockMode
+		switch {
+		case m.mode == "ecb":
+			bm = ecbMode{block: block, decrypt: decrypt}
+		case decrypt:
+			bm = cipher.NewCBCDecrypter(block, iv)
+		default:
+			bm = cipher.NewCBCEncrypter(block, iv)
+		}
+
+		if !decrypt {
+			pad := aes.BlockSize - len(src)%aes.BlockSize
+			dst := make([]byte, len(src)+pad)
+			copy(dst, src)
+			for i := len(src); i < len(dst); i++ {
+				dst[i] = byte(pad)
+			}
+			bm.CryptBlocks(dst, dst)
+			return dst, true
+		}
+
+		if len(src) == 0 || len(src)%aes.BlockSize != 0 {
+			return nil, false
+		}
+		dst := make([]byte, len(src))
+		bm.CryptBlocks(dst, src)
+		pad := int(dst[len(dst)-1])
+		if pad == 0 || pad > aes.BlockSize {
+			return nil, false
+		}
+		for _, b := range dst[len(dst)-pad:] {
+			if int(b) != pad {
+				return nil, false
+			}
+		}
+		return dst[:len(dst)-pad], true
+	case "cfb1":
+		return aesCFB(block, iv, src, 1, decrypt), true
+	case "cfb8":
+		return aesCFB(block, iv, src, 8, decrypt), true
+	case "cfb128":
+		return aesCFB(block, iv, src, 128, decrypt), true
+	case "ofb":
+		return aesOFB(block, iv, src), true
+	}
+	return nil, false
+}
+
+// ecbMode is the ECB block mode, which the standard library does not
+// provide because it is insecure, but that MySQL uses by default
+type ecbMode struct {
+	block   cipher.Block
+	decrypt bool
+}
+
+func (ecb ecbMode) BlockSize() int {
+	return ecb.block.BlockSize()
+}
+
+func (ecb ecbMode) CryptBlocks(dst, src []byte) {
+	for i := 0; i < len(src); i += aes.BlockSize {
+		if ecb.decrypt {
+			ecb.block.Decrypt(dst[i:], src[i:])
+		} else {
+			ecb.block.Encrypt(dst[i:], src[i:])
+		}
+	}
+}
+
+// aesCFB implements the CFB mode with segments of 1, 8 or 128 bits. The shift
+// register is fed with the ciphertext, so the mode cannot use cipher.Stream
+// for the segment sizes smaller than the block size.
+func aesCFB(block cipher.Block, iv, src []byte, segment int, decrypt bool) []byte {
+	var reg, ks [aes.BlockSize]byte
+	copy(reg[:], iv)
+	dst := make([]byte, len(src))
+
+	switch segment {
+	case 1:
+		for i := 0; i < len(src)*8; i++ {
+			block.Encrypt(ks[:], reg[:])
+			shift := 7 - i%8
+			in := src[i/8] >> shift & 1
+			out := in ^ ks[0]>>7
+			dst[i/8] |= out << shift
+
+			feed := out
+			if decrypt {
+				feed = in
+			}
+			for j := 0; j < aes.BlockSize-1; j++ {
+				reg[j] = reg[j]<<1 | reg[j+1]>>7
+			}
+			reg[aes.BlockSize-1] = reg[aes.BlockSize-1]<<1 | feed
+		}
+	case 8:
+		for i := range src {
+			block.Encrypt(ks[:], reg[:])
+			dst[i] = src[i] ^ ks[0]
+
+			feed := dst[i]
+			if decrypt {
+				feed = src[i]
+			}
+			copy(reg[:], reg[1:])
+			reg[aes.BlockSize-1] = feed
+		}
+	default:
+		for i := 0; i < len(src); i += aes.BlockSize {
+			end := min(i+aes.BlockSize, len(src))
+			block.Encrypt(ks[:], reg[:])
+			subtle.XORBytes(dst[i:end], src[i:end], ks[:])
+			if decrypt {
+				copy(reg[:], src[i:end])
+			} else {
+				copy(reg[:], dst[i:end])
+			}
+		}
+	}
+	return dst
+}
+
+// aesOFB implements the OFB mode, which is symmetric for encryption and decryption
+func aesOFB(block cipher.Block, iv, src []byte) []byte {
+	var reg [aes.BlockSize]byte
+	copy(reg[:], iv)
+	dst := make([]byte, len(src))
+	for i := 0; i < len(src); i += aes.BlockSize {
+		end := min(i+aes.BlockSize, len(src))
+		block.Encrypt(reg[:], reg[:])
+		subtle.XORBytes(dst[i:end], src[i:end], reg[:])
+	}
+	return dst
+}
+
+type builtinAES struct {
+	CallExpr
+	decrypt bool
+}
+
+var _ IR = (*builtinAES)(nil)
+
+func (call *builtinAES) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return call.aes(env, args)
+}
+
+// aes encrypts or decrypts the first argument with the key and the optional
+// initialization vector in the rest of the arguments, using the current
+// block_encryption_mode of the session
+func (call *builtinAES) aes(env *ExpressionEnv, args []eval) (eval, error) {
+	mode := env.currentBlockEncryptionMode()
+	m, ok := parseAESMode(mode)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported block_encryption_mode '%s'", mode)
+	}
+
+	var iv []byte
+	if m.mode != "ecb" {
+		if len(args) < 3 {
+			return nil, argError(call.Method)
+		}
+		if args[2] == nil {
+			return nil, nil
+		}
+		iv = evalToBinary(args[2]).bytes
+		if len(iv) < aes.BlockSize {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "The initialization vector supplied to %s is too short. Must be at least %d bytes long", call.Method, aes.BlockSize)
+		}
+	}
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	res, ok := aesCrypt(m, evalToBinary(args[0]).bytes, evalToBinary(args[1]).bytes, iv, call.decrypt)
+	if !ok {
+		return nil, nil
+	}
+	return newEvalBinary(res), nil
+}
+
+func (call *builtinAES) constant() bool {
+	// the result depends on the block_encryption_mode of the session
+	return false
+}
+
+func (call *builtinAES) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+
+	c.asm.Fn_AES(call, len(call.Arguments))
+	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: flagNullable}, nil
+}
+
+// uncompressedSizeMask masks the length prefix of compressed strings, which
+// MySQL stores in the lower 30 bits
+const uncompressedSizeMask = 0x3FFFFFFF
+
+// compress returns the input compressed in the format of MySQL's COMPRESS(): the
+// length of the uncompressed input, followed by the zlib compressed input
+func compress(in []byte) []byte {
+	if len(in) == 0 {
+		return []byte{}
+	}
+	out := binary.LittleEndian.AppendUint32(nil, uint32(len(in)&uncompressedSizeMask))
+	out = zlib.Compress(out, in)
+	// MySQL makes sure that the result can be stored in a CHAR column, which trims spaces
+	if out[len(out)-1] == ' ' {
+		out = append(out, '.')
+	}
+	return out
+}
+
+// uncompress reverses compress, returning false if the input is not a valid compressed string
+func uncompress(in []byte) ([]byte, bool) {
+	if len(in) == 0 {
+		return []byte{}, true
+	}
+	if len(in) <= 4 {
+		return nil, false
+	}
+	size := binary.LittleEndian.Uint32(in) & uncompressedSizeMask
+	out, err := zlib.Uncompress(in[4:], int(size))
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func uncompressedLength(in []byte) int64 {
+	if len(in) <= 4 {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint32(in) & uncompressedSizeMask)
+}
+
+type builtinCompress struct {
+	CallExpr
+}
+
+var _ IR = (*builtinCompress)(nil)
+
+func (call *builtinCompress) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	return newEvalBinary(compress(evalToBinary(arg).bytes)), nil
+}
+
+func (call *builtinCompress) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	c.asm.Fn_COMPRESS()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: nullableFlags(str.Flag)}, nil
+}
+
+type builtinUncompress struct {
+	CallExpr
+}
+
+var _ IR = (*builtinUncompress)(nil)
+
+func (call *builtinUncompress) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	out, ok := uncompress(evalToBinary(arg).bytes)
+	if !ok {
+		return nil, nil
+	}
+	return newEvalRaw(sqltypes.Blob, out, collationBinary), nil
+}
+
+func (call *builtinUncompress) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	c.asm.Fn_UNCOMPRESS()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Blob, Col: collationBinary, Flag: flagNullable}, nil
+}
+
+type builtinUncompressedLength struct {
+	CallExpr
+}
+
+var _ IR = (*builtinUncompressedLength)(nil)
+
+func (call *builtinUncompressedLength) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	return newEvalInt64(uncompressedLength(evalToBinary(arg).bytes)), nil
+}
+
+func (call *builtinUncompressedLength) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	c.asm.Fn_UNCOMPRESSED_LENGTH()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: nullableFlags(str.Flag)}, nil
+}
@@ -70,7 +70,7 @@ func (call *builtinHex) compile(c *compiler) (ctype, error) {
 	switch {
 	case sqltypes.IsNumber(str.Type):
 		c.asm.Fn_HEX_d(col)
-	case str.isTextual():
+	case str.isTextual(), str.Type == sqltypes.Geometry:
 		c.asm.Fn_HEX_c(t, col)
 	default:
 		c.asm.Convert_xc(1, t, c.collation, nil)
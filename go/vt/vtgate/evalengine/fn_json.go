@@ -17,7 +17,10 @@ limitations under the License.
 package evalengine
 
 import (
+	"unicode/utf8"
+
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/colldata"
 	"vitess.io/vitess/go/mysql/json"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
@@ -57,6 +60,24 @@ type (
 	builtinJSONKeys struct {
 		CallExpr
 	}
+
+	builtinJSONModify struct {
+		CallExpr
+		t json.Transformation
+	}
+
+	builtinJSONMergePatch struct {
+		CallExpr
+	}
+
+	builtinJSONSearch struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinJSONOverlaps struct {
+		CallExpr
+	}
 )
 
 var _ IR = (*builtinJSONExtract)(nil)
@@ -67,8 +88,14 @@ var _ IR = (*builtinJSONDepth)(nil)
 var _ IR = (*builtinJSONLength)(nil)
 var _ IR = (*builtinJSONContainsPath)(nil)
 var _ IR = (*builtinJSONKeys)(nil)
+var _ IR = (*builtinJSONModify)(nil)
+var _ IR = (*builtinJSONMergePatch)(nil)
+var _ IR = (*builtinJSONSearch)(nil)
+var _ IR = (*builtinJSONOverlaps)(nil)
 
 var errInvalidPathForTransform = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
+var errVacuousPath = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "The path expression '$' is not allowed in this context.")
+var errInvalidPathForArrayInsert = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "A path expression is not a path to a cell in an array.")
 
 func (call *builtinJSONExtract) eval(env *ExpressionEnv) (eval, error) {
 	args, err := call.args(env)
@@ -511,3 +538,487 @@ func (call *builtinJSONKeys) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_JSON_KEYS(jp)
 	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
 }
+
+// pathArguments returns the arguments of the call that are paths; the rest of the arguments
+// are the values for the paths
+func (call *builtinJSONModify) pathArguments() []IR {
+	if call.t == json.Remove {
+		return call.Arguments[1:]
+	}
+	paths := make([]IR, 0, len(call.Arguments)/2)
+	for i := 1; i < len(call.Arguments); i += 2 {
+		paths = append(paths, call.Arguments[i])
+	}
+	return paths
+}
+
+func intoTransformPath(t json.Transformation, jp *json.Path) error {
+	if jp.ContainsWildcards() {
+		return errInvalidPathForTransform
+	}
+	switch t {
+	case json.Remove:
+		if jp.IsDocumentRoot() {
+			return errVacuousPath
+		}
+	case json.ArrayInsert:
+		if !jp.IsArrayCell() {
+			return errInvalidPathForArrayInsert
+		}
+	}
+	return nil
+}
+
+func (call *builtinJSONModify) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	step := 2
+	if call.t == json.Remove {
+		step = 1
+	}
+
+	var paths []*json.Path
+	var values []*json.Value
+	for i := 1; i < len(args); i += step {
+		if args[i] == nil {
+			return nil, nil
+		}
+		jp, err := intoJSONPath(args[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := intoTransformPath(call.t, jp); err != nil {
+			return nil, err
+		}
+		paths = append(paths, jp)
+
+		if call.t != json.Remove {
+			val, err := argToJSON(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, val.Clone())
+		}
+	}
+	return json.ApplyTransform(call.t, doc.Clone(), paths, values)
+}
+
+func (call *builtinJSONModify) compile(c *compiler) (ctype, error) {
+	pathArgs := call.pathArguments()
+	if !slice.All(pathArgs, func(expr IR) bool { return expr.constant() }) {
+		return ctype{}, c.unsupported(call)
+	}
+
+	paths := make([]*json.Path, 0, len(pathArgs))
+	for _, arg := range pathArgs {
+		jp, err := c.jsonExtractPath(arg)
+		if err != nil {
+			return ctype{}, err
+		}
+		if err := intoTransformPath(call.t, jp); err != nil {
+			return ctype{}, err
+		}
+		paths = append(paths, jp)
+	}
+
+	doct, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(doct)
+
+	_, err = c.compileParseJSON(call.Method, doct, 1)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	if call.t != json.Remove {
+		for i := 2; i < len(call.Arguments); i += 2 {
+			vt, err := call.Arguments[i].compile(c)
+			if err != nil {
+				return ctype{}, err
+			}
+			_, err = c.compileArgToJSON(vt, 1)
+			if err != nil {
+				return ctype{}, err
+			}
+		}
+	}
+
+	c.asm.Fn_JSON_MODIFY(call.t, paths)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.TypeJSON, Flag: nullableFlags(doct.Flag), Col: collationJSON}, nil
+}
+
+// jsonMergePatch merges the documents following RFC 7396. A NULL document makes the result NULL,
+// unless it is followed by a document that is not an object, which replaces it.
+func jsonMergePatch(docs []*json.Value) *json.Value {
+	var result *json.Value
+	var null bool
+	for i, doc := range docs {
+		switch {
+		case doc == nil:
+			null = true
+		case doc.Type() != json.TypeObject:
+			result, null = doc, false
+		case null:
+		case i == 0:
+			result = doc.Clone()
+		default:
+			result = json.MergePatch(result, doc)
+		}
+	}
+	if null {
+		return nil
+	}
+	return result
+}
+
+func (call *builtinJSONMergePatch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]*json.Value, 0, len(args))
+	for _, arg := range args {
+		if arg == nil {
+			docs = append(docs, nil)
+			continue
+		}
+		doc, err := intoJSON(call.Method, arg)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	if result := jsonMergePatch(docs); result != nil {
+		return result, nil
+	}
+	return nil, nil
+}
+
+func (call *builtinJSONMergePatch) compile(c *compiler) (ctype, error) {
+	var flag typeFlag
+	for _, arg := range call.Arguments {
+		doct, err := arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		_, err = c.compileParseJSON(call.Method, doct, 1)
+		if err != nil {
+			return ctype{}, err
+		}
+		flag |= nullableFlags(doct.Flag)
+	}
+
+	c.asm.Fn_JSON_MERGE_PATCH(len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flag, Col: collationJSON}, nil
+}
+
+func jsonSearchEscape(e eval) (rune, error) {
+	if e == nil {
+		return '\\', nil
+	}
+	b := evalToBinary(e).bytes
+	switch utf8.RuneCount(b) {
+	case 0:
+		return '\\', nil
+	case 1:
+		r, _ := utf8.DecodeRune(b)
+		return r, nil
+	default:
+		return 0, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongArguments, "Incorrect arguments to ESCAPE")
+	}
+}
+
+// jsonSearch returns the paths to the strings in the document that match the pattern, optionally
+// only inside the values matched by the given paths. A single match is returned as a JSON string,
+// while multiple matches are returned as a JSON array.
+func jsonSearch(doc *json.Value, wc colldata.WildcardPattern, match jsonMatch, paths []*json.Path) *json.Value {
+	var found []*json.Value
+	seen := make(map[string]struct{})
+
+	search := func(prefix []byte, root *json.Value) bool {
+		root.Walk(func(path []byte, v *json.Value) bool {
+			if match == jsonMatchOne && len(found) > 0 {
+				return false
+			}
+			str, ok := v.StringBytes()
+			if !ok || v.Type() != json.TypeString || !wc.Match(str) {
+				return true
+			}
+			full := string(prefix) + string(path[1:])
+			if _, dup := seen[full]; !dup {
+				seen[full] = struct{}{}
+				found = append(found, json.NewString(full))
+			}
+			return true
+		})
+		return match == jsonMatchAll || len(found) == 0
+	}
+
+	if len(paths) == 0 {
+		search([]byte{'$'}, doc)
+	}
+	for _, jp := range paths {
+		roots := make(map[*json.Value]struct{})
+		jp.Match(doc, true, func(v *json.Value) {
+			roots[v] = struct{}{}
+		})
+		more := true
+		doc.Walk(func(path []byte, v *json.Value) bool {
+			if !more {
+				return false
+			}
+			if _, ok := roots[v]; ok {
+				more = search(path, v)
+				return false
+			}
+			return true
+		})
+		if !more {
+			break
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil
+	case 1:
+		return found[0]
+	default:
+		return json.NewArray(found)
+	}
+}
+
+func (call *builtinJSONSearch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil || args[1] == nil || args[2] == nil {
+		return nil, nil
+	}
+
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := intoOneOrAll(call.Method, evalToBinary(args[1]).string())
+	if err != nil {
+		return nil, err
+	}
+
+	escape := '\\'
+	if len(args) > 3 {
+		escape, err = jsonSearchEscape(args[3])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var paths []*json.Path
+	if len(args) > 4 {
+		for _, arg := range args[4:] {
+			if arg == nil {
+				return nil, nil
+			}
+			jp, err := intoJSONPath(arg)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, jp)
+		}
+	}
+
+	search, ok := args[2].(*evalBytes)
+	if !ok {
+		search, err = evalToVarchar(args[2], call.collate, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wc := colldata.Lookup(search.col.Collation).Wildcard(search.bytes, 0, 0, escape)
+	if result := jsonSearch(doc, wc, match, paths); result != nil {
+		return result, nil
+	}
+	return nil, nil
+}
+
+func (call *builtinJSONSearch) compile(c *compiler) (ctype, error) {
+	if !call.Arguments[1].constant() {
+		return ctype{}, c.unsupported(call)
+	}
+
+	if len(call.Arguments) > 3 && !slice.All(call.Arguments[3:], func(expr IR) bool { return expr.constant() }) {
+		return ctype{}, c.unsupported(call)
+	}
+
+	match, err := c.jsonExtractOneOrAll(call.Method, call.Arguments[1])
+	if err != nil {
+		return ctype{}, err
+	}
+
+	escape := '\\'
+	var paths []*json.Path
+	if len(call.Arguments) > 3 {
+		lit, ok := call.Arguments[3].(*Literal)
+		if !ok {
+			return ctype{}, c.unsupported(call)
+		}
+		escape, err = jsonSearchEscape(lit.inner)
+		if err != nil {
+			return ctype{}, err
+		}
+		for _, arg := range call.Arguments[4:] {
+			jp, err := c.jsonExtractPath(arg)
+			if err != nil {
+				return ctype{}, err
+			}
+			paths = append(paths, jp)
+		}
+	}
+
+	doct, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	searcht, err := call.Arguments[2].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(doct, searcht)
+
+	_, err = c.compileParseJSON(call.Method, doct, 2)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	switch {
+	case searcht.isTextual():
+	default:
+		c.asm.Convert_xc(1, sqltypes.VarChar, call.collate, nil)
+	}
+
+	c.asm.Fn_JSON_SEARCH(match, escape, paths)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+// jsonOverlaps returns true if the documents have any array element or object key-value pair in
+// common. Scalars are compared as if they were single-element arrays.
+func jsonOverlaps(a, b *json.Value) (bool, error) {
+	aryA, okA := a.Array()
+	aryB, okB := b.Array()
+	switch {
+	case okA || okB:
+		if !okA {
+			aryA = []*json.Value{a}
+		}
+		if !okB {
+			aryB = []*json.Value{b}
+		}
+		for _, va := range aryA {
+			for _, vb := range aryB {
+				cmp, err := compareJSONValue(va, vb)
+				if err != nil {
+					return false, err
+				}
+				if cmp == 0 {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case a.Type() == json.TypeObject && b.Type() == json.TypeObject:
+		objA, _ := a.Object()
+		objB, _ := b.Object()
+		var overlaps bool
+		var err error
+		objA.Visit(func(key string, va *json.Value) {
+			if overlaps || err != nil {
+				return
+			}
+			vb := objB.Get(key)
+			if vb == nil {
+				return
+			}
+			var cmp int
+			cmp, err = compareJSONValue(va, vb)
+			overlaps = cmp == 0
+		})
+		return overlaps && err == nil, err
+	default:
+		cmp, err := compareJSONValue(a, b)
+		return cmp == 0, err
+	}
+}
+
+func (call *builtinJSONOverlaps) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	a, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := intoJSON(call.Method, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	overlaps, err := jsonOverlaps(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(overlaps), nil
+}
+
+func (call *builtinJSONOverlaps) compile(c *compiler) (ctype, error) {
+	a, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	b, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(a, b)
+
+	_, err = c.compileParseJSON(call.Method, a, 2)
+	if err != nil {
+		return ctype{}, err
+	}
+	_, err = c.compileParseJSON(call.Method, b, 1)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	c.asm.Fn_JSON_OVERLAPS()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | nullableFlags(a.Flag|b.Flag)}, nil
+}
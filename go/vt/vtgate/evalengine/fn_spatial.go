@@ -0,0 +1,494 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"math"
+	"strings"
+
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/geometry"
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// Geometry values are evaluated as binary strings in MySQL's internal geometry format: a 4-byte
+// SRID followed by the WKB representation of the geometry. Geometries can be in SRID 0, the
+// Cartesian plane, or in WGS 84 (SRID 4326). Like MySQL, the coordinates of WGS 84 are stored
+// with the longitude first, and they are only swapped into the latitude-longitude order of the
+// SRS by the functions that parse or format WKT and WKB, and by ST_X and ST_Y. The spatial
+// relations are only evaluated in SRID 0: they are not translated when one of their arguments
+// is a constant geometry in another SRID, so they are left to MySQL, and they return an error
+// for any other geometry that is not in SRID 0.
+
+type (
+	builtinGeomFromText struct {
+		CallExpr
+		expect geometry.Type
+	}
+
+	builtinGeomFromWKB struct {
+		CallExpr
+		expect geometry.Type
+	}
+
+	builtinGeomFormat struct {
+		CallExpr
+		binary  bool
+		collate collations.ID
+	}
+
+	builtinPoint struct {
+		CallExpr
+	}
+
+	builtinPointCoordinate struct {
+		CallExpr
+		y bool
+	}
+
+	builtinDistanceSphere struct {
+		CallExpr
+	}
+
+	builtinSpatialRelation struct {
+		CallExpr
+		relation geometry.Relation
+	}
+)
+
+var _ IR = (*builtinGeomFromText)(nil)
+var _ IR = (*builtinGeomFromWKB)(nil)
+var _ IR = (*builtinGeomFormat)(nil)
+var _ IR = (*builtinPoint)(nil)
+var _ IR = (*builtinPointCoordinate)(nil)
+var _ IR = (*builtinDistanceSphere)(nil)
+var _ IR = (*builtinSpatialRelation)(nil)
+
+var spatialRelations = map[string]geometry.Relation{
+	"st_contains":   geometry.Contains,
+	"st_within":     geometry.Within,
+	"mbrcontains":   geometry.MBRContains,
+	"mbrcoveredby":  geometry.MBRCoveredBy,
+	"mbrcovers":     geometry.MBRCovers,
+	"mbrdisjoint":   geometry.MBRDisjoint,
+	"mbrequals":     geometry.MBREquals,
+	"mbrintersects": geometry.MBRIntersects,
+	"mbroverlaps":   geometry.MBROverlaps,
+	"mbrtouches":    geometry.MBRTouches,
+	"mbrwithin":     geometry.MBRWithin,
+}
+
+func errInvalidGISData(method string) error {
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid GIS data provided to function %s.", strings.ToLower(method))
+}
+
+func errUnsupportedSRID(method string, srid uint32) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "Function %s is only supported in SRID 0, but one of its arguments is in SRID %d.", strings.ToLower(method), srid)
+}
+
+func errUnsupportedGeographicSRID(method string, srid uint32) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "Function %s is only supported in SRID 0 and SRID %d, but one of its arguments is in SRID %d.", strings.ToLower(method), geometry.WGS84, srid)
+}
+
+func newEvalGeometryFrom(g *geometry.Geometry) *evalBytes {
+	return newEvalGeometry(g.MarshalInternal())
+}
+
+// intoGeometry parses an argument of a spatial function, which must be a geometry in SRID 0
+func intoGeometry(method string, e eval) (*geometry.Geometry, error) {
+	g, err := intoGeographicGeometry(method, e)
+	if err != nil {
+		return nil, err
+	}
+	if g.SRID != 0 {
+		return nil, errUnsupportedSRID(method, g.SRID)
+	}
+	return g, nil
+}
+
+// intoGeographicGeometry parses an argument of a spatial function, which must be a geometry
+// in SRID 0 or in WGS 84
+func intoGeographicGeometry(method string, e eval) (*geometry.Geometry, error) {
+	b, ok := e.(*evalBytes)
+	if !ok {
+		return nil, errInvalidGISData(method)
+	}
+	g, err := geometry.ParseInternal(b.bytes)
+	if err != nil {
+		return nil, errInvalidGISData(method)
+	}
+	if g.SRID != 0 && g.SRID != geometry.WGS84 {
+		return nil, errUnsupportedGeographicSRID(method, g.SRID)
+	}
+	return g, nil
+}
+
+// intoSRID checks the optional SRID argument of the functions that build geometries,
+// and returns the SRID to build them in
+func intoSRID(method string, args []eval) (uint32, error) {
+	if len(args) < 2 {
+		return 0, nil
+	}
+	srid := evalToInt64(args[1]).i
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "SRID value is out of range in '%s'", strings.ToLower(method))
+	}
+	if srid != 0 && srid != geometry.WGS84 {
+		return 0, errUnsupportedGeographicSRID(method, uint32(srid))
+	}
+	return uint32(srid), nil
+}
+
+// fromGeographicAxisOrder converts a geometry that was parsed from WKT or WKB into the order
+// its coordinates are stored in, and checks that the coordinates of a geometry in WGS 84 are
+// valid latitudes and longitudes, in degrees. Geometries in SRID 0 are always valid.
+func fromGeographicAxisOrder(method string, g *geometry.Geometry) error {
+	if g.SRID != geometry.WGS84 {
+		return nil
+	}
+	g.SwapXY()
+	return checkCoordinates(method, g)
+}
+
+// checkCoordinates checks that the coordinates of a geometry are valid longitudes in X and
+// latitudes in Y, in degrees
+func checkCoordinates(method string, g *geometry.Geometry) error {
+	for _, p := range g.Points {
+		if p.X <= -180 || p.X > 180 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Longitude %f is out of range in function %s. It must be within (-180.000000, 180.000000].", p.X, strings.ToLower(method))
+		}
+		if p.Y < -90 || p.Y > 90 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Latitude %f is out of range in function %s. It must be within [-90.000000, 90.000000].", p.Y, strings.ToLower(method))
+		}
+	}
+	for _, part := range g.Parts {
+		if err := checkCoordinates(method, part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func spatialFloat(e eval) float64 {
+	f, _ := evalToFloat(e)
+	return f.f
+}
+
+// compileSpatialArguments compiles all the arguments of a spatial function, and returns the
+// jump that skips the function with a NULL result when any one of them is NULL
+func (c *compiler) compileSpatialArguments(method string, args []IR) (*jump, typeFlag, error) {
+	var types []ctype
+	var flag typeFlag
+	for _, arg := range args {
+		ct, err := arg.compile(c)
+		if err != nil {
+			return nil, 0, err
+		}
+		types = append(types, ct)
+		flag |= nullableFlags(ct.Flag)
+	}
+
+	switch len(types) {
+	case 1:
+		return c.compileNullCheck1(types[0]), flag, nil
+	case 2:
+		return c.compileNullCheck2(types[0], types[1]), flag, nil
+	case 3:
+		return c.compileNullCheck3(types[0], types[1], types[2]), flag, nil
+	default:
+		return nil, 0, argError(method)
+	}
+}
+
+// evalSpatial evaluates all the arguments of a spatial function and calls f with them,
+// unless any one of them is NULL
+func evalSpatial(env *ExpressionEnv, call *CallExpr, f func(args []eval) (eval, error)) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+	return f(args)
+}
+
+func (call *builtinGeomFromText) spatial(args []eval) (eval, error) {
+	srid, err := intoSRID(call.Method, args)
+	if err != nil {
+		return nil, err
+	}
+	text := evalToBinary(args[0])
+	g, err := geometry.ParseWKT(srid, hack.String(text.bytes))
+	if err != nil || (call.expect != geometry.Any && g.Type != call.expect) {
+		return nil, errInvalidGISData(call.Method)
+	}
+	if err := fromGeographicAxisOrder(call.Method, g); err != nil {
+		return nil, err
+	}
+	return newEvalGeometryFrom(g), nil
+}
+
+func (call *builtinGeomFromText) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinGeomFromText) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Geometry, Col: collationBinary, Flag: flag}, nil
+}
+
+func (call *builtinGeomFromWKB) spatial(args []eval) (eval, error) {
+	srid, err := intoSRID(call.Method, args)
+	if err != nil {
+		return nil, err
+	}
+	wkb := evalToBinary(args[0])
+	g, err := geometry.ParseWKB(srid, wkb.bytes)
+	if err != nil || (call.expect != geometry.Any && g.Type != call.expect) {
+		return nil, errInvalidGISData(call.Method)
+	}
+	if err := fromGeographicAxisOrder(call.Method, g); err != nil {
+		return nil, err
+	}
+	return newEvalGeometryFrom(g), nil
+}
+
+func (call *builtinGeomFromWKB) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinGeomFromWKB) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Geometry, Col: collationBinary, Flag: flag}, nil
+}
+
+func (call *builtinGeomFormat) spatial(args []eval) (eval, error) {
+	g, err := intoGeographicGeometry(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if g.SRID == geometry.WGS84 {
+		g.SwapXY()
+	}
+	if call.binary {
+		return newEvalBinary(g.AppendWKB(nil)), nil
+	}
+	return newEvalText(g.AppendWKT(nil), typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinGeomFormat) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinGeomFormat) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	if call.binary {
+		return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: flag}, nil
+	}
+	return ctype{Type: sqltypes.VarChar, Col: typedCoercionCollation(sqltypes.VarChar, call.collate), Flag: flag}, nil
+}
+
+func (call *builtinPoint) spatial(args []eval) (eval, error) {
+	x := spatialFloat(args[0])
+	y := spatialFloat(args[1])
+	return newEvalGeometryFrom(geometry.NewPoint(0, x, y)), nil
+}
+
+func (call *builtinPoint) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinPoint) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Geometry, Col: collationBinary, Flag: flag}, nil
+}
+
+// spatial returns the X or Y coordinate of a point, or when a second argument is given,
+// a copy of the point with that coordinate replaced. For points in WGS 84, X is the
+// latitude and Y the longitude, which are stored the other way around.
+func (call *builtinPointCoordinate) spatial(args []eval) (eval, error) {
+	g, err := intoGeographicGeometry(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if g.Type != geometry.Point {
+		return nil, errInvalidGISData(call.Method)
+	}
+
+	p := &g.Points[0]
+	coord := &p.X
+	if call.y != (g.SRID == geometry.WGS84) {
+		coord = &p.Y
+	}
+	if len(args) == 1 {
+		return newEvalFloat(*coord), nil
+	}
+
+	*coord = spatialFloat(args[1])
+	if g.SRID == geometry.WGS84 {
+		if err := checkCoordinates(call.Method, g); err != nil {
+			return nil, err
+		}
+	}
+	return newEvalGeometryFrom(g), nil
+}
+
+func (call *builtinPointCoordinate) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinPointCoordinate) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	if len(call.Arguments) > 1 {
+		return ctype{Type: sqltypes.Geometry, Col: collationBinary, Flag: flag}, nil
+	}
+	return ctype{Type: sqltypes.Float64, Col: collationNumeric, Flag: flag}, nil
+}
+
+func (call *builtinDistanceSphere) spatial(args []eval) (eval, error) {
+	method := strings.ToLower(call.Method)
+	a, err := intoGeographicGeometry(method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := intoGeographicGeometry(method, args[1])
+	if err != nil {
+		return nil, err
+	}
+	if a.SRID != b.SRID {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", method, a.SRID, b.SRID)
+	}
+
+	for _, g := range []*geometry.Geometry{a, b} {
+		if g.Type != geometry.Point && g.Type != geometry.MultiPoint {
+			srs := "Cartesian"
+			if a.SRID == geometry.WGS84 {
+				srs = "geographic"
+			}
+			return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "%s(%s, %s) has not been implemented for %s spatial reference systems.", method, a.Type, b.Type, srs)
+		}
+	}
+
+	radius := float64(geometry.DefaultSphereRadius)
+	if len(args) > 2 {
+		radius = spatialFloat(args[2])
+		if radius <= 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid radius provided to function %s: Radius must be greater than zero.", method)
+		}
+	}
+
+	for _, g := range []*geometry.Geometry{a, b} {
+		if err := checkCoordinates(method, g); err != nil {
+			return nil, err
+		}
+	}
+
+	return newEvalFloat(geometry.DistanceSphere(a, b, radius)), nil
+}
+
+func (call *builtinDistanceSphere) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinDistanceSphere) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Float64, Col: collationNumeric, Flag: flag}, nil
+}
+
+// spatialRelationSupported returns false if one of the arguments of a spatial relation is a
+// constant geometry in a SRID other than 0. The relations on geographic SRIDs can only be
+// evaluated by MySQL, and the SRID of the other arguments is only known when they are evaluated.
+func (ast *astCompiler) spatialRelationSupported(args []IR) bool {
+	env := EmptyExpressionEnv(ast.cfg.Environment)
+	for _, arg := range args {
+		if !arg.constant() {
+			continue
+		}
+		e, err := arg.eval(env)
+		if err != nil {
+			continue
+		}
+		if b, ok := e.(*evalBytes); ok {
+			if g, err := geometry.ParseInternal(b.bytes); err == nil && g.SRID != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (call *builtinSpatialRelation) spatial(args []eval) (eval, error) {
+	a, err := intoGeometry(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := intoGeometry(call.Method, args[1])
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(call.relation.Relate(a, b)), nil
+}
+
+func (call *builtinSpatialRelation) eval(env *ExpressionEnv) (eval, error) {
+	return evalSpatial(env, &call.CallExpr, call.spatial)
+}
+
+func (call *builtinSpatialRelation) compile(c *compiler) (ctype, error) {
+	skip, flag, err := c.compileSpatialArguments(call.Method, call.Arguments)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_SPATIAL(call.Method, len(call.Arguments), call.spatial)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | flag}, nil
+}
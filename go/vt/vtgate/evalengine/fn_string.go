@@ -19,11 +19,15 @@ package evalengine
 import (
 	"bytes"
 	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"vitess.io/vitess/go/mysql/capabilities"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/collations/charset"
 	"vitess.io/vitess/go/mysql/collations/colldata"
+	"vitess.io/vitess/go/mysql/decimal"
 	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -150,6 +154,16 @@ type (
 		CallExpr
 		collate collations.ID
 	}
+
+	builtinSoundex struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinFormat struct {
+		CallExpr
+		collate collations.ID
+	}
 )
 
 var _ IR = (*builtinField)(nil)
@@ -176,6 +190,8 @@ var _ IR = (*builtinRepeat)(nil)
 var _ IR = (*builtinConcat)(nil)
 var _ IR = (*builtinConcatWs)(nil)
 var _ IR = (*builtinReplace)(nil)
+var _ IR = (*builtinSoundex)(nil)
+var _ IR = (*builtinFormat)(nil)
 
 func fieldSQLType(arg sqltypes.Type, tt sqltypes.Type) sqltypes.Type {
 	if sqltypes.IsNull(arg) {
@@ -2191,3 +2207,278 @@ func replace(str, from, to []byte) []byte {
 	end += copy(out[end:], str[start:])
 	return out[0:end]
 }
+
+// soundexCodes are the soundex codes for the letters A to Z
+const soundexCodes = "01230120022455012623010202"
+
+func soundexCode(r rune) byte {
+	if r >= 'a' && r <= 'z' {
+		r -= 'a' - 'A'
+	}
+	if r < 'A' || r > 'Z' {
+		// letters outside of the basic latin alphabet are treated as vowels
+		return '0'
+	}
+	return soundexCodes[r-'A']
+}
+
+func soundex(in *evalBytes) []byte {
+	cs := colldata.Lookup(in.col.Collation).Charset()
+	binary := in.col.Collation == collations.CollationBinaryID
+	isAlpha := func(r rune, size int) bool {
+		if r < utf8.RuneSelf {
+			return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		}
+		return !binary && r >= 0xC0 && (size > 1 || r <= 0xFF)
+	}
+
+	b := in.bytes
+	out := make([]byte, 0, 4)
+
+	// the first letter of the string is kept as it is, but in upper case
+	var last byte
+	for {
+		r, size := cs.DecodeRune(b)
+		if size == 0 || r == utf8.RuneError {
+			return out
+		}
+		b = b[size:]
+		if !isAlpha(r, size) {
+			continue
+		}
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		var buf [4]byte
+		out = append(out, buf[:cs.EncodeRune(buf[:], r)]...)
+		last = soundexCode(r)
+		break
+	}
+
+	digits := 0
+	for {
+		r, size := cs.DecodeRune(b)
+		if size == 0 || r == utf8.RuneError {
+			break
+		}
+		b = b[size:]
+		if !isAlpha(r, size) {
+			continue
+		}
+		// like in MySQL, only the appended codes are compared, so letters with
+		// the same code that are separated by vowels are still coded once
+		code := soundexCode(r)
+		if code != '0' && code != last {
+			out = append(out, code)
+			digits++
+			last = code
+		}
+	}
+
+	for ; digits < 3; digits++ {
+		out = append(out, '0')
+	}
+	return out
+}
+
+func (call *builtinSoundex) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	b, ok := arg.(*evalBytes)
+	if !ok {
+		b, err = evalToVarchar(arg, call.collate, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newEvalText(soundex(b), b.col), nil
+}
+
+func (call *builtinSoundex) compile(c *compiler) (ctype, error) {
+	arg, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(arg)
+
+	col := arg.Col
+	switch {
+	case arg.isTextual():
+	default:
+		c.asm.Convert_xc(1, sqltypes.VarChar, c.collation, nil)
+		col = typedCoercionCollation(sqltypes.VarChar, c.collation)
+	}
+
+	c.asm.Fn_SOUNDEX()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: flagNullable}, nil
+}
+
+// numberLocale contains the separators used by FORMAT for a locale. Only a few locales are
+// known, so FORMAT with any other locale is not evaluated by vtgate, see numberLocaleSupported.
+type numberLocale struct {
+	name         string
+	decimalPoint byte
+	thousandsSep byte
+}
+
+var numberLocales = []numberLocale{
+	{name: "en_US", decimalPoint: '.', thousandsSep: ','},
+	{name: "en_GB", decimalPoint: '.', thousandsSep: ','},
+	{name: "de_DE", decimalPoint: ',', thousandsSep: '.'},
+}
+
+// numberLocaleSupported returns true if the locale argument of FORMAT is NULL or one of the
+// known locales. Since MySQL has separators for many more locales, the other ones can only
+// be evaluated by MySQL.
+func numberLocaleSupported(expr IR) bool {
+	lit, ok := expr.(*Literal)
+	if !ok {
+		return false
+	}
+	if lit.inner == nil {
+		return true
+	}
+	name := evalToBinary(lit.inner).string()
+	for _, l := range numberLocales {
+		if strings.EqualFold(l.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func numberLocaleFor(e eval) numberLocale {
+	if e != nil {
+		name := evalToBinary(e).string()
+		for _, l := range numberLocales {
+			if strings.EqualFold(l.name, name) {
+				return l
+			}
+		}
+	}
+	return numberLocales[0]
+}
+
+// formatNumber rounds num to dec decimals and formats it with the separators of the locale,
+// the same way MySQL's FORMAT does.
+func formatNumber(num eval, dec int64, loc numberLocale) []byte {
+	dec = max(0, min(dec, 30))
+
+	var str []byte
+	switch num := num.(type) {
+	case *evalDecimal:
+		str = []byte(num.dec.StringFixed(int32(dec)))
+	default:
+		f, _ := evalToFloat(num)
+		v := f.f
+		if m := v * math.Pow10(int(dec)); !math.IsInf(m, 0) {
+			v = math.RoundToEven(m) / math.Pow10(int(dec))
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return strconv.AppendFloat(nil, v, 'f', -1, 64)
+		}
+		str = strconv.AppendFloat(nil, v, 'f', int(dec), 64)
+	}
+
+	var sign []byte
+	if len(str) > 0 && str[0] == '-' {
+		sign, str = str[:1], str[1:]
+	}
+	intPart, fracPart := str, []byte(nil)
+	if dec > 0 {
+		intPart, fracPart = str[:len(str)-int(dec)-1], str[len(str)-int(dec):]
+	}
+
+	out := make([]byte, 0, len(sign)+len(str)+len(intPart)/3)
+	out = append(out, sign...)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			out = append(out, loc.thousandsSep)
+		}
+		out = append(out, c)
+	}
+	if dec > 0 {
+		out = append(out, loc.decimalPoint)
+		out = append(out, fracPart...)
+	}
+	return out
+}
+
+func (call *builtinFormat) eval(env *ExpressionEnv) (eval, error) {
+	num, dec, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if num == nil || dec == nil {
+		return nil, nil
+	}
+
+	var loc eval
+	if len(call.Arguments) > 2 {
+		loc, err = call.Arguments[2].eval(env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch n := num.(type) {
+	case *evalInt64:
+		num = newEvalDecimalWithPrec(decimal.NewFromInt(n.i), 0)
+	case *evalUint64:
+		num = newEvalDecimalWithPrec(decimal.NewFromUint(n.u), 0)
+	}
+	return newEvalText(formatNumber(num, evalToInt64(dec).i, numberLocaleFor(loc)), typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinFormat) compile(c *compiler) (ctype, error) {
+	num, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(num)
+
+	switch num.Type {
+	case sqltypes.Int64:
+		c.asm.Convert_id(1)
+	case sqltypes.Uint64:
+		c.asm.Convert_ud(1)
+	case sqltypes.Decimal, sqltypes.Float64:
+	default:
+		c.asm.Convert_xf(1)
+	}
+
+	dec, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(dec)
+
+	switch dec.Type {
+	case sqltypes.Int64:
+	default:
+		c.asm.Convert_xi(1)
+	}
+
+	locale := len(call.Arguments) > 2
+	if locale {
+		if _, err = call.Arguments[2].compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+
+	col := typedCoercionCollation(sqltypes.VarChar, c.collation)
+	c.asm.Fn_FORMAT(locale, col)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: num.Flag | dec.Flag | flagNullable}, nil
+}
@@ -18,6 +18,7 @@ package evalengine
 
 import (
 	"math"
+	"strings"
 	"time"
 
 	"vitess.io/vitess/go/hack"
@@ -191,6 +192,21 @@ type (
 		unit    datetime.IntervalType
 		collate collations.ID
 	}
+
+	builtinStrToDate struct {
+		CallExpr
+	}
+
+	builtinTimestampDiff struct {
+		CallExpr
+		unit datetime.IntervalType
+	}
+
+	builtinGetFormat struct {
+		CallExpr
+		typ     sqltypes.Type
+		collate collations.ID
+	}
 )
 
 var _ IR = (*builtinNow)(nil)
@@ -227,6 +243,9 @@ var _ IR = (*builtinYear)(nil)
 var _ IR = (*builtinYearWeek)(nil)
 var _ IR = (*builtinPeriodAdd)(nil)
 var _ IR = (*builtinPeriodDiff)(nil)
+var _ IR = (*builtinStrToDate)(nil)
+var _ IR = (*builtinTimestampDiff)(nil)
+var _ IR = (*builtinGetFormat)(nil)
 
 func (call *builtinNow) eval(env *ExpressionEnv) (eval, error) {
 	now := env.time(call.utc)
@@ -2149,3 +2168,265 @@ func (call *builtinDateMath) compile(c *compiler) (ctype, error) {
 	}
 	return ret, nil
 }
+
+// resultType returns the type of the value returned by STR_TO_DATE. Like in MySQL, it depends
+// on the format string when it is a constant, and is a DATETIME(6) otherwise.
+func (call *builtinStrToDate) resultType() (sqltypes.Type, int) {
+	lit, ok := call.Arguments[1].(*Literal)
+	if !ok || lit.inner == nil {
+		return sqltypes.Datetime, datetime.DefaultPrecision
+	}
+
+	f := datetime.ParseStrToDateFormat(evalToBinary(lit.inner).string())
+	var prec int
+	if f.Frac {
+		prec = datetime.DefaultPrecision
+	}
+	switch {
+	case f.Date && f.Time:
+		return sqltypes.Datetime, prec
+	case f.Time:
+		return sqltypes.Time, prec
+	default:
+		return sqltypes.Date, 0
+	}
+}
+
+func strToDate(tt sqltypes.Type, prec int, str, format string, allowZero bool) eval {
+	switch tt {
+	case sqltypes.Time:
+		t, ok := datetime.StrToTime(format, str)
+		if !ok {
+			return nil
+		}
+		return newEvalTime(t, prec)
+	case sqltypes.Date:
+		dt, ok := datetime.StrToDate(format, str, allowZero)
+		if !ok {
+			return nil
+		}
+		return newEvalDate(dt.Date, true)
+	default:
+		dt, ok := datetime.StrToDate(format, str, allowZero)
+		if !ok {
+			return nil
+		}
+		return newEvalDateTime(dt, prec, true)
+	}
+}
+
+func (call *builtinStrToDate) eval(env *ExpressionEnv) (eval, error) {
+	str, format, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil || format == nil {
+		return nil, nil
+	}
+
+	tt, prec := call.resultType()
+	return strToDate(tt, prec, evalToBinary(str).string(), evalToBinary(format).string(), env.sqlmode.AllowZeroDate()), nil
+}
+
+func (call *builtinStrToDate) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(str)
+
+	switch str.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	format, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(format)
+
+	switch format.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	tt, prec := call.resultType()
+	c.asm.Fn_STR_TO_DATE(tt, prec, c.sqlmode.AllowZeroDate())
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: tt, Col: collationBinary, Flag: flagNullable, Size: int32(prec)}, nil
+}
+
+// timestampDiff returns the difference between two datetimes in the given unit, truncated
+// towards zero, the same way MySQL's TIMESTAMPDIFF computes it.
+func timestampDiff(unit datetime.IntervalType, t1, t2 datetime.DateTime) int64 {
+	days := int64(datetime.MysqlDayNumber(t2.Date.Year(), t2.Date.Month(), t2.Date.Day()) -
+		datetime.MysqlDayNumber(t1.Date.Year(), t1.Date.Month(), t1.Date.Day()))
+	secs := days*24*3600 + secondsOfDay(t2.Time) - secondsOfDay(t1.Time)
+	usecs := secs*1_000_000 + int64(t2.Time.Nanosecond()/1000-t1.Time.Nanosecond()/1000)
+
+	sign := int64(1)
+	if usecs < 0 {
+		sign = -1
+		usecs = -usecs
+		t1, t2 = t2, t1
+	}
+	secs = usecs / 1_000_000
+
+	switch unit {
+	case datetime.IntervalYear:
+		return monthsBetween(t1, t2) / 12 * sign
+	case datetime.IntervalQuarter:
+		return monthsBetween(t1, t2) / 3 * sign
+	case datetime.IntervalMonth:
+		return monthsBetween(t1, t2) * sign
+	case datetime.IntervalWeek:
+		return secs / (24 * 3600) / 7 * sign
+	case datetime.IntervalDay:
+		return secs / (24 * 3600) * sign
+	case datetime.IntervalHour:
+		return secs / 3600 * sign
+	case datetime.IntervalMinute:
+		return secs / 60 * sign
+	case datetime.IntervalSecond:
+		return secs * sign
+	default:
+		return usecs * sign
+	}
+}
+
+func secondsOfDay(t datetime.Time) int64 {
+	return int64(t.Hour()*3600 + t.Minute()*60 + t.Second())
+}
+
+// monthsBetween returns the number of full months between beg and end, where beg <= end.
+func monthsBetween(beg, end datetime.DateTime) int64 {
+	yearBeg, monthBeg, dayBeg := beg.Date.Year(), beg.Date.Month(), beg.Date.Day()
+	yearEnd, monthEnd, dayEnd := end.Date.Year(), end.Date.Month(), end.Date.Day()
+
+	months := 12*(yearEnd-yearBeg) + monthEnd - monthBeg
+
+	switch {
+	case dayEnd < dayBeg:
+		months--
+	case dayEnd == dayBeg:
+		secBeg, secEnd := secondsOfDay(beg.Time), secondsOfDay(end.Time)
+		if secEnd < secBeg || (secEnd == secBeg && end.Time.Nanosecond() < beg.Time.Nanosecond()) {
+			months--
+		}
+	}
+	return int64(months)
+}
+
+func evalToTimestampDiffArg(e eval, now time.Time) *evalTemporal {
+	t := evalToDateTime(e, datetime.DefaultPrecision, now, false)
+	if t == nil || t.dt.Date.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func (call *builtinTimestampDiff) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+
+	t1 := evalToTimestampDiffArg(arg1, env.now)
+	t2 := evalToTimestampDiffArg(arg2, env.now)
+	if t1 == nil || t2 == nil {
+		return nil, nil
+	}
+	return newEvalInt64(timestampDiff(call.unit, t1.dt, t2.dt)), nil
+}
+
+func (call *builtinTimestampDiff) compile(c *compiler) (ctype, error) {
+	arg1, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(arg1)
+
+	arg2, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(arg2)
+
+	c.asm.Fn_TIMESTAMPDIFF(call.unit)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagNullable}, nil
+}
+
+var getFormats = []struct {
+	name                 string
+	date, datetime, time string
+}{
+	{name: "USA", date: "%m.%d.%Y", datetime: "%Y-%m-%d %H.%i.%s", time: "%h:%i:%s %p"},
+	{name: "JIS", date: "%Y-%m-%d", datetime: "%Y-%m-%d %H:%i:%s", time: "%H:%i:%s"},
+	{name: "ISO", date: "%Y-%m-%d", datetime: "%Y-%m-%d %H:%i:%s", time: "%H:%i:%s"},
+	{name: "EUR", date: "%d.%m.%Y", datetime: "%Y-%m-%d %H.%i.%s", time: "%H.%i.%s"},
+	{name: "INTERNAL", date: "%Y%m%d", datetime: "%Y%m%d%H%i%s", time: "%H%i%s"},
+}
+
+func getFormat(tt sqltypes.Type, name string) (string, bool) {
+	for _, f := range getFormats {
+		if !strings.EqualFold(f.name, name) {
+			continue
+		}
+		switch tt {
+		case sqltypes.Date:
+			return f.date, true
+		case sqltypes.Time:
+			return f.time, true
+		default:
+			return f.datetime, true
+		}
+	}
+	return "", false
+}
+
+func (call *builtinGetFormat) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, ok := getFormat(call.typ, evalToBinary(arg).string())
+	if !ok {
+		return nil, nil
+	}
+	return newEvalText([]byte(f), typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinGetFormat) compile(c *compiler) (ctype, error) {
+	arg, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(arg)
+
+	switch arg.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	col := typedCoercionCollation(sqltypes.VarChar, c.collation)
+	c.asm.Fn_GET_FORMAT(call.typ, col)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: flagNullable}, nil
+}
@@ -18,6 +18,7 @@ package evalengine
 
 import (
 	"fmt"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
@@ -278,6 +279,24 @@ func (c *builtinWeightString) format(buf *sqlparser.TrackedBuffer) {
 	buf.WriteByte(')')
 }
 
+func (call *builtinTimestampDiff) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteLiteral("timestampdiff(")
+	buf.WriteLiteral(call.unit.ToString())
+	buf.WriteString(", ")
+	formatExpr(buf, call, call.Arguments[0], true)
+	buf.WriteString(", ")
+	formatExpr(buf, call, call.Arguments[1], true)
+	buf.WriteByte(')')
+}
+
+func (call *builtinGetFormat) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteLiteral("get_format(")
+	buf.WriteLiteral(strings.ToLower(call.typ.String()))
+	buf.WriteString(", ")
+	formatExpr(buf, call, call.Arguments[0], true)
+	buf.WriteByte(')')
+}
+
 func (n *NegateExpr) format(buf *sqlparser.TrackedBuffer) {
 	buf.WriteByte('-')
 	formatExpr(buf, n, n.Inner, true)
@@ -18,6 +18,7 @@ package testcases
 
 import (
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -33,6 +34,14 @@ var Cases = []TestCase{
 	{Run: JSONPathOperations},
 	{Run: JSONArray},
 	{Run: JSONObject},
+	{Run: JSONModify},
+	{Run: JSONMergePatch},
+	{Run: JSONSearch},
+	{Run: JSONOverlaps},
+	{Run: SpatialFromText},
+	{Run: SpatialRelations},
+	{Run: SpatialDistanceSphere},
+	{Run: SpatialGeographicColumn, Schema: SpatialGeographicColumn_Schema},
 	{Run: CharsetConversionOperators},
 	{Run: CaseExprWithPredicate},
 	{Run: CaseExprWithValue},
@@ -86,6 +95,7 @@ var Cases = []TestCase{
 	{Run: FnSubstr},
 	{Run: FnLocate},
 	{Run: FnReplace},
+	{Run: FnSoundex},
 	{Run: FnConcat},
 	{Run: FnConcatWs},
 	{Run: FnChar},
@@ -118,6 +128,7 @@ var Cases = []TestCase{
 	{Run: FnSqrt},
 	{Run: FnRound},
 	{Run: FnTruncate},
+	{Run: FnFormat},
 	{Run: FnCrc32},
 	{Run: FnConv},
 	{Run: FnBin},
@@ -126,6 +137,8 @@ var Cases = []TestCase{
 	{Run: FnSHA1},
 	{Run: FnSHA2},
 	{Run: FnRandomBytes},
+	{Run: FnAES},
+	{Run: FnCompress},
 	{Run: FnDateFormat},
 	{Run: FnConvertTz},
 	{Run: FnDate},
@@ -157,6 +170,9 @@ var Cases = []TestCase{
 	{Run: FnYearWeek},
 	{Run: FnPeriodAdd},
 	{Run: FnPeriodDiff},
+	{Run: FnStrToDate},
+	{Run: FnTimestampDiff},
+	{Run: FnGetFormat},
 	{Run: FnInetAton},
 	{Run: FnInetNtoa},
 	{Run: FnInet6Aton},
@@ -214,6 +230,192 @@ func JSONObject(yield Query) {
 	yield("JSON_OBJECT()", nil, false)
 }
 
+func JSONModify(yield Query) {
+	for _, fn := range []string{"JSON_SET", "JSON_INSERT", "JSON_REPLACE", "JSON_ARRAY_APPEND", "JSON_ARRAY_INSERT"} {
+		for _, obj := range inputJSONObjects {
+			for _, path := range inputJSONPaths {
+				yield(fmt.Sprintf("%s('%s', '%s', 1)", fn, obj, path), nil, false)
+				yield(fmt.Sprintf("%s('%s', '%s', 'foo', '$[5]', JSON_ARRAY(1, NULL))", fn, obj, path), nil, false)
+				yield(fmt.Sprintf("%s('%s', '%s', JSON_OBJECT('x', 1), '%s[1]', NULL)", fn, obj, path, path), nil, false)
+			}
+		}
+		yield(fmt.Sprintf("%s(NULL, '$.a', 1)", fn), nil, false)
+		yield(fmt.Sprintf("%s('{\"a\": 1}', '$.a[1]', 2, '$.a[0]', 3)", fn), nil, false)
+	}
+
+	for _, obj := range inputJSONObjects {
+		for _, path1 := range inputJSONPaths {
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '%s')", obj, path1), nil, false)
+			for _, path2 := range inputJSONPaths {
+				yield(fmt.Sprintf("JSON_REMOVE('%s', '%s', '%s')", obj, path1, path2), nil, false)
+			}
+		}
+	}
+}
+
+func JSONMergePatch(yield Query) {
+	docs := []string{`'{"a": null, "b": {"d": [1]}}'`, `'"foo"'`, `NULL`}
+	for _, obj := range inputJSONObjects {
+		docs = append(docs, "'"+obj+"'")
+	}
+
+	for _, a := range docs {
+		for _, b := range docs {
+			yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, %s)", a, b), nil, false)
+			for _, c := range docs {
+				yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, %s, %s)", a, b, c), nil, false)
+			}
+		}
+	}
+}
+
+func JSONSearch(yield Query) {
+	var docs = []string{
+		`["abc", [{"k": "10"}, "def"], {"x":"abc"}, {"y":"bcd"}]`,
+		`{"a": "foo", "b": ["foobar", {"c": "FOO"}], "d e": "f_o"}`,
+		`"foo"`,
+		`[1, 2, true, null]`,
+	}
+	var patterns = []string{
+		`'abc'`, `'%b%'`, `'foo%'`, `'f_o'`, `'f\_o'`, `'10'`, `10`, `'%'`, `''`, `NULL`, `_binary 'foo'`,
+	}
+
+	for _, doc := range docs {
+		for _, pattern := range patterns {
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'one', %s)", doc, pattern), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s)", doc, pattern), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s, '|')", doc, pattern), nil, false)
+			for _, path := range inputJSONPaths {
+				yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s, NULL, '%s')", doc, pattern, path), nil, false)
+				yield(fmt.Sprintf("JSON_SEARCH('%s', 'one', %s, NULL, '%s', '$')", doc, pattern, path), nil, false)
+			}
+		}
+	}
+}
+
+func JSONOverlaps(yield Query) {
+	var docs = []string{
+		`'[1, 3, 5, 7]'`, `'[2, 5, 7]'`, `'[2, 4, 6]'`, `'[[1, 2], [3, 4], 5]'`, `'[5, 6]'`,
+		`'{"a": 1, "b": 10, "d": 10}'`, `'{"c": 1, "e": 10, "f": 1, "d": 10}'`, `'{"a": 5, "e": 10, "f": 1, "d": 20}'`,
+		`'5'`, `'"foo"'`, `'[{"a": 1, "b": 10, "d": 10}]'`, `NULL`,
+	}
+
+	for _, a := range docs {
+		for _, b := range docs {
+			yield(fmt.Sprintf("JSON_OVERLAPS(%s, %s)", a, b), nil, false)
+		}
+	}
+}
+
+func SpatialFromText(yield Query) {
+	var fns = []string{
+		"ST_GeomFromText", "ST_PointFromText", "ST_LineStringFromText", "ST_PolygonFromText",
+		"ST_MultiPointFromText", "ST_GeomCollFromText",
+	}
+
+	for _, fn := range fns {
+		for _, g := range inputGeometries {
+			yield(fmt.Sprintf("ST_AsText(%s(%s))", fn, g), nil, false)
+			yield(fmt.Sprintf("HEX(ST_AsBinary(%s(%s, 0)))", fn, g), nil, false)
+		}
+	}
+
+	for _, g := range inputGeometries {
+		yield(fmt.Sprintf("ST_AsText(ST_GeomFromWKB(ST_AsBinary(ST_GeomFromText(%s))))", g), nil, false)
+		yield(fmt.Sprintf("ST_X(ST_GeomFromText(%s))", g), nil, false)
+		yield(fmt.Sprintf("ST_Y(ST_GeomFromText(%s))", g), nil, false)
+		yield(fmt.Sprintf("ST_AsText(ST_X(ST_GeomFromText(%s), 10))", g), nil, false)
+	}
+
+	for _, arg := range inputBitwise {
+		yield(fmt.Sprintf("ST_AsText(POINT(%s, %s))", arg, arg), nil, false)
+		yield(fmt.Sprintf("ST_AsText(%s)", arg), nil, false)
+	}
+}
+
+func SpatialRelations(yield Query) {
+	var fns = []string{
+		"ST_Contains", "ST_Within", "MBRContains", "MBRCoveredBy", "MBRCovers", "MBRDisjoint",
+		"MBREquals", "MBRIntersects", "MBROverlaps", "MBRTouches", "MBRWithin",
+	}
+	var geoms = []string{
+		`POINT(5, 5)`, `POINT(10, 5)`, `ST_GeomFromText('LINESTRING(1 5, 9 5)')`,
+		`ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))')`,
+		`ST_GeomFromText('POLYGON((0 0,10 0,10 10,5 2,0 10,0 0))')`,
+		`ST_GeomFromText('MULTIPOINT(1 1, 5 5)')`, `ST_GeomFromText('GEOMETRYCOLLECTION EMPTY')`, `NULL`,
+	}
+
+	for _, fn := range fns {
+		for _, a := range geoms {
+			for _, b := range geoms {
+				yield(fmt.Sprintf("%s(%s, %s)", fn, a, b), nil, false)
+			}
+		}
+	}
+}
+
+func SpatialDistanceSphere(yield Query) {
+	var geoms = []string{
+		`POINT(0, 0)`, `POINT(-73.9949, 40.7501)`, `POINT(180, -90)`, `POINT(-180, 0)`, `POINT(0, 91)`,
+		`ST_GeomFromText('MULTIPOINT(10 10, -20 20)')`, `ST_GeomFromText('LINESTRING(0 0, 1 1)')`, `NULL`,
+	}
+	var radius = []string{"", ", 1", ", 0", ", -1", ", NULL", ", '6370986'"}
+	var geographic = []string{
+		`ST_GeomFromText('POINT(0 0)', 4326)`, `ST_GeomFromText('POINT(40.7501 -73.9949)', 4326)`,
+		`ST_GeomFromText('MULTIPOINT(10 10, 20 -20)', 4326)`, `ST_GeomFromText('LINESTRING(0 0, 1 1)', 4326)`,
+		`ST_GeomFromText('POINT(91 0)', 4326)`, `POINT(0, 0)`,
+	}
+
+	for _, a := range geoms {
+		for _, b := range geoms {
+			for _, r := range radius {
+				yield(fmt.Sprintf("ST_Distance_Sphere(%s, %s%s)", a, b, r), nil, false)
+			}
+		}
+	}
+
+	for _, a := range geographic {
+		for _, b := range geographic {
+			yield(fmt.Sprintf("ST_Distance_Sphere(%s, %s)", a, b), nil, false)
+		}
+		yield(fmt.Sprintf("ST_AsText(%s)", a), nil, false)
+		yield(fmt.Sprintf("ST_X(%s)", a), nil, false)
+	}
+}
+
+// SpatialGeographicColumn evaluates the spatial functions on WGS 84 values read from a column,
+// which MySQL stores with the longitude first
+func SpatialGeographicColumn(yield Query) {
+	var exprs = []string{
+		`ST_AsText(column0)`, `HEX(ST_AsBinary(column0))`, `HEX(column0)`, `ST_X(column0)`, `ST_Y(column0)`,
+		`ST_AsText(ST_X(column0, 10))`, `ST_AsText(ST_Y(column0, 10))`, `ST_X(column0, 100)`,
+		`ST_Distance_Sphere(column0, ST_GeomFromText('POINT(40.7528 -73.9706)', 4326))`,
+		`ST_Distance_Sphere(column0, ST_GeomFromText('MULTIPOINT((10 10),(20 -20))', 4326), 1)`,
+	}
+	var points = [][2]float64{{-73.9949, 40.7501}, {180, -90}, {0, 0}, {-0.5, 89.5}}
+
+	for _, p := range points {
+		raw := binary.LittleEndian.AppendUint32(nil, 4326)
+		raw = append(raw, 1)
+		raw = binary.LittleEndian.AppendUint32(raw, 1)
+		raw = binary.LittleEndian.AppendUint64(raw, math.Float64bits(p[0]))
+		raw = binary.LittleEndian.AppendUint64(raw, math.Float64bits(p[1]))
+		row := sqltypes.MakeTrusted(sqltypes.Geometry, raw)
+
+		for _, expr := range exprs {
+			yield(expr, []sqltypes.Value{row}, false)
+		}
+	}
+}
+
+var SpatialGeographicColumn_Schema = []*querypb.Field{
+	{
+		Name:       "column0",
+		Type:       sqltypes.Geometry,
+		ColumnType: "GEOMETRY SRID 4326",
+	},
+}
+
 func CharsetConversionOperators(yield Query) {
 	var introducers = []string{
 		"", "_latin1", "_utf8mb4", "_utf8", "_binary",
@@ -625,6 +827,22 @@ func FnTruncate(yield Query) {
 	}
 }
 
+func FnFormat(yield Query) {
+	for _, num := range inputConversions {
+		for _, dec := range []string{"0", "2", "-1", "NULL", "'4'", "31"} {
+			yield(fmt.Sprintf("FORMAT(%s, %s)", num, dec), nil, false)
+		}
+	}
+
+	for _, num := range []string{"12332.123456", "-12332.2", "1234567.891e0", "0.5", "12332", "-1234567"} {
+		for _, dec := range []string{"0", "1", "4"} {
+			for _, locale := range []string{"'en_US'", "'en_GB'", "'de_DE'", "NULL"} {
+				yield(fmt.Sprintf("FORMAT(%s, %s, %s)", num, dec, locale), nil, false)
+			}
+		}
+	}
+}
+
 func FnCrc32(yield Query) {
 	for _, num := range radianInputs {
 		yield(fmt.Sprintf("CRC32(%s)", num), nil, false)
@@ -752,6 +970,27 @@ func FnRandomBytes(yield Query) {
 	}
 }
 
+func FnAES(yield Query) {
+	keys := []string{"'key'", "''", "NULL", "1234", "'a very long key that is folded into the buffer'"}
+	for _, str := range inputConversions {
+		for _, key := range keys {
+			yield(fmt.Sprintf("HEX(AES_ENCRYPT(%s, %s))", str, key), nil, false)
+			yield(fmt.Sprintf("AES_DECRYPT(AES_ENCRYPT(%s, %s), %s)", str, key, key), nil, false)
+		}
+		yield(fmt.Sprintf("AES_DECRYPT(%s, 'key')", str), nil, false)
+	}
+}
+
+func FnCompress(yield Query) {
+	for _, str := range inputConversions {
+		yield(fmt.Sprintf("HEX(COMPRESS(%s))", str), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESS(COMPRESS(%s))", str), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESSED_LENGTH(COMPRESS(%s))", str), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESS(%s)", str), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESSED_LENGTH(%s)", str), nil, false)
+	}
+}
+
 func CaseExprWithValue(yield Query) {
 	var elements []string
 	elements = append(elements, inputBitwise...)
@@ -1717,6 +1956,32 @@ func FnReplace(yield Query) {
 	}
 }
 
+func FnSoundex(yield Query) {
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("SOUNDEX(%s)", str), nil, false)
+	}
+
+	mysqlDocSamples := []string{
+		`SOUNDEX('Hello')`,
+		`SOUNDEX('Quadratically')`,
+		`SOUNDEX('Robert')`,
+		`SOUNDEX('Rupert')`,
+		`SOUNDEX('Tymczak')`,
+		`SOUNDEX('  Ashcraft')`,
+		`SOUNDEX('Ashcraft')`,
+		`SOUNDEX('Pfister')`,
+		`SOUNDEX('Honeyman')`,
+		`SOUNDEX('Müller')`,
+		`SOUNDEX('Ölschläger')`,
+		`SOUNDEX(_latin1 'Ölschläger')`,
+		`SOUNDEX('123')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
 func FnConcat(yield Query) {
 	for _, str := range inputStrings {
 		yield(fmt.Sprintf("CONCAT(%s)", str), nil, false)
@@ -2289,6 +2554,83 @@ func FnPeriodDiff(yield Query) {
 	}
 }
 
+func FnStrToDate(yield Query) {
+	mysqlDocSamples := []string{
+		`STR_TO_DATE('01,5,2013','%d,%m,%Y')`,
+		`STR_TO_DATE('May 1, 2013','%M %d,%Y')`,
+		`STR_TO_DATE('a09:30:17','a%h:%i:%s')`,
+		`STR_TO_DATE('a09:30:17','%h:%i:%s')`,
+		`STR_TO_DATE('09:30:17a','%h:%i:%s')`,
+		`STR_TO_DATE('abc','abc')`,
+		`STR_TO_DATE('9','%m')`,
+		`STR_TO_DATE('9','%s')`,
+		`STR_TO_DATE('00/00/0000', '%m/%d/%Y')`,
+		`STR_TO_DATE('04/31/2004', '%m/%d/%Y')`,
+		`STR_TO_DATE('200442 Monday', '%X%V %W')`,
+		`STR_TO_DATE('2013-05-01 10:11:12.5', '%Y-%m-%d %T.%f')`,
+		`STR_TO_DATE('10:11:12 pm', '%r')`,
+		`STR_TO_DATE('12.5', '%s.%f')`,
+		`STR_TO_DATE('2004 042', '%Y %j')`,
+		`STR_TO_DATE('3rd March 21', '%D %M %y')`,
+		`STR_TO_DATE(NULL, '%Y')`,
+		`STR_TO_DATE('2013', NULL)`,
+		`STR_TO_DATE(20130501, '%Y%m%d')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+
+	formats := []string{`'%Y-%m-%d'`, `'%Y-%m-%d %H:%i:%s'`, `'%H:%i:%s'`, `'%Y-%m-%d %H:%i:%s.%f'`, `'%y%m%d'`}
+	for _, d := range inputConversions {
+		for _, f := range formats {
+			yield(fmt.Sprintf("STR_TO_DATE(%s, %s)", d, f), nil, false)
+		}
+	}
+}
+
+func FnTimestampDiff(yield Query) {
+	mysqlDocSamples := []string{
+		`TIMESTAMPDIFF(MONTH,'2003-02-01','2003-05-01')`,
+		`TIMESTAMPDIFF(YEAR,'2002-05-01','2001-01-01')`,
+		`TIMESTAMPDIFF(MINUTE,'2003-02-01','2003-05-01 12:05:55')`,
+		`TIMESTAMPDIFF(MONTH,'2003-01-31','2003-02-28')`,
+		`TIMESTAMPDIFF(MONTH,'2003-01-31 10:00:00','2003-02-28 09:00:00')`,
+		`TIMESTAMPDIFF(MICROSECOND,'2003-01-01 00:00:00.5','2003-01-01 00:00:00.25')`,
+		`TIMESTAMPDIFF(DAY, NULL, '2003-01-01')`,
+		`TIMESTAMPDIFF(DAY, 'foobar', '2003-01-01')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+
+	dates := []string{
+		`DATE'2018-05-01'`,
+		`TIMESTAMP'2020-12-31 23:59:59'`,
+		`TIMESTAMP'2025-01-01 00:00:00.123456'`,
+		`'2018-05-31'`,
+		`'2020-02-29 23:59:59'`,
+		`20250101`,
+		`'pokemon trainers'`,
+	}
+	for _, unit := range []string{"MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR"} {
+		for _, d1 := range dates {
+			for _, d2 := range dates {
+				yield(fmt.Sprintf("TIMESTAMPDIFF(%s, %s, %s)", unit, d1, d2), nil, false)
+			}
+		}
+	}
+}
+
+func FnGetFormat(yield Query) {
+	for _, t := range []string{"DATE", "TIME", "DATETIME", "TIMESTAMP"} {
+		for _, f := range []string{"'USA'", "'jis'", "'ISO'", "'EUR'", "'internal'", "'foobar'", "NULL", "1"} {
+			yield(fmt.Sprintf("GET_FORMAT(%s, %s)", t, f), nil, false)
+		}
+	}
+}
+
 func FnInetAton(yield Query) {
 	for _, d := range ipInputs {
 		yield(fmt.Sprintf("INET_ATON(%s)", d), nil, false)
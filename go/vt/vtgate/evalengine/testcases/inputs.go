@@ -417,3 +417,12 @@ var inputIntervals = []string{"day",
 	"second_microsecond",
 	"year_month",
 }
+
+var inputGeometries = []string{
+	`'POINT(1 2)'`, `'POINT(-1.5 2e3)'`, `'LINESTRING(0 0, 1 1, 2 2)'`,
+	`'POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))'`, `'POLYGON((0 0,10 0,10 10,5 2,0 10,0 0))'`,
+	`'MULTIPOINT(0 0, 5 5, 20 20)'`, `'MULTILINESTRING((10 10, 20 20), (15 15, 30 15))'`,
+	`'MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((20 20,30 20,30 30,20 20)))'`,
+	`'GEOMETRYCOLLECTION(POINT(5 5), LINESTRING(1 1, 2 2))'`, `'GEOMETRYCOLLECTION EMPTY'`,
+	`'POINT(1)'`, `'POLYGON((0 0,1 0,1 1))'`, `'foobar'`, `''`, `NULL`,
+}
@@ -21,6 +21,9 @@ import (
 	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/geometry"
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -323,6 +326,21 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinReverse{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "soundex":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSoundex{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "format":
+		switch len(args) {
+		case 2, 3:
+			if len(args) == 3 && !numberLocaleSupported(args[2]) {
+				return nil, translateExprNotSupported(fn)
+			}
+			return &builtinFormat{CallExpr: call, collate: ast.cfg.Collation}, nil
+		default:
+			return nil, argError(method)
+		}
 	case "space":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -385,6 +403,11 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinDateFormat{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "str_to_date":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinStrToDate{CallExpr: call}, nil
 	case "date":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -641,6 +664,31 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinSHA2{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "aes_encrypt", "aes_decrypt":
+		switch len(args) {
+		case 2, 3:
+		case 4, 5, 6:
+			// the key derivation functions are not supported
+			return nil, translateExprNotSupported(fn)
+		default:
+			return nil, argError(method)
+		}
+		return &builtinAES{CallExpr: call, decrypt: method == "aes_decrypt"}, nil
+	case "compress":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinCompress{CallExpr: call}, nil
+	case "uncompress":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinUncompress{CallExpr: call}, nil
+	case "uncompressed_length":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinUncompressedLength{CallExpr: call}, nil
 	case "convert_tz":
 		if len(args) != 3 {
 			return nil, argError(method)
@@ -667,6 +715,22 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinLastInsertID{CallExpr: call}, nil
+	case "st_contains", "st_within", "mbrcontains", "mbrcoveredby", "mbrcovers", "mbrdisjoint",
+		"mbrequals", "mbrintersects", "mbroverlaps", "mbrtouches", "mbrwithin":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		if !ast.spatialRelationSupported(args) {
+			return nil, translateExprNotSupported(fn)
+		}
+		return &builtinSpatialRelation{CallExpr: call, relation: spatialRelations[method]}, nil
+	case "st_distance_sphere":
+		switch len(args) {
+		case 2, 3:
+			return &builtinDistanceSphere{CallExpr: call}, nil
+		default:
+			return nil, argError(method)
+		}
 	default:
 		return nil, translateExprNotSupported(fn)
 	}
@@ -787,6 +851,102 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			Method:    "JSON_KEYS",
 		}}, nil
 
+	case *sqlparser.JSONValueModifierExpr:
+		var t json.Transformation
+		var method string
+		switch call.Type {
+		case sqlparser.JSONArrayAppendType:
+			t, method = json.ArrayAppend, "JSON_ARRAY_APPEND"
+		case sqlparser.JSONArrayInsertType:
+			t, method = json.ArrayInsert, "JSON_ARRAY_INSERT"
+		case sqlparser.JSONInsertType:
+			t, method = json.Insert, "JSON_INSERT"
+		case sqlparser.JSONReplaceType:
+			t, method = json.Replace, "JSON_REPLACE"
+		case sqlparser.JSONSetType:
+			t, method = json.Set, "JSON_SET"
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
+		doc, err := ast.translateExpr(call.JSONDoc)
+		if err != nil {
+			return nil, err
+		}
+		args := []IR{doc}
+		for _, param := range call.Params {
+			path, err := ast.translateExpr(param.Key)
+			if err != nil {
+				return nil, err
+			}
+			val, err := ast.translateExpr(param.Value)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, path, val)
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    method,
+			},
+			t: t,
+		}, nil
+
+	case *sqlparser.JSONRemoveExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.PathList...))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_REMOVE",
+			},
+			t: json.Remove,
+		}, nil
+
+	case *sqlparser.JSONValueMergeExpr:
+		if call.Type != sqlparser.JSONMergePatchType {
+			return nil, translateExprNotSupported(call)
+		}
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.JSONDocList...))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONMergePatch{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_MERGE_PATCH",
+		}}, nil
+
+	case *sqlparser.JSONSearchExpr:
+		exprs := []sqlparser.Expr{call.JSONDoc, call.OneOrAll, call.SearchStr}
+		if call.EscapeChar != nil {
+			exprs = append(exprs, call.EscapeChar)
+			exprs = append(exprs, call.PathList...)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONSearch{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_SEARCH",
+			},
+			collate: ast.cfg.Collation,
+		}, nil
+
+	case *sqlparser.JSONOverlapsExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc1, call.JSONDoc2})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONOverlaps{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_OVERLAPS",
+		}}, nil
+
 	case *sqlparser.CurTimeFuncExpr:
 		if call.Fsp > 6 {
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Too-big precision %d specified for '%s'. Maximum is 6.", call.Fsp, call.Name.String())
@@ -908,6 +1068,47 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			collate:  ast.cfg.Collation,
 		}, nil
 
+	case *sqlparser.TimestampDiffExpr:
+		var err error
+		args := make([]IR, 2)
+		args[0], err = ast.translateExpr(call.Expr1)
+		if err != nil {
+			return nil, err
+		}
+		args[1], err = ast.translateExpr(call.Expr2)
+		if err != nil {
+			return nil, err
+		}
+
+		return &builtinTimestampDiff{
+			CallExpr: CallExpr{Arguments: args, Method: "TIMESTAMPDIFF"},
+			unit:     call.Unit,
+		}, nil
+
+	case *sqlparser.GetFormatExpr:
+		arg, err := ast.translateExpr(call.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		var tt sqltypes.Type
+		switch call.Type {
+		case sqlparser.GetFormatDate:
+			tt = sqltypes.Date
+		case sqlparser.GetFormatTime:
+			tt = sqltypes.Time
+		case sqlparser.GetFormatDatetime:
+			tt = sqltypes.Datetime
+		default:
+			tt = sqltypes.Timestamp
+		}
+
+		return &builtinGetFormat{
+			CallExpr: CallExpr{Arguments: []IR{arg}, Method: "GET_FORMAT"},
+			typ:      tt,
+			collate:  ast.cfg.Collation,
+		}, nil
+
 	case *sqlparser.RegexpLikeExpr:
 		input, err := ast.translateExpr(call.Expr)
 		if err != nil {
@@ -1124,11 +1325,124 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			CallExpr: cexpr,
 			collate:  coll,
 		}, nil
+
+	case *sqlparser.PointExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.XCordinate, call.YCordinate})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinPoint{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "POINT",
+		}}, nil
+
+	case *sqlparser.GeomFromTextExpr:
+		if call.AxisOrderOpt != nil {
+			return nil, translateExprNotSupported(call)
+		}
+		args, err := ast.translateFuncArgs(spatialArgs(call.WktText, call.Srid))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinGeomFromText{
+			CallExpr: CallExpr{Arguments: args, Method: call.Type.ToString()},
+			expect:   geomFromTextType(call.Type),
+		}, nil
+
+	case *sqlparser.GeomFromWKBExpr:
+		if call.AxisOrderOpt != nil {
+			return nil, translateExprNotSupported(call)
+		}
+		args, err := ast.translateFuncArgs(spatialArgs(call.WkbBlob, call.Srid))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinGeomFromWKB{
+			CallExpr: CallExpr{Arguments: args, Method: call.Type.ToString()},
+			expect:   geomFromWKBType(call.Type),
+		}, nil
+
+	case *sqlparser.GeomFormatExpr:
+		if call.AxisOrderOpt != nil {
+			return nil, translateExprNotSupported(call)
+		}
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.Geom})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinGeomFormat{
+			CallExpr: CallExpr{Arguments: args, Method: call.FormatType.ToString()},
+			binary:   call.FormatType == sqlparser.BinaryFormat,
+			collate:  ast.cfg.Collation,
+		}, nil
+
+	case *sqlparser.PointPropertyFuncExpr:
+		if call.Property != sqlparser.XCordinate && call.Property != sqlparser.YCordinate {
+			return nil, translateExprNotSupported(call)
+		}
+		args, err := ast.translateFuncArgs(spatialArgs(call.Point, call.ValueToSet))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinPointCoordinate{
+			CallExpr: CallExpr{Arguments: args, Method: call.Property.ToString()},
+			y:        call.Property == sqlparser.YCordinate,
+		}, nil
 	default:
 		return nil, translateExprNotSupported(call)
 	}
 }
 
+// spatialArgs returns the arguments of a spatial function that has an optional second argument
+func spatialArgs(arg, optional sqlparser.Expr) []sqlparser.Expr {
+	if optional == nil {
+		return []sqlparser.Expr{arg}
+	}
+	return []sqlparser.Expr{arg, optional}
+}
+
+func geomFromTextType(t sqlparser.GeomFromWktType) geometry.Type {
+	switch t {
+	case sqlparser.PointFromText:
+		return geometry.Point
+	case sqlparser.LineStringFromText:
+		return geometry.LineString
+	case sqlparser.PolygonFromText:
+		return geometry.Polygon
+	case sqlparser.MultiPointFromText:
+		return geometry.MultiPoint
+	case sqlparser.MultiLinestringFromText:
+		return geometry.MultiLineString
+	case sqlparser.MultiPolygonFromText:
+		return geometry.MultiPolygon
+	case sqlparser.GeometryCollectionFromText:
+		return geometry.GeometryCollection
+	default:
+		return geometry.Any
+	}
+}
+
+func geomFromWKBType(t sqlparser.GeomFromWkbType) geometry.Type {
+	switch t {
+	case sqlparser.PointFromWKB:
+		return geometry.Point
+	case sqlparser.LineStringFromWKB:
+		return geometry.LineString
+	case sqlparser.PolygonFromWKB:
+		return geometry.Polygon
+	case sqlparser.MultiPointFromWKB:
+		return geometry.MultiPoint
+	case sqlparser.MultiLinestringFromWKB:
+		return geometry.MultiLineString
+	case sqlparser.MultiPolygonFromWKB:
+		return geometry.MultiPolygon
+	case sqlparser.GeometryCollectionFromWKB:
+		return geometry.GeometryCollection
+	default:
+		return geometry.Any
+	}
+}
+
 func builtinJSONExtractUnquoteRewrite(left IR, right IR) (IR, error) {
 	extract, err := builtinJSONExtractRewrite(left, right)
 	if err != nil {
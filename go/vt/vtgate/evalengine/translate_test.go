@@ -386,6 +386,18 @@ func TestTranslationFailures(t *testing.T) {
 		}, {
 			expression:  "cast('3.4' as FLOAT(3))",
 			expectedErr: "Unsupported type conversion: FLOAT(3)",
+		}, {
+			expression:  "format(1234.5, 2, 'fr_FR')",
+			expectedErr: "expr cannot be translated, not supported: format(1234.5, 2, 'fr_FR')",
+		}, {
+			expression:  "format(1234.5, 2, :locale)",
+			expectedErr: "expr cannot be translated, not supported: format(1234.5, 2, :locale)",
+		}, {
+			expression:  "ST_Contains(ST_GeomFromText('POLYGON((0 0,0 10,10 10,10 0,0 0))', 4326), :point)",
+			expectedErr: "expr cannot be translated, not supported: ST_Contains(st_geometryfromtext('POLYGON((0 0,0 10,10 10,10 0,0 0))', 4326), :point)",
+		}, {
+			expression:  "MBRWithin(:point, ST_GeomFromText('POLYGON((0 0,0 10,10 10,10 0,0 0))', 4326))",
+			expectedErr: "expr cannot be translated, not supported: MBRWithin(:point, st_geometryfromtext('POLYGON((0 0,0 10,10 10,10 0,0 0))', 4326))",
 		},
 	}
 
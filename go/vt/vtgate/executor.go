@@ -50,6 +50,7 @@ import (
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
@@ -117,6 +118,9 @@ type (
 		AllowScatter        bool
 		WarmingReadsPercent int
 		QueryLogToFile      string
+		// ResultCache caches the results of the queries on the tables that have
+		// a result cache TTL in the vschema. The results are not cached if nil.
+		ResultCache *ResultCache
 	}
 
 	Executor struct {
@@ -470,6 +474,12 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 			bindVars[sqlparser.FoundRowsName] = sqltypes.Int64BindVariable(int64(session.FoundRows))
 		case sqlparser.RowCountName:
 			bindVars[sqlparser.RowCountName] = sqltypes.Int64BindVariable(session.RowCount)
+		case sqlparser.TenantIDName:
+			tenantID := session.GetTenantID()
+			if tenantID == "" {
+				return vterrors.VT09033()
+			}
+			bindVars[sqlparser.TenantIDName] = sqltypes.StringBindVariable(tenantID)
 		}
 	}
 
@@ -480,6 +490,8 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 			bindVars[key] = sqltypes.BoolBindVariable(session.Autocommit)
 		case sysvars.QueryTimeout.Name:
 			bindVars[key] = sqltypes.Int64BindVariable(session.GetQueryTimeout())
+		case sysvars.CTEMaxRecursionDepth.Name:
+			bindVars[key] = sqltypes.Int64BindVariable(session.GetCTEMaxRecursionDepth())
 		case sysvars.ClientFoundRows.Name:
 			var v bool
 			ifOptionsExist(session, func(options *querypb.ExecuteOptions) {
@@ -514,6 +526,8 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 			bindVars[key] = sqltypes.StringBindVariable(session.DDLStrategy)
 		case sysvars.MigrationContext.Name:
 			bindVars[key] = sqltypes.StringBindVariable(session.MigrationContext)
+		case sysvars.TenantID.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.TenantId)
 		case sysvars.SessionUUID.Name:
 			bindVars[key] = sqltypes.StringBindVariable(session.SessionUUID)
 		case sysvars.SessionEnableSystemSettings.Name:
@@ -583,6 +597,30 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 	return nil
 }
 
+// checkTenantValues checks that the values the plan gives to the tenant columns
+// are the tenant id of the session. A query that gives values to tenant columns
+// fails when the session has no tenant id.
+func checkTenantValues(ctx context.Context, vcursor *econtext.VCursorImpl, plan *engine.Plan, bindVars map[string]*querypb.BindVariable, session *econtext.SafeSession) error {
+	if len(plan.TenantValues) == 0 {
+		return nil
+	}
+	tenantID := session.GetTenantID()
+	if tenantID == "" {
+		return vterrors.VT09033()
+	}
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	for _, expr := range plan.TenantValues {
+		result, err := env.Evaluate(expr)
+		if err != nil {
+			return err
+		}
+		if value := result.Value(vcursor.ConnCollation()).ToString(); value != tenantID {
+			return vterrors.VT09034(value, tenantID)
+		}
+	}
+	return nil
+}
+
 func ifOptionsExist(session *econtext.SafeSession, f func(*querypb.ExecuteOptions)) {
 	options := session.GetOptions()
 	if options != nil {
@@ -1063,6 +1101,58 @@ func (e *Executor) MessageStream(ctx context.Context, keyspace string, shard str
 	return formatError(err)
 }
 
+// MessageAck acks the messages of a message table, or changes their visibility
+// timeout if visibilityTimeout is set. In a sharded keyspace, the ids are routed
+// with the primary vindex of the table, which must be on its id column.
+func (e *Executor) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	vschema := e.VSchema()
+	if vschema == nil {
+		return 0, vterrors.VT13001("vschema not initialized")
+	}
+	table, err := vschema.FindTable(keyspace, name)
+	if err != nil {
+		return 0, err
+	}
+
+	var rss []*srvtopo.ResolvedShard
+	var rssValues [][]*querypb.Value
+	if table.Keyspace.Sharded {
+		if len(table.ColumnVindexes) == 0 || len(table.ColumnVindexes[0].Columns) != 1 || !table.ColumnVindexes[0].Columns[0].EqualString("id") {
+			return 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "message table %s must have its primary vindex on the id column", name)
+		}
+		mapper, ok := table.ColumnVindexes[0].Vindex.(vindexes.SingleColumn)
+		if !ok {
+			return 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "primary vindex of message table %s is not a single column vindex", name)
+		}
+		values := make([]sqltypes.Value, 0, len(ids))
+		for _, id := range ids {
+			values = append(values, sqltypes.ProtoToValue(id))
+		}
+		safeSession := econtext.NewSafeSession(&vtgatepb.Session{TargetString: table.Keyspace.Name})
+		logStats := logstats.NewLogStats(ctx, "MessageAck", "", safeSession.GetSessionUUID(), nil, streamlog.GetQueryLogConfig())
+		vcursor, err := econtext.NewVCursorImpl(safeSession, sqlparser.MarginComments{}, e, logStats, e.vm, vschema, e.resolver.resolver, e.serv, nullResultsObserver{}, e.vConfig)
+		if err != nil {
+			return 0, err
+		}
+		destinations, err := mapper.Map(ctx, vcursor, values)
+		if err != nil {
+			return 0, err
+		}
+		rss, rssValues, err = e.resolver.resolver.ResolveDestinations(ctx, table.Keyspace.Name, topodatapb.TabletType_PRIMARY, ids, destinations)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		// All the ids are in the only shard of the keyspace.
+		rss, err = e.resolver.resolver.ResolveDestination(ctx, table.Keyspace.Name, topodatapb.TabletType_PRIMARY, key.DestinationAnyShard{})
+		if err != nil {
+			return 0, err
+		}
+		rssValues = [][]*querypb.Value{ids}
+	}
+	return e.scatterConn.MessageAck(ctx, rss, rssValues, table.Name.String(), visibilityTimeout)
+}
+
 // VSchema returns the VSchema.
 func (e *Executor) VSchema() *vindexes.VSchema {
 	e.mu.Lock()
@@ -1076,6 +1166,9 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	defer e.mu.Unlock()
 	if vschema != nil {
 		e.vschema = vschema
+		if e.config.ResultCache != nil {
+			e.config.ResultCache.SetVSchema(vschema)
+		}
 	}
 	e.vschemaStats = stats
 	e.ClearPlans()
@@ -1695,6 +1788,9 @@ func (e *Executor) Close() {
 	}
 	topo.Close()
 	e.plans.Close()
+	if e.config.ResultCache != nil {
+		e.config.ResultCache.Close()
+	}
 }
 
 func (e *Executor) Environment() *vtenv.Environment {
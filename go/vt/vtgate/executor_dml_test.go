@@ -3144,6 +3144,45 @@ func TestDeleteMultiTable(t *testing.T) {
 	testQueryLog(t, executor, logChan, "TestExecute", "DELETE", "delete `user` from `user` join music on `user`.col = music.col where music.user_id = 1", 18)
 }
 
+// TestDeleteOrderByLimitMultiShard tests that the rows to delete are selected with a merge sort
+// across the shards, and that the delete is only sent to the shards owning these rows.
+func TestDeleteOrderByLimitMultiShard(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	executor.vschema.Keyspaces["TestExecutor"].Tables["user"].PrimaryKey = sqlparser.Columns{sqlparser.NewIdentifierCI("id")}
+
+	fields := sqltypes.MakeTestFields("id|col|weight_string(col)", "int64|int64|varbinary")
+	sbc1.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(fields, "1|20|20")})
+	sbc2.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(fields, "3|10|10")})
+
+	session := &vtgatepb.Session{TargetString: "@primary"}
+	_, err := executorExec(ctx, executor, session, "delete from user where id in (1, 3) order by col limit 1", nil)
+	require.NoError(t, err)
+
+	selectQuery := func(id int64) *querypb.BoundQuery {
+		return &querypb.BoundQuery{
+			Sql: "select `user`.id, col, weight_string(col) from `user` where id in ::__vals order by col asc limit :__upper_limit",
+			BindVariables: map[string]*querypb.BindVariable{
+				"__upper_limit": sqltypes.Int64BindVariable(1),
+				"__vals":        sqltypes.TestBindVariable([]any{id}),
+			},
+		}
+	}
+	assertQueries(t, sbc1, []*querypb.BoundQuery{selectQuery(1)})
+
+	// only the row with the lowest col is deleted, which lives on the second shard
+	dmlVals := &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{sqltypes.ValueToProto(sqltypes.NewInt64(3))}}
+	assertQueries(t, sbc2, []*querypb.BoundQuery{
+		selectQuery(3),
+		{
+			Sql:           "select Id, `name` from `user` where `user`.id in ::dml_vals for update",
+			BindVariables: map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(1), "dml_vals": dmlVals},
+		}, {
+			Sql:           "delete from `user` where `user`.id in ::dml_vals",
+			BindVariables: map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(1), "__vals": dmlVals, "dml_vals": dmlVals},
+		},
+	})
+}
+
 // TestSessionRowsAffected test that rowsAffected is set correctly for each shard session.
 func TestSessionRowsAffected(t *testing.T) {
 	executor, _, sbc4060, _, ctx := createExecutorEnv(t)
@@ -42,6 +42,7 @@ import (
 	"vitess.io/vitess/go/vt/discovery"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -444,6 +445,25 @@ func TestSetSystemVariablesWithSetVarInvalidSQLMode(t *testing.T) {
 	require.Equal(t, "''", session.SystemVariables["sql_mode"])
 }
 
+func TestSetCTEMaxRecursionDepthWithSetVar(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
+
+	session := econtext.NewAutocommitSession(&vtgatepb.Session{EnableSystemSettings: true, SystemVariables: map[string]string{}})
+
+	// cte_max_recursion_depth is kept by vtgate, so setting it does not reserve a connection
+	_, err := executor.Execute(context.Background(), nil, "TestSetStmt", session, "set @@cte_max_recursion_depth = 10", map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.False(t, session.InReservedConn())
+	require.EqualValues(t, 10, session.GetCTEMaxRecursionDepth())
+
+	_, err = executor.Execute(context.Background(), nil, "TestSelect", session, "select age from user where id = 1", map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	wantQueries := []*querypb.BoundQuery{
+		{Sql: "select /*+ SET_VAR(cte_max_recursion_depth = 10) */ age from `user` where id = :id /* INT64 */", BindVariables: map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(1)}},
+	}
+	utils.MustMatch(t, wantQueries, sbc1.Queries)
+}
+
 func TestSelectVindexFunc(t *testing.T) {
 	executor, _, _, _, _ := createExecutorEnv(t)
 
@@ -4528,3 +4548,80 @@ func BenchmarkSelectMirror(b *testing.B) {
 		})
 	}
 }
+
+func TestSelectTenantPredicate(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	executor.vschema.Keyspaces[KsTestSharded].MultiTenantSpec = &vschemapb.MultiTenantSpec{
+		TenantIdColumnName:  "id",
+		TenantPredicateMode: vschemapb.MultiTenantSpec_inject,
+	}
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	// The tenant predicate needs the tenant of the session.
+	sql := "select id from `user` where intcol = 1"
+	_, err := executorExecSession(ctx, executor, session, sql, nil)
+	require.ErrorContains(t, err, "VT09033: tenant_id is not set")
+
+	_, err = executorExecSession(ctx, executor, session, "set @@tenant_id = 1", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	wantQueries := []*querypb.BoundQuery{{
+		Sql:           "select id from `user` where intcol = 1 and id = :__vttenantid",
+		BindVariables: map[string]*querypb.BindVariable{"__vttenantid": sqltypes.StringBindVariable("1")},
+	}}
+	utils.MustMatch(t, wantQueries, sbc1.Queries)
+	assert.Empty(t, sbc2.Queries)
+}
+
+func TestSelectTenantPredicateWithoutTenant(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	executor.vschema.Keyspaces[KsTestSharded].MultiTenantSpec = &vschemapb.MultiTenantSpec{
+		TenantIdColumnName:  "id",
+		TenantPredicateMode: vschemapb.MultiTenantSpec_reject,
+	}
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	// A tenant predicate is not enough when the session has no tenant.
+	_, err := executorExecSession(ctx, executor, session, "select id from `user` where id = 1", nil)
+	require.ErrorContains(t, err, "VT09033: tenant_id is not set")
+	_, err = executorExecSession(ctx, executor, session, "insert into `user`(id, name) values (1, 'a')", nil)
+	require.ErrorContains(t, err, "VT09033: tenant_id is not set")
+	assert.Empty(t, sbc1.Queries)
+	assert.Empty(t, sbc2.Queries)
+
+	_, err = executorExecSession(ctx, executor, session, "set @@tenant_id = 1", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from `user` where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Queries, 1)
+	assert.Empty(t, sbc2.Queries)
+}
+
+func TestSelectTenantPredicateMismatch(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	executor.vschema.Keyspaces[KsTestSharded].MultiTenantSpec = &vschemapb.MultiTenantSpec{
+		TenantIdColumnName:  "id",
+		TenantPredicateMode: vschemapb.MultiTenantSpec_inject,
+	}
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	_, err := executorExecSession(ctx, executor, session, "set @@tenant_id = 1", nil)
+	require.NoError(t, err)
+
+	// The tenant column can only be compared to the tenant of the session.
+	_, err = executorExecSession(ctx, executor, session, "select id from `user` where id = 2", nil)
+	require.ErrorContains(t, err, "VT09034: tenant column value '2' does not match the tenant_id '1' of the session")
+	_, err = executorExecSession(ctx, executor, session, "select id from `user` where id = :id", map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(2)})
+	require.ErrorContains(t, err, "VT09034")
+	_, err = executorExecSession(ctx, executor, session, "insert into `user`(id, name) values (2, 'a')", nil)
+	require.ErrorContains(t, err, "VT09034")
+	_, err = executorExecSession(ctx, executor, session, "insert into `user`(id, name) select 2, name from `user` where id = 1", nil)
+	require.ErrorContains(t, err, "VT09034: tenant column value '2' does not match the tenant_id '1' of the session")
+	assert.Empty(t, sbc1.Queries)
+	assert.Empty(t, sbc2.Queries)
+
+	_, err = executorExecSession(ctx, executor, session, "select id from `user` where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Queries, 1)
+	assert.Empty(t, sbc2.Queries)
+}
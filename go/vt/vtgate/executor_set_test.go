@@ -264,6 +264,24 @@ func TestExecutorSet(t *testing.T) {
 	}, {
 		in:  "set @@query_timeout = 50, query_timeout = 75",
 		out: &vtgatepb.Session{Autocommit: true, QueryTimeout: 75},
+	}, {
+		in:  "set @@tenant_id = 42",
+		out: &vtgatepb.Session{Autocommit: true, TenantId: "42"},
+	}, {
+		in:  "set tenant_id = 'acme'",
+		out: &vtgatepb.Session{Autocommit: true, TenantId: "acme"},
+	}, {
+		in:  "set tenant_id = null",
+		out: &vtgatepb.Session{Autocommit: true},
+	}, {
+		in:  "set tenant_id = 4.2",
+		err: "incorrect argument type to variable 'tenant_id': DECIMAL",
+	}, {
+		in:  "set @@cte_max_recursion_depth = 5000",
+		out: &vtgatepb.Session{Autocommit: true, SystemVariables: map[string]string{"cte_max_recursion_depth": "5000"}},
+	}, {
+		in:  "set cte_max_recursion_depth = -1",
+		err: "variable 'cte_max_recursion_depth' can't be set to the value of '-1'",
 	}}
 	for i, tcase := range testcases {
 		t.Run(fmt.Sprintf("%d-%s", i, tcase.in), func(t *testing.T) {
@@ -55,6 +55,7 @@ import (
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtgate/buffer"
@@ -3035,6 +3036,38 @@ func TestExecutorKillStmt(t *testing.T) {
 	}
 }
 
+func TestExecutorMessageAck(t *testing.T) {
+	executor, sbc1, sbc2, sbclookup, ctx := createExecutorEnv(t)
+	visibilityTimeout := &vttimepb.Duration{Seconds: 30}
+
+	// In a sharded keyspace, the ids are routed with the primary vindex.
+	ids := []*querypb.Value{
+		sqltypes.ValueToProto(sqltypes.NewInt64(1)),
+		sqltypes.ValueToProto(sqltypes.NewInt64(3)),
+	}
+	count, err := executor.MessageAck(ctx, KsTestSharded, "user", ids, visibilityTimeout)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	utils.MustMatch(t, ids[:1], sbc1.MessageIDs)
+	utils.MustMatch(t, visibilityTimeout, sbc1.MessageVisibilityTimeout)
+	utils.MustMatch(t, ids[1:], sbc2.MessageIDs)
+	utils.MustMatch(t, visibilityTimeout, sbc2.MessageVisibilityTimeout)
+
+	// In an unsharded keyspace, all the ids go to its only shard.
+	count, err = executor.MessageAck(ctx, KsTestUnsharded, "user_msgs", ids, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	utils.MustMatch(t, ids, sbclookup.MessageIDs)
+	assert.Nil(t, sbclookup.MessageVisibilityTimeout)
+
+	// The ids cannot be routed if the primary vindex is not on the id column.
+	_, err = executor.MessageAck(ctx, KsTestSharded, "sharded_user_msgs", ids, nil)
+	require.EqualError(t, err, "message table sharded_user_msgs must have its primary vindex on the id column")
+
+	_, err = executor.MessageAck(ctx, KsTestSharded, "unknown", ids, nil)
+	require.ErrorContains(t, err, "table unknown not found")
+}
+
 type fakeMysqlConnection struct {
 	ErrMsg string
 	Log    []string
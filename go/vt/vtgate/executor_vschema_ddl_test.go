@@ -273,6 +273,70 @@ func TestPlanExecutorDropVindexDDL(t *testing.T) {
 	}
 }
 
+func TestPlanExecutorAlterVindexDDL(t *testing.T) {
+	vschemaacl.AuthorizedDDLUsers.Set(vschemaacl.NewAuthorizedDDLUsers("%"))
+	defer func() {
+		vschemaacl.AuthorizedDDLUsers.Set(vschemaacl.NewAuthorizedDDLUsers(""))
+	}()
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	ks := "TestExecutor"
+
+	vschemaUpdates := make(chan *vschemapb.SrvVSchema, 4)
+	executor.serv.WatchSrvVSchema(ctx, "aa", func(vschema *vschemapb.SrvVSchema, err error) bool {
+		vschemaUpdates <- vschema
+		return true
+	})
+	<-vschemaUpdates
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: ks})
+	stmt := "alter vschema alter vindex test_vindex with split_points=`2024-01-01=,2024-07-01=80`"
+	_, err := executorExecSession(ctx, executor, session, stmt, nil)
+	require.EqualError(t, err, "vindex test_vindex does not exists in keyspace TestExecutor")
+
+	stmt = "alter vschema create vindex test_vindex using `range` with split_points=`2024-01-01=,2024-07-01=80`, type=datetime"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.NoError(t, err)
+	_, vindex := waitForVindex(t, ks, "test_vindex", vschemaUpdates, executor)
+	assert.Equal(t, map[string]string{"split_points": "2024-01-01=,2024-07-01=80", "type": "datetime"}, vindex.Params)
+
+	// changes to the mapping of existing values are rejected
+	stmt = "alter vschema alter vindex test_vindex with split_points=`2024-01-01=,2024-07-01=40`"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.EqualError(t, err, "cannot change the split points of vindex test_vindex in keyspace TestExecutor: Range: new split points can only be added after the last one")
+
+	stmt = "alter vschema alter vindex test_vindex with type=int"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.EqualError(t, err, "cannot change param type of vindex test_vindex in keyspace TestExecutor, as it would change the keyspace ids of existing rows")
+
+	stmt = "alter vschema alter vindex test_vindex with split_points=`2024-01-01=,2024-07-01=80,2024-03-01=c0`"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.ErrorContains(t, err, "invalid params for vindex test_vindex in keyspace TestExecutor")
+
+	// so are the split points that existing rows may be at or above
+	stmt = "alter vschema alter vindex test_vindex with split_points=`2024-01-01=,2024-07-01=80,2025-01-01=c0`"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.ErrorContains(t, err, "cannot change the split points of vindex test_vindex in keyspace TestExecutor: Range: cannot add split point \"2025-01-01\", as it is not in the future")
+
+	// only the given params are changed
+	stmt = "alter vschema alter vindex test_vindex with split_points=`2024-01-01=,2024-07-01=80,9999-01-01=c0`"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.NoError(t, err)
+	vschema := <-vschemaUpdates
+	vindex = vschema.Keyspaces[ks].Vindexes["test_vindex"]
+	assert.Equal(t, "range", vindex.Type)
+	assert.Equal(t, map[string]string{"split_points": "2024-01-01=,2024-07-01=80,9999-01-01=c0", "type": "datetime"}, vindex.Params)
+
+	// split points cannot be added to the other types, as the existing values are not known
+	stmt = "alter vschema create vindex test_int_vindex using `range` with split_points=`0=,100=80`"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.NoError(t, err)
+	_, _ = waitForVindex(t, ks, "test_int_vindex", vschemaUpdates, executor)
+
+	stmt = "alter vschema alter vindex test_int_vindex with split_points=`0=,100=80,200=c0`"
+	_, err = executorExecSession(ctx, executor, session, stmt, nil)
+	require.ErrorContains(t, err, "cannot change the split points of vindex test_int_vindex in keyspace TestExecutor: Range: cannot add split point \"200\" to a vindex of type int")
+}
+
 func TestPlanExecutorAddDropVschemaTableDDL(t *testing.T) {
 	vschemaacl.AuthorizedDDLUsers.Set(vschemaacl.NewAuthorizedDDLUsers("%"))
 	defer func() {
@@ -19,12 +19,14 @@ package executorcontext
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/datetime"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -671,6 +673,24 @@ func (session *SafeSession) TimeZone() *time.Location {
 	return loc
 }
 
+// BlockEncryptionMode returns the block_encryption_mode stored in system_variables map in the session,
+// which is kept there whenever the session sets it, or the MySQL default if it has not been set.
+func (session *SafeSession) BlockEncryptionMode() string {
+	session.mu.Lock()
+	modeSQL, ok := session.SystemVariables[sysvars.BlockEncryptionMode]
+	session.mu.Unlock()
+
+	if !ok {
+		return config.DefaultBlockEncryptionMode
+	}
+
+	mode, err := sqltypes.DecodeStringSQL(modeSQL)
+	if err != nil {
+		return config.DefaultBlockEncryptionMode
+	}
+	return mode
+}
+
 // ForeignKeyChecks returns the foreign_key_checks stored in system_variables map in the session.
 func (session *SafeSession) ForeignKeyChecks() *bool {
 	session.mu.Lock()
@@ -848,6 +868,46 @@ func (session *SafeSession) GetMigrationContext() string {
 	return session.MigrationContext
 }
 
+// SetTenantID sets the tenant_id setting.
+func (session *SafeSession) SetTenantID(tenantID string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.TenantId = tenantID
+}
+
+// GetTenantID returns the tenant_id value.
+func (session *SafeSession) GetTenantID() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.TenantId
+}
+
+// SetCTEMaxRecursionDepth sets the cte_max_recursion_depth value. It is kept with the
+// system variables of the session, but is not sent to MySQL as a session setting.
+func (session *SafeSession) SetCTEMaxRecursionDepth(depth int64) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.SystemVariables == nil {
+		session.SystemVariables = make(map[string]string)
+	}
+	session.SystemVariables[sysvars.CTEMaxRecursionDepth.Name] = strconv.FormatInt(depth, 10)
+}
+
+// GetCTEMaxRecursionDepth returns the cte_max_recursion_depth value.
+func (session *SafeSession) GetCTEMaxRecursionDepth() int64 {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	value, ok := session.SystemVariables[sysvars.CTEMaxRecursionDepth.Name]
+	if !ok {
+		value = sysvars.CTEMaxRecursionDepth.Default
+	}
+	depth, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return depth
+}
+
 // GetSessionUUID returns the SessionUUID value.
 func (session *SafeSession) GetSessionUUID() string {
 	session.mu.Lock()
@@ -205,3 +205,28 @@ func TestTimeZone(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockEncryptionMode(t *testing.T) {
+	testCases := []struct {
+		mode string
+		want string
+	}{
+		{mode: "'aes-256-cbc'", want: "aes-256-cbc"},
+		{mode: "", want: "aes-128-ecb"},
+		{mode: "aes-256-cbc", want: "aes-128-ecb"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			sysvars := map[string]string{}
+			if tc.mode != "" {
+				sysvars["block_encryption_mode"] = tc.mode
+			}
+			session := NewSafeSession(&vtgatepb.Session{
+				SystemVariables: sysvars,
+			})
+
+			assert.Equal(t, tc.want, session.BlockEncryptionMode())
+		})
+	}
+}
@@ -248,6 +248,13 @@ func (vc *VCursorImpl) PrepareSetVarComment() string {
 			res = append(res, fmt.Sprintf("SET_VAR(%s = %s)", k, v))
 		}
 	})
+	// cte_max_recursion_depth is enforced by vtgate, and also by MySQL for the recursive CTEs it runs.
+	vc.SafeSession.mu.Lock()
+	depth, ok := vc.SafeSession.SystemVariables[sysvars.CTEMaxRecursionDepth.Name]
+	vc.SafeSession.mu.Unlock()
+	if ok {
+		res = append(res, fmt.Sprintf("SET_VAR(%s = %s)", sysvars.CTEMaxRecursionDepth.Name, depth))
+	}
 
 	return strings.Join(res, " ")
 }
@@ -364,6 +371,10 @@ func (vc *VCursorImpl) TimeZone() *time.Location {
 	return vc.SafeSession.TimeZone()
 }
 
+func (vc *VCursorImpl) BlockEncryptionMode() string {
+	return vc.SafeSession.BlockEncryptionMode()
+}
+
 func (vc *VCursorImpl) SQLMode() string {
 	// TODO: Implement return the current sql_mode.
 	// This is currently hardcoded to the default in MySQL 8.0.
@@ -1246,6 +1257,26 @@ func (vc *VCursorImpl) GetMigrationContext() string {
 	return vc.SafeSession.GetMigrationContext()
 }
 
+// SetTenantID implements the SessionActions interface
+func (vc *VCursorImpl) SetTenantID(tenantID string) {
+	vc.SafeSession.SetTenantID(tenantID)
+}
+
+// GetTenantID implements the SessionActions interface
+func (vc *VCursorImpl) GetTenantID() string {
+	return vc.SafeSession.GetTenantID()
+}
+
+// SetCTEMaxRecursionDepth implements the SessionActions interface
+func (vc *VCursorImpl) SetCTEMaxRecursionDepth(depth int64) {
+	vc.SafeSession.SetCTEMaxRecursionDepth(depth)
+}
+
+// GetCTEMaxRecursionDepth implements the SessionActions interface
+func (vc *VCursorImpl) GetCTEMaxRecursionDepth() int64 {
+	return vc.SafeSession.GetCTEMaxRecursionDepth()
+}
+
 // GetSessionUUID implements the SessionActions interface
 func (vc *VCursorImpl) GetSessionUUID() string {
 	return vc.SafeSession.GetSessionUUID()
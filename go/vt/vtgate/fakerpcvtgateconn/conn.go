@@ -33,6 +33,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 // queryExecute contains all the fields we use to test Execute
@@ -188,6 +189,11 @@ func (conn *FakeVTGateConn) CloseSession(ctx context.Context, session *vtgatepb.
 	panic("not implemented")
 }
 
+// MessageAck please see vtgateconn.Impl.MessageAck
+func (conn *FakeVTGateConn) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	panic("not implemented")
+}
+
 // VStream streams binlog events.
 func (conn *FakeVTGateConn) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
 	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
@@ -35,6 +35,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtgateservicepb "vitess.io/vitess/go/vt/proto/vtgateservice"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 var (
@@ -240,6 +241,21 @@ func (conn *vtgateConn) CloseSession(ctx context.Context, session *vtgatepb.Sess
 	return nil
 }
 
+func (conn *vtgateConn) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	request := &vtgatepb.MessageAckRequest{
+		CallerId:          callerid.EffectiveCallerIDFromContext(ctx),
+		Keyspace:          keyspace,
+		Name:              name,
+		Ids:               ids,
+		VisibilityTimeout: visibilityTimeout,
+	}
+	response, err := conn.c.MessageAck(ctx, request)
+	if err != nil {
+		return 0, vterrors.FromGRPC(err)
+	}
+	return response.Count, nil
+}
+
 type vstreamAdapter struct {
 	stream vtgateservicepb.Vitess_VStreamClient
 }
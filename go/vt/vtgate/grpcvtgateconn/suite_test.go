@@ -39,6 +39,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
@@ -236,6 +237,34 @@ func (f *fakeVTGateService) CloseSession(ctx context.Context, session *vtgatepb.
 	panic("unimplemented")
 }
 
+// MessageAck is part of the VTGateService interface
+func (f *fakeVTGateService) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	if f.hasError {
+		return 0, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "MessageAck")
+	if keyspace != messageAckKeyspace || name != messageAckName {
+		return 0, fmt.Errorf("no match for: %s.%s", keyspace, name)
+	}
+	if !proto.Equal(visibilityTimeout, messageAckVisibilityTimeout) {
+		f.t.Errorf("MessageAck visibility timeout: %v, want %v", visibilityTimeout, messageAckVisibilityTimeout)
+	}
+	if len(ids) != len(messageAckIDs) {
+		f.t.Errorf("MessageAck ids: %v, want %v", ids, messageAckIDs)
+		return 0, nil
+	}
+	for i := range ids {
+		if !proto.Equal(ids[i], messageAckIDs[i]) {
+			f.t.Errorf("MessageAck ids: %v, want %v", ids, messageAckIDs)
+			return 0, nil
+		}
+	}
+	return int64(len(ids)), nil
+}
+
 func (f *fakeVTGateService) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
 	panic("unimplemented")
 }
@@ -282,6 +311,7 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testStreamExecute(t, session)
 	testExecuteBatch(t, session)
 	testPrepare(t, session)
+	testMessageAck(t, conn)
 
 	// force a panic at every call, then test that works
 	fs.panics = true
@@ -289,6 +319,7 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testExecuteBatchPanic(t, session)
 	testStreamExecutePanic(t, session)
 	testPreparePanic(t, session)
+	testMessageAckPanic(t, conn)
 	fs.panics = false
 }
 
@@ -308,6 +339,7 @@ func RunErrorTests(t *testing.T, fakeServer vtgateservice.VTGateService) {
 	testExecuteBatchError(t, session, fs)
 	testStreamExecuteError(t, session, fs)
 	testPrepareError(t, session, fs)
+	testMessageAckError(t, conn)
 	fs.hasError = false
 }
 
@@ -514,6 +546,40 @@ func testPreparePanic(t *testing.T, session *vtgateconn.VTGateSession) {
 	expectPanic(t, err)
 }
 
+func testMessageAck(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	count, err := conn.MessageAck(ctx, messageAckKeyspace, messageAckName, messageAckIDs, messageAckVisibilityTimeout)
+	require.NoError(t, err)
+	require.EqualValues(t, len(messageAckIDs), count)
+
+	_, err = conn.MessageAck(ctx, messageAckKeyspace, "none", messageAckIDs, messageAckVisibilityTimeout)
+	require.EqualError(t, err, "no match for: connection_ks.none")
+}
+
+func testMessageAckError(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.MessageAck(ctx, messageAckKeyspace, messageAckName, messageAckIDs, messageAckVisibilityTimeout)
+	verifyError(t, err, "MessageAck")
+}
+
+func testMessageAckPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.MessageAck(ctx, messageAckKeyspace, messageAckName, messageAckIDs, messageAckVisibilityTimeout)
+	expectPanic(t, err)
+}
+
+const (
+	messageAckKeyspace = "connection_ks"
+	messageAckName     = "msg"
+)
+
+var messageAckIDs = []*querypb.Value{
+	sqltypes.ValueToProto(sqltypes.NewInt64(1)),
+	sqltypes.ValueToProto(sqltypes.NewInt64(2)),
+}
+
+var messageAckVisibilityTimeout = &vttimepb.Duration{Seconds: 30}
+
 var testCallerID = &vtrpcpb.CallerID{
 	Principal:    "test_principal",
 	Component:    "test_component",
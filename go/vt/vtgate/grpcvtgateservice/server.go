@@ -249,6 +249,20 @@ func (vtg *VTGate) CloseSession(ctx context.Context, request *vtgatepb.CloseSess
 	}, nil
 }
 
+// MessageAck is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) MessageAck(ctx context.Context, request *vtgatepb.MessageAckRequest) (response *vtgatepb.MessageAckResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+
+	count, vtgErr := vtg.server.MessageAck(ctx, request.Keyspace, request.Name, request.Ids, request.VisibilityTimeout)
+	if vtgErr != nil {
+		return nil, vterrors.ToGRPC(vtgErr)
+	}
+	return &vtgatepb.MessageAckResponse{
+		Count: count,
+	}, nil
+}
+
 // VStream is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) VStream(request *vtgatepb.VStreamRequest, stream vtgateservicepb.Vitess_VStreamServer) (err error) {
 	defer vtg.server.HandlePanic(&err)
@@ -154,6 +154,11 @@ func (e *Executor) newExecute(
 			logStats.Error = err
 			return err
 		}
+		err = checkTenantValues(ctx, vcursor, plan, bindVars, safeSession)
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
 
 		// Execute the plan.
 		if plan.Instructions.NeedsTransaction() {
@@ -165,6 +170,12 @@ func (e *Executor) newExecute(
 			err = execPlan(ctx, plan, vcursor, bindVars, execStart)
 		}
 
+		// The next queries of the session must see the changes of its autocommitted
+		// DML, which may not have come through the invalidation stream yet.
+		if e.config.ResultCache != nil && !safeSession.InTransaction() {
+			e.config.ResultCache.invalidateWrites(plan)
+		}
+
 		if err == nil || safeSession.InTransaction() {
 			return err
 		}
@@ -317,6 +328,17 @@ func (e *Executor) executePlan(
 	execStart time.Time,
 ) (*sqltypes.Result, error) {
 
+	// Answer the query from the result cache if possible.
+	var cacheQuery *resultCacheQuery
+	if e.config.ResultCache != nil {
+		var qr *sqltypes.Result
+		qr, cacheQuery = e.config.ResultCache.lookup(ctx, safeSession, vcursor.TabletType(), plan, bindVars)
+		if qr != nil {
+			e.setLogStats(logStats, plan, vcursor, execStart, nil, qr)
+			return qr, nil
+		}
+	}
+
 	// 4: Execute!
 	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
 
@@ -327,6 +349,9 @@ func (e *Executor) executePlan(
 	if err != nil {
 		return nil, e.rollbackExecIfNeeded(ctx, safeSession, bindVars, logStats, err)
 	}
+	if cacheQuery != nil {
+		e.config.ResultCache.store(cacheQuery, qr)
+	}
 	return qr, nil
 }
 
@@ -104,6 +104,14 @@ func TestBuilder(query string, vschema plancontext.VSchema, keyspace string) (*e
 
 // BuildFromStmt builds a plan based on the AST provided.
 func BuildFromStmt(ctx context.Context, query string, stmt sqlparser.Statement, reservedVars *sqlparser.ReservedVars, vschema plancontext.VSchema, bindVarNeeds *sqlparser.BindVarNeeds, cfg dynamicconfig.DDL) (*engine.Plan, error) {
+	if bindVarNeeds == nil {
+		bindVarNeeds = &sqlparser.BindVarNeeds{}
+	}
+	tenantValues, err := enforceTenantPredicates(stmt, vschema, bindVarNeeds)
+	if err != nil {
+		return nil, err
+	}
+	cacheable := resultCacheable(stmt)
 	planResult, err := createInstructionFor(ctx, query, stmt, reservedVars, vschema, cfg)
 	if err != nil {
 		return nil, err
@@ -115,7 +123,10 @@ func BuildFromStmt(ctx context.Context, query string, stmt sqlparser.Statement,
 		primitive = planResult.primitive
 		tablesUsed = planResult.tables
 	}
-	return engine.NewPlan(query, stmt, primitive, bindVarNeeds, tablesUsed), nil
+	plan := engine.NewPlan(query, stmt, primitive, bindVarNeeds, tablesUsed)
+	plan.Cacheable = cacheable
+	plan.TenantValues = tenantValues
+	return plan, nil
 }
 
 func getConfiguredPlanner(vschema plancontext.VSchema, stmt sqlparser.Statement, query string) (stmtPlanner, error) {
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// rewriteForCoveringLookup returns a copy of the given select that reads from the
+// lookup table of a covering vindex instead of from the table itself, if the
+// lookup table stores every column that the query uses, and the query filters on
+// every from column of the vindex with `=` or `IN`. Since the lookup table has one
+// row for every row of its owner table, the results are the same, but the query
+// is sent to the lookup keyspace only. It also returns the name of the owner table,
+// which must still be reported as used by the query. It returns nil if no covering
+// vindex can answer the query.
+//
+// The tablets of the lookup keyspace only see the lookup table, so their table ACLs
+// must not grant more access to the lookup table than the ones of the owner table do.
+func rewriteForCoveringLookup(sel *sqlparser.Select, vschema plancontext.VSchema) (*sqlparser.Select, string) {
+	if len(sel.From) != 1 || sel.Where == nil || sel.Lock != sqlparser.NoLock || sel.Into != nil || sel.With != nil {
+		return nil, ""
+	}
+	aliased, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok || aliased.Hints != nil || len(aliased.Partitions) != 0 || len(aliased.Columns) != 0 {
+		return nil, ""
+	}
+	tableName, ok := aliased.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil, ""
+	}
+	vtable, _, _, dest, err := vschema.FindTable(tableName)
+	if err != nil || vtable == nil || dest != nil {
+		return nil, ""
+	}
+
+	for _, colVindex := range vtable.ColumnVindexes {
+		if newSel := coveringLookupSelect(sel, aliased, tableName, colVindex, vschema); newSel != nil {
+			return newSel, singleTable(vtable.Keyspace.Name, vtable.Name.String())
+		}
+	}
+	return nil, ""
+}
+
+func coveringLookupSelect(sel *sqlparser.Select, aliased *sqlparser.AliasedTableExpr, tableName sqlparser.TableName, colVindex *vindexes.ColumnVindex, vschema plancontext.VSchema) *sqlparser.Select {
+	covering, ok := colVindex.Vindex.(vindexes.Covering)
+	if !ok || !colVindex.Owned || colVindex.IsBackfilling() {
+		return nil
+	}
+	table, fromColumns, coveringColumns := covering.CoveringTable()
+	columns := append(append([]string(nil), fromColumns...), coveringColumns...)
+	if len(columns) != len(colVindex.Columns) {
+		return nil
+	}
+	lookupKs, lookupTable, err := vschema.Environment().Parser().ParseTable(table)
+	if err != nil {
+		return nil
+	}
+	lookupTableName := sqlparser.NewTableNameWithQualifier(lookupTable, lookupKs)
+	if _, _, _, _, err := vschema.FindTable(lookupTableName); err != nil {
+		return nil
+	}
+
+	lookupColumns := make(map[string]sqlparser.IdentifierCI, len(columns))
+	for i, col := range colVindex.Columns {
+		lookupColumns[col.Lowered()] = sqlparser.NewIdentifierCI(columns[i])
+	}
+	alias := aliased.As
+	if alias.IsEmpty() {
+		alias = tableName.Name
+	}
+
+	// every column the query uses must be stored in the lookup table
+	for _, expr := range sel.GetColumns() {
+		if _, ok := expr.(*sqlparser.AliasedExpr); !ok {
+			return nil
+		}
+	}
+	covered := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			if !node.Qualifier.IsEmpty() && (!node.Qualifier.Qualifier.IsEmpty() || node.Qualifier.Name.String() != alias.String()) {
+				covered = false
+			} else if _, ok := lookupColumns[node.Name.Lowered()]; !ok {
+				covered = false
+			}
+		case *sqlparser.Subquery:
+			covered = false
+		}
+		return covered, nil
+	}, sel)
+	if !covered || !filtersOnColumns(sel.Where.Expr, colVindex.Columns[:len(fromColumns)]) {
+		return nil
+	}
+
+	newSel := sqlparser.CloneRefOfSelect(sel)
+	for _, expr := range newSel.GetColumns() {
+		// keep the names of the columns of the original table in the result
+		ae := expr.(*sqlparser.AliasedExpr)
+		if col, ok := ae.Expr.(*sqlparser.ColName); ok && ae.As.IsEmpty() && !col.Name.Equal(lookupColumns[col.Name.Lowered()]) {
+			ae.As = col.Name
+		}
+	}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			col.Name = lookupColumns[col.Name.Lowered()]
+		}
+		return true, nil
+	}, newSel)
+	newSel.From = []sqlparser.TableExpr{&sqlparser.AliasedTableExpr{
+		Expr: lookupTableName,
+		As:   alias,
+	}}
+	return newSel
+}
+
+// filtersOnColumns returns true if the predicate restricts each of the columns
+// to one or more values, with either `col = value` or `col IN (values)`.
+func filtersOnColumns(predicate sqlparser.Expr, columns []sqlparser.IdentifierCI) bool {
+	exprs := sqlparser.SplitAndExpression(nil, predicate)
+	for _, column := range columns {
+		if !filtersOnColumn(exprs, column) {
+			return false
+		}
+	}
+	return true
+}
+
+// filtersOnColumn returns true if one of the predicates restricts the column to
+// one or more values.
+func filtersOnColumn(predicates []sqlparser.Expr, column sqlparser.IdentifierCI) bool {
+	for _, expr := range predicates {
+		cmp, ok := expr.(*sqlparser.ComparisonExpr)
+		if !ok {
+			continue
+		}
+		col, ok := cmp.Left.(*sqlparser.ColName)
+		if !ok || !col.Name.Equal(column) {
+			continue
+		}
+		switch cmp.Operator {
+		case sqlparser.EqualOp:
+			if sqlparser.IsValue(cmp.Right) {
+				return true
+			}
+		case sqlparser.InOp:
+			if sqlparser.IsSimpleTuple(cmp.Right) {
+				return true
+			}
+		}
+	}
+	return false
+}
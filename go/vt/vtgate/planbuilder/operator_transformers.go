@@ -18,12 +18,14 @@ package planbuilder
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/sysvars"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -45,6 +47,8 @@ func transformToPrimitive(ctx *plancontext.PlanningContext, op operators.Operato
 		return transformUnionPlan(ctx, op)
 	case *operators.Vindex:
 		return transformVindexPlan(ctx, op)
+	case *operators.JSONTable:
+		return transformJSONTable(ctx, op)
 	case *operators.SubQuery:
 		return transformSubQuery(ctx, op)
 	case *operators.Filter:
@@ -61,6 +65,8 @@ func transformToPrimitive(ctx *plancontext.PlanningContext, op operators.Operato
 		return transformAggregator(ctx, op)
 	case *operators.Distinct:
 		return transformDistinct(ctx, op)
+	case *operators.Window:
+		return transformWindow(ctx, op)
 	case *operators.FkCascade:
 		return transformFkCascade(ctx, op)
 	case *operators.FkVerify:
@@ -272,11 +278,27 @@ func transformSubQuery(ctx *plancontext.PlanningContext, op *operators.SubQuery)
 		}, nil
 	}
 
-	return &engine.SemiJoin{
-		Left:  outer,
-		Right: inner,
-		Vars:  op.Vars,
-	}, nil
+	if op.PredicateWithOffsets == nil && !op.ValueColumn {
+		return &engine.SemiJoin{
+			Left:  outer,
+			Right: inner,
+			Vars:  op.Vars,
+		}, nil
+	}
+
+	cs := &engine.CorrelatedSubquery{
+		Opcode:         op.FilterType,
+		SubqueryResult: op.SubqueryValueName,
+		HasValues:      op.HasValuesName,
+		Vars:           op.Vars,
+		Subquery:       inner,
+		Outer:          outer,
+	}
+	if op.PredicateWithOffsets != nil {
+		cs.Predicate = op.PredicateWithOffsets
+		cs.ASTPredicate = ctx.SemTable.AndExpressions(op.FilterPredicates...)
+	}
+	return cs, nil
 }
 
 // transformFkVerify transforms a FkVerify operator into a engine primitive
@@ -310,9 +332,6 @@ func transformFkVerify(ctx *plancontext.PlanningContext, fkv *operators.FkVerify
 }
 
 func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggregator) (engine.Primitive, error) {
-	if op.WithRollup {
-		return nil, vterrors.VT12001("GROUP BY WITH ROLLUP not supported for sharded queries")
-	}
 	src, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
 		return nil, err
@@ -339,6 +358,20 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 		aggrParam.OrigOpcode = aggr.OriginalOpCode
 		aggrParam.WCol = aggr.WSOffset
 		aggrParam.Type = aggr.GetTypeCollation(ctx)
+		aggrParam.HashDistinct = aggr.HashDistinct
+		aggrParam.DistinctCols = aggr.DistinctOffsets
+		if gcFunc, isGc := aggrParam.Func.(*sqlparser.GroupConcatExpr); isGc {
+			for idx, order := range gcFunc.OrderBy {
+				typ, _ := ctx.TypeForExpr(operators.GroupConcatOrderExpr(gcFunc, order.Expr))
+				aggrParam.OrderBy = append(aggrParam.OrderBy, evalengine.OrderByParams{
+					Col:             aggr.OrderOffsets[idx],
+					WeightStringCol: aggr.OrderWSOffsets[idx],
+					Desc:            order.Direction == sqlparser.DescOrder,
+					Type:            typ,
+					CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
+				})
+			}
+		}
 		aggregates = append(aggregates, aggrParam)
 	}
 
@@ -361,6 +394,20 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 		}, nil
 	}
 
+	if op.WithRollup {
+		// the rollup needs the grouping columns, so the truncation is done after it
+		return &engine.Rollup{
+			Aggregates:  aggregates,
+			GroupByKeys: groupByKeys,
+			Input: &engine.OrderedAggregate{
+				Aggregates:  aggregates,
+				GroupByKeys: groupByKeys,
+				Input:       src,
+			},
+			TruncateColumnCount: op.ResultColumns,
+		}, nil
+	}
+
 	return &engine.OrderedAggregate{
 		Aggregates:          aggregates,
 		GroupByKeys:         groupByKeys,
@@ -369,6 +416,57 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 	}, nil
 }
 
+func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (engine.Primitive, error) {
+	src, err := transformToPrimitive(ctx, op.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &evalengine.Config{
+		Collation:   ctx.SemTable.Collation,
+		ResolveType: ctx.TypeForExpr,
+		Environment: ctx.VSchema.Environment(),
+	}
+	var funcs []*engine.WindowFuncParams
+	for _, wf := range op.Functions {
+		param := &engine.WindowFuncParams{
+			Opcode: wf.OpCode,
+			Col:    wf.ColOffset,
+			Offset: wf.Offset,
+			Alias:  wf.Original.ColumnName(),
+		}
+		if wf.Default != nil {
+			param.Default, err = evalengine.Translate(wf.Default, cfg)
+			if err != nil {
+				return nil, vterrors.Wrap(err, "unexpected expression in default value of window function")
+			}
+		}
+		funcs = append(funcs, param)
+	}
+
+	keys := func(in []operators.GroupBy) (out []*engine.GroupByParams) {
+		for _, key := range in {
+			typ, _ := ctx.TypeForExpr(key.Inner)
+			out = append(out, &engine.GroupByParams{
+				KeyCol:          key.ColOffset,
+				WeightStringCol: key.WSOffset,
+				Expr:            key.Inner,
+				Type:            typ,
+				CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
+			})
+		}
+		return out
+	}
+
+	return &engine.Window{
+		Functions:           funcs,
+		PartitionBy:         keys(op.PartitionBy),
+		OrderBy:             keys(op.OrderBy),
+		TruncateColumnCount: op.ResultColumns,
+		Input:               src,
+	}, nil
+}
+
 func transformDistinct(ctx *plancontext.PlanningContext, op *operators.Distinct) (engine.Primitive, error) {
 	src, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
@@ -763,6 +861,7 @@ func buildUpdatePrimitive(
 	return &engine.Update{
 		DML:                 edml,
 		ChangedVindexValues: upd.ChangedVindexValues,
+		MoveRows:            upd.MoveRows,
 	}, nil
 }
 
@@ -1005,19 +1104,63 @@ func transformVindexPlan(ctx *plancontext.PlanningContext, op *operators.Vindex)
 	return prim, nil
 }
 
-func transformRecurseCTE(ctx *plancontext.PlanningContext, op *operators.RecurseCTE) (engine.Primitive, error) {
-	seed, err := transformToPrimitive(ctx, op.Seed())
+func transformJSONTable(ctx *plancontext.PlanningContext, op *operators.JSONTable) (engine.Primitive, error) {
+	jt, err := evalengine.TranslateJSONTable(op.Expr, &evalengine.Config{
+		Collation:   ctx.SemTable.Collation,
+		ResolveType: ctx.TypeForExpr,
+		Environment: ctx.VSchema.Environment(),
+	})
 	if err != nil {
 		return nil, err
 	}
-	term, err := transformToPrimitive(ctx, op.Term())
+
+	fields := jt.Fields()
+	var cols []int
+	for _, col := range op.Columns {
+		colName, ok := col.Expr.(*sqlparser.ColName)
+		if !ok {
+			return nil, vterrors.VT13001(fmt.Sprintf("expected a JSON_TABLE column: %s", sqlparser.String(col.Expr)))
+		}
+		idx := slices.IndexFunc(fields, func(f *querypb.Field) bool {
+			return strings.EqualFold(f.Name, colName.Name.String())
+		})
+		if idx < 0 {
+			return nil, vterrors.VT13001(fmt.Sprintf("unknown JSON_TABLE column: %s", sqlparser.String(colName)))
+		}
+		cols = append(cols, idx)
+	}
+
+	return &engine.JSONTable{
+		JSONTable: jt,
+		Cols:      cols,
+		Doc:       sqlparser.String(op.Expr.Expr),
+		Path:      sqlparser.String(op.Expr.Filter),
+	}, nil
+}
+
+func transformRecurseCTE(ctx *plancontext.PlanningContext, op *operators.RecurseCTE) (engine.Primitive, error) {
+	seed, err := transformToPrimitive(ctx, op.Seed())
 	if err != nil {
 		return nil, err
 	}
+	var terms []engine.Primitive
+	for _, termOp := range op.Terms() {
+		term, err := transformToPrimitive(ctx, termOp)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	term := terms[0]
+	if len(terms) > 1 {
+		// every recursive query block is evaluated with the rows from the previous iteration
+		term = engine.NewConcatenate(terms, nil)
+	}
 	return &engine.RecurseCTE{
-		Seed: seed,
-		Term: term,
-		Vars: op.Vars,
+		Seed:     seed,
+		Term:     term,
+		Vars:     op.Vars,
+		Distinct: op.Distinct,
 	}, nil
 }
 
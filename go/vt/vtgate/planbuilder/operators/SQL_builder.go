@@ -52,6 +52,14 @@ func ToSQL(ctx *plancontext.PlanningContext, op Operator) (_ sqlparser.Statement
 
 	q := &queryBuilder{ctx: ctx}
 	buildQuery(op, q)
+	if q.stmt == nil {
+		q.stmt = &sqlparser.Select{}
+	}
+	if sel, ok := q.stmt.(*sqlparser.Select); ok && len(sel.From) == 0 {
+		// the recursive table of a CTE evaluated on the vtgate is not sent to mysql, but when it
+		// is on its own, e.g. on the LHS of an outer join, we still need a query returning a single row
+		q.addTable("", "dual", "", semantics.EmptyTableSet(), nil)
+	}
 	if ctx.SemTable != nil {
 		q.sortTables()
 	}
@@ -118,9 +126,13 @@ func (qb *queryBuilder) addTableExpr(
 }
 
 func (qb *queryBuilder) addPredicate(expr sqlparser.Expr) {
-	if jp, ok := expr.(*predicates.JoinPredicate); ok {
+	for {
 		// we have to strip out the join predicate containers,
 		// otherwise precedence calculations get messed up
+		jp, ok := expr.(*predicates.JoinPredicate)
+		if !ok {
+			break
+		}
 		expr = jp.Current()
 	}
 	if expr == nil {
@@ -251,11 +263,14 @@ func (qb *queryBuilder) unionWith(other *queryBuilder, distinct bool) {
 	}
 }
 
-func (qb *queryBuilder) recursiveCteWith(other *queryBuilder, name, alias string, distinct bool, columns sqlparser.Columns) {
-	cteUnion := &sqlparser.Union{
-		Left:     qb.stmt.(sqlparser.TableStatement),
-		Right:    other.stmt.(sqlparser.TableStatement),
-		Distinct: distinct,
+func (qb *queryBuilder) recursiveCteWith(terms []*queryBuilder, name, alias string, distinct bool, columns sqlparser.Columns) {
+	cteUnion := qb.stmt.(sqlparser.TableStatement)
+	for _, term := range terms {
+		cteUnion = &sqlparser.Union{
+			Left:     cteUnion,
+			Right:    term.stmt.(sqlparser.TableStatement),
+			Distinct: distinct,
+		}
 	}
 
 	qb.stmt = &sqlparser.Select{
@@ -439,6 +454,8 @@ func buildQuery(op Operator, qb *queryBuilder) {
 	switch op := op.(type) {
 	case *Table:
 		buildTable(op, qb)
+	case *JSONTable:
+		buildJSONTable(op, qb)
 	case *Projection:
 		buildProjection(op, qb)
 	case *ApplyJoin:
@@ -584,6 +601,17 @@ func buildTable(op *Table, qb *queryBuilder) {
 	}
 }
 
+func buildJSONTable(op *JSONTable, qb *queryBuilder) {
+	if qb.stmt == nil {
+		qb.stmt = &sqlparser.Select{}
+	}
+	stmt := qb.stmt.(FromStatement)
+	stmt.SetFrom(append(stmt.GetFrom(), op.Expr))
+	for _, col := range op.Columns {
+		qb.addProjection(col)
+	}
+}
+
 func buildProjection(op *Projection, qb *queryBuilder) {
 	buildQuery(op.Source, qb)
 
@@ -722,14 +750,18 @@ func buildHorizon(op *Horizon, qb *queryBuilder) {
 
 func buildRecursiveCTE(op *RecurseCTE, qb *queryBuilder) {
 	buildQuery(op.Seed(), qb)
-	qbR := &queryBuilder{ctx: qb.ctx}
-	buildQuery(op.Term(), qbR)
+	var terms []*queryBuilder
+	for _, term := range op.Terms() {
+		qbR := &queryBuilder{ctx: qb.ctx}
+		buildQuery(term, qbR)
+		terms = append(terms, qbR)
+	}
 	infoFor, err := qb.ctx.SemTable.TableInfoFor(op.OuterID)
 	if err != nil {
 		panic(err)
 	}
 
-	qb.recursiveCteWith(qbR, op.Def.Name, infoFor.GetAliasedTableExpr().As.String(), op.Distinct, op.Def.Columns)
+	qb.recursiveCteWith(terms, op.Def.Name, infoFor.GetAliasedTableExpr().As.String(), op.Distinct, op.Def.Columns)
 }
 
 func mergeHaving(h1, h2 *sqlparser.Where) *sqlparser.Where {
@@ -27,20 +27,13 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 )
 
-func errDistinctAggrWithMultiExpr(f sqlparser.AggrFunc) {
-	if f == nil {
-		panic(vterrors.VT12001("distinct aggregation function with multiple expressions"))
-	}
-	panic(vterrors.VT12001(fmt.Sprintf("distinct aggregation function with multiple expressions '%s'", sqlparser.String(f))))
-}
-
 func tryPushAggregator(ctx *plancontext.PlanningContext, aggregator *Aggregator) (output Operator, applyResult *ApplyResult) {
 	if aggregator.Pushed {
 		return aggregator, NoRewrite
 	}
 
 	// this rewrite is always valid, and we should do it whenever possible
-	if route, ok := aggregator.Source.(*Route); ok && (route.IsSingleShard() || overlappingUniqueVindex(ctx, aggregator.Grouping)) {
+	if route, ok := aggregator.Source.(*Route); ok && (route.IsSingleShard() || canPushGroupingUnderRoute(ctx, aggregator)) {
 		return Swap(aggregator, route, "push down aggregation under route - remove original")
 	}
 
@@ -55,6 +48,16 @@ func tryPushAggregator(ctx *plancontext.PlanningContext, aggregator *Aggregator)
 		return splitAvgAggregations(ctx, aggregator)
 	}
 
+	if aggregator.WithRollup {
+		checkRollupAggregations(aggregator)
+	}
+
+	if slices.ContainsFunc(aggregator.Aggregations, isOrderedGroupConcat) {
+		// GROUP_CONCAT with ORDER BY needs to see all the values of a group before ordering them,
+		// so we can't split it into partial aggregations. It is evaluated at the vtgate level instead
+		return aggregator, NoRewrite
+	}
+
 	switch src := aggregator.Source.(type) {
 	case *Route:
 		// if we have a single sharded route, we can push it down
@@ -169,9 +172,7 @@ func pushAggregationThroughRoute(
 
 // pushAggregations splits aggregations between the original aggregator and the one we are pushing down
 func pushAggregations(ctx *plancontext.PlanningContext, aggregator *Aggregator, aggrBelowRoute *Aggregator) {
-	canPushDistinctAggr, distinctExprs := checkIfWeCanPush(ctx, aggregator)
-
-	distinctAggrGroupByAdded := false
+	canPushDistinctAggr, distinctExprs, hashed := checkIfWeCanPush(ctx, aggregator)
 
 	for i, aggr := range aggregator.Aggregations {
 		if !aggr.Distinct || canPushDistinctAggr {
@@ -181,35 +182,40 @@ func pushAggregations(ctx *plancontext.PlanningContext, aggregator *Aggregator,
 			continue
 		}
 
-		if len(distinctExprs) != 1 {
-			errDistinctAggrWithMultiExpr(aggr.Func)
-		}
-
 		// We handle a distinct aggregation by turning it into a group by and
 		// doing the aggregating on the vtgate level instead
-		aeDistinctExpr := aeWrap(distinctExprs[0])
-		aggrBelowRoute.Columns[aggr.ColOffset] = aeDistinctExpr
-
-		// We handle a distinct aggregation by turning it into a group by and
-		// doing the aggregating on the vtgate level instead
-		// Adding to group by can be done only once even though there are multiple distinct aggregation with same expression.
-		if !distinctAggrGroupByAdded {
-			groupBy := NewGroupBy(distinctExprs[0])
-			groupBy.ColOffset = aggr.ColOffset
-			aggrBelowRoute.Grouping = append(aggrBelowRoute.Grouping, groupBy)
-			distinctAggrGroupByAdded = true
+		args := aggr.Func.GetArgs()
+		aggrBelowRoute.Columns[aggr.ColOffset] = aeWrap(args[0])
+		addDistinctGrouping(ctx, aggrBelowRoute, args[0], aggr.ColOffset)
+		for _, arg := range args[1:] {
+			addDistinctGrouping(ctx, aggrBelowRoute, arg, -1)
 		}
 	}
 
-	if !canPushDistinctAggr {
+	if !canPushDistinctAggr && !hashed {
 		aggregator.DistinctExpr = distinctExprs[0]
 	}
 }
 
-func checkIfWeCanPush(ctx *plancontext.PlanningContext, aggregator *Aggregator) (bool, []sqlparser.Expr) {
-	canPush := true
-	var distinctExprs []sqlparser.Expr
-	var differentExpr *sqlparser.AliasedExpr
+// addDistinctGrouping adds a grouping on an argument of a distinct aggregation, unless we are already grouping on it.
+// Multiple distinct aggregations can use the same expression, but the grouping should only be added once.
+func addDistinctGrouping(ctx *plancontext.PlanningContext, aggrBelowRoute *Aggregator, expr sqlparser.Expr, offset int) {
+	for _, gb := range aggrBelowRoute.Grouping {
+		if ctx.SemTable.EqualsExpr(gb.Inner, expr) {
+			return
+		}
+	}
+	groupBy := NewGroupBy(expr)
+	groupBy.ColOffset = offset
+	aggrBelowRoute.Grouping = append(aggrBelowRoute.Grouping, groupBy)
+}
+
+// checkIfWeCanPush checks if the distinct aggregations can be pushed down as they are.
+// It also returns all the distinct expressions used, and whether the aggregations
+// need hashing to find the distinct values. Sorting the input by the distinct expression
+// only works when all the distinct aggregations use the same single expression.
+func checkIfWeCanPush(ctx *plancontext.PlanningContext, aggregator *Aggregator) (canPush bool, distinctExprs []sqlparser.Expr, hashed bool) {
+	canPush = true
 
 	for _, aggr := range aggregator.Aggregations {
 		if !aggr.Distinct {
@@ -227,22 +233,24 @@ func checkIfWeCanPush(ctx *plancontext.PlanningContext, aggregator *Aggregator)
 		if !hasUniqVindex {
 			canPush = false
 		}
-		if len(distinctExprs) == 0 {
-			distinctExprs = args
+		if len(args) > 1 || aggr.OpCode == opcode.AggregateGroupConcat {
+			hashed = true
 		}
-		for idx, expr := range distinctExprs {
-			if !ctx.SemTable.EqualsExpr(expr, args[idx]) {
-				differentExpr = aggr.Original
-				break
+		for _, arg := range args {
+			if !slices.ContainsFunc(distinctExprs, func(expr sqlparser.Expr) bool {
+				return ctx.SemTable.EqualsExpr(expr, arg)
+			}) {
+				distinctExprs = append(distinctExprs, arg)
 			}
 		}
 	}
 
-	if !canPush && differentExpr != nil {
-		panic(vterrors.VT12001(fmt.Sprintf("only one DISTINCT aggregation is allowed in a SELECT: %s", sqlparser.String(differentExpr))))
-	}
+	return canPush, distinctExprs, hashed || len(distinctExprs) > 1
+}
 
-	return canPush, distinctExprs
+func isOrderedGroupConcat(aggr Aggr) bool {
+	gc, ok := aggr.Func.(*sqlparser.GroupConcatExpr)
+	return ok && len(gc.OrderBy) > 0
 }
 
 func pushAggregationThroughFilter(
@@ -294,6 +302,30 @@ func collectColNamesNeeded(ctx *plancontext.PlanningContext, f *Filter) (columns
 	return
 }
 
+// canPushGroupingUnderRoute returns true if the groups can be fully calculated on the shards
+func canPushGroupingUnderRoute(ctx *plancontext.PlanningContext, aggregator *Aggregator) bool {
+	if aggregator.WithRollup {
+		// even when every group lives on a single shard, the super-aggregate rows span all shards
+		return false
+	}
+	return overlappingUniqueVindex(ctx, aggregator.Grouping)
+}
+
+// checkRollupAggregations fails the planning if the aggregator has aggregations that can't be rolled up on vtgate.
+// The super-aggregate rows are calculated by combining the values of the groups, which can't be done for
+// DISTINCT aggregations, since the same value can show up in more than one group. The GROUPING() function
+// is not supported either, since the shards only see the groups and not the super-aggregate rows.
+func checkRollupAggregations(aggregator *Aggregator) {
+	if aggregator.QP != nil && aggregator.QP.hasGroupingFunc {
+		panic(vterrors.VT12001("GROUPING() with GROUP BY WITH ROLLUP on sharded queries"))
+	}
+	for _, aggr := range aggregator.Aggregations {
+		if aggr.Distinct {
+			panic(vterrors.VT12001(fmt.Sprintf("DISTINCT aggregation with GROUP BY WITH ROLLUP on sharded queries: %s", sqlparser.String(aggr.Original))))
+		}
+	}
+}
+
 func overlappingUniqueVindex(ctx *plancontext.PlanningContext, groupByExprs []GroupBy) bool {
 	for _, groupByExpr := range groupByExprs {
 		if exprHasUniqueVindex(ctx, groupByExpr.Inner) {
@@ -542,15 +574,15 @@ func splitAggrColumnsToLeftAndRight(
 		outerJoin:   leftJoin,
 	}
 
-	canPushDistinctAggr, distinctExprs := checkIfWeCanPush(ctx, aggregator)
+	canPushDistinctAggr, distinctExprs, hashed := checkIfWeCanPush(ctx, aggregator)
 
 	// Distinctable aggregation cannot be pushed down in the join.
-	// We keep node of the distinct aggregation expression to be used later for ordering.
+	// We keep node of the distinct aggregation expression to be used later for ordering,
+	// unless the distinct values will be found using hashing.
 	if !canPushDistinctAggr {
-		if len(distinctExprs) != 1 {
-			errDistinctAggrWithMultiExpr(nil)
+		if !hashed {
+			aggregator.DistinctExpr = distinctExprs[0]
 		}
-		aggregator.DistinctExpr = distinctExprs[0]
 		return nil, errAbortAggrPushing
 	}
 
@@ -611,13 +643,9 @@ func splitAvgAggregations(ctx *plancontext.PlanningContext, aggr *Aggregator) (O
 			continue
 		}
 
-		if avg.Distinct {
-			panic(vterrors.VT12001("AVG(distinct <>)"))
-		}
-
 		// We have an AVG that we need to split
-		sumExpr := &sqlparser.Sum{Arg: avg.Arg}
-		countExpr := &sqlparser.Count{Args: []sqlparser.Expr{avg.Arg}}
+		sumExpr := &sqlparser.Sum{Arg: avg.Arg, Distinct: avg.Distinct}
+		countExpr := &sqlparser.Count{Args: []sqlparser.Expr{avg.Arg}, Distinct: avg.Distinct}
 		calcExpr := &sqlparser.BinaryExpr{
 			Operator: sqlparser.DivOp,
 			Left:     sumExpr,
@@ -633,9 +661,13 @@ func splitAvgAggregations(ctx *plancontext.PlanningContext, aggr *Aggregator) (O
 			if offset == aggregation.ColOffset {
 				// We have found the AVG column. We'll change it to SUM, and then we add a COUNT as well
 				aggr.Aggregations[aggrOffset].OpCode = opcode.AggregateSum
+				if avg.Distinct {
+					aggr.Aggregations[aggrOffset].OpCode = opcode.AggregateSumDistinct
+				}
 
 				countExprAlias := aeWrap(countExpr)
-				countAggr := NewAggr(opcode.AggregateCount, countExpr, countExprAlias, sqlparser.String(countExpr))
+				countAggr := createAggrFromAggrFunc(countExpr, countExprAlias)
+				countAggr.Alias = sqlparser.String(countExpr)
 				countAggr.ColOffset = len(aggr.Columns) + len(columns)
 				aggregations = append(aggregations, countAggr)
 				columns = append(columns, countExprAlias)
@@ -19,6 +19,7 @@ package operators
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"vitess.io/vitess/go/slice"
@@ -427,9 +428,10 @@ func (aggr Aggr) getPushColumn() sqlparser.Expr {
 		}
 		return aggr.Func.GetArg()
 	default:
-		if len(aggr.Func.GetArgs()) > 1 {
+		if len(aggr.Func.GetArgs()) > 1 && !aggr.Distinct {
 			panic(vterrors.VT03001(sqlparser.String(aggr.Func)))
 		}
+		// the remaining arguments of a distinct aggregation are added by planEngineOffsets
 		return aggr.Func.GetArg()
 	}
 }
@@ -467,6 +469,50 @@ func (a *Aggregator) planOffsetsNotPushed(ctx *plancontext.PlanningContext) {
 	a.pushRemainingGroupingColumnsAndWeightStrings(ctx)
 }
 
+// planEngineOffsets adds the columns needed to evaluate the aggregations at the vtgate level,
+// apart from the column being aggregated. These are the remaining arguments of DISTINCT aggregations
+// that can't rely on the input being sorted by the distinct expression, and the GROUP_CONCAT ORDER BY expressions.
+func (a *Aggregator) planEngineOffsets(ctx *plancontext.PlanningContext) {
+	for idx, aggr := range a.Aggregations {
+		evaluatesDistinct := aggr.OpCode.IsDistinct() || aggr.OpCode == opcode.AggregateGroupConcat
+		if aggr.Distinct && evaluatesDistinct && !aggr.PushedDown && a.DistinctExpr == nil {
+			a.Aggregations[idx].HashDistinct = true
+			for _, arg := range aggr.Func.GetArgs()[1:] {
+				offset := a.internalAddColumn(ctx, aeWrap(arg), a.Pushed)
+				a.Aggregations[idx].DistinctOffsets = append(a.Aggregations[idx].DistinctOffsets, offset)
+			}
+		}
+
+		gc, isGc := aggr.Func.(*sqlparser.GroupConcatExpr)
+		if !isGc {
+			continue
+		}
+		for _, order := range gc.OrderBy {
+			expr := GroupConcatOrderExpr(gc, order.Expr)
+			offset := a.internalAddColumn(ctx, aeWrap(expr), false)
+			wsOffset := -1
+			if ctx.NeedsWeightString(expr) {
+				wsOffset = a.internalAddWSColumn(ctx, offset, aeWrap(weightStringFor(expr)))
+			}
+			a.Aggregations[idx].OrderOffsets = append(a.Aggregations[idx].OrderOffsets, offset)
+			a.Aggregations[idx].OrderWSOffsets = append(a.Aggregations[idx].OrderWSOffsets, wsOffset)
+		}
+	}
+}
+
+// GroupConcatOrderExpr returns the expression to order by. A number refers to a GROUP_CONCAT argument
+func GroupConcatOrderExpr(gc *sqlparser.GroupConcatExpr, expr sqlparser.Expr) sqlparser.Expr {
+	lit, isLit := expr.(*sqlparser.Literal)
+	if !isLit || lit.Type != sqlparser.IntVal {
+		return expr
+	}
+	num, err := strconv.Atoi(lit.Val)
+	if err != nil || num < 1 || num > len(gc.Exprs) {
+		panic(vterrors.VT03014(lit.Val, "group_concat"))
+	}
+	return gc.Exprs[num-1]
+}
+
 func (a *Aggregator) addIfAggregationColumn(ctx *plancontext.PlanningContext, colIdx int) int {
 	for _, aggr := range a.Aggregations {
 		if aggr.ColOffset != colIdx {
@@ -579,6 +625,8 @@ func (a *Aggregator) SplitAggregatorBelowOperators(ctx *plancontext.PlanningCont
 	newOp.Pushed = false
 	newOp.Original = false
 	newOp.DT = nil
+	// the super-aggregate rows are only produced by the original aggregator
+	newOp.WithRollup = false
 
 	// We need to make sure that the columns are cloned so that the original operator is not affected
 	// by the changes we make to the new operator
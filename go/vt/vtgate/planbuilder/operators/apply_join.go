@@ -50,6 +50,10 @@ type (
 		// these are needed by other operators further down the right hand side of the join
 		ExtraLHSVars []BindVarExpr
 
+		// Lateral is set when the RHS uses values from the LHS that are not part of the original query,
+		// such as a LATERAL derived table or a JSON_TABLE. The two sides can then not be merged into a single route
+		Lateral bool
+
 		// After offset planning
 
 		// Columns stores the column indexes of the columns coming from the left and right side
@@ -267,23 +271,14 @@ func (aj *ApplyJoin) AddWSColumn(ctx *plancontext.PlanningContext, offset int, u
 	}
 
 	i := aj.Columns[offset]
-	out := 0
 	if i < 0 {
-		out = aj.LHS.AddWSColumn(ctx, FromLeftOffset(i), underRoute)
-		out = ToLeftOffset(out)
+		out := aj.LHS.AddWSColumn(ctx, FromLeftOffset(i), underRoute)
 		aj.JoinColumns.addLeft(wsExpr)
+		aj.addOffset(ToLeftOffset(out))
 	} else {
-		out = aj.RHS.AddWSColumn(ctx, FromRightOffset(i), underRoute)
-		out = ToRightOffset(out)
+		out := aj.RHS.AddWSColumn(ctx, FromRightOffset(i), underRoute)
 		aj.JoinColumns.addRight(wsExpr)
-	}
-
-	if out >= 0 {
-		aj.addOffset(out)
-	} else {
-		col := aj.getJoinColumnFor(ctx, aeWrap(wsExpr), wsExpr, !ctx.ContainsAggr(wsExpr))
-		aj.JoinColumns.add(col)
-		aj.planOffsetFor(ctx, col)
+		aj.addOffset(ToRightOffset(out))
 	}
 
 	return len(aj.Columns) - 1
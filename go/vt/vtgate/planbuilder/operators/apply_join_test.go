@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/vschemawrapper"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+func TestApplyJoinAddWSColumn(t *testing.T) {
+	lcol, rcol := sqlparser.NewColName("lhs"), sqlparser.NewColName("rhs")
+	ctx := &plancontext.PlanningContext{
+		SemTable: semantics.EmptySemTable(),
+		VSchema: &vschemawrapper.VSchemaWrapper{
+			V:             &vindexes.VSchema{},
+			SysVarEnabled: true,
+			Env:           vtenv.NewTestEnv(),
+		},
+	}
+	lid := semantics.SingleTableSet(0)
+	rid := semantics.SingleTableSet(1)
+	ctx.SemTable.Recursive[lcol] = lid
+	ctx.SemTable.Recursive[rcol] = rid
+	lhs := &fakeOp{id: lid}
+	rhs := &fakeOp{id: rid}
+
+	aj := &ApplyJoin{
+		binaryOperator: newBinaryOp(lhs, rhs),
+		Vars:           map[string]int{},
+		JoinColumns:    &applyJoinColumns{},
+		JoinPredicates: &applyJoinColumns{},
+	}
+	aj.AddColumn(ctx, true, false, aeWrap(lcol))
+	aj.AddColumn(ctx, true, false, aeWrap(rcol))
+
+	// The weight string of a column from the LHS is fetched from the LHS
+	// once, and passed through the join as a single column.
+	assert.Equal(t, 2, aj.AddWSColumn(ctx, 0, false))
+	assert.Equal(t, 3, aj.AddWSColumn(ctx, 1, false))
+	assert.Equal(t, []int{-1, 1, -2, 2}, aj.Columns)
+	require.Len(t, aj.JoinColumns.columns, 4)
+	assert.Len(t, lhs.cols, 2)
+	assert.Len(t, rhs.cols, 2)
+
+	// Asking again returns the existing column.
+	assert.Equal(t, 2, aj.AddWSColumn(ctx, 0, false))
+	assert.Len(t, aj.Columns, 4)
+}
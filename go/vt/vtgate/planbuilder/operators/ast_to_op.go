@@ -18,6 +18,7 @@ package operators
 
 import (
 	"fmt"
+	"slices"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -66,6 +67,7 @@ func createOperatorFromSelect(ctx *plancontext.PlanningContext, sel *sqlparser.S
 	op := crossJoin(ctx, sel.From)
 
 	op = addWherePredicates(ctx, sel.GetWherePredicate(), op)
+	op, sel = pullOutGroupingSubqueries(ctx, sel, op)
 
 	if sel.Comments != nil || sel.Lock != sqlparser.NoLock {
 		op = newLockAndComment(op, sel.Comments, sel.Lock)
@@ -102,12 +104,52 @@ func addWherePredsToSubQueryBuilder(ctx *plancontext.PlanningContext, in sqlpars
 			expr = sqlparser.NewIntLiteral("0")
 		}
 
+		// if we are inside a CTE, we need to check if we depend on the recursion table
+		if cte := ctx.ActiveCTE(); cte != nil && ctx.SemTable.DirectDeps(expr).IsOverlapping(cte.Id) {
+			expr = addCTEPredicate(ctx, expr, cte)
+		}
 		op = op.AddPredicate(ctx, expr)
 		addColumnEquality(ctx, expr)
 	}
 	return op
 }
 
+// pullOutGroupingSubqueries evaluates the subqueries used in the GROUP BY before the query is run,
+// and returns a copy of the SELECT where the grouping expressions use their results as arguments.
+// An argument that is the whole grouping expression is wrapped in COALESCE, since the tablet inlines
+// its value, and MySQL reads an integer in the GROUP BY or ORDER BY as the position of a column.
+func pullOutGroupingSubqueries(ctx *plancontext.PlanningContext, sel *sqlparser.Select, op Operator) (Operator, *sqlparser.Select) {
+	if sel.GroupBy == nil {
+		return op, sel
+	}
+	sqc := &SubQueryBuilder{}
+	var exprs []sqlparser.Expr
+	for _, expr := range sel.GroupBy.Exprs {
+		if subq, _, _ := getSubQuery(expr); subq == nil {
+			exprs = append(exprs, expr)
+			continue
+		}
+		if sel.GroupBy.WithRollup {
+			panic(vterrors.VT12001("subqueries in GROUP BY with ROLLUP"))
+		}
+		expr = sqc.pullOutUncorrelatedSubqueries(ctx, expr, TableID(op), "GROUP BY")
+		if arg, ok := expr.(*sqlparser.Argument); ok {
+			expr = &sqlparser.FuncExpr{Name: sqlparser.NewIdentifierCI("coalesce"), Exprs: []sqlparser.Expr{arg}}
+		}
+		exprs = append(exprs, expr)
+	}
+
+	if len(sqc.Inner) == 0 {
+		return op, sel
+	}
+
+	// the original statement is shared with the rest of the planning, so we don't change it in place
+	newSel := *sel
+	newSel.GroupBy = &sqlparser.GroupBy{Exprs: exprs}
+	ctx.SemTable.CopySemanticInfo(sel, &newSel)
+	return sqc.getRootOperator(op, nil), &newSel
+}
+
 // cloneASTAndSemState clones the AST and the semantic state of the input node.
 func cloneASTAndSemState[T sqlparser.SQLNode](ctx *plancontext.PlanningContext, original T) T {
 	return sqlparser.CopyOnRewrite(original, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
@@ -242,6 +284,8 @@ func getOperatorFromTableExpr(ctx *plancontext.PlanningContext, tableExpr sqlpar
 		return getOperatorFromJoinTableExpr(ctx, tableExpr)
 	case *sqlparser.ParenTableExpr:
 		return crossJoin(ctx, tableExpr.Exprs)
+	case *sqlparser.JSONTableExpr:
+		return newJSONTable(ctx, tableExpr)
 	default:
 		panic(vterrors.VT13001(fmt.Sprintf("unable to use: %T table type", tableExpr)))
 	}
@@ -249,6 +293,9 @@ func getOperatorFromTableExpr(ctx *plancontext.PlanningContext, tableExpr sqlpar
 
 func getOperatorFromJoinTableExpr(ctx *plancontext.PlanningContext, tableExpr *sqlparser.JoinTableExpr) Operator {
 	lhs := getOperatorFromTableExpr(ctx, tableExpr.LeftExpr, false)
+	if lateral, cols := lateralColumns(ctx, tableExpr.RightExpr, TableID(lhs)); len(cols) > 0 {
+		return createLateralJoin(ctx, lhs, lateral, cols, tableExpr.Join, tableExpr.Condition.On)
+	}
 	rhs := getOperatorFromTableExpr(ctx, tableExpr.RightExpr, false)
 
 	switch tableExpr.Join {
@@ -318,21 +365,26 @@ func getOperatorFromAliasedTableExpr(ctx *plancontext.PlanningContext, tableExpr
 			tbl.Select.SetOrderBy(nil)
 		}
 
-		inner := translateQueryToOp(ctx, tbl.Select)
-		if horizon, ok := inner.(*Horizon); ok {
-			horizon.TableId = &tableID
-			horizon.Alias = tableExpr.As.String()
-			horizon.ColumnAliases = tableExpr.Columns
-			qp := CreateQPFromSelectStatement(ctx, tbl.Select)
-			horizon.QP = qp
-		}
-
-		return inner
+		return getOperatorFromDerivedTable(ctx, tableExpr, tableID, tbl.Select)
 	default:
 		panic(vterrors.VT13001(fmt.Sprintf("unable to use: %T", tbl)))
 	}
 }
 
+// getOperatorFromDerivedTable plans the given statement as the derived table of tableExpr
+func getOperatorFromDerivedTable(ctx *plancontext.PlanningContext, tableExpr *sqlparser.AliasedTableExpr, tableID semantics.TableSet, stmt sqlparser.TableStatement) Operator {
+	inner := translateQueryToOp(ctx, stmt)
+	if horizon, ok := inner.(*Horizon); ok {
+		horizon.TableId = &tableID
+		horizon.Alias = tableExpr.As.String()
+		horizon.ColumnAliases = tableExpr.Columns
+		qp := CreateQPFromSelectStatement(ctx, horizon.Query)
+		horizon.QP = qp
+	}
+
+	return inner
+}
+
 func createDualCTETable(ctx *plancontext.PlanningContext, tableID semantics.TableSet, tableInfo *semantics.CTETable) Operator {
 	vschemaTable, _, _, _, _, err := ctx.VSchema.FindTableOrVindex(sqlparser.NewTableName("dual"))
 	if err != nil {
@@ -352,40 +404,67 @@ func createRecursiveCTE(ctx *plancontext.PlanningContext, def *semantics.CTE, ou
 		panic(vterrors.VT13001("expected UNION in recursive CTE"))
 	}
 
-	seed := translateQueryToOp(ctx, union.Left)
+	seedStmt, termStmts := splitRecursiveUnion(def, union)
+	seed := translateQueryToOp(ctx, seedStmt)
 
 	// Push the CTE definition to the stack so that it can be used in the recursive part of the query
 	ctx.PushCTE(def, *def.IDForRecurse)
 
-	term := translateQueryToOp(ctx, union.Right)
-	horizon, ok := term.(*Horizon)
-	if !ok {
-		panic(vterrors.VT09027(def.Name))
+	var terms []Operator
+	var horizons []*Horizon
+	for _, termStmt := range termStmts {
+		term := translateQueryToOp(ctx, termStmt)
+		horizon, ok := term.(*Horizon)
+		if !ok {
+			panic(vterrors.VT09027(def.Name))
+		}
+		terms = append(terms, horizon.Source)
+		horizon.Source = nil // not sure about this
+		horizons = append(horizons, horizon)
 	}
-	term = horizon.Source
-	horizon.Source = nil // not sure about this
 	activeCTE, err := ctx.PopCTE()
 	if err != nil {
 		panic(err)
 	}
 
-	return newRecurse(def, seed, term, activeCTE.Predicates, horizon, idForRecursiveTable(ctx, def), outerID, union.Distinct)
+	return newRecurse(def, seed, terms, activeCTE.Predicates, horizons, *def.IDForRecurse, outerID, union.Distinct)
 }
 
-func idForRecursiveTable(ctx *plancontext.PlanningContext, def *semantics.CTE) semantics.TableSet {
-	for i, table := range ctx.SemTable.Tables {
-		tbl, ok := table.(*semantics.CTETable)
-		if !ok || tbl.CTE.Name != def.Name {
-			continue
+// splitRecursiveUnion splits the UNION of a recursive CTE into the seed, made up of the non-recursive
+// query blocks, and the recursive query blocks that follow it
+func splitRecursiveUnion(def *semantics.CTE, union *sqlparser.Union) (sqlparser.TableStatement, []sqlparser.TableStatement) {
+	var terms []sqlparser.TableStatement
+	var seed sqlparser.TableStatement = union
+	for {
+		u, ok := seed.(*sqlparser.Union)
+		if !ok {
+			break
+		}
+		sel, ok := u.Right.(*sqlparser.Select)
+		if !ok || !slices.Contains(def.RecursiveSelects, sel) {
+			break
 		}
-		return semantics.SingleTableSet(i)
+		if u.Distinct != union.Distinct {
+			panic(vterrors.VT12001("mixing UNION ALL and UNION DISTINCT in a recursive CTE"))
+		}
+		terms = append([]sqlparser.TableStatement{sel}, terms...)
+		seed = u.Left
+	}
+	if len(terms) != len(def.RecursiveSelects) {
+		panic(vterrors.VT12001("recursive query blocks that do not follow all the non-recursive query blocks"))
 	}
-	panic(vterrors.VT13001("recursive table not found"))
+	return seed, terms
 }
 
 func crossJoin(ctx *plancontext.PlanningContext, exprs sqlparser.TableExprs) Operator {
 	var output Operator
 	for _, tableExpr := range exprs {
+		if output != nil {
+			if lateral, cols := lateralColumns(ctx, tableExpr, TableID(output)); len(cols) > 0 {
+				output = createLateralJoin(ctx, output, lateral, cols, sqlparser.NormalJoinType, nil)
+				continue
+			}
+		}
 		op := getOperatorFromTableExpr(ctx, tableExpr, len(exprs) == 1)
 		if output == nil {
 			output = op
@@ -22,7 +22,12 @@ import (
 )
 
 func tryMergeRecurse(ctx *plancontext.PlanningContext, in *RecurseCTE) (Operator, *ApplyResult) {
-	op := tryMergeCTE(ctx, in.Seed(), in.Term(), in)
+	terms := in.Terms()
+	if len(terms) != 1 {
+		// CTEs with multiple recursive query blocks are always evaluated on the vtgate
+		return in, NoRewrite
+	}
+	op := tryMergeCTE(ctx, in.Seed(), terms[0], in)
 	if op == nil {
 		return in, NoRewrite
 	}
@@ -76,7 +81,7 @@ func tryMergeCTESharded(ctx *plancontext.PlanningContext, seed, term *Route, in
 
 func mergeCTE(ctx *plancontext.PlanningContext, seed, term *Route, r Routing, in *RecurseCTE) *Route {
 	in.Def.Merged = true
-	hz := in.Horizon
+	hz := in.Horizons[0]
 	hz.Source = term.Source
 	newTerm, _ := expandHorizon(ctx, hz)
 	for _, predicate := range in.Predicates {
@@ -86,12 +91,12 @@ func mergeCTE(ctx *plancontext.PlanningContext, seed, term *Route, r Routing, in
 	}
 
 	cte := &RecurseCTE{
-		binaryOperator: newBinaryOp(seed.Source, newTerm),
-		Predicates:     in.Predicates,
-		Def:            in.Def,
-		LeftID:         in.LeftID,
-		OuterID:        in.OuterID,
-		Distinct:       in.Distinct,
+		Sources:    []Operator{seed.Source, newTerm},
+		Predicates: in.Predicates,
+		Def:        in.Def,
+		LeftID:     in.LeftID,
+		OuterID:    in.OuterID,
+		Distinct:   in.Distinct,
 	}
 	return &Route{
 		Routing:       r,
@@ -96,7 +96,9 @@ func (h *Horizon) AddPredicate(ctx *plancontext.PlanningContext, expr sqlparser.
 	}
 
 	newExpr := semantics.RewriteDerivedTableExpression(expr, tableInfo)
-	if ctx.ContainsAggr(newExpr) {
+	if ctx.ContainsAggr(newExpr) || h.hasWindowFunctions() {
+		// predicates on aggregations can't be evaluated before the aggregation, and
+		// pushing a predicate below window functions would change the rows they are computed over
 		return newFilter(h, expr)
 	}
 	h.Source = h.Source.AddPredicate(ctx, newExpr)
@@ -215,3 +217,8 @@ func (h *Horizon) introducesTableID() semantics.TableSet {
 func (h *Horizon) IsDerived() bool {
 	return h.TableId != nil
 }
+
+func (h *Horizon) hasWindowFunctions() bool {
+	sel, isSel := h.Query.(*sqlparser.Select)
+	return isSel && containsWindowFunctions(sel)
+}
@@ -87,9 +87,12 @@ func expandSelectHorizon(ctx *plancontext.PlanningContext, horizon *Horizon, sel
 	}
 
 	op := createProjectionFromSelect(ctx, horizon)
-	if qp.HasAggr {
+	switch {
+	case qp.HasAggr:
 		extracted = append(extracted, "Aggregation")
-	} else {
+	case needsWindow(ctx, horizon):
+		extracted = append(extracted, "Window")
+	default:
 		extracted = append(extracted, "Projection")
 	}
 
@@ -194,9 +197,16 @@ func createProjectionFromSelect(ctx *plancontext.PlanningContext, horizon *Horiz
 	}
 
 	if qp.NeedsAggregation() {
+		if needsWindow(ctx, horizon) {
+			panic(vterrors.VT12001("in scatter query: window functions with aggregation"))
+		}
 		return createProjectionWithAggr(ctx, qp, dt, horizon)
 	}
 
+	if needsWindow(ctx, horizon) {
+		return createWindowFromSelect(ctx, qp, horizon, dt)
+	}
+
 	projX := createProjectionWithoutAggr(ctx, qp, horizon.src())
 	projX.DT = dt
 	return projX
@@ -17,6 +17,9 @@ limitations under the License.
 package operators
 
 import (
+	"fmt"
+	"slices"
+
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -32,6 +35,9 @@ type Join struct {
 	// NormalJoinType, StraightJoinType and LeftJoinType.
 	JoinType sqlparser.JoinType
 
+	// LateralVars are the values a LATERAL derived table on the RHS needs from the LHS
+	LateralVars []BindVarExpr
+
 	noColumns
 }
 
@@ -42,6 +48,7 @@ func (j *Join) Clone(inputs []Operator) Operator {
 	clone := *j
 	clone.LHS = inputs[0]
 	clone.RHS = inputs[1]
+	clone.LateralVars = slices.Clone(j.LateralVars)
 	return &clone
 }
 
@@ -102,17 +109,31 @@ func createLeftOuterJoin(ctx *plancontext.PlanningContext, join *sqlparser.JoinT
 	ctx.OuterTables = ctx.OuterTables.Merge(TableID(rhs))
 
 	// for outer joins we have to be careful with the predicates we use
-	var op Operator
-	subq, _, _ := getSubQuery(join.Condition.On)
-	if subq != nil {
-		panic(vterrors.VT12001("subquery in outer join predicate"))
+	return addOuterJoinPredicate(ctx, join.Condition.On, joinOp)
+}
+
+// addOuterJoinPredicate sets the ON condition of an outer join. Subqueries in the condition
+// can't be used to filter rows, so they are evaluated before the join and replaced with arguments
+func addOuterJoinPredicate(ctx *plancontext.PlanningContext, predicate sqlparser.Expr, joinOp *Join) Operator {
+	sqc := &SubQueryBuilder{}
+	if subq, _, _ := getSubQuery(predicate); subq != nil {
+		predicate = sqc.pullOutUncorrelatedSubqueries(ctx, predicate, TableID(joinOp), "outer join predicate")
 	}
-	predicate := join.Condition.On
 	sqlparser.RemoveKeyspaceInCol(predicate)
-	joinOp.Predicate = predicate
-	op = joinOp
 
-	return op
+	// if we are inside a CTE, the parts of the predicate that depend on the recursion table use arguments instead
+	if cte := ctx.ActiveCTE(); cte != nil && ctx.SemTable.DirectDeps(predicate).IsOverlapping(cte.Id) {
+		var exprs []sqlparser.Expr
+		for _, pred := range sqlparser.SplitAndExpression(nil, predicate) {
+			if ctx.SemTable.DirectDeps(pred).IsOverlapping(cte.Id) {
+				pred = addCTEPredicate(ctx, pred, cte)
+			}
+			exprs = append(exprs, pred)
+		}
+		predicate = sqlparser.AndExpressions(exprs...)
+	}
+	joinOp.Predicate = predicate
+	return sqc.getRootOperator(joinOp, nil)
 }
 
 func createInnerJoin(ctx *plancontext.PlanningContext, tableExpr *sqlparser.JoinTableExpr, lhs, rhs Operator) Operator {
@@ -235,3 +256,104 @@ func (j *Join) AddJoinPredicate(ctx *plancontext.PlanningContext, expr sqlparser
 func (j *Join) ShortDescription() string {
 	return sqlparser.String(j.Predicate)
 }
+
+// lateralColumns returns the columns that a LATERAL derived table uses from the tables on its left
+func lateralColumns(ctx *plancontext.PlanningContext, tableExpr sqlparser.TableExpr, lhsID semantics.TableSet) (*sqlparser.AliasedTableExpr, []*sqlparser.ColName) {
+	aliased, ok := tableExpr.(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return nil, nil
+	}
+	dt, ok := aliased.Expr.(*sqlparser.DerivedTable)
+	if !ok || !dt.Lateral {
+		return nil, nil
+	}
+
+	var cols []*sqlparser.ColName
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		col, ok := node.(*sqlparser.ColName)
+		if ok && ctx.SemTable.DirectDeps(col).IsSolvedBy(lhsID) {
+			cols = append(cols, col)
+		}
+		return true, nil
+	}, dt.Select)
+	return aliased, cols
+}
+
+// createLateralJoin creates a join with a LATERAL derived table on the RHS that uses columns from the LHS.
+// The columns are replaced by arguments inside the derived table, and the join is always planned as
+// a nested loop join that passes the values from the LHS to the RHS for every row.
+func createLateralJoin(
+	ctx *plancontext.PlanningContext,
+	lhs Operator,
+	tableExpr *sqlparser.AliasedTableExpr,
+	cols []*sqlparser.ColName,
+	joinType sqlparser.JoinType,
+	on sqlparser.Expr,
+) Operator {
+	switch joinType {
+	case sqlparser.NormalJoinType, sqlparser.StraightJoinType, sqlparser.LeftJoinType:
+	default:
+		panic(vterrors.VT12001(fmt.Sprintf("LATERAL derived table in %s", joinType.ToString())))
+	}
+
+	dt := tableExpr.Expr.(*sqlparser.DerivedTable)
+	stmt, lateralVars := replaceLateralColumns(ctx, dt.Select, cols)
+	rhs := getOperatorFromDerivedTable(ctx, tableExpr, ctx.SemTable.TableSetFor(tableExpr), stmt)
+	joinOp := &Join{
+		binaryOperator: newBinaryOp(lhs, rhs),
+		JoinType:       joinType,
+		LateralVars:    lateralVars,
+	}
+
+	if joinType != sqlparser.LeftJoinType {
+		return addJoinPredicates(ctx, on, joinOp)
+	}
+
+	ctx.OuterTables = ctx.OuterTables.Merge(TableID(rhs))
+	return addOuterJoinPredicate(ctx, on, joinOp)
+}
+
+// replaceLateralColumns returns a copy of the statement of a LATERAL derived table where the LHS columns
+// are replaced with arguments. The original statement is left untouched, and only the copied expressions
+// that contained these columns have their dependencies on the LHS tables removed.
+func replaceLateralColumns(ctx *plancontext.PlanningContext, stmt sqlparser.TableStatement, cols []*sqlparser.ColName) (sqlparser.TableStatement, []BindVarExpr) {
+	var lhsID semantics.TableSet
+	for _, col := range cols {
+		lhsID = lhsID.Merge(ctx.SemTable.DirectDeps(col))
+	}
+
+	var vars []BindVarExpr
+	post := func(cursor *sqlparser.CopyOnWriteCursor) {
+		col, ok := cursor.Node().(*sqlparser.ColName)
+		if !ok || !slices.Contains(cols, col) {
+			return
+		}
+
+		bvName := ctx.GetReservedArgumentFor(col)
+		vars = append(vars, BindVarExpr{
+			Name: bvName,
+			Expr: col,
+		})
+		typ, _ := ctx.TypeForExpr(col)
+		arg := sqlparser.NewTypedArgument(bvName, typ.Type())
+		arg.Scale = typ.Scale()
+		arg.Size = typ.Size()
+		cursor.Replace(arg)
+	}
+	cloned := func(before, after sqlparser.SQLNode) {
+		ctx.SemTable.CopySemanticInfo(before, after)
+		expr, ok := after.(sqlparser.Expr)
+		if !ok || !semantics.ValidAsMapKey(expr) {
+			return
+		}
+		if deps, found := ctx.SemTable.Recursive[expr]; found {
+			ctx.SemTable.Recursive[expr] = deps.Remove(lhsID)
+		}
+		if deps, found := ctx.SemTable.Direct[expr]; found {
+			ctx.SemTable.Direct[expr] = deps.Remove(lhsID)
+		}
+	}
+
+	result := sqlparser.CopyOnRewrite(stmt, nil, post, cloned)
+	return result.(sqlparser.TableStatement), vars
+}
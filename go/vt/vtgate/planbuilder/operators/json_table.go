@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"slices"
+
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+)
+
+// JSONTable is a JSON_TABLE table expression. It is either merged into a route together with
+// the tables it depends on, or evaluated by vtgate with the values it needs passed in as arguments.
+type JSONTable struct {
+	ID   semantics.TableSet
+	Expr *sqlparser.JSONTableExpr
+
+	// Columns are the expressions the operators above this one need from the JSON_TABLE
+	Columns []*sqlparser.AliasedExpr
+
+	nullaryOperator
+}
+
+func newJSONTable(ctx *plancontext.PlanningContext, expr *sqlparser.JSONTableExpr) *JSONTable {
+	for i, tbl := range ctx.SemTable.Tables {
+		jt, ok := tbl.(*semantics.JSONTable)
+		if ok && jt.ASTNode == expr {
+			return &JSONTable{
+				ID:   semantics.SingleTableSet(i),
+				Expr: expr,
+			}
+		}
+	}
+	panic(vterrors.VT13001("could not find the JSON_TABLE in the semantic table"))
+}
+
+// Clone implements the Operator interface
+func (jt *JSONTable) Clone([]Operator) Operator {
+	return &JSONTable{
+		ID:      jt.ID,
+		Expr:    jt.Expr,
+		Columns: slices.Clone(jt.Columns),
+	}
+}
+
+// introducesTableID implements the tableIDIntroducer interface
+func (jt *JSONTable) introducesTableID() semantics.TableSet {
+	return jt.ID
+}
+
+// AddPredicate implements the Operator interface
+func (jt *JSONTable) AddPredicate(_ *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	return newFilter(jt, expr)
+}
+
+func (jt *JSONTable) AddColumn(ctx *plancontext.PlanningContext, reuse bool, _ bool, ae *sqlparser.AliasedExpr) int {
+	if reuse {
+		offset := jt.FindCol(ctx, ae.Expr, true)
+		if offset > -1 {
+			return offset
+		}
+	}
+	jt.Columns = append(jt.Columns, ae)
+	return len(jt.Columns) - 1
+}
+
+func (jt *JSONTable) AddWSColumn(ctx *plancontext.PlanningContext, offset int, _ bool) int {
+	return jt.AddColumn(ctx, true, false, aeWrap(weightStringFor(jt.Columns[offset].Expr)))
+}
+
+func (jt *JSONTable) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, _ bool) int {
+	return slices.IndexFunc(jt.Columns, func(ae *sqlparser.AliasedExpr) bool {
+		return ctx.SemTable.EqualsExprWithDeps(ae.Expr, expr)
+	})
+}
+
+func (jt *JSONTable) GetColumns(*plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	return jt.Columns
+}
+
+func (jt *JSONTable) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
+	return transformColumnsToSelectExprs(ctx, jt)
+}
+
+func (jt *JSONTable) GetOrdering(*plancontext.PlanningContext) []OrderBy {
+	return nil
+}
+
+func (jt *JSONTable) ShortDescription() string {
+	return sqlparser.String(jt.Expr)
+}
+
+// planOffsets is only called when the JSON_TABLE is evaluated by vtgate. The engine primitive
+// can only return the JSON_TABLE columns, so any other expressions are evaluated by a projection on top
+func (jt *JSONTable) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	if jt.onlyColumns() {
+		return nil
+	}
+
+	proj := newAliasedProjection(&JSONTable{ID: jt.ID, Expr: jt.Expr})
+	proj.addProjExpr(slice.Map(jt.Columns, newProjExpr)...)
+	proj.planOffsets(ctx)
+	return proj
+}
+
+func (jt *JSONTable) onlyColumns() bool {
+	for _, col := range jt.Columns {
+		if _, ok := col.Expr.(*sqlparser.ColName); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// planJSONTableJoin plans a join where one of the sides is a JSON_TABLE. A JSON_TABLE that only uses tables
+// from a route on the other side is merged into that route. Otherwise, it is evaluated by vtgate for every
+// row coming from the LHS, with the values it needs from the LHS passed in as arguments.
+func planJSONTableJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredicates []sqlparser.Expr, joinType sqlparser.JoinType) (Operator, *ApplyResult) {
+	jt := findJSONTable(rhs)
+	if jt == nil {
+		if lhsJT := findJSONTable(lhs); lhsJT != nil && joinType.IsInner() && ctx.SemTable.RecursiveDeps(lhsJT.Expr.Expr).IsEmpty() {
+			// a JSON_TABLE that does not use any other tables can be joined on all the shards of the route
+			if route, ok := rhs.(*Route); ok {
+				route.Source = newMergedApplyJoin(ctx, lhs, route.Source, joinPredicates, joinType)
+				return route, Rewrote("merge JSON_TABLE into route")
+			}
+		}
+		return nil, nil
+	}
+
+	deps := ctx.SemTable.RecursiveDeps(jt.Expr.Expr)
+	if route, ok := lhs.(*Route); ok && deps.IsSolvedBy(TableID(route)) {
+		route.Source = newMergedApplyJoin(ctx, route.Source, rhs, joinPredicates, joinType)
+		return route, Rewrote("merge JSON_TABLE into route")
+	}
+
+	rhs = Clone(rhs)
+	join := NewApplyJoin(ctx, Clone(lhs), rhs, nil, joinType, false)
+	if !deps.IsEmpty() {
+		jt = findJSONTable(rhs)
+		col := breakExpressionInLHSandRHS(ctx, jt.Expr.Expr, TableID(lhs))
+		expr := *jt.Expr
+		expr.Expr = col.RHSExpr
+		jt.Expr = &expr
+		join.ExtraLHSVars = append(join.ExtraLHSVars, col.LHSExprs...)
+		join.Lateral = true
+	}
+	for _, pred := range joinPredicates {
+		join.AddJoinPredicate(ctx, pred, true)
+	}
+	return join, Rewrote("JSON_TABLE evaluated by vtgate")
+}
+
+func newMergedApplyJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredicates []sqlparser.Expr, joinType sqlparser.JoinType) *ApplyJoin {
+	aj := NewApplyJoin(ctx, lhs, rhs, ctx.SemTable.AndExpressions(joinPredicates...), joinType, false)
+	for _, column := range aj.JoinPredicates.columns {
+		if column.JoinPredicateID != nil {
+			ctx.PredTracker.Set(*column.JoinPredicateID, column.Original)
+		}
+	}
+	return aj
+}
+
+// findJSONTable returns the JSON_TABLE if the operator is one, possibly with filters on top of it
+func findJSONTable(op Operator) *JSONTable {
+	for {
+		switch in := op.(type) {
+		case *JSONTable:
+			return in
+		case *Filter:
+			op = in.Source
+		default:
+			return nil
+		}
+	}
+}
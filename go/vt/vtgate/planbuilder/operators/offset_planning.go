@@ -41,6 +41,10 @@ func planOffsets(ctx *plancontext.PlanningContext, root Operator) Operator {
 			if newOp == nil {
 				newOp = op
 			}
+			if aggr, isAggr := op.(*Aggregator); isAggr {
+				// we only get here for aggregators that are evaluated at the vtgate level
+				aggr.planEngineOffsets(ctx)
+			}
 
 			if DebugOperatorTree {
 				fmt.Println("Planned offsets for:")
@@ -353,23 +353,24 @@ func planRecursiveCTEHorizons(ctx *plancontext.PlanningContext, root Operator) O
 		if !ok {
 			return in, NoRewrite
 		}
-		hz := rcte.Horizon
-		hz.Source = rcte.Term()
-		newTerm, _ := expandHorizon(ctx, hz)
-		pr := findProjection(newTerm)
-		ap, err := pr.GetAliasedProjections()
-		if err != nil {
-			panic(vterrors.VT09015())
-		}
+		for i, hz := range rcte.Horizons {
+			hz.Source = rcte.Sources[i+1]
+			newTerm, _ := expandHorizon(ctx, hz)
+			pr := findProjection(newTerm)
+			ap, err := pr.GetAliasedProjections()
+			if err != nil {
+				panic(vterrors.VT09015())
+			}
 
-		// We need to break the expressions into LHS and RHS, and store them in the CTE for later use
-		projections := slice.Map(ap, func(p *ProjExpr) *plancontext.RecurseExpression {
-			recurseExpression := breakCTEExpressionInLhsAndRhs(ctx, p.EvalExpr, rcte.LeftID)
-			p.EvalExpr = recurseExpression.RightExpr
-			return recurseExpression
-		})
-		rcte.Projections = projections
-		rcte.RHS = newTerm
+			// We need to break the expressions into LHS and RHS, and store them in the CTE for later use
+			projections := slice.Map(ap, func(p *ProjExpr) *plancontext.RecurseExpression {
+				recurseExpression := breakCTEExpressionInLhsAndRhs(ctx, p.EvalExpr, rcte.LeftID)
+				p.EvalExpr = recurseExpression.RightExpr
+				return recurseExpression
+			})
+			rcte.Projections = append(rcte.Projections, projections...)
+			rcte.Sources[i+1] = newTerm
+		}
 		return rcte, Rewrote("expanded horizon on term side of recursive CTE")
 	}, stopAtRoute)
 }
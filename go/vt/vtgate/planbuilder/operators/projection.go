@@ -458,6 +458,11 @@ func (p *Projection) AllOffsets() (cols []int, colNames []string) {
 			return nil, nil
 		}
 		colName := ""
+		switch c.Original.Expr.(type) {
+		case *sqlparser.Subquery, *sqlparser.ExistsExpr:
+			// the value of a correlated subquery is named after its bind variable in the input
+			colName = sqlparser.String(c.Original.Expr)
+		}
 		if c.Original.As.NotEmpty() {
 			colName = c.Original.As.String()
 		}
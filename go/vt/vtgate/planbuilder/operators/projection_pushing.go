@@ -152,6 +152,10 @@ func pushProjectionToOuter(ctx *plancontext.PlanningContext, p *Projection, sq *
 
 		se, ok := pe.Info.(SubQueryExpression)
 		if ok {
+			if sq.ValueColumn && slices.ContainsFunc(se, func(other *SubQuery) bool { return other.ArgName == sq.ArgName }) {
+				// the subquery value is only available after the outer has been executed
+				return p, NoRewrite
+			}
 			pe.EvalExpr = rewriteColNameToArgument(ctx, pe.EvalExpr, se, sq)
 		}
 	}
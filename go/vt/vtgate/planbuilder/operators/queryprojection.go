@@ -19,9 +19,9 @@ package operators
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"slices"
 	"sort"
-	"strings"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -50,6 +50,9 @@ type (
 		// AddedColumn keeps a counter for expressions added to solve HAVING expressions the user is not selecting
 		AddedColumn int
 
+		// hasGroupingFunc is true if the query uses the GROUPING() function of a GROUP BY WITH ROLLUP
+		hasGroupingFunc bool
+
 		hasCheckedAlignment bool
 	}
 
@@ -85,6 +88,16 @@ type (
 		SubQueryExpression []*SubQuery // Subqueries associated with this aggregation
 
 		PushedDown bool // Whether the aggregation has been pushed down to the next layer
+
+		// HashDistinct is set for DISTINCT aggregations that can't rely on the input being
+		// sorted by the aggregation arguments. The engine keeps the values already seen in a hash set instead.
+		HashDistinct bool
+		// DistinctOffsets are the offsets of the remaining arguments of a DISTINCT aggregation with multiple arguments
+		DistinctOffsets []int
+
+		// OrderOffsets and OrderWSOffsets are the offsets of the GROUP_CONCAT ORDER BY expressions and their weight strings
+		OrderOffsets   []int
+		OrderWSOffsets []int
 	}
 )
 
@@ -170,11 +183,27 @@ func createQPFromSelect(ctx *plancontext.PlanningContext, sel *sqlparser.Select)
 	if !qp.HasAggr && sel.Having != nil {
 		qp.HasAggr = ctx.ContainsAggr(sel.Having.Expr)
 	}
+	if qp.WithRollup {
+		qp.hasGroupingFunc = containsGroupingFunc(sel)
+	}
 	qp.calculateDistinct(ctx)
 
 	return qp
 }
 
+// containsGroupingFunc returns true if the projection, HAVING or ORDER BY of the query call the GROUPING() function
+func containsGroupingFunc(sel *sqlparser.Select) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if fn, ok := node.(*sqlparser.FuncExpr); ok && fn.Name.EqualString("grouping") {
+			found = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, sel.SelectExprs, sel.Having, sel.OrderBy)
+	return found
+}
+
 func (qp *QueryProjection) addSelectExpressions(ctx *plancontext.PlanningContext, sel *sqlparser.Select) {
 	for _, selExp := range sel.GetColumns() {
 		switch selExp := selExp.(type) {
@@ -687,12 +716,9 @@ func checkForInvalidGroupingExpressions(ctx *plancontext.PlanningContext, expr s
 		if ctx.IsAggr(node) {
 			panic(vterrors.VT03005(sqlparser.String(expr)))
 		}
+		// subqueries have already been pulled out of the grouping expressions
 		_, isSubQ := node.(*sqlparser.Subquery)
-		arg, isArg := node.(*sqlparser.Argument)
-		if isSubQ || (isArg && strings.HasPrefix(arg.Name, "__sq")) {
-			panic(vterrors.VT12001("subqueries in GROUP BY"))
-		}
-		return true, nil
+		return !isSubQ, nil
 	}, expr)
 }
 
@@ -32,7 +32,8 @@ import (
 
 // RecurseCTE is used to represent a recursive CTE
 type RecurseCTE struct {
-	binaryOperator
+	// Sources holds the seed, followed by one term for every recursive query block of the CTE
+	Sources []Operator
 
 	// Def is the CTE definition according to the semantics
 	Def *semantics.CTE
@@ -48,10 +49,10 @@ type RecurseCTE struct {
 	// MyTableID is the id of the CTE
 	MyTableInfo *semantics.CTETable
 
-	// Horizon is stored here until we either expand it or push it under a route
-	Horizon *Horizon
+	// Horizons are stored here, one per term, until we either expand them or push them under a route
+	Horizons []*Horizon
 
-	// The LeftID is the id of the left side of the CTE
+	// The LeftID is the id of the recursive table used in the terms of the CTE
 	LeftID,
 
 	// The OuterID is the id for this use of the CTE
@@ -65,33 +66,42 @@ var _ Operator = (*RecurseCTE)(nil)
 
 func newRecurse(
 	def *semantics.CTE,
-	seed, term Operator,
+	seed Operator,
+	terms []Operator,
 	predicates []*plancontext.RecurseExpression,
-	horizon *Horizon,
+	horizons []*Horizon,
 	leftID, outerID semantics.TableSet,
 	distinct bool,
 ) *RecurseCTE {
 	return &RecurseCTE{
-		binaryOperator: newBinaryOp(seed, term),
-		Def:            def,
-		Predicates:     predicates,
-		Horizon:        horizon,
-		LeftID:         leftID,
-		OuterID:        outerID,
-		Distinct:       distinct,
+		Sources:    append([]Operator{seed}, terms...),
+		Def:        def,
+		Predicates: predicates,
+		Horizons:   horizons,
+		LeftID:     leftID,
+		OuterID:    outerID,
+		Distinct:   distinct,
 	}
 }
 
 func (r *RecurseCTE) Clone(inputs []Operator) Operator {
 	klone := *r
-	klone.LHS = inputs[0]
-	klone.RHS = inputs[1]
+	klone.Sources = inputs
+	klone.Horizons = slices.Clone(r.Horizons)
 	klone.Vars = maps.Clone(r.Vars)
 	klone.Predicates = slices.Clone(r.Predicates)
 	klone.Projections = slices.Clone(r.Projections)
 	return &klone
 }
 
+func (r *RecurseCTE) Inputs() []Operator {
+	return r.Sources
+}
+
+func (r *RecurseCTE) SetInputs(operators []Operator) {
+	r.Sources = operators
+}
+
 func (r *RecurseCTE) AddPredicate(_ *plancontext.PlanningContext, e sqlparser.Expr) Operator {
 	return newFilter(r, e)
 }
@@ -126,9 +136,10 @@ func (r *RecurseCTE) makeSureWeHaveTableInfo(ctx *plancontext.PlanningContext) {
 
 func (r *RecurseCTE) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
 	seed := r.Seed().AddWSColumn(ctx, offset, underRoute)
-	term := r.Term().AddWSColumn(ctx, offset, underRoute)
-	if seed != term {
-		panic(vterrors.VT13001("CTE columns don't match"))
+	for _, term := range r.Terms() {
+		if term.AddWSColumn(ctx, offset, underRoute) != seed {
+			panic(vterrors.VT13001("CTE columns don't match"))
+		}
 	}
 	return seed
 }
@@ -199,9 +210,10 @@ func (r *RecurseCTE) introducesTableID() semantics.TableSet {
 }
 
 func (r *RecurseCTE) Seed() Operator {
-	return r.LHS
+	return r.Sources[0]
 }
 
-func (r *RecurseCTE) Term() Operator {
-	return r.RHS
+// Terms returns the recursive parts of the CTE, one for every recursive query block
+func (r *RecurseCTE) Terms() []Operator {
+	return r.Sources[1:]
 }
@@ -53,6 +53,18 @@ func pushDerived(ctx *plancontext.PlanningContext, op *Horizon) (Operator, *Appl
 }
 
 func optimizeJoin(ctx *plancontext.PlanningContext, op *Join) (Operator, *ApplyResult) {
+	if len(op.LateralVars) > 0 {
+		join := NewApplyJoin(ctx, op.LHS, op.RHS, nil, op.JoinType, false)
+		join.ExtraLHSVars = op.LateralVars
+		join.Lateral = true
+		for _, pred := range sqlparser.SplitAndExpression(nil, op.Predicate) {
+			if b := ctx.IsConstantBool(pred); b != nil && *b {
+				continue
+			}
+			join.AddJoinPredicate(ctx, pred, true)
+		}
+		return join, Rewrote("lateral join to applyJoin")
+	}
 	if newOp := op.tryCompact(ctx); newOp != nil {
 		return newOp, Rewrote("merged query graphs")
 	}
@@ -297,6 +309,10 @@ func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredic
 		return newPlan, Rewrote("merge routes into single operator")
 	}
 
+	if op, result := planJSONTableJoin(ctx, lhs, rhs, joinPredicates, joinType); op != nil {
+		return op, result
+	}
+
 	if len(joinPredicates) > 0 && requiresSwitchingSides(ctx, rhs) {
 		if !joinType.IsCommutative() || requiresSwitchingSides(ctx, lhs) {
 			// we can't switch sides, so let's see if we can use a HashJoin to solve it
@@ -238,38 +238,147 @@ func (tr *ShardedRouting) searchForNewVindexes(ctx *plancontext.PlanningContext,
 	case *sqlparser.IsExpr:
 		found := tr.planIsExpr(ctx, node)
 		newVindexFound = newVindexFound || found
+
+	case *sqlparser.OrExpr:
+		found := tr.planRangesOr(ctx, node)
+		newVindexFound = newVindexFound || found
 	}
 
 	return nil, newVindexFound
 }
 
 func (tr *ShardedRouting) planBetweenOp(ctx *plancontext.PlanningContext, node *sqlparser.BetweenExpr) (routing Routing, foundNew bool) {
-	column, ok := node.Left.(*sqlparser.ColName)
-	if !ok {
+	if !node.IsBetween {
 		return nil, false
 	}
-	var vdValue sqlparser.ValTuple = sqlparser.ValTuple([]sqlparser.Expr{node.From, node.To})
+	return nil, tr.planRange(ctx, node)
+}
+
+// planRange plans a predicate that restricts a column to a range of values, such
+// as `col BETWEEN a AND b` or `col > a`, using a Sequential vindex on the column.
+func (tr *ShardedRouting) planRange(ctx *plancontext.PlanningContext, node sqlparser.Expr) bool {
+	column, vdValue := rangeOfColumn(node)
+	if column == nil {
+		return false
+	}
+
+	val := makeEvalEngineExpr(ctx, vdValue)
+	if val == nil {
+		return false
+	}
+	return tr.haveMatchingVindex(ctx, node, vdValue, column, val, betweenOrScatter, sequentialVindex)
+}
+
+// planRangesOr plans an OR of ranges on the same column, such as
+// `col BETWEEN a AND b OR col > c`. Every range becomes one of the values of the
+// Between route, and the engine routes the query to the union of their shards.
+func (tr *ShardedRouting) planRangesOr(ctx *plancontext.PlanningContext, node *sqlparser.OrExpr) bool {
+	var column *sqlparser.ColName
+	var valueExprs []sqlparser.Expr
+	var values []evalengine.Expr
+	for _, expr := range splitOrExpression(nil, node) {
+		col, vdValue := rangeOfColumn(expr)
+		if col == nil {
+			return false
+		}
+		if column != nil && !ctx.SemTable.EqualsExprWithDeps(column, col) {
+			return false
+		}
+		column = col
 
-	opcode := func(vindex *vindexes.ColumnVindex) engine.Opcode {
-		if _, ok := vindex.Vindex.(vindexes.Sequential); ok {
-			return engine.Between
+		val := makeEvalEngineExpr(ctx, vdValue)
+		if val == nil {
+			return false
 		}
-		return engine.Scatter
+		valueExprs = append(valueExprs, vdValue)
+		values = append(values, val)
 	}
 
-	sequentialVdx := func(vindex *vindexes.ColumnVindex) vindexes.Vindex {
-		if _, ok := vindex.Vindex.(vindexes.Sequential); ok {
-			return vindex.Vindex
+	newVindexFound := false
+	for _, v := range tr.VindexPreds {
+		if !ctx.SemTable.DirectDeps(column).IsSolvedBy(v.TableID) {
+			continue
 		}
-		// if vindex is not of type Sequential, we can't use this vindex at all
-		return nil
+		if _, ok := v.ColVindex.Vindex.(vindexes.SingleColumn); !ok {
+			continue
+		}
+		if !column.Name.Equal(v.ColVindex.Columns[0]) {
+			continue
+		}
+		vindex := sequentialVindex(v.ColVindex)
+		if vindex == nil {
+			continue
+		}
+		v.Options = append(v.Options, &VindexOption{
+			Values:      values,
+			ValueExprs:  valueExprs,
+			Predicates:  []sqlparser.Expr{node},
+			OpCode:      engine.Between,
+			FoundVindex: vindex,
+			Cost:        costFor(v.ColVindex, engine.Between),
+			Ready:       true,
+		})
+		newVindexFound = true
 	}
+	return newVindexFound
+}
 
-	val := makeEvalEngineExpr(ctx, vdValue)
-	if val == nil {
-		return nil, false
+// rangeOfColumn returns the column that the given predicate restricts to a range
+// of values, and the tuple of the start and end of that range. A NULL start or end
+// means that the range is open on that side. It returns a nil column if the
+// predicate is not a range on a column.
+func rangeOfColumn(expr sqlparser.Expr) (*sqlparser.ColName, sqlparser.ValTuple) {
+	switch node := expr.(type) {
+	case *sqlparser.BetweenExpr:
+		column, ok := node.Left.(*sqlparser.ColName)
+		if !ok || !node.IsBetween {
+			return nil, nil
+		}
+		return column, sqlparser.ValTuple{node.From, node.To}
+	case *sqlparser.ComparisonExpr:
+		op := node.Operator
+		column, ok := node.Left.(*sqlparser.ColName)
+		other := node.Right
+		if !ok {
+			if column, ok = node.Right.(*sqlparser.ColName); !ok {
+				return nil, nil
+			}
+			other = node.Left
+			op, _ = op.SwitchSides()
+		}
+		switch op {
+		case sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+			return column, sqlparser.ValTuple{other, &sqlparser.NullVal{}}
+		case sqlparser.LessThanOp, sqlparser.LessEqualOp:
+			return column, sqlparser.ValTuple{&sqlparser.NullVal{}, other}
+		}
+	}
+	return nil, nil
+}
+
+// splitOrExpression breaks up the Expr into OR-separated conditions
+// and appends them to filters.
+func splitOrExpression(filters []sqlparser.Expr, node sqlparser.Expr) []sqlparser.Expr {
+	if or, ok := node.(*sqlparser.OrExpr); ok {
+		filters = splitOrExpression(filters, or.Left)
+		return splitOrExpression(filters, or.Right)
+	}
+	return append(filters, node)
+}
+
+func betweenOrScatter(vindex *vindexes.ColumnVindex) engine.Opcode {
+	if _, ok := vindex.Vindex.(vindexes.Sequential); ok {
+		return engine.Between
 	}
-	return nil, tr.haveMatchingVindex(ctx, node, vdValue, column, val, opcode, sequentialVdx)
+	return engine.Scatter
+}
+
+func sequentialVindex(vindex *vindexes.ColumnVindex) vindexes.Vindex {
+	if _, ok := vindex.Vindex.(vindexes.Sequential); ok {
+		return vindex.Vindex
+	}
+	// if vindex is not of type Sequential, we can't use this vindex at all
+	return nil
 }
 
 func (tr *ShardedRouting) planComparison(ctx *plancontext.PlanningContext, cmp *sqlparser.ComparisonExpr) (routing Routing, foundNew bool) {
@@ -283,7 +392,9 @@ func (tr *ShardedRouting) planComparison(ctx *plancontext.PlanningContext, cmp *
 	case sqlparser.LikeOp:
 		found := tr.planLikeOp(ctx, cmp)
 		return nil, found
-
+	case sqlparser.LessThanOp, sqlparser.LessEqualOp, sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+		found := tr.planRange(ctx, cmp)
+		return nil, found
 	}
 	return nil, false
 }
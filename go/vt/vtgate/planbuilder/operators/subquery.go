@@ -25,6 +25,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 )
@@ -55,6 +56,18 @@ type SubQuery struct {
 
 	// IsArgument is set to true if the subquery puts the
 	IsArgument bool
+
+	// PulledOut is set when the expression using the subquery has already been rewritten to use
+	// the subquery arguments, e.g. in outer join predicates and grouping expressions. These subqueries
+	// are always evaluated before the outer query instead of being merged into it.
+	PulledOut bool
+
+	// Fields used when a correlated subquery can't be merged and has to be executed once per outer row:
+	// FilterPredicates are evaluated on vtgate to filter the outer rows, using the subquery result as arguments.
+	FilterPredicates     []sqlparser.Expr
+	PredicateWithOffsets evalengine.Expr
+	// ValueColumn is set when the subquery value is returned as the first column of the outer rows.
+	ValueColumn bool
 }
 
 func (sq *SubQuery) planOffsets(ctx *plancontext.PlanningContext) Operator {
@@ -69,6 +82,21 @@ func (sq *SubQuery) planOffsets(ctx *plancontext.PlanningContext) Operator {
 			sq.Vars[lhsExpr.Name] = offset
 		}
 	}
+
+	if len(sq.FilterPredicates) == 0 {
+		return nil
+	}
+	predicate := sqlparser.AndExpressions(sq.FilterPredicates...)
+	rewritten := useOffsets(ctx, predicate, sq)
+	eexpr, err := evalengine.Translate(rewritten, &evalengine.Config{
+		ResolveType: ctx.TypeForExpr,
+		Collation:   ctx.SemTable.Collation,
+		Environment: ctx.VSchema.Environment(),
+	})
+	if err != nil {
+		panic(err)
+	}
+	sq.PredicateWithOffsets = eexpr
 	return nil
 }
 
@@ -126,6 +154,7 @@ func (sq *SubQuery) Clone(inputs []Operator) Operator {
 	klone.JoinColumns = slices.Clone(sq.JoinColumns)
 	klone.Vars = maps.Clone(sq.Vars)
 	klone.Predicates = slices.Clone(sq.Predicates)
+	klone.FilterPredicates = slices.Clone(sq.FilterPredicates)
 	return &klone
 }
 
@@ -180,23 +209,70 @@ func (sq *SubQuery) AddColumn(ctx *plancontext.PlanningContext, reuseExisting bo
 	ae = sqlparser.Clone(ae)
 	// we need to rewrite the column name to an argument if it's the same as the subquery column name
 	ae.Expr = rewriteColNameToArgument(ctx, ae.Expr, []*SubQuery{sq}, sq)
-	return sq.Outer.AddColumn(ctx, reuseExisting, addToGroupBy, ae)
+	if !sq.ValueColumn {
+		return sq.Outer.AddColumn(ctx, reuseExisting, addToGroupBy, ae)
+	}
+	if sq.isValueArgument(ae.Expr) {
+		return 0
+	}
+	return sq.Outer.AddColumn(ctx, reuseExisting, addToGroupBy, ae) + 1
 }
 
 func (sq *SubQuery) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
-	return sq.Outer.AddWSColumn(ctx, offset, underRoute)
+	if !sq.ValueColumn {
+		return sq.Outer.AddWSColumn(ctx, offset, underRoute)
+	}
+	if offset == 0 {
+		panic(vterrors.VT12001("weight_string of a correlated subquery value"))
+	}
+	return sq.Outer.AddWSColumn(ctx, offset-1, underRoute) + 1
 }
 
 func (sq *SubQuery) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
-	return sq.Outer.FindCol(ctx, expr, underRoute)
+	if !sq.ValueColumn {
+		return sq.Outer.FindCol(ctx, expr, underRoute)
+	}
+	if sq.isValueArgument(expr) {
+		return 0
+	}
+	offset := sq.Outer.FindCol(ctx, expr, underRoute)
+	if offset < 0 {
+		return offset
+	}
+	return offset + 1
 }
 
 func (sq *SubQuery) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
-	return sq.Outer.GetColumns(ctx)
+	if !sq.ValueColumn {
+		return sq.Outer.GetColumns(ctx)
+	}
+	return append([]*sqlparser.AliasedExpr{aeWrap(sq.valueArgument())}, sq.Outer.GetColumns(ctx)...)
 }
 
 func (sq *SubQuery) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
-	return sq.Outer.GetSelectExprs(ctx)
+	if !sq.ValueColumn {
+		return sq.Outer.GetSelectExprs(ctx)
+	}
+	return append([]sqlparser.SelectExpr{aeWrap(sq.valueArgument())}, sq.Outer.GetSelectExprs(ctx)...)
+}
+
+// valueArgument returns the argument that the value of the subquery is stored in
+func (sq *SubQuery) valueArgument() *sqlparser.Argument {
+	if sq.FilterType == opcode.PulloutExists {
+		return sqlparser.NewArgument(sq.HasValuesName)
+	}
+	return sqlparser.NewArgument(sq.ArgName)
+}
+
+// isValueArgument returns true if the expression is referencing the value of this subquery
+func (sq *SubQuery) isValueArgument(expr sqlparser.Expr) bool {
+	switch expr := expr.(type) {
+	case *sqlparser.ColName:
+		return expr.Qualifier.IsEmpty() && expr.Name.String() == sq.ArgName
+	case *sqlparser.Argument:
+		return expr.Name == sq.ArgName || (sq.HasValuesName != "" && expr.Name == sq.HasValuesName)
+	}
+	return false
 }
 
 // GetMergePredicates returns the predicates that we can use to try to merge this subquery with the outer query.
@@ -213,13 +289,13 @@ func (sq *SubQuery) settle(ctx *plancontext.PlanningContext, outer Operator) Ope
 	if !sq.TopLevel && sq.correlated {
 		panic(subqueryNotAtTopErr)
 	}
-	if sq.correlated && sq.FilterType != opcode.PulloutExists {
-		panic(correlatedSubqueryErr)
-	}
 	if sq.IsArgument {
 		if len(sq.GetMergePredicates()) > 0 {
 			// this means that we have a correlated subquery on our hands
-			panic(correlatedSubqueryErr)
+			return sq.settleCorrelatedValue(ctx, outer)
+		}
+		if sq.correlated {
+			panic(correlatedWithoutPredicatesErr)
 		}
 		sq.SubqueryValueName = sq.ArgName
 		return outer
@@ -227,7 +303,49 @@ func (sq *SubQuery) settle(ctx *plancontext.PlanningContext, outer Operator) Ope
 	return sq.settleFilter(ctx, outer)
 }
 
-var correlatedSubqueryErr = vterrors.VT12001("correlated subquery is only supported for EXISTS")
+// settleCorrelatedValue is used for correlated subqueries that are used as values, e.g. in the SELECT list.
+// The subquery is executed once per outer row, and the value is added as the first column of the outer rows.
+func (sq *SubQuery) settleCorrelatedValue(ctx *plancontext.PlanningContext, outer Operator) Operator {
+	sq.checkCorrelation(ctx, outer)
+	switch sq.FilterType {
+	case opcode.PulloutValue:
+		sq.SubqueryValueName = sq.ArgName
+	case opcode.PulloutExists:
+		sq.addLimit()
+		if sq.HasValuesName == "" {
+			sq.HasValuesName = ctx.ReservedVars.ReserveHasValuesSubQuery()
+		}
+	default:
+		panic(correlatedSubqueryErr)
+	}
+	sq.ValueColumn = true
+	return outer
+}
+
+// checkCorrelation verifies that all the values the subquery needs from the outer query
+// can be fetched from the outer rows, so the subquery can be executed once per outer row
+func (sq *SubQuery) checkCorrelation(ctx *plancontext.PlanningContext, outer Operator) {
+	available := TableID(outer).Merge(TableID(sq.Subquery))
+	for _, pred := range sq.Predicates {
+		if !ctx.SemTable.RecursiveDeps(pred).IsSolvedBy(available) {
+			panic(vterrors.VT12001("correlated subquery referencing a query it is not directly nested in: " + sqlparser.String(pred)))
+		}
+	}
+	columns, err := sq.GetJoinColumns(ctx, outer)
+	if err != nil {
+		panic(err)
+	}
+	for _, jc := range columns {
+		for _, lhsExpr := range jc.LHSExprs {
+			if ctx.ContainsAggr(lhsExpr.Expr) {
+				panic(vterrors.VT12001("aggregation on outer query columns inside a correlated subquery: " + sqlparser.String(jc.Original)))
+			}
+		}
+	}
+}
+
+var correlatedSubqueryErr = vterrors.VT12001("correlated subquery is only supported for EXISTS, IN, NOT IN and scalar values")
+var correlatedWithoutPredicatesErr = vterrors.VT12001("correlated subquery using outer columns outside of its predicates")
 var subqueryNotAtTopErr = vterrors.VT12001("unmergable subquery can not be inside complex expression")
 
 func (sq *SubQuery) addLimit() {
@@ -236,12 +354,13 @@ func (sq *SubQuery) addLimit() {
 }
 
 func (sq *SubQuery) settleFilter(ctx *plancontext.PlanningContext, outer Operator) Operator {
-	if len(sq.Predicates) > 0 {
-		if sq.FilterType != opcode.PulloutExists {
-			panic(correlatedSubqueryErr)
-		}
+	correlated := len(sq.Predicates) > 0
+	switch {
+	case correlated && sq.FilterType == opcode.PulloutExists:
 		sq.addLimit()
 		return outer
+	case !correlated && sq.correlated && sq.FilterType != opcode.PulloutExists:
+		panic(correlatedWithoutPredicatesErr)
 	}
 
 	hasValuesArg := func() string {
@@ -306,6 +425,13 @@ func (sq *SubQuery) settleFilter(ctx *plancontext.PlanningContext, outer Operato
 		predicates = append(predicates, rhsPred)
 		sq.SubqueryValueName = sq.ArgName
 	}
+	if correlated {
+		sq.checkCorrelation(ctx, outer)
+		// the subquery needs values from the outer row, so the
+		// predicates are evaluated on vtgate for every outer row
+		sq.FilterPredicates = predicates
+		return outer
+	}
 	return newFilter(outer, predicates...)
 }
 
@@ -18,6 +18,7 @@ package operators
 
 import (
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
@@ -355,6 +356,75 @@ func (sqb *SubQueryBuilder) pullOutValueSubqueries(
 	return sqe.new, newSubqs
 }
 
+// pullOutUncorrelatedSubqueries extracts the subqueries used in an expression that can't be used to
+// filter rows, such as an outer join predicate or a grouping expression. The subqueries are evaluated
+// before the outer query, and the expression is rewritten to use their results as arguments.
+func (sqb *SubQueryBuilder) pullOutUncorrelatedSubqueries(
+	ctx *plancontext.PlanningContext,
+	expr sqlparser.Expr,
+	outerID semantics.TableSet,
+	clause string,
+) sqlparser.Expr {
+	original := expr
+	add := func(subq *sqlparser.Subquery, parent sqlparser.Expr, filterType opcode.PulloutOpcode) *SubQuery {
+		if !ctx.SemTable.RecursiveDeps(subq).IsEmpty() {
+			panic(vterrors.VT12001("correlated subquery in " + clause))
+		}
+		argName := ctx.GetReservedArgumentFor(subq)
+		sq := createSubquery(ctx, original, subq, outerID, parent, argName, filterType, true)
+		if filterType != opcode.PulloutValue {
+			sq.HasValuesName = ctx.ReservedVars.ReserveVariable(string(sqlparser.HasValueSubQueryBaseName))
+		}
+		sq.PulledOut = true
+		sqb.Inner = append(sqb.Inner, sq)
+		return sq
+	}
+
+	return sqlparser.CopyOnRewrite(expr, dontEnterSubqueries, func(cursor *sqlparser.CopyOnWriteCursor) {
+		switch node := cursor.Node().(type) {
+		case *sqlparser.Subquery:
+			if isPulledOutByParent(cursor.Parent()) {
+				return
+			}
+			sq := add(node, node, opcode.PulloutValue)
+			cursor.Replace(sqlparser.NewArgument(sq.ArgName))
+		case *sqlparser.ExistsExpr:
+			sq := add(node.Subquery, node, opcode.PulloutExists)
+			cursor.Replace(sqlparser.NewArgument(sq.HasValuesName))
+		case *sqlparser.ComparisonExpr:
+			subq, ok := node.Right.(*sqlparser.Subquery)
+			if !ok || !isPulledOutByParent(node) {
+				return
+			}
+			cmp := sqlparser.Clone(node)
+			if node.Operator == sqlparser.InOp {
+				sq := add(subq, node, opcode.PulloutIn)
+				cmp.Right = sqlparser.NewListArg(sq.ArgName)
+				cursor.Replace(sqlparser.AndExpressions(sqlparser.NewArgument(sq.HasValuesName), cmp))
+				return
+			}
+			sq := add(subq, node, opcode.PulloutNotIn)
+			cmp.Right = sqlparser.NewListArg(sq.ArgName)
+			cursor.Replace(&sqlparser.OrExpr{
+				Left:  sqlparser.NewNotExpr(sqlparser.NewArgument(sq.HasValuesName)),
+				Right: cmp,
+			})
+		}
+	}, ctx.SemTable.CopySemanticInfo).(sqlparser.Expr)
+}
+
+// isPulledOutByParent returns true if the subquery below this node is pulled out together with the node
+func isPulledOutByParent(parent sqlparser.SQLNode) bool {
+	switch parent := parent.(type) {
+	case *sqlparser.ExistsExpr:
+		return true
+	case *sqlparser.ComparisonExpr:
+		_, isSubq := parent.Right.(*sqlparser.Subquery)
+		return isSubq && (parent.Operator == sqlparser.InOp || parent.Operator == sqlparser.NotInOp)
+	}
+	return false
+}
+
 type subqueryExtraction struct {
 	new         sqlparser.Expr
 	subq        []*sqlparser.Subquery
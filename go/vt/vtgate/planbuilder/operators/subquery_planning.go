@@ -64,6 +64,10 @@ func isMergeable(ctx *plancontext.PlanningContext, query sqlparser.TableStatemen
 			return false
 		}
 
+		if containsWindowFunctions(node) && !canPushWindowFunctions(ctx, node, op) {
+			return false
+		}
+
 		return true
 	case *sqlparser.Union:
 		return isMergeable(ctx, node.Left, op) && isMergeable(ctx, node.Right, op)
@@ -565,6 +569,9 @@ func mergingIsBlocked(subQuery *SubQuery, updOp *Update) bool {
 }
 
 func pushOrMerge(ctx *plancontext.PlanningContext, outer Operator, inner *SubQuery) (Operator, *ApplyResult) {
+	if inner.PulledOut {
+		return outer, NoRewrite
+	}
 	switch o := outer.(type) {
 	case *Route:
 		return tryMergeSubQuery(ctx, inner, o)
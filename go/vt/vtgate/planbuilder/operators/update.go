@@ -41,6 +41,10 @@ type (
 		Assignments         []SetExpr
 		ChangedVindexValues map[string]*engine.VindexValues
 
+		// MoveRows is set when the primary vindex columns are updated,
+		// and the rows getting a new keyspace id have to be moved to another shard.
+		MoveRows *engine.MoveRows
+
 		// these subqueries cannot be merged as they are part of the changed vindex values
 		// these values are needed to be sent over to lookup vindex for update.
 		// On merging this information will be lost, so subquery merge is blocked.
@@ -371,7 +375,7 @@ func createUpdateOperator(ctx *plancontext.PlanningContext, updStmt *sqlparser.U
 		Name:   name,
 	}
 
-	cvv, ovq, subQueriesArgOnChangedVindex := getUpdateVindexInformation(ctx, updStmt, targetTbl, assignments)
+	cvv, ovq, moveRows, subQueriesArgOnChangedVindex := getUpdateVindexInformation(ctx, updStmt, targetTbl, assignments)
 
 	updOp := &Update{
 		DMLCommon: &DMLCommon{
@@ -381,6 +385,7 @@ func createUpdateOperator(ctx *plancontext.PlanningContext, updStmt *sqlparser.U
 		},
 		Assignments:                  assignments,
 		ChangedVindexValues:          cvv,
+		MoveRows:                     moveRows,
 		SubQueriesArgOnChangedVindex: subQueriesArgOnChangedVindex,
 		VerifyAll:                    ctx.VerifyAllFKs,
 	}
@@ -403,14 +408,14 @@ func getUpdateVindexInformation(
 	updStmt *sqlparser.Update,
 	table TargetTable,
 	assignments []SetExpr,
-) (map[string]*engine.VindexValues, *sqlparser.Select, []string) {
+) (map[string]*engine.VindexValues, *sqlparser.Select, *engine.MoveRows, []string) {
 	if !table.VTable.Keyspace.Sharded {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	primaryVindex := getVindexInformation(table.ID, table.VTable)
-	changedVindexValues, ownedVindexQuery, subQueriesArgOnChangedVindex := buildChangedVindexesValues(ctx, updStmt, table.VTable, primaryVindex.Columns, assignments)
-	return changedVindexValues, ownedVindexQuery, subQueriesArgOnChangedVindex
+	changedVindexValues, ownedVindexQuery, moveRows, subQueriesArgOnChangedVindex := buildChangedVindexesValues(ctx, updStmt, table, primaryVindex.Columns, assignments)
+	return changedVindexValues, ownedVindexQuery, moveRows, subQueriesArgOnChangedVindex
 }
 
 func buildFkOperator(ctx *plancontext.PlanningContext, updOp Operator, updClone *sqlparser.Update, parentFks []vindexes.ParentFKInfo, childFks []vindexes.ChildFKInfo, targetTbl TargetTable) Operator {
@@ -1031,10 +1036,11 @@ func nullSafeNotInComparison(ctx *plancontext.PlanningContext, updatedTable *vin
 func buildChangedVindexesValues(
 	ctx *plancontext.PlanningContext,
 	update *sqlparser.Update,
-	table *vindexes.BaseTable,
+	target TargetTable,
 	ksidCols []sqlparser.IdentifierCI,
 	assignments []SetExpr,
-) (changedVindexes map[string]*engine.VindexValues, ovq *sqlparser.Select, subQueriesArgOnChangedVindex []string) {
+) (changedVindexes map[string]*engine.VindexValues, ovq *sqlparser.Select, moveRows *engine.MoveRows, subQueriesArgOnChangedVindex []string) {
+	table := target.VTable
 	changedVindexes = make(map[string]*engine.VindexValues)
 	selExprs, offset := initialQuery(ksidCols, table)
 	for i, vindex := range table.ColumnVindexes {
@@ -1049,9 +1055,10 @@ func buildChangedVindexesValues(
 			continue
 		}
 		if i == 0 {
-			panic(vterrors.VT12001(fmt.Sprintf("you cannot UPDATE primary vindex columns; invalid update on vindex: %v", vindex.Name)))
-		}
-		if _, ok := vindex.Vindex.(vindexes.Lookup); !ok {
+			if !table.AllowPrimaryVindexUpdate {
+				panic(vterrors.VT12001(fmt.Sprintf("you cannot UPDATE primary vindex columns; invalid update on vindex: %v", vindex.Name)))
+			}
+		} else if _, ok := vindex.Vindex.(vindexes.Lookup); !ok {
 			panic(vterrors.VT12001(fmt.Sprintf("you can only UPDATE lookup vindexes; invalid update on vindex: %v", vindex.Name)))
 		}
 
@@ -1064,7 +1071,10 @@ func buildChangedVindexesValues(
 		offset++
 	}
 	if len(changedVindexes) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
+	}
+	if _, ok := changedVindexes[table.ColumnVindexes[0].Name]; ok {
+		moveRows = buildMoveRows(ctx, target, assignments, selExprs, offset)
 	}
 	// generate rest of the owned vindex query.
 	ovq = &sqlparser.Select{
@@ -1073,7 +1083,54 @@ func buildChangedVindexesValues(
 		Limit:       update.Limit,
 		Lock:        sqlparser.ForUpdateLock,
 	}
-	return changedVindexes, ovq, subQueriesArgOnChangedVindex
+	return changedVindexes, ovq, moveRows, subQueriesArgOnChangedVindex
+}
+
+// buildMoveRows adds the values that the columns of the updated rows get, to the owned vindex query.
+// They are used to insert the rows that get a new keyspace id on their new shard. Like in MySQL,
+// the assignments are applied from left to right, and the columns that are not assigned but have
+// an ON UPDATE clause get that value. Generated columns are left out, as MySQL computes them.
+func buildMoveRows(ctx *plancontext.PlanningContext, target TargetTable, assignments []SetExpr, selExprs *sqlparser.SelectExprs, offset int) *engine.MoveRows {
+	table := target.VTable
+	if len(table.PrimaryKey) == 0 || !table.ColumnListAuthoritative || len(table.Columns) == 0 {
+		panic(vterrors.VT09015())
+	}
+
+	newValues := make(map[string]sqlparser.Expr, len(assignments))
+	for _, assignment := range assignments {
+		// The columns assigned earlier already have their new value in the later assignments.
+		expr := sqlparser.CopyOnRewrite(assignment.Expr.EvalExpr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+			col, ok := cursor.Node().(*sqlparser.ColName)
+			if !ok || ctx.SemTable.RecursiveDeps(col) != target.ID {
+				return
+			}
+			if value, ok := newValues[col.Name.Lowered()]; ok {
+				cursor.Replace(value)
+			}
+		}, nil).(sqlparser.Expr)
+		newValues[assignment.Name.Name.Lowered()] = expr
+	}
+
+	moveRows := &engine.MoveRows{Offset: offset}
+	for _, col := range table.Columns {
+		if col.Generated {
+			continue
+		}
+		value, ok := newValues[col.Name.Lowered()]
+		switch {
+		case ok:
+		case col.OnUpdate != nil:
+			value = col.OnUpdate
+		default:
+			value = sqlparser.NewColNameWithQualifier(col.Name.String(), target.Name)
+		}
+		moveRows.Columns = append(moveRows.Columns, col.Name.String())
+		selExprs.Exprs = append(selExprs.Exprs, aeWrap(value))
+	}
+	for _, col := range table.PrimaryKey {
+		moveRows.PrimaryKey = append(moveRows.PrimaryKey, col.String())
+	}
+	return moveRows
 }
 
 func initialQuery(ksidCols []sqlparser.IdentifierCI, table *vindexes.BaseTable) (*sqlparser.SelectExprs, int) {
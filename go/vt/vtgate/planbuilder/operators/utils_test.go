@@ -58,8 +58,9 @@ func (f *fakeOp) AddColumn(ctx *plancontext.PlanningContext, reuseExisting bool,
 	return len(f.cols) - 1
 }
 
-func (*fakeOp) AddWSColumn(*plancontext.PlanningContext, int, bool) int {
-	panic("implement me")
+func (f *fakeOp) AddWSColumn(_ *plancontext.PlanningContext, offset int, _ bool) int {
+	f.cols = append(f.cols, aeWrap(weightStringFor(f.cols[offset].Expr)))
+	return len(f.cols) - 1
 }
 
 func (f *fakeOp) FindCol(ctx *plancontext.PlanningContext, a sqlparser.Expr, underRoute bool) int {
@@ -0,0 +1,387 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+)
+
+type (
+	// Window evaluates window functions on the rows produced by its input.
+	// All functions share a single window specification, and the input is ordered by
+	// the partitioning expressions followed by the ordering expressions of the window.
+	// Every function owns one column of the input: the function argument for functions
+	// that take one, and a placeholder for the ranking functions. The result of the function
+	// replaces the value in that column - all other columns are passed through as is.
+	Window struct {
+		unaryOperator
+
+		Functions []WindowFunc
+
+		// PartitionBy and OrderBy hold the keys of the window.
+		// OrderBy is only used to find the peers of a row, the ordering itself is done by the input.
+		PartitionBy []GroupBy
+		OrderBy     []GroupBy
+
+		// ResultColumns signals how many columns will be produced by this operator
+		// This is used to truncate the columns in the final result
+		ResultColumns int
+
+		DT *DerivedTable
+	}
+
+	// WindowFunc is a single window function evaluated by the Window operator
+	WindowFunc struct {
+		Original *sqlparser.AliasedExpr
+		OpCode   opcode.WindowOpcode
+
+		// Offset and Default are only used by LAG and LEAD
+		Offset  int
+		Default sqlparser.Expr
+
+		// ColOffset is the column that holds the argument to the function, and that the result is written to
+		ColOffset int
+	}
+)
+
+// createWindowFromSelect builds the operators needed to evaluate the window functions of the query on vtgate:
+// a projection producing the columns, ordered by the window keys, and the Window operator on top of it.
+func createWindowFromSelect(ctx *plancontext.PlanningContext, qp *QueryProjection, horizon *Horizon, dt *DerivedTable) Operator {
+	var spec *sqlparser.WindowSpecification
+	var funcs []WindowFunc
+	src := horizon.src()
+	proj := newAliasedProjection(nil)
+	sqc := &SubQueryBuilder{}
+	outerID := TableID(src)
+	for idx, expr := range qp.SelectExprs {
+		ae, ok := expr.Col.(*sqlparser.AliasedExpr)
+		if !ok {
+			panic(vterrors.VT12001("in scatter query: window functions with '*'"))
+		}
+		if !sqlparser.ContainsWindowFunction(ae.Expr) {
+			org := ctx.SemTable.Clone(ae).(*sqlparser.AliasedExpr)
+			newExpr, subqs := sqc.pullOutValueSubqueries(ctx, ae.Expr, outerID, false)
+			if newExpr == nil {
+				proj.addUnexploredExpr(org, ae.Expr)
+			} else {
+				proj.addSubqueryExpr(ctx, org, newExpr, subqs...)
+			}
+			continue
+		}
+
+		over := sqlparser.GetOverClause(ae.Expr)
+		if over == nil {
+			panic(vterrors.VT12001(fmt.Sprintf("in scatter query: complex window function expression '%s'", sqlparser.String(ae.Expr))))
+		}
+		if !over.WindowName.IsEmpty() || !over.WindowSpec.Name.IsEmpty() {
+			panic(vterrors.VT12001("in scatter query: named windows"))
+		}
+		if over.WindowSpec.FrameClause != nil {
+			panic(vterrors.VT12001("in scatter query: window frame clause"))
+		}
+		if spec == nil {
+			spec = over.WindowSpec
+		} else if !ctx.SemTable.ASTEquals().RefOfWindowSpecification(spec, over.WindowSpec) {
+			panic(vterrors.VT12001("in scatter query: window functions with different window specifications"))
+		}
+
+		wf, arg := newWindowFunc(ae)
+		wf.ColOffset = idx
+		funcs = append(funcs, wf)
+		proj.addUnexploredExpr(aeWrap(arg), arg)
+	}
+	if spec == nil {
+		// the window functions are only used in the ORDER BY or HAVING clauses
+		panic(vterrors.VT12001("in scatter query: window functions that are not in the SELECT expressions"))
+	}
+	proj.Source = sqc.getRootOperator(src, nil)
+
+	var order []OrderBy
+	var partitionBy []GroupBy
+	for _, expr := range spec.PartitionClause {
+		partitionBy = append(partitionBy, NewGroupBy(expr))
+		order = append(order, OrderBy{
+			Inner:          &sqlparser.Order{Expr: expr, Direction: sqlparser.AscOrder},
+			SimplifiedExpr: expr,
+		})
+	}
+	var orderBy []GroupBy
+	for _, o := range spec.OrderClause {
+		orderBy = append(orderBy, NewGroupBy(o.Expr))
+		order = append(order, OrderBy{Inner: o, SimplifiedExpr: o.Expr})
+	}
+
+	var input Operator = proj
+	if len(order) > 0 {
+		input = newOrdering(proj, order)
+	}
+
+	return &Window{
+		unaryOperator: newUnaryOp(input),
+		Functions:     funcs,
+		PartitionBy:   partitionBy,
+		OrderBy:       orderBy,
+		DT:            dt,
+	}
+}
+
+// newWindowFunc returns the window function for the expression, and the argument the input needs to produce for it
+func newWindowFunc(ae *sqlparser.AliasedExpr) (WindowFunc, sqlparser.Expr) {
+	wf := WindowFunc{Original: ae}
+	var arg sqlparser.Expr = &sqlparser.NullVal{}
+	switch fn := ae.Expr.(type) {
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch fn.Type {
+		case sqlparser.RowNumberExprType:
+			wf.OpCode = opcode.WindowRowNumber
+		case sqlparser.RankExprType:
+			wf.OpCode = opcode.WindowRank
+		case sqlparser.DenseRankExprType:
+			wf.OpCode = opcode.WindowDenseRank
+		default:
+			panic(vterrors.VT12001(fmt.Sprintf("in scatter query: window function '%s'", sqlparser.String(fn))))
+		}
+	case *sqlparser.LagLeadExpr:
+		if fn.NullTreatmentClause != nil {
+			panic(vterrors.VT12001(fmt.Sprintf("in scatter query: window function '%s'", sqlparser.String(fn))))
+		}
+		wf.OpCode = opcode.WindowLag
+		if fn.Type == sqlparser.LeadExprType {
+			wf.OpCode = opcode.WindowLead
+		}
+		wf.Offset = 1
+		if fn.N != nil {
+			lit, ok := fn.N.(*sqlparser.Literal)
+			if !ok || lit.Type != sqlparser.IntVal {
+				panic(vterrors.VT12001(fmt.Sprintf("in scatter query: window function '%s'", sqlparser.String(fn))))
+			}
+			n, err := strconv.Atoi(lit.Val)
+			if err != nil {
+				panic(vterrors.VT13001(err.Error()))
+			}
+			wf.Offset = n
+		}
+		wf.Default = fn.Default
+		arg = fn.Expr
+	case *sqlparser.Sum:
+		if fn.Distinct {
+			panic(vterrors.VT12001(fmt.Sprintf("in scatter query: window function '%s'", sqlparser.String(fn))))
+		}
+		wf.OpCode = opcode.WindowSum
+		arg = fn.Arg
+	default:
+		panic(vterrors.VT12001(fmt.Sprintf("in scatter query: window function '%s'", sqlparser.String(fn))))
+	}
+	return wf, arg
+}
+
+// canPushWindowFunctions returns true if the window functions of the query can be evaluated by the
+// route below the horizon. This is the case when we hit a single shard, or when every window is
+// partitioned by a unique vindex column, which means that all rows of a partition live on the same shard.
+func canPushWindowFunctions(ctx *plancontext.PlanningContext, sel *sqlparser.Select, op Operator) bool {
+	if rb, isRoute := op.(*Route); isRoute && rb.IsSingleShard() {
+		return true
+	}
+
+	canPush := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node.(type) {
+		case *sqlparser.Subquery, *sqlparser.DerivedTable:
+			return false, nil
+		}
+		over := sqlparser.GetOverClause(node)
+		if over == nil {
+			return true, nil
+		}
+		if over.WindowSpec == nil || !over.WindowSpec.Name.IsEmpty() {
+			canPush = false
+			return false, nil
+		}
+		partitionedByVindex := slices.ContainsFunc(over.WindowSpec.PartitionClause, func(expr sqlparser.Expr) bool {
+			vindex := findColumnVindex(ctx, op, expr)
+			return vindex != nil && vindex.IsUnique()
+		})
+		if !partitionedByVindex {
+			canPush = false
+		}
+		return true, nil
+	}, sel.SelectExprs, sel.Having, sel.OrderBy)
+	return canPush
+}
+
+// needsWindow returns true if the window functions of the horizon have to be evaluated on vtgate
+func needsWindow(ctx *plancontext.PlanningContext, horizon *Horizon) bool {
+	sel, isSel := horizon.selectStatement().(*sqlparser.Select)
+	if !isSel || !containsWindowFunctions(sel) {
+		return false
+	}
+	rb, isRoute := horizon.src().(*Route)
+	return !isRoute || !canPushWindowFunctions(ctx, sel, rb)
+}
+
+// containsWindowFunctions returns true if the SELECT evaluates any window functions
+func containsWindowFunctions(sel *sqlparser.Select) bool {
+	return sqlparser.ContainsWindowFunction(sel.SelectExprs) ||
+		sqlparser.ContainsWindowFunction(sel.Having) ||
+		sqlparser.ContainsWindowFunction(sel.OrderBy)
+}
+
+func (w *Window) Clone(inputs []Operator) Operator {
+	kopy := *w
+	kopy.Source = inputs[0]
+	kopy.Functions = slices.Clone(w.Functions)
+	kopy.PartitionBy = slices.Clone(w.PartitionBy)
+	kopy.OrderBy = slices.Clone(w.OrderBy)
+	return &kopy
+}
+
+func (w *Window) AddPredicate(_ *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	// predicates can't be pushed below the window, since that would change the rows the functions are computed over
+	return newFilter(w, expr)
+}
+
+func (w *Window) AddColumn(ctx *plancontext.PlanningContext, reuse bool, gb bool, ae *sqlparser.AliasedExpr) int {
+	ae = &sqlparser.AliasedExpr{
+		Expr: w.DT.RewriteExpression(ctx, ae.Expr),
+		As:   ae.As,
+	}
+	if reuse {
+		if offset := w.FindCol(ctx, ae.Expr, false); offset >= 0 {
+			return offset
+		}
+	}
+	if sqlparser.ContainsWindowFunction(ae.Expr) {
+		panic(vterrors.VT12001(fmt.Sprintf("in scatter query: complex window function expression '%s'", sqlparser.String(ae.Expr))))
+	}
+	offset := w.Source.AddColumn(ctx, false, gb, ae)
+	w.checkOffset(offset)
+	return offset
+}
+
+func (w *Window) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
+	if w.isFunctionColumn(offset) {
+		panic(vterrors.VT12001("in scatter query: weight_string of window function"))
+	}
+	wsOffset := w.Source.AddWSColumn(ctx, offset, underRoute)
+	w.checkOffset(wsOffset)
+	return wsOffset
+}
+
+func (w *Window) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
+	expr = w.DT.RewriteExpression(ctx, expr)
+	for _, wf := range w.Functions {
+		if ctx.SemTable.EqualsExprWithDeps(wf.Original.Expr, expr) {
+			return wf.ColOffset
+		}
+	}
+	if sqlparser.ContainsWindowFunction(expr) {
+		return -1
+	}
+	offset := w.Source.FindCol(ctx, expr, underRoute)
+	if w.isFunctionColumn(offset) {
+		// the input column is overwritten by the window function, so it can't be used
+		return -1
+	}
+	w.checkOffset(offset)
+	return offset
+}
+
+func (w *Window) isFunctionColumn(offset int) bool {
+	return slices.ContainsFunc(w.Functions, func(wf WindowFunc) bool {
+		return wf.ColOffset == offset
+	})
+}
+
+func (w *Window) checkOffset(offset int) {
+	// if the offset is greater than the number of columns we expect to produce, we need to update the number of columns
+	// this is to make sure that the column is not truncated in the final result
+	if w.ResultColumns > 0 && w.ResultColumns <= offset {
+		w.ResultColumns = offset + 1
+	}
+}
+
+func (w *Window) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	columns := slices.Clone(w.Source.GetColumns(ctx))
+	for _, wf := range w.Functions {
+		columns[wf.ColOffset] = wf.Original
+	}
+	return truncate(w, columns)
+}
+
+func (w *Window) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
+	return transformColumnsToSelectExprs(ctx, w)
+}
+
+func (w *Window) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
+	return w.Source.GetOrdering(ctx)
+}
+
+func (w *Window) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	for i, key := range w.PartitionBy {
+		w.PartitionBy[i] = w.planKeyOffsets(ctx, key)
+	}
+	for i, key := range w.OrderBy {
+		w.OrderBy[i] = w.planKeyOffsets(ctx, key)
+	}
+	for i, wf := range w.Functions {
+		if wf.Default != nil {
+			// the default value is evaluated against the input row
+			w.Functions[i].Default = useOffsets(ctx, w.DT.RewriteExpression(ctx, wf.Default), w)
+		}
+	}
+	return nil
+}
+
+func (w *Window) planKeyOffsets(ctx *plancontext.PlanningContext, key GroupBy) GroupBy {
+	key.ColOffset = w.AddColumn(ctx, true, false, aeWrap(key.Inner))
+	if ctx.NeedsWeightString(key.Inner) {
+		key.WSOffset = w.AddWSColumn(ctx, key.ColOffset, false)
+	}
+	return key
+}
+
+func (w *Window) ShortDescription() string {
+	funcs := slice.Map(w.Functions, func(wf WindowFunc) string {
+		return sqlparser.String(wf.Original)
+	})
+	if w.DT != nil {
+		funcs = append([]string{w.DT.String()}, funcs...)
+	}
+	return strings.Join(funcs, ", ")
+}
+
+func (w *Window) introducesTableID() semantics.TableSet {
+	return w.DT.introducesTableID()
+}
+
+func (w *Window) setTruncateColumnCount(offset int) {
+	w.ResultColumns = offset
+}
+
+func (w *Window) getTruncateColumnCount() int {
+	return w.ResultColumns
+}
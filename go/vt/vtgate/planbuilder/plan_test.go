@@ -80,7 +80,8 @@ func (s *planTestSuite) TestPlan() {
 	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
 	require.NoError(s.T(), err)
 
-	s.addPKs(vschema, "user", []string{"user", "music"})
+	s.addPKs(vschema, "user", []string{"user", "music", "movable_user"})
+	s.addMovableUserColumns(vschema)
 	s.addPKsProvided(vschema, "user", []string{"user_extra"}, []string{"id", "user_id"})
 	s.addPKsProvided(vschema, "ordering", []string{"order"}, []string{"oid", "region_id"})
 	s.addPKsProvided(vschema, "ordering", []string{"order_event"}, []string{"oid", "ename"})
@@ -248,6 +249,21 @@ func (s *planTestSuite) addPKs(vschema *vindexes.VSchema, ks string, tbls []stri
 	}
 }
 
+// addMovableUserColumns adds the columns that schema tracking finds for movable_user,
+// which include a generated column and a column with an ON UPDATE clause.
+func (s *planTestSuite) addMovableUserColumns(vschema *vindexes.VSchema) {
+	tbl := vschema.Keyspaces["user"].Tables["movable_user"]
+	tbl.ColumnListAuthoritative = true
+	tbl.Columns = []vindexes.Column{
+		{Name: sqlparser.NewIdentifierCI("id"), Type: sqltypes.Int64},
+		{Name: sqlparser.NewIdentifierCI("tenant_id"), Type: sqltypes.Int64},
+		{Name: sqlparser.NewIdentifierCI("val"), Type: sqltypes.Int64},
+		{Name: sqlparser.NewIdentifierCI("status"), Type: sqltypes.VarChar},
+		{Name: sqlparser.NewIdentifierCI("val_plus_one"), Type: sqltypes.Int64, Generated: true},
+		{Name: sqlparser.NewIdentifierCI("updated_at"), Type: sqltypes.Timestamp, OnUpdate: &sqlparser.CurTimeFuncExpr{Name: sqlparser.NewIdentifierCI("current_timestamp")}},
+	}
+}
+
 func (s *planTestSuite) addPKsProvided(vschema *vindexes.VSchema, ks string, tbls []string, pks []string) {
 	for _, tbl := range tbls {
 		require.NoError(s.T(),
@@ -300,7 +316,8 @@ func (s *planTestSuite) TestOne() {
 	require.NoError(s.T(), err)
 
 	s.setFks(vschema)
-	s.addPKs(vschema, "user", []string{"user", "music"})
+	s.addPKs(vschema, "user", []string{"user", "music", "movable_user"})
+	s.addMovableUserColumns(vschema)
 	s.addPKs(vschema, "main", []string{"unsharded"})
 	s.addPKsProvided(vschema, "user", []string{"user_extra"}, []string{"id", "user_id"})
 	s.addPKsProvided(vschema, "ordering", []string{"order"}, []string{"oid", "region_id"})
@@ -592,6 +609,15 @@ func (s *planTestSuite) TestMirrorPlanning() {
 	s.testFile("mirror_cases.json", vw, false)
 }
 
+func (s *planTestSuite) TestTenantPlanning() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/tenant_schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+
+	s.testFile("tenant_cases.json", vw, false)
+}
+
 func (s *planTestSuite) TestOneMirror() {
 	reset := operators.EnableDebugPrinting()
 	defer reset()
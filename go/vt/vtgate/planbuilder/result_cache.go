@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// nonDeterministicFuncs are the functions that can return a different result
+// for the same arguments, or that have side effects.
+var nonDeterministicFuncs = map[string]bool{
+	"benchmark":         true,
+	"connection_id":     true,
+	"curdate":           true,
+	"current_date":      true,
+	"current_role":      true,
+	"current_user":      true,
+	"last_insert_id":    true,
+	"master_pos_wait":   true,
+	"rand":              true,
+	"random_bytes":      true,
+	"release_all_locks": true,
+	"session_user":      true,
+	"sleep":             true,
+	"source_pos_wait":   true,
+	"system_user":       true,
+	"unix_timestamp":    true,
+	"user":              true,
+	"utc_date":          true,
+	"uuid":              true,
+	"uuid_short":        true,
+}
+
+// resultCacheable returns true if the results of the statement only depend on
+// its bind vars and on the rows that it reads, so that vtgate can cache them.
+// This excludes the statements that lock rows, that have side effects on the
+// session, and that call functions like NOW() or RAND().
+func resultCacheable(stmt sqlparser.Statement) bool {
+	switch stmt.(type) {
+	case *sqlparser.Select, *sqlparser.Union:
+	default:
+		return false
+	}
+
+	cacheable := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			if node.Lock != sqlparser.NoLock || node.Into != nil || node.SQLCalcFoundRows ||
+				(node.Cache != nil && !*node.Cache) {
+				cacheable = false
+			}
+		case *sqlparser.Union:
+			if node.Lock != sqlparser.NoLock || node.Into != nil {
+				cacheable = false
+			}
+		case *sqlparser.CurTimeFuncExpr, *sqlparser.LockingFunc, *sqlparser.Variable:
+			cacheable = false
+		case *sqlparser.FuncExpr:
+			if nonDeterministicFuncs[node.Name.Lowered()] {
+				cacheable = false
+			}
+		}
+		return cacheable, nil
+	}, stmt)
+	return cacheable
+}
@@ -79,6 +79,11 @@ func (r *rewriter) rewriteHavingClause(node *sqlparser.Select) {
 	if node.Having == nil {
 		return
 	}
+	if node.GroupBy != nil && node.GroupBy.WithRollup {
+		// the HAVING clause also filters the super-aggregate rows of the rollup,
+		// which a WHERE predicate would not see
+		return
+	}
 
 	// for each expression in the having clause, we check if it contains aggregation.
 	// if it does, we keep the expression in the having clause ; and if it does not
@@ -18,6 +18,7 @@ package planbuilder
 
 import (
 	"fmt"
+	"slices"
 
 	"vitess.io/vitess/go/vt/key"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -37,6 +38,7 @@ func gen4SelectStmtPlanner(
 	reservedVars *sqlparser.ReservedVars,
 	vschema plancontext.VSchema,
 ) (*planResult, error) {
+	var coveringOwner string
 	sel, isSel := stmt.(*sqlparser.Select)
 	if isSel {
 		// handle dual table for processing at vtgate.
@@ -60,10 +62,21 @@ func gen4SelectStmtPlanner(
 		}
 		// if there was no limit, we can safely ignore the SQLCalcFoundRows directive
 		sel.SQLCalcFoundRows = false
+
+		if covering, owner := rewriteForCoveringLookup(sel, vschema); covering != nil {
+			sel, stmt = covering, covering
+			coveringOwner = owner
+		}
 	}
 
 	getPlan := func(selStatement sqlparser.SelectStatement) (engine.Primitive, []string, error) {
-		return newBuildSelectPlan(selStatement, reservedVars, vschema, plannerVersion)
+		prim, tablesUsed, err := newBuildSelectPlan(selStatement, reservedVars, vschema, plannerVersion)
+		if err == nil && coveringOwner != "" {
+			// the query reads from the lookup table, but it still uses the owner table
+			tablesUsed = append(tablesUsed, coveringOwner)
+			slices.Sort(tablesUsed)
+		}
+		return prim, tablesUsed, err
 	}
 
 	plan, tablesUsed, err := getPlan(stmt)
@@ -0,0 +1,368 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// tenantTable is a table of a multi-tenant keyspace that is used by a query.
+type tenantTable struct {
+	expr   *sqlparser.AliasedTableExpr
+	column sqlparser.IdentifierCI
+	mode   vschemapb.MultiTenantSpec_TenantPredicateMode
+	// join is the outer join that the table is on the inner side of, if any.
+	// The predicate on the tenant column of such a table belongs to the join
+	// condition, and not to the WHERE clause.
+	join *sqlparser.JoinTableExpr
+}
+
+// enforceTenantPredicates checks that the queries on the tables of the
+// multi-tenant keyspaces that have a tenant predicate mode have a predicate on
+// the tenant column. Depending on the mode, a missing predicate either fails the
+// query, or is added with the tenant id of the session as its value. The added
+// predicates also let the query be routed to the shard of the tenant.
+// It returns the values that the query gives to the tenant column, which have to
+// match the tenant id of the session when the query is executed.
+func enforceTenantPredicates(stmt sqlparser.Statement, vschema plancontext.VSchema, bindVarNeeds *sqlparser.BindVarNeeds) ([]evalengine.Expr, error) {
+	switch stmt.(type) {
+	case *sqlparser.Select, *sqlparser.Union, *sqlparser.Update, *sqlparser.Delete, *sqlparser.Insert:
+	default:
+		return nil, nil
+	}
+
+	te := &tenantEnforcer{vschema: vschema}
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			return true, te.enforce(node.From, node.Where, node.AddWhere, nil)
+		case *sqlparser.Update:
+			return true, te.enforce(node.TableExprs, node.Where, node.AddWhere, node.Exprs)
+		case *sqlparser.Delete:
+			return true, te.enforce(node.TableExprs, node.Where, node.AddWhere, nil)
+		case *sqlparser.Insert:
+			return true, te.enforceInsert(node)
+		}
+		return true, nil
+	}, stmt)
+	if err != nil {
+		return nil, err
+	}
+	if te.injected && !bindVarNeeds.NeedsFuncResult(sqlparser.TenantIDName) {
+		bindVarNeeds.AddFuncResult(sqlparser.TenantIDName)
+	}
+	return te.values, nil
+}
+
+type tenantEnforcer struct {
+	vschema plancontext.VSchema
+	// injected is set when a predicate on the tenant id of the session was added.
+	injected bool
+	// values are the values that the query compares the tenant columns to.
+	values []evalengine.Expr
+}
+
+// tenantColumn returns the tenant column of the table, if the table is in a
+// multi-tenant keyspace with a tenant predicate mode, and has the tenant column.
+func (te *tenantEnforcer) tenantColumn(tableName sqlparser.TableName) (sqlparser.IdentifierCI, vschemapb.MultiTenantSpec_TenantPredicateMode, bool) {
+	none := vschemapb.MultiTenantSpec_none
+	tbl, _, _, _, err := te.vschema.FindTable(tableName)
+	if err != nil || tbl == nil || tbl.Keyspace == nil || tbl.Type == vindexes.TypeReference {
+		// The planner reports the tables that can't be found.
+		return sqlparser.IdentifierCI{}, none, false
+	}
+	vs := te.vschema.GetVSchema()
+	if vs == nil {
+		return sqlparser.IdentifierCI{}, none, false
+	}
+	ks := vs.Keyspaces[tbl.Keyspace.Name]
+	if ks == nil || ks.MultiTenantSpec == nil || ks.MultiTenantSpec.TenantPredicateMode == none {
+		return sqlparser.IdentifierCI{}, none, false
+	}
+	column := sqlparser.NewIdentifierCI(ks.MultiTenantSpec.TenantIdColumnName)
+	if column.IsEmpty() {
+		return sqlparser.IdentifierCI{}, none, false
+	}
+	for _, col := range tbl.Columns {
+		if col.Name.Equal(column) {
+			return column, ks.MultiTenantSpec.TenantPredicateMode, true
+		}
+	}
+	if len(tbl.ColumnVindexes) > 0 {
+		for _, col := range tbl.ColumnVindexes[0].Columns {
+			if col.Equal(column) {
+				return column, ks.MultiTenantSpec.TenantPredicateMode, true
+			}
+		}
+	}
+	return sqlparser.IdentifierCI{}, none, false
+}
+
+// collect returns the tenant tables in the table expression.
+func (te *tenantEnforcer) collect(expr sqlparser.TableExpr, join *sqlparser.JoinTableExpr, tables []tenantTable) []tenantTable {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		tableName, ok := expr.Expr.(sqlparser.TableName)
+		if !ok {
+			return tables
+		}
+		if column, mode, ok := te.tenantColumn(tableName); ok {
+			tables = append(tables, tenantTable{expr: expr, column: column, mode: mode, join: join})
+		}
+	case *sqlparser.JoinTableExpr:
+		leftJoin, rightJoin := join, join
+		switch expr.Join {
+		case sqlparser.LeftJoinType, sqlparser.NaturalLeftJoinType:
+			rightJoin = expr
+		case sqlparser.RightJoinType, sqlparser.NaturalRightJoinType:
+			leftJoin = expr
+		}
+		tables = te.collect(expr.LeftExpr, leftJoin, tables)
+		tables = te.collect(expr.RightExpr, rightJoin, tables)
+	case *sqlparser.ParenTableExpr:
+		for _, e := range expr.Exprs {
+			tables = te.collect(e, join, tables)
+		}
+	}
+	return tables
+}
+
+// enforce checks the tenant predicates of the tables of a SELECT, UPDATE or
+// DELETE statement. The predicate on a table has to be in the WHERE clause, or
+// in the condition of the outer join that the table is on the inner side of.
+// The conditions of inner joins compare columns with each other, and do not
+// scope the tables to a tenant. The values that an UPDATE sets the tenant
+// columns to are checked as well.
+func (te *tenantEnforcer) enforce(from []sqlparser.TableExpr, where *sqlparser.Where, addWhere func(sqlparser.Expr), set sqlparser.UpdateExprs) error {
+	var tables []tenantTable
+	for _, expr := range from {
+		tables = te.collect(expr, nil, tables)
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	var conds []sqlparser.Expr
+	if where != nil {
+		conds = sqlparser.SplitAndExpression(nil, where.Expr)
+	}
+
+	// A single table does not need its columns to be qualified.
+	qualify := len(from) > 1
+	if _, isAliased := from[0].(*sqlparser.AliasedTableExpr); !isAliased {
+		qualify = true
+	}
+	for _, tt := range tables {
+		tableName, err := tt.expr.TableName()
+		if err != nil {
+			return err
+		}
+		if err := te.enforceSet(set, tableName, tt.column); err != nil {
+			return err
+		}
+		tableConds := conds
+		if tt.join != nil {
+			tableConds = nil
+			if tt.join.Condition != nil {
+				tableConds = sqlparser.SplitAndExpression(nil, tt.join.Condition.On)
+			}
+		}
+		values := tenantPredicateValues(tableConds, tableName, tt.column)
+		if len(values) > 0 {
+			if err := te.addValues(values); err != nil {
+				return err
+			}
+			continue
+		}
+		if tt.mode == vschemapb.MultiTenantSpec_reject {
+			return vterrors.VT09032(sqlparser.String(tableName), tt.column.String())
+		}
+
+		col := sqlparser.NewColName(tt.column.String())
+		if qualify {
+			col.Qualifier = sqlparser.NewTableName(tableName.Name.String())
+		}
+		pred := &sqlparser.ComparisonExpr{
+			Operator: sqlparser.EqualOp,
+			Left:     col,
+			Right:    sqlparser.NewArgument(sqlparser.TenantIDName),
+		}
+		te.injected = true
+		if tt.join == nil {
+			addWhere(pred)
+			continue
+		}
+		if tt.join.Condition == nil || len(tt.join.Condition.Using) > 0 {
+			return vterrors.VT12001("tenant predicate on a NATURAL outer join or an outer join with a USING clause")
+		}
+		tt.join.Condition.On = sqlparser.AndExpressions(tt.join.Condition.On, pred)
+	}
+	return nil
+}
+
+// enforceInsert checks that the rows of an INSERT statement have a value for the
+// tenant column.
+func (te *tenantEnforcer) enforceInsert(ins *sqlparser.Insert) error {
+	tableName, ok := ins.Table.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil
+	}
+	column, mode, ok := te.tenantColumn(tableName)
+	if !ok {
+		return nil
+	}
+	if err := te.enforceSet(sqlparser.UpdateExprs(ins.OnDup), tableName, column); err != nil {
+		return err
+	}
+
+	if len(ins.Columns) == 0 {
+		// Without a column list, the rows have a value for every column, in the
+		// order of the columns of the table.
+		tbl, _, _, _, err := te.vschema.FindTable(tableName)
+		if err != nil {
+			return err
+		}
+		if !tbl.ColumnListAuthoritative {
+			return vterrors.VT12001("INSERT without a column list into a multi-tenant table without an authoritative column list")
+		}
+		for idx, col := range tbl.Columns {
+			if col.Name.Equal(column) {
+				return te.enforceInsertRows(ins.Rows, idx)
+			}
+		}
+		return vterrors.VT09032(sqlparser.String(tableName), column.String())
+	}
+	if idx := ins.Columns.FindColumn(column); idx >= 0 {
+		return te.enforceInsertRows(ins.Rows, idx)
+	}
+	if mode == vschemapb.MultiTenantSpec_reject {
+		return vterrors.VT09032(sqlparser.String(tableName), column.String())
+	}
+
+	switch rows := ins.Rows.(type) {
+	case sqlparser.Values:
+		for i := range rows {
+			rows[i] = append(rows[i], sqlparser.NewArgument(sqlparser.TenantIDName))
+		}
+	case *sqlparser.Select:
+		rows.AddSelectExpr(&sqlparser.AliasedExpr{Expr: sqlparser.NewArgument(sqlparser.TenantIDName)})
+	default:
+		return vterrors.VT12001("tenant column value in an INSERT with a UNION")
+	}
+	ins.Columns = append(ins.Columns, column)
+	te.injected = true
+	return nil
+}
+
+// enforceInsertRows adds the values that the rows of an INSERT give to the
+// tenant column, which is the column at position idx, to the ones that have to
+// match the tenant id of the session.
+func (te *tenantEnforcer) enforceInsertRows(rows sqlparser.InsertRows, idx int) error {
+	var values []sqlparser.Expr
+	switch rows := rows.(type) {
+	case sqlparser.Values:
+		for _, row := range rows {
+			if idx >= len(row) {
+				// The planner reports the rows that don't match the columns.
+				continue
+			}
+			values = append(values, row[idx])
+		}
+	case *sqlparser.Select:
+		for i, expr := range rows.GetColumns() {
+			ae, ok := expr.(*sqlparser.AliasedExpr)
+			if !ok {
+				return vterrors.VT12001("INSERT ... SELECT * into a multi-tenant table")
+			}
+			if i == idx {
+				values = append(values, ae.Expr)
+			}
+		}
+	default:
+		return vterrors.VT12001("tenant column value in an INSERT with a UNION")
+	}
+	return te.addValues(values)
+}
+
+// enforceSet adds the values that the update expressions give to the tenant
+// column of the table to the ones that have to match the tenant id of the
+// session.
+func (te *tenantEnforcer) enforceSet(set sqlparser.UpdateExprs, tableName sqlparser.TableName, column sqlparser.IdentifierCI) error {
+	var values []sqlparser.Expr
+	for _, ue := range set {
+		if !ue.Name.Name.Equal(column) {
+			continue
+		}
+		if !ue.Name.Qualifier.IsEmpty() && ue.Name.Qualifier.Name.String() != tableName.Name.String() {
+			continue
+		}
+		values = append(values, ue.Expr)
+	}
+	return te.addValues(values)
+}
+
+// addValues adds the values of the tenant column to the ones that have to match
+// the tenant id of the session. The values have to be literals or bind
+// variables, since the others can't be checked before the query is run.
+func (te *tenantEnforcer) addValues(values []sqlparser.Expr) error {
+	cfg := &evalengine.Config{
+		Collation:   te.vschema.ConnCollation(),
+		Environment: te.vschema.Environment(),
+	}
+	for _, value := range values {
+		if !sqlparser.IsValue(value) {
+			return vterrors.VT12001("a value of the tenant column that is not a literal or a bind variable: " + sqlparser.String(value))
+		}
+		expr, err := evalengine.Translate(value, cfg)
+		if err != nil {
+			return err
+		}
+		te.values = append(te.values, expr)
+	}
+	return nil
+}
+
+// tenantPredicateValues returns the values of the predicates that compare the
+// tenant column of the table to a literal or a bind variable. Comparisons with
+// other columns do not scope the table to a tenant, and are not counted.
+func tenantPredicateValues(predicates []sqlparser.Expr, tableName sqlparser.TableName, column sqlparser.IdentifierCI) []sqlparser.Expr {
+	isTenantColumn := func(expr sqlparser.Expr) bool {
+		col, ok := expr.(*sqlparser.ColName)
+		if !ok || !col.Name.Equal(column) {
+			return false
+		}
+		return col.Qualifier.IsEmpty() || col.Qualifier.Name.String() == tableName.Name.String()
+	}
+	var values []sqlparser.Expr
+	for _, pred := range predicates {
+		cmp, ok := pred.(*sqlparser.ComparisonExpr)
+		if !ok || cmp.Operator != sqlparser.EqualOp {
+			continue
+		}
+		switch {
+		case isTenantColumn(cmp.Left) && sqlparser.IsValue(cmp.Right):
+			values = append(values, cmp.Right)
+		case isTenantColumn(cmp.Right) && sqlparser.IsValue(cmp.Left):
+			values = append(values, cmp.Left)
+		}
+	}
+	return values
+}
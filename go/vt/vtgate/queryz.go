@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logz"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 )
 
@@ -34,6 +35,7 @@ var (
 	queryzHeader = []byte(`<thead>
 		<tr>
 			<th>Query</th>
+			<th>Digest</th>
 			<th>Count</th>
 			<th>Time</th>
 			<th>Shard Queries</th>
@@ -51,6 +53,7 @@ var (
 	queryzTmpl = template.Must(template.New("example").Parse(`
 		<tr class="{{.Color}}">
 			<td>{{.Query}}</td>
+			<td>{{.Digest}}</td>
 			<td>{{.Count}}</td>
 			<td>{{.Time}}</td>
 			<td>{{.ShardQueries}}</td>
@@ -70,6 +73,7 @@ var (
 // using go's template.
 type queryzRow struct {
 	Query        string
+	Digest       string
 	Table        string
 	Count        uint64
 	tm           time.Duration
@@ -144,7 +148,8 @@ func queryzHandler(e *Executor, w http.ResponseWriter, r *http.Request) {
 
 	e.ForEachPlan(func(plan *engine.Plan) bool {
 		Value := &queryzRow{
-			Query: logz.Wrappable(e.env.Parser().TruncateForUI(plan.Original)),
+			Query:  logz.Wrappable(e.env.Parser().TruncateForUI(plan.Original)),
+			Digest: sqlparser.QueryDigest(plan.Original),
 		}
 		Value.Count, Value.tm, Value.ShardQueries, Value.RowsAffected, Value.RowsReturned, Value.Errors = plan.Stats()
 		var timepq time.Duration
@@ -30,7 +30,10 @@ import (
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
@@ -87,6 +90,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern1 := []string{
 		`<tr class="low">`,
 		"<td>select id from `user` where id = 1</td>",
+		`<td>[0-9a-f]{16}</td>`,
 		`<td>1</td>`,
 		`<td>0.001000</td>`,
 		`<td>1</td>`,
@@ -104,6 +108,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern2 := []string{
 		`<tr class="high">`,
 		"<td>select id from `user`</td>",
+		`<td>[0-9a-f]{16}</td>`,
 		`<td>1</td>`,
 		`<td>1.000000</td>`,
 		`<td>8</td>`,
@@ -121,6 +126,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern3 := []string{
 		`<tr class="medium">`,
 		"<td>insert into `user`.*</td>",
+		`<td>[0-9a-f]{16}</td>`,
 		`<td>2</td>`,
 		`<td>0.100000</td>`,
 		`<td>2</td>`,
@@ -138,6 +144,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern4 := []string{
 		`<tr class="high">`,
 		`<td>insert into name_user_map.*</td>`,
+		`<td>[0-9a-f]{16}</td>`,
 		`<td>2</td>`,
 		`<td>0.200000</td>`,
 		`<td>2</td>`,
@@ -161,3 +168,36 @@ func checkQueryzHasPlan(t *testing.T, planPattern []string, plan *engine.Plan, p
 		t.Fatalf("queryz page does not contain\nplan:\n%v\npattern:\n%v\npage:\n%s", plan, strings.Join(planPattern, `\s*`), string(page))
 	}
 }
+
+func TestQueryzDigestMatchesTabletRule(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+	executor.config.Normalize = true
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	for _, sql := range []string{
+		"select id from user where id = 1",
+		"select /* comment */ id, name FROM user WHERE id = 1 and name != 'a'",
+		"select id from user where id = 1 and name in ('a', 'b') order by id limit 10",
+	} {
+		sbc1.Queries = nil
+		_, err := executorExec(ctx, executor, session, sql, nil)
+		require.NoError(t, err)
+		require.Len(t, sbc1.Queries, 1)
+
+		// The digest that queryz shows for the plan of vtgate matches the
+		// query that the tablet gets.
+		var digest string
+		require.Eventually(t, func() bool {
+			executor.ForEachPlan(func(plan *engine.Plan) bool {
+				digest = sqlparser.QueryDigest(plan.Original)
+				return false
+			})
+			return digest != ""
+		}, 5*time.Second, 10*time.Millisecond)
+
+		qr := rules.NewQueryRule("", "digest", rules.QRFail)
+		require.NoError(t, qr.SetQueryDigestCond(digest))
+		require.NotNil(t, qr.FilterByPlan(sbc1.Queries[0].Sql, planbuilder.PlanSelect, []string{"user"}), sbc1.Queries[0].Sql)
+		executor.ClearPlans()
+	}
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+var (
+	resultCacheHits          = stats.NewCounter("ResultCacheHits", "Queries answered from the vtgate result cache")
+	resultCacheMisses        = stats.NewCounter("ResultCacheMisses", "Cacheable queries that were not found in the vtgate result cache")
+	resultCacheInvalidations = stats.NewCountersWithSingleLabel("ResultCacheInvalidations", "Invalidations of the cached results of a table by the changes streamed from its keyspace", "Table")
+
+	// resultCacheRetryDelay is the initial delay before the invalidation stream of
+	// a keyspace is restarted after an error. It doubles up to resultCacheMaxRetryDelay.
+	resultCacheRetryDelay    = 1 * time.Second
+	resultCacheMaxRetryDelay = 1 * time.Minute
+)
+
+// ResultCacheStreamer streams the changes to the given tables of a keyspace,
+// from the current position, to the send function.
+type ResultCacheStreamer func(ctx context.Context, keyspace string, tables []string, send func([]*binlogdatapb.VEvent) error) error
+
+// ResultCache caches the results of the SELECT queries on the tables that have
+// a result cache TTL in the vschema. The results are keyed on the normalized
+// query, its bind vars and the target of the session.
+//
+// The cached results of a table are invalidated by the row events of the table,
+// which are streamed from the primaries of its keyspace. Every table has a
+// generation that is incremented by its row events, and a cached result is only
+// valid as long as the generations of its tables are the ones that were read
+// before the query was executed. The results are only cached while the stream
+// of their keyspace is running, and the TTL limits how long a result is kept in
+// any case.
+//
+// Only the queries that target the primaries are cached: the stream says when
+// the primary changed, but not when a replica applied the change, so a result
+// read from a lagging replica could be cached after its invalidation.
+//
+// The autocommitted DMLs of a session invalidate the results of their tables as
+// soon as they are executed, so that the next queries of the session see their
+// changes. The changes of the other sessions, and the ones committed by an
+// explicit transaction, are only seen once they come through the stream, i.e.
+// the cached results lag behind the primary by the delay of the stream.
+type ResultCache struct {
+	entries *cache.LRUCache[*resultCacheEntry]
+	maxRows int
+	stream  ResultCacheStreamer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// ttls are the TTLs of the tables that are cached, keyed on keyspace.table.
+	ttls map[string]time.Duration
+	// generations are incremented by the changes to the tables.
+	generations map[string]uint64
+	// watchers stream the changes to the cached tables of each keyspace.
+	watchers map[string]*resultCacheWatcher
+}
+
+type resultCacheEntry struct {
+	result      *sqltypes.Result
+	expires     time.Time
+	generations []uint64
+}
+
+// resultCacheQuery is a cacheable query that is being executed.
+type resultCacheQuery struct {
+	key         string
+	ttl         time.Duration
+	tables      []string
+	generations []uint64
+}
+
+// resultCacheWatcher streams the changes to the cached tables of a keyspace.
+type resultCacheWatcher struct {
+	keyspace string
+	tables   []string
+	cancel   context.CancelFunc
+	// ready is set once the stream is running. It is protected by ResultCache.mu.
+	ready bool
+}
+
+// NewResultCache creates a result cache that holds up to size results of up to
+// maxRows rows each.
+func NewResultCache(size, maxRows int, stream ResultCacheStreamer) *ResultCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ResultCache{
+		entries:     cache.NewLRUCache[*resultCacheEntry](int64(size)),
+		maxRows:     maxRows,
+		stream:      stream,
+		ctx:         ctx,
+		cancel:      cancel,
+		ttls:        make(map[string]time.Duration),
+		generations: make(map[string]uint64),
+		watchers:    make(map[string]*resultCacheWatcher),
+	}
+}
+
+// vstreamResultCacheStreamer returns a ResultCacheStreamer that streams the
+// changes from the primaries through the vstream manager.
+func vstreamResultCacheStreamer(vsm *vstreamManager) ResultCacheStreamer {
+	return func(ctx context.Context, keyspace string, tables []string, send func([]*binlogdatapb.VEvent) error) error {
+		vgtid := &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: keyspace, Gtid: "current"}},
+		}
+		filter := &binlogdatapb.Filter{}
+		for _, table := range tables {
+			filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: table})
+		}
+		return vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, vgtid, filter, &vtgatepb.VStreamFlags{}, send)
+	}
+}
+
+// SetVSchema updates the TTLs of the tables, and starts and stops the streams
+// of the keyspaces accordingly.
+func (rc *ResultCache) SetVSchema(vschema *vindexes.VSchema) {
+	ttls := make(map[string]time.Duration)
+	keyspaceTables := make(map[string][]string)
+	for ksName, ks := range vschema.Keyspaces {
+		if ks.Error != nil {
+			continue
+		}
+		for tableName, table := range ks.Tables {
+			if table.ResultCacheTTL <= 0 {
+				continue
+			}
+			ttls[ksName+"."+tableName] = table.ResultCacheTTL
+			keyspaceTables[ksName] = append(keyspaceTables[ksName], tableName)
+		}
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.ttls = ttls
+	for ksName, w := range rc.watchers {
+		tables := keyspaceTables[ksName]
+		slices.Sort(tables)
+		if slices.Equal(tables, w.tables) {
+			continue
+		}
+		w.cancel()
+		rc.invalidateLocked(w)
+		delete(rc.watchers, ksName)
+	}
+	for ksName, tables := range keyspaceTables {
+		if _, ok := rc.watchers[ksName]; ok {
+			continue
+		}
+		slices.Sort(tables)
+		ctx, cancel := context.WithCancel(rc.ctx)
+		w := &resultCacheWatcher{keyspace: ksName, tables: tables, cancel: cancel}
+		rc.watchers[ksName] = w
+		rc.wg.Add(1)
+		go func() {
+			defer rc.wg.Done()
+			rc.watch(ctx, w)
+		}()
+	}
+}
+
+// Close stops the streams of the keyspaces.
+func (rc *ResultCache) Close() {
+	rc.cancel()
+	rc.wg.Wait()
+}
+
+// watch streams the changes to the tables of the watcher until its context is
+// canceled, and restarts the stream after errors.
+func (rc *ResultCache) watch(ctx context.Context, w *resultCacheWatcher) {
+	delay := resultCacheRetryDelay
+	for {
+		err := rc.stream(ctx, w.keyspace, w.tables, func(events []*binlogdatapb.VEvent) error {
+			rc.handleEvents(w, events)
+			return nil
+		})
+
+		// The changes are not known until the stream is running again.
+		rc.mu.Lock()
+		rc.invalidateLocked(w)
+		rc.mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("result cache: the stream of keyspace %s stopped, restarting it in %v: %v", w.keyspace, delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, resultCacheMaxRetryDelay)
+	}
+}
+
+func (rc *ResultCache) handleEvents(w *resultCacheWatcher, events []*binlogdatapb.VEvent) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, event := range events {
+		switch event.Type {
+		case binlogdatapb.VEventType_VGTID:
+			// The first VGTID event is sent once the stream has its starting
+			// position on every shard.
+			w.ready = true
+		case binlogdatapb.VEventType_ROW:
+			rc.invalidateTableLocked(event.RowEvent.TableName)
+		case binlogdatapb.VEventType_DDL, binlogdatapb.VEventType_JOURNAL:
+			for _, table := range w.tables {
+				rc.invalidateTableLocked(w.keyspace + "." + table)
+			}
+		}
+	}
+}
+
+// invalidateLocked invalidates the results of the tables of the watcher, and
+// stops caching them until its stream is running again.
+func (rc *ResultCache) invalidateLocked(w *resultCacheWatcher) {
+	w.ready = false
+	for _, table := range w.tables {
+		rc.invalidateTableLocked(w.keyspace + "." + table)
+	}
+}
+
+func (rc *ResultCache) invalidateTableLocked(table string) {
+	rc.generations[table]++
+	resultCacheInvalidations.Add(table, 1)
+}
+
+// lookup returns the cached result of the query, if it has one. Otherwise, it
+// returns the query to pass to store once it is executed, if the query is
+// cacheable.
+func (rc *ResultCache) lookup(ctx context.Context, safeSession *econtext.SafeSession, tabletType topodatapb.TabletType, plan *engine.Plan, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, *resultCacheQuery) {
+	if !plan.Cacheable || len(plan.TablesUsed) == 0 || tabletType != topodatapb.TabletType_PRIMARY ||
+		safeSession.InTransaction() || safeSession.InReservedConn() || safeSession.HasSystemVariables() {
+		return nil, nil
+	}
+
+	q := &resultCacheQuery{tables: plan.TablesUsed}
+	rc.mu.Lock()
+	for _, table := range plan.TablesUsed {
+		ttl, ok := rc.ttls[table]
+		if !ok {
+			rc.mu.Unlock()
+			return nil, nil
+		}
+		ksName, _, _ := strings.Cut(table, ".")
+		if w := rc.watchers[ksName]; w == nil || !w.ready {
+			rc.mu.Unlock()
+			return nil, nil
+		}
+		if q.ttl == 0 || ttl < q.ttl {
+			q.ttl = ttl
+		}
+		q.generations = append(q.generations, rc.generations[table])
+	}
+	rc.mu.Unlock()
+
+	var err error
+	q.key, err = resultCacheKey(callerid.EffectiveCallerIDFromContext(ctx), callerid.ImmediateCallerIDFromContext(ctx), safeSession.TargetString, plan.Original, bindVars)
+	if err != nil {
+		return nil, nil
+	}
+	if entry, ok := rc.entries.Get(q.key); ok {
+		if time.Now().Before(entry.expires) && slices.Equal(entry.generations, q.generations) {
+			resultCacheHits.Add(1)
+			return entry.result.Copy(), nil
+		}
+		rc.entries.Delete(q.key)
+	}
+	resultCacheMisses.Add(1)
+	return nil, q
+}
+
+// invalidateWrites invalidates the results of the tables written by the DML of
+// the plan, without waiting for its changes to come through the stream.
+func (rc *ResultCache) invalidateWrites(plan *engine.Plan) {
+	switch plan.QueryType {
+	case sqlparser.StmtInsert, sqlparser.StmtReplace, sqlparser.StmtUpdate, sqlparser.StmtDelete:
+	default:
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, table := range plan.TablesUsed {
+		if _, ok := rc.ttls[table]; ok {
+			rc.invalidateTableLocked(table)
+		}
+	}
+}
+
+// store caches the result of the query, unless one of its tables changed
+// while it was executed.
+func (rc *ResultCache) store(q *resultCacheQuery, result *sqltypes.Result) {
+	if len(result.Rows) > rc.maxRows {
+		return
+	}
+	rc.mu.Lock()
+	for i, table := range q.tables {
+		if rc.generations[table] != q.generations[i] {
+			rc.mu.Unlock()
+			return
+		}
+	}
+	rc.mu.Unlock()
+
+	rc.entries.Set(q.key, &resultCacheEntry{
+		result:      result.Copy(),
+		expires:     time.Now().Add(q.ttl),
+		generations: q.generations,
+	})
+}
+
+// resultCacheKey returns the key of the results of a query of the callers on
+// the target. The results of a caller are not served to the others, since the
+// table ACLs of the tablets are enforced for each caller.
+func resultCacheKey(effectiveCaller *vtrpcpb.CallerID, immediateCaller *querypb.VTGateCallerID, target, query string, bindVars map[string]*querypb.BindVariable) (string, error) {
+	effective, err := effectiveCaller.MarshalVT()
+	if err != nil {
+		return "", err
+	}
+	immediate, err := immediateCaller.MarshalVT()
+	if err != nil {
+		return "", err
+	}
+	var key strings.Builder
+	writeLength := func(b []byte) {
+		key.WriteString(strconv.Itoa(len(b)))
+		key.WriteByte(':')
+		key.Write(b)
+	}
+	writeLength(effective)
+	writeLength(immediate)
+	key.WriteString(target)
+	key.WriteByte(0)
+	key.WriteString(query)
+
+	names := make([]string, 0, len(bindVars))
+	for name := range bindVars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		bv, err := bindVars[name].MarshalVT()
+		if err != nil {
+			return "", err
+		}
+		key.WriteByte(0)
+		key.WriteString(name)
+		key.WriteByte(0)
+		key.Write(bv)
+	}
+	return key.String(), nil
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+)
+
+// fakeResultCacheStreamer sends the events of its channel to the streams.
+type fakeResultCacheStreamer struct {
+	events  chan []*binlogdatapb.VEvent
+	streams chan []string
+}
+
+func newFakeResultCacheStreamer() *fakeResultCacheStreamer {
+	return &fakeResultCacheStreamer{
+		events:  make(chan []*binlogdatapb.VEvent),
+		streams: make(chan []string, 10),
+	}
+}
+
+func (f *fakeResultCacheStreamer) stream(ctx context.Context, keyspace string, tables []string, send func([]*binlogdatapb.VEvent) error) error {
+	f.streams <- append([]string{keyspace}, tables...)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case events := <-f.events:
+			if events == nil {
+				return errors.New("stream failed")
+			}
+			if err := send(events); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends the events, and waits until they are handled.
+func (f *fakeResultCacheStreamer) send(events ...*binlogdatapb.VEvent) {
+	f.events <- events
+	// The events channel is not buffered, so an empty batch is only received
+	// once the previous one is handled.
+	f.events <- []*binlogdatapb.VEvent{}
+}
+
+func vgtidEvent() *binlogdatapb.VEvent {
+	return &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{}}
+}
+
+func rowEvent(table string) *binlogdatapb.VEvent {
+	return &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: table}}
+}
+
+func TestResultCache(t *testing.T) {
+	defer func(delay time.Duration) { resultCacheRetryDelay = delay }(resultCacheRetryDelay)
+	resultCacheRetryDelay = time.Millisecond
+
+	streamer := newFakeResultCacheStreamer()
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.ResultCache = NewResultCache(10, 2, streamer.stream)
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+
+	vschema := executor.VSchema()
+	vschema.Keyspaces[KsTestSharded].Tables["user"].ResultCacheTTL = time.Minute
+	executor.SaveVSchema(vschema, executor.vschemaStats)
+	require.Equal(t, []string{KsTestSharded, "user"}, <-streamer.streams)
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	sbc1.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")})
+	exec := func(sql string, bindVars map[string]*querypb.BindVariable) *sqltypes.Result {
+		t.Helper()
+		qr, err := executorExecSession(ctx, executor, session, sql, bindVars)
+		require.NoError(t, err)
+		return qr
+	}
+	sql := "select id from `user` where id = 1"
+
+	// Nothing is cached until the stream of the keyspace is running.
+	exec(sql, nil)
+	exec(sql, nil)
+	assert.Len(t, sbc1.Queries, 2)
+	streamer.send(vgtidEvent())
+
+	// The second query is answered from the cache.
+	sbc1.Queries = nil
+	queries := func() int { return len(sbc1.Queries) }
+	want := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "5")
+	sbc1.SetResults([]*sqltypes.Result{want})
+	assert.Equal(t, want, exec(sql, nil))
+	assert.Equal(t, want, exec(sql, nil))
+	assert.Equal(t, 1, queries())
+
+	// The bind vars are part of the key.
+	bindVars := map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(2)}
+	exec("select id from `user` where id = :id", bindVars)
+	assert.Equal(t, 2, queries())
+	exec("select id from `user` where id = :id", bindVars)
+	assert.Equal(t, 2, queries())
+
+	// A change to the table invalidates its results.
+	streamer.send(rowEvent(KsTestSharded + ".user"))
+	exec(sql, nil)
+	assert.Equal(t, 3, queries())
+	exec(sql, nil)
+	assert.Equal(t, 3, queries())
+
+	// The changes to the other tables don't.
+	streamer.send(rowEvent(KsTestSharded + ".music"))
+	exec(sql, nil)
+	assert.Equal(t, 3, queries())
+
+	// The queries in a transaction, that lock rows, or that are not deterministic
+	// are not cached.
+	for _, sql := range []string{
+		"select id from `user` where id = 1 for update",
+		"select id, now() from `user` where id = 1",
+		"select sql_no_cache id from `user` where id = 1",
+	} {
+		exec(sql, nil)
+		exec(sql, nil)
+	}
+	assert.Equal(t, 9, queries())
+	exec("begin", nil)
+	exec(sql, nil)
+	exec("rollback", nil)
+	assert.Equal(t, 10, queries())
+
+	// The results with too many rows are not cached.
+	sbc1.Queries = nil
+	many := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2", "3")
+	sbc1.SetResults([]*sqltypes.Result{many, many})
+	exec("select id as x from `user` where id = 1", nil)
+	exec("select id as x from `user` where id = 1", nil)
+	assert.Equal(t, 2, queries())
+
+	// Once the stream fails, the results are invalidated and are not cached
+	// until the stream is running again.
+	sbc1.Queries = nil
+	streamer.events <- nil
+	require.Equal(t, []string{KsTestSharded, "user"}, <-streamer.streams)
+	exec(sql, nil)
+	exec(sql, nil)
+	assert.Equal(t, 2, queries())
+	streamer.send(vgtidEvent())
+	exec(sql, nil)
+	exec(sql, nil)
+	assert.Equal(t, 3, queries())
+
+	// The tables are no longer cached once their TTL is removed from the vschema.
+	vschema.Keyspaces[KsTestSharded].Tables["user"].ResultCacheTTL = 0
+	executor.SaveVSchema(vschema, executor.vschemaStats)
+	exec(sql, nil)
+	assert.Equal(t, 4, queries())
+}
+
+func TestResultCacheTTL(t *testing.T) {
+	streamer := newFakeResultCacheStreamer()
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.ResultCache = NewResultCache(10, 10, streamer.stream)
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+
+	vschema := executor.VSchema()
+	vschema.Keyspaces[KsTestSharded].Tables["user"].ResultCacheTTL = 50 * time.Millisecond
+	executor.SaveVSchema(vschema, executor.vschemaStats)
+	require.Equal(t, []string{KsTestSharded, "user"}, <-streamer.streams)
+	streamer.send(vgtidEvent())
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	sql := "select id from `user` where id = 1"
+	for range 2 {
+		_, err := executorExecSession(ctx, executor, session, sql, nil)
+		require.NoError(t, err)
+	}
+	assert.Len(t, sbc1.Queries, 1)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err := executorExecSession(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	assert.Len(t, sbc1.Queries, 2)
+}
+
+func TestResultCacheCallers(t *testing.T) {
+	streamer := newFakeResultCacheStreamer()
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.ResultCache = NewResultCache(10, 10, streamer.stream)
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+
+	vschema := executor.VSchema()
+	vschema.Keyspaces[KsTestSharded].Tables["user"].ResultCacheTTL = time.Minute
+	executor.SaveVSchema(vschema, executor.vschemaStats)
+	require.Equal(t, []string{KsTestSharded, "user"}, <-streamer.streams)
+	streamer.send(vgtidEvent())
+
+	// The results of a caller are not served to another caller, who may not be
+	// allowed to read the table.
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	sql := "select id from `user` where id = 1"
+	aliceCtx := callerid.NewContext(ctx, callerid.NewEffectiveCallerID("alice", "", ""), callerid.NewImmediateCallerID("alice"))
+	bobCtx := callerid.NewContext(ctx, callerid.NewEffectiveCallerID("bob", "", ""), callerid.NewImmediateCallerID("bob"))
+	for _, callerCtx := range []context.Context{aliceCtx, aliceCtx, bobCtx, bobCtx} {
+		_, err := executorExecSession(callerCtx, executor, session, sql, nil)
+		require.NoError(t, err)
+	}
+	assert.Len(t, sbc1.Queries, 2)
+}
+
+func TestResultCacheReplica(t *testing.T) {
+	streamer := newFakeResultCacheStreamer()
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.ResultCache = NewResultCache(10, 10, streamer.stream)
+	var primary, replica *sandboxconn.SandboxConn
+	executor, ctx := createExecutorEnvCallback(t, eConfig, func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		switch {
+		case ks == KsTestUnsharded && tabletType == topodatapb.TabletType_PRIMARY:
+			primary = conn
+		case ks == KsTestUnsharded && tabletType == topodatapb.TabletType_REPLICA:
+			replica = conn
+		}
+	})
+
+	vschema := executor.VSchema()
+	vschema.Keyspaces[KsTestUnsharded].Tables["main1"].ResultCacheTTL = time.Minute
+	executor.SaveVSchema(vschema, executor.vschemaStats)
+	require.Equal(t, []string{KsTestUnsharded, "main1"}, <-streamer.streams)
+	streamer.send(vgtidEvent())
+
+	// The changes are streamed from the primaries, so only the results read
+	// from the primaries are cached.
+	sql := "select id from main1 where id = 1"
+	for _, target := range []string{"@replica", "@replica", "@primary", "@primary"} {
+		session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: target, Autocommit: true})
+		_, err := executorExecSession(ctx, executor, session, sql, nil)
+		require.NoError(t, err)
+	}
+	assert.Len(t, replica.Queries, 2)
+	assert.Len(t, primary.Queries, 1)
+}
+
+func TestResultCacheDMLThenSelectInSameSession(t *testing.T) {
+	streamer := newFakeResultCacheStreamer()
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.ResultCache = NewResultCache(10, 10, streamer.stream)
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+
+	vschema := executor.VSchema()
+	vschema.Keyspaces[KsTestSharded].Tables["user"].ResultCacheTTL = time.Minute
+	executor.SaveVSchema(vschema, executor.vschemaStats)
+	require.Equal(t, []string{KsTestSharded, "user"}, <-streamer.streams)
+	streamer.send(vgtidEvent())
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	exec := func(sql string) *sqltypes.Result {
+		t.Helper()
+		qr, err := executorExecSession(ctx, executor, session, sql, nil)
+		require.NoError(t, err)
+		return qr
+	}
+	sql := "select id from `user` where id = 1"
+	before := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	sbc1.SetResults([]*sqltypes.Result{before})
+	assert.Equal(t, before, exec(sql))
+	assert.Equal(t, before, exec(sql))
+	assert.Len(t, sbc1.Queries, 1)
+
+	// The SELECT that follows the DML sees its change, even though the change
+	// has not come through the stream yet.
+	exec("update `user` set a = 2 where id = 1")
+	after := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "2")
+	sbc1.SetResults([]*sqltypes.Result{after})
+	assert.Equal(t, after, exec(sql))
+}
+
+func TestResultCacheKey(t *testing.T) {
+	key := func(target string, bindVars map[string]*querypb.BindVariable) string {
+		k, err := resultCacheKey(nil, nil, target, "select 1", bindVars)
+		require.NoError(t, err)
+		return k
+	}
+	bv := map[string]*querypb.BindVariable{
+		"a": sqltypes.Int64BindVariable(1),
+		"b": sqltypes.StringBindVariable("x"),
+	}
+	assert.Equal(t, key("@primary", bv), key("@primary", map[string]*querypb.BindVariable{
+		"b": sqltypes.StringBindVariable("x"),
+		"a": sqltypes.Int64BindVariable(1),
+	}))
+	assert.NotEqual(t, key("@primary", bv), key("@replica", bv))
+	callerKey := func(effective, immediate string) string {
+		k, err := resultCacheKey(callerid.NewEffectiveCallerID(effective, "", ""), callerid.NewImmediateCallerID(immediate), "@primary", "select 1", bv)
+		require.NoError(t, err)
+		return k
+	}
+	assert.Equal(t, callerKey("a", "b"), callerKey("a", "b"))
+	assert.NotEqual(t, callerKey("a", "b"), callerKey("a", "c"))
+	assert.NotEqual(t, callerKey("a", "b"), callerKey("c", "b"))
+	assert.NotEqual(t, key("@primary", bv), callerKey("a", "b"))
+	assert.NotEqual(t, key("@primary", bv), key("@primary", map[string]*querypb.BindVariable{
+		"a": sqltypes.StringBindVariable("1"),
+		"b": sqltypes.StringBindVariable("x"),
+	}))
+}
@@ -36,6 +36,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -594,6 +595,21 @@ func (stc *ScatterConn) MessageStream(ctx context.Context, rss []*srvtopo.Resolv
 	return allErrors.AggrError(vterrors.Aggregate)
 }
 
+// MessageAck acks messages across multiple shards, or changes their
+// visibility timeout if visibilityTimeout is set.
+func (stc *ScatterConn) MessageAck(ctx context.Context, rss []*srvtopo.ResolvedShard, values [][]*querypb.Value, name string, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	var totalCount atomic.Int64
+	allErrors := stc.multiGo("MessageAck", rss, func(rs *srvtopo.ResolvedShard, i int) error {
+		count, err := rs.Gateway.MessageAck(ctx, rs.Target, name, values[i], visibilityTimeout)
+		if err != nil {
+			return err
+		}
+		totalCount.Add(count)
+		return nil
+	})
+	return totalCount.Load(), allErrors.AggrError(vterrors.Aggregate)
+}
+
 // Close closes the underlying Gateway.
 func (stc *ScatterConn) Close() error {
 	return stc.gateway.Close(context.Background())
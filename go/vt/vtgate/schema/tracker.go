@@ -400,6 +400,8 @@ func getColumns(tblSpec *sqlparser.TableSpec) []vindexes.Column {
 				Scale:         int32(scale),
 				Nullable:      nullable,
 				Values:        column.Type.EnumValues,
+				Generated:     column.Type.Options.As != nil,
+				OnUpdate:      column.Type.Options.OnUpdate,
 			})
 	}
 	return cols
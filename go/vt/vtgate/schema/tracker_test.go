@@ -282,6 +282,22 @@ func TestTableTracking(t *testing.T) {
 	testTracker(t, false, schemaResponse, testcases)
 }
 
+// TestGetColumnsGeneratedAndOnUpdate tests that generated columns and ON UPDATE
+// clauses are recorded on the tracked columns.
+func TestGetColumnsGeneratedAndOnUpdate(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().Parse("create table t(a int, b int as (a + 1), c timestamp default current_timestamp on update current_timestamp)")
+	require.NoError(t, err)
+
+	cols := getColumns(stmt.(*sqlparser.CreateTable).TableSpec)
+	require.Len(t, cols, 3)
+	assert.False(t, cols[0].Generated)
+	assert.Nil(t, cols[0].OnUpdate)
+	assert.True(t, cols[1].Generated)
+	assert.Nil(t, cols[1].OnUpdate)
+	assert.False(t, cols[2].Generated)
+	assert.Equal(t, "current_timestamp()", sqlparser.String(cols[2].OnUpdate))
+}
+
 // TestViewsTracking tests that the tracker is able to track views.
 func TestViewsTracking(t *testing.T) {
 	schemaDefResult := []sandboxconn.SchemaResult{
@@ -183,6 +183,18 @@ func TestBindingMultiTablePositive(t *testing.T) {
 		query:          "select u1.a + u2.a from u1, u2",
 		deps:           MergeTableSets(TS0, TS1),
 		numberOfTables: 2,
+	}, {
+		query:          "select x.c from t1, lateral (select t1.id as c) x",
+		deps:           TS0,
+		numberOfTables: 1,
+	}, {
+		query:          "select jt.a from t1, json_table(t1.id, '$' columns(a int path '$')) as jt",
+		deps:           TS1,
+		numberOfTables: 1,
+	}, {
+		query:          "select jt.b + t1.id from t1 join json_table('[]', '$[*]' columns(a int path '$.a', nested path '$.n[*]' columns(b int path '$'))) as jt",
+		deps:           MergeTableSets(TS0, TS1),
+		numberOfTables: 2,
 	}}
 	for _, query := range queries {
 		t.Run(query.query, func(t *testing.T) {
@@ -233,9 +245,17 @@ func TestRecursiveCTEChecking(t *testing.T) {
 		query: "with recursive x as (select id from user union select id+1 from x where id < 10 group by 1) select t.id from x join x t",
 		err:   "VT09027: Recursive Common Table Expression 'x' can contain neither aggregation nor window functions in recursive query block",
 	}, {
-		name:  "use the same recursive cte twice in definition",
-		query: "with recursive x as (select 1 union select id+1 from x where id < 10 union select id+2 from x where id < 20) select t.id from x",
+		name:  "use the same recursive cte twice in a query block",
+		query: "with recursive x as (select 1 as id union select x.id+1 from x join x as y on x.id = y.id where x.id < 10) select id from x",
+		err:   "VT09029: In recursive query block of Recursive Common Table Expression x, the recursive table must be referenced only once, and not in any subquery",
+	}, {
+		name:  "use the recursive cte in a subquery",
+		query: "with recursive x as (select 1 as id union select id+1 from user where id in (select id from x)) select id from x",
 		err:   "VT09029: In recursive query block of Recursive Common Table Expression x, the recursive table must be referenced only once, and not in any subquery",
+	}, {
+		name:  "use the recursive cte in the aggregation of a second recursive query block",
+		query: "with recursive x as (select 1 as id union select id+1 from x where id < 10 union select count(*) from x) select id from x",
+		err:   "VT09027: Recursive Common Table Expression 'x' can contain neither aggregation nor window functions in recursive query block",
 	}}
 	for _, tc := range queries {
 		t.Run(tc.query, func(t *testing.T) {
@@ -956,8 +976,8 @@ func TestInvalidQueries(t *testing.T) {
 		sql:  "select is_free_lock('xyz') from user",
 		serr: "is_free_lock('xyz') allowed only with dual",
 	}, {
-		sql: "SELECT * FROM JSON_TABLE('[ {\"c1\": null} ]','$[*]' COLUMNS( c1 INT PATH '$.c1' ERROR ON ERROR )) as jt",
-		err: &JSONTablesError{},
+		sql:  "SELECT * FROM JSON_TABLE('[ {\"c1\": null} ]','$[*]' COLUMNS( c1 INT PATH '$.c1', NESTED PATH '$.n[*]' COLUMNS ( c1 INT PATH '$' ) )) as jt",
+		serr: "Duplicate column name 'c1'",
 	}, {
 		sql:             "select does_not_exist from t1",
 		notUnshardedErr: "column 'does_not_exist' not found in table 't1'",
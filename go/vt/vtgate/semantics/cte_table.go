@@ -156,6 +156,9 @@ type CTE struct {
 	Columns         sqlparser.Columns
 	IDForRecurse    *TableSet
 
+	// RecursiveSelects are the query blocks of a recursive CTE that reference the recursive table
+	RecursiveSelects []*sqlparser.Select
+
 	// Was this CTE marked for being recursive?
 	Recursive bool
 
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// JSONTable contains the information about a JSON_TABLE table expression.
+// The columns of a JSON_TABLE are the columns of its definition, with the columns
+// of NESTED PATH definitions flattened into the list in the order they appear.
+type JSONTable struct {
+	tableName string
+	ASTNode   *sqlparser.JSONTableExpr
+
+	// aliasedTable is a synthetic table expression used to identify this table
+	aliasedTable *sqlparser.AliasedTableExpr
+	columns      []ColumnInfo
+}
+
+var _ TableInfo = (*JSONTable)(nil)
+
+func newJSONTable(node *sqlparser.JSONTableExpr, collationEnv *collations.Environment) (*JSONTable, error) {
+	jt := &JSONTable{
+		tableName: node.Alias.String(),
+		ASTNode:   node,
+		aliasedTable: &sqlparser.AliasedTableExpr{
+			Expr: sqlparser.NewTableName(node.Alias.String()),
+		},
+	}
+	jt.addColumns(node.Columns, collationEnv)
+	for i, col := range jt.columns {
+		for _, other := range jt.columns[i+1:] {
+			if strings.EqualFold(col.Name, other.Name) {
+				return nil, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.DupFieldName, "Duplicate column name '%s'", col.Name)
+			}
+		}
+	}
+	return jt, nil
+}
+
+func (jt *JSONTable) addColumns(defs []*sqlparser.JtColumnDefinition, collationEnv *collations.Environment) {
+	for _, def := range defs {
+		switch {
+		case def.JtOrdinal != nil:
+			jt.columns = append(jt.columns, ColumnInfo{Name: def.JtOrdinal.Name.String(), Type: evalengine.JSONTableOrdinalityType})
+		case def.JtPath != nil:
+			jt.columns = append(jt.columns, ColumnInfo{Name: def.JtPath.Name.String(), Type: evalengine.NewTypeFromColumnType(def.JtPath.Type, collationEnv)})
+		case def.JtNestedPath != nil:
+			jt.addColumns(def.JtNestedPath.Columns, collationEnv)
+		}
+	}
+}
+
+// Name implements the TableInfo interface
+func (jt *JSONTable) Name() (sqlparser.TableName, error) {
+	return sqlparser.NewTableName(jt.tableName), nil
+}
+
+// GetVindexTable implements the TableInfo interface
+func (jt *JSONTable) GetVindexTable() *vindexes.BaseTable {
+	return nil
+}
+
+// IsInfSchema implements the TableInfo interface
+func (jt *JSONTable) IsInfSchema() bool {
+	return false
+}
+
+func (jt *JSONTable) matches(name sqlparser.TableName) bool {
+	return jt.tableName == name.Name.String() && name.Qualifier.IsEmpty()
+}
+
+func (jt *JSONTable) authoritative() bool {
+	return true
+}
+
+// GetAliasedTableExpr implements the TableInfo interface
+func (jt *JSONTable) GetAliasedTableExpr() *sqlparser.AliasedTableExpr {
+	return jt.aliasedTable
+}
+
+func (jt *JSONTable) canShortCut() shortCut {
+	return canShortCut
+}
+
+func (jt *JSONTable) getColumns(bool) []ColumnInfo {
+	return jt.columns
+}
+
+func (jt *JSONTable) dependencies(colName string, org originable) (dependencies, error) {
+	ts := org.tableSetFor(jt.aliasedTable)
+	for _, col := range jt.columns {
+		if strings.EqualFold(col.Name, colName) {
+			return createCertain(ts, ts, col.Type), nil
+		}
+	}
+	return &nothing{}, nil
+}
+
+func (jt *JSONTable) getExprFor(s string) (sqlparser.Expr, error) {
+	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Unknown column '%s' in 'field list'", s)
+}
+
+func (jt *JSONTable) getTableSet(org originable) TableSet {
+	return org.tableSetFor(jt.aliasedTable)
+}
+
+// GetMirrorRule implements TableInfo.
+func (jt *JSONTable) GetMirrorRule() *vindexes.MirrorRule {
+	return nil
+}
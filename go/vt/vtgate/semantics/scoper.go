@@ -175,6 +175,11 @@ func (s *scoper) enterJoinScope(cursor *sqlparser.Cursor) {
 		// To create this special context, we will find the parent scope of the select statement involved.
 		currScope := s.currentScope()
 		stmtScope := currScope.findParentScopeOfStatement()
+		if isLateral(cursor.Node()) {
+			// LATERAL derived tables and JSON_TABLE expressions are also allowed to
+			// see the tables that come before them in the FROM clause
+			stmtScope = currScope
+		}
 		nScope := newScope(stmtScope)
 		if stmtScope == nil {
 			// TODO: this feels hacky. revisit with a better plan
@@ -185,6 +190,17 @@ func (s *scoper) enterJoinScope(cursor *sqlparser.Cursor) {
 	}
 }
 
+func isLateral(node sqlparser.SQLNode) bool {
+	switch node := node.(type) {
+	case *sqlparser.JSONTableExpr:
+		return true
+	case *sqlparser.AliasedTableExpr:
+		dt, ok := node.Expr.(*sqlparser.DerivedTable)
+		return ok && dt.Lateral
+	}
+	return false
+}
+
 func (s *scoper) pushSelectScope(node *sqlparser.Select) {
 	currScope := newScope(s.currentScope())
 	currScope.stmtScope = true
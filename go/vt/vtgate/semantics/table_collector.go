@@ -18,6 +18,7 @@ package semantics
 
 import (
 	"fmt"
+	"slices"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 
@@ -130,6 +131,8 @@ func (tc *tableCollector) up(cursor *sqlparser.Cursor) error {
 	switch node := cursor.Node().(type) {
 	case *sqlparser.AliasedTableExpr:
 		return tc.visitAliasedTableExpr(node)
+	case *sqlparser.JSONTableExpr:
+		return tc.visitJSONTable(node)
 	case *sqlparser.Union:
 		return tc.visitUnion(node)
 	case *sqlparser.RowAlias:
@@ -153,6 +156,16 @@ func (tc *tableCollector) visitAliasedTableExpr(node *sqlparser.AliasedTableExpr
 	return nil
 }
 
+func (tc *tableCollector) visitJSONTable(node *sqlparser.JSONTableExpr) error {
+	tableInfo, err := newJSONTable(node, tc.org.collationEnv())
+	if err != nil {
+		return err
+	}
+	tc.Tables = append(tc.Tables, tableInfo)
+	scope := tc.scoper.currentScope()
+	return scope.addTable(tableInfo)
+}
+
 func (tc *tableCollector) visitUnion(union *sqlparser.Union) error {
 	firstSelect, err := sqlparser.GetFirstSelect(union)
 	if err != nil {
@@ -384,14 +397,19 @@ func (etc *earlyTableCollector) buildRecursiveCTE(node *sqlparser.AliasedTableEx
 	if sc != nil && len(sc.commonTableExprScopes) > 0 {
 		cte := sc.commonTableExprScopes[len(sc.commonTableExprScopes)-1]
 		if cte.ID.String() == t.Name.String() {
-
-			if err := checkValidRecursiveCTE(cteDef); err != nil {
+			// the recursive table has to be used directly in the FROM clause of one of the query blocks of the UNION
+			sel, _ := sc.currentScope().stmt.(*sqlparser.Select)
+			if err := checkValidRecursiveCTE(cteDef, sel); err != nil {
 				return nil, err
 			}
 
 			cteTable := newCTETable(node, t, cteDef)
 			cteTableSet := SingleTableSet(len(etc.Tables))
+			if cteDef.IDForRecurse != nil {
+				cteTableSet = cteTableSet.Merge(*cteDef.IDForRecurse)
+			}
 			cteDef.IDForRecurse = &cteTableSet
+			cteDef.RecursiveSelects = append(cteDef.RecursiveSelects, sel)
 			if !cteDef.Recursive {
 				return nil, nil
 			}
@@ -406,25 +424,24 @@ func (etc *earlyTableCollector) buildRecursiveCTE(node *sqlparser.AliasedTableEx
 	}, nil
 }
 
-func checkValidRecursiveCTE(cteDef *CTE) error {
-	if cteDef.IDForRecurse != nil {
-		return vterrors.VT09029(cteDef.Name)
-	}
-
+// checkValidRecursiveCTE checks a reference to the recursive table from the given query block.
+// Every recursive query block can reference the recursive table once.
+func checkValidRecursiveCTE(cteDef *CTE, sel *sqlparser.Select) error {
 	union, isUnion := cteDef.Query.(*sqlparser.Union)
 	if !isUnion {
 		return vterrors.VT09026(cteDef.Name)
 	}
 
-	firstSelect, err := sqlparser.GetFirstSelect(union.Right)
-	if err != nil {
-		return err
+	queryBlocks := sqlparser.GetAllSelects(union)
+	if sel == nil || slices.Contains(cteDef.RecursiveSelects, sel) || !slices.Contains(queryBlocks[1:], sqlparser.TableStatement(sel)) {
+		return vterrors.VT09029(cteDef.Name)
 	}
-	if firstSelect.GroupBy != nil {
+
+	if sel.GroupBy != nil {
 		return vterrors.VT09027(cteDef.Name)
 	}
 
-	for _, expr := range firstSelect.GetColumns() {
+	for _, expr := range sel.GetColumns() {
 		if sqlparser.ContainsAggregation(expr) {
 			return vterrors.VT09027(cteDef.Name)
 		}
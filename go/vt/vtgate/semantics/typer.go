@@ -18,6 +18,7 @@ package semantics
 
 import (
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -61,6 +62,15 @@ func (t *typer) up(cursor *sqlparser.Cursor) error {
 			}
 		}
 		t.m[node] = code.ResolveType(inputType, t.collationEnv)
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch node.Type {
+		case sqlparser.RowNumberExprType, sqlparser.RankExprType, sqlparser.DenseRankExprType:
+			t.m[node] = evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)
+		}
+	case *sqlparser.LagLeadExpr:
+		if tt, ok := t.m[node.Expr]; ok {
+			t.m[node] = tt
+		}
 	}
 	return nil
 }
@@ -194,6 +194,36 @@ func TestTabletGatewayReplicaTransactionError(t *testing.T) {
 	verifyContainsError(t, err, "query service can only be used for non-transactional queries on replicas", vtrpcpb.Code_INTERNAL)
 }
 
+// TestTabletGatewayAdmissionShedRetried tests that a replica read shed by the
+// admission control of the tablet is retried on another tablet.
+func TestTabletGatewayAdmissionShedRetried(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "ks"
+	shard := "0"
+	tabletType := topodatapb.TabletType_REPLICA
+	host := "1.1.1.1"
+	port := int32(1001)
+	target := &querypb.Target{
+		Keyspace:   keyspace,
+		Shard:      shard,
+		TabletType: tabletType,
+	}
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	// The tablet of the local cell is tried first.
+	sc1 := hc.AddTestTablet("cell", host, port, keyspace, shard, tabletType, true, 10, nil)
+	sc2 := hc.AddTestTablet("cell2", host, port+1, keyspace, shard, tabletType, true, 10, nil)
+	sc1.EphemeralShardErr = vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "query shed by admission control with priority 100: self/threads_running metric value 120 exceeds threshold 100")
+
+	_, err := tg.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sc1.ExecCount.Load())
+	assert.EqualValues(t, 1, sc2.ExecCount.Load())
+}
+
 func testTabletGatewayGeneric(t *testing.T, ctx context.Context, f func(ctx context.Context, tg *TabletGateway, target *querypb.Target) error, verifyExpectedCount func(t *testing.T, sc *sandboxconn.SandboxConn, want int64)) {
 	t.Helper()
 	testTabletGatewayGenericHelper(t, ctx, f, verifyExpectedCount)
@@ -109,15 +109,24 @@ func (*Binary) ReverseMap(_ VCursor, ksids [][]byte) ([]sqltypes.Value, error) {
 	return reverseIds, nil
 }
 
-// RangeMap can map ids to key.ShardDestination objects.
+// RangeMap implements Sequential. The resulting key range ends after the keyspace id
+// of endId, so that endId is part of the range. If a bound cannot be mapped to a
+// keyspace id, the range can't be determined and all the shards are targeted.
 func (vind *Binary) RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error) {
-	startKsId, err := vind.Hash(startId)
-	if err != nil {
-		return nil, err
+	var startKsId, endKsId []byte
+	if !startId.IsNull() {
+		ksid, err := vind.Hash(startId)
+		if err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}, nil
+		}
+		startKsId = ksid
 	}
-	endKsId, err := vind.Hash(endId)
-	if err != nil {
-		return nil, err
+	if !endId.IsNull() {
+		ksid, err := vind.Hash(endId)
+		if err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}, nil
+		}
+		endKsId = key.PrefixEnd(ksid)
 	}
 	out := []key.ShardDestination{&key.DestinationKeyRange{KeyRange: key.NewKeyRange(startKsId, endKsId)}}
 	return out, nil
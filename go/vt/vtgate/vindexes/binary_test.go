@@ -157,7 +157,20 @@ func TestBinaryRangeMap(t *testing.T) {
 	got, err := binOnlyVindex.(Sequential).RangeMap(context.Background(), nil, sqltypes.NewHexNum([]byte(startInterval)),
 		sqltypes.NewHexNum([]byte(endInterval)))
 	require.NoError(t, err)
-	want := "DestinationKeyRange(01-10)"
+	want := "DestinationKeyRange(01-11)"
 	assert.Equal(t, want, got[0].String())
 
+	// a NULL bound leaves the range open on that side
+	got, err = binOnlyVindex.(Sequential).RangeMap(context.Background(), nil, sqltypes.NewHexNum([]byte(startInterval)), sqltypes.NULL)
+	require.NoError(t, err)
+	assert.Equal(t, "DestinationKeyRange(01-)", got[0].String())
+
+	got, err = binOnlyVindex.(Sequential).RangeMap(context.Background(), nil, sqltypes.NULL, sqltypes.NewHexNum([]byte(endInterval)))
+	require.NoError(t, err)
+	assert.Equal(t, "DestinationKeyRange(-11)", got[0].String())
+
+	// the end is the keyspace id following all the ones that start with endId
+	got, err = binOnlyVindex.(Sequential).RangeMap(context.Background(), nil, sqltypes.NULL, sqltypes.NewHexNum([]byte("0x40ff")))
+	require.NoError(t, err)
+	assert.Equal(t, "DestinationKeyRange(-41)", got[0].String())
 }
@@ -181,13 +181,34 @@ func (cached *LookupCost) CachedSize(alloc bool) int64 {
 	size += cached.LookupNonUnique.CachedSize(true)
 	return size
 }
+func (cached *LookupCovering) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(224)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field lkp vitess.io/vitess/go/vt/vtgate/vindexes.lookupInternal
+	size += cached.lkp.CachedSize(false)
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
 func (cached *LookupHash) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(224)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -208,7 +229,7 @@ func (cached *LookupHashUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(224)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -229,7 +250,7 @@ func (cached *LookupNonUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(224)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -250,7 +271,7 @@ func (cached *LookupUnicodeLooseMD5Hash) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(224)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -271,7 +292,7 @@ func (cached *LookupUnicodeLooseMD5HashUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(224)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -292,7 +313,7 @@ func (cached *LookupUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(224)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -412,6 +433,34 @@ func (cached *NumericStaticMap) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *Range) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(96)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field typ string
+	size += hack.RuntimeAllocSize(int64(len(cached.typ)))
+	// field splitPoints []vitess.io/vitess/go/vt/vtgate/vindexes.rangeSplitPoint
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.splitPoints)) * int64(64))
+		for _, elem := range cached.splitPoints {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
 func (cached *RegionExperimental) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -470,6 +519,31 @@ func (cached *RegionMap) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeMapSize(*cached)
 	return size
 }
+func (cached *Remote) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(176)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field connKey vitess.io/vitess/go/vt/vtgate/vindexes.remoteConnKey
+	size += cached.connKey.CachedSize(false)
+	// field cache vitess.io/vitess/go/vt/vtgate/vindexes.remoteCache
+	if cc, ok := cached.cache.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
 func (cached *ReverseBits) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -575,7 +649,7 @@ func (cached *clCommon) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(288)
+		size += int64(320)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -608,7 +682,7 @@ func (cached *lookupInternal) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(144)
+		size += int64(176)
 	}
 	// field Table string
 	size += hack.RuntimeAllocSize(int64(len(cached.Table)))
@@ -619,6 +693,13 @@ func (cached *lookupInternal) CachedSize(alloc bool) int64 {
 			size += hack.RuntimeAllocSize(int64(len(elem)))
 		}
 	}
+	// field CoveringColumns []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.CoveringColumns)) * int64(16))
+		for _, elem := range cached.CoveringColumns {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
 	// field To string
 	size += hack.RuntimeAllocSize(int64(len(cached.To)))
 	// field ReadLock string
@@ -645,3 +726,43 @@ func (cached *prefixCFC) CachedSize(alloc bool) int64 {
 	size += cached.cfcCommon.CachedSize(true)
 	return size
 }
+func (cached *rangeSplitPoint) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field value string
+	size += hack.RuntimeAllocSize(int64(len(cached.value)))
+	// field key []byte
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.key)))
+	}
+	// field ksid []byte
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.ksid)))
+	}
+	return size
+}
+func (cached *remoteConnKey) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field address string
+	size += hack.RuntimeAllocSize(int64(len(cached.address)))
+	// field cert string
+	size += hack.RuntimeAllocSize(int64(len(cached.cert)))
+	// field key string
+	size += hack.RuntimeAllocSize(int64(len(cached.key)))
+	// field ca string
+	size += hack.RuntimeAllocSize(int64(len(cached.ca)))
+	// field serverName string
+	size += hack.RuntimeAllocSize(int64(len(cached.serverName)))
+	return size
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	lookupCoveringParamCovering = "covering"
+)
+
+var (
+	_ MultiColumn     = (*LookupCovering)(nil)
+	_ Lookup          = (*LookupCovering)(nil)
+	_ LookupBackfill  = (*LookupCovering)(nil)
+	_ Covering        = (*LookupCovering)(nil)
+	_ ParamValidating = (*LookupCovering)(nil)
+
+	// lookupCoveringParams don't include autocommit: deletes are ignored in
+	// autocommit mode, which would leave stale covering columns behind.
+	lookupCoveringParams = append(
+		append(make([]string, 0), lookupInternalParams...),
+		lookupParamNoVerify,
+		lookupParamWriteOnly,
+		lookupCoveringParamCovering,
+	)
+)
+
+func init() {
+	Register("lookup_covering", newLookupCovering)
+}
+
+// LookupCovering defines a unique lookup vindex whose lookup table also stores
+// other columns of the owner table, the covering columns. The columns of the
+// ColumnVindex are the owner columns of the from columns, followed by the owner
+// columns that are copied into the covering columns. The covering columns are
+// kept up to date by DML like the from columns. It's Unique and a Lookup.
+//
+// The from columns together identify a row of the lookup table, so it's a
+// MultiColumn vindex. It's also a partial vindex: the values of the leading
+// from columns only map to the keyspace ids of all the rows that share them.
+//
+// Selects that only use the from and covering columns are answered by the lookup
+// table, so the table ACLs of the lookup table must be as strict as the ones of
+// the owner table.
+type LookupCovering struct {
+	name          string
+	writeOnly     bool
+	noVerify      bool
+	lkp           lookupInternal
+	unknownParams []string
+}
+
+// newLookupCovering creates a LookupCovering vindex.
+// The supplied map has the following required fields:
+//
+//	table: name of the backing table. It can be qualified by the keyspace.
+//	from: list of columns in the table that have the 'from' values of the lookup vindex.
+//	to: The 'to' column name of the table.
+//	covering: list of the other columns of the table, which store the values of
+//	  the remaining columns of the ColumnVindex.
+//
+// The following fields are optional:
+//
+//	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
+//	no_verify: in this mode, Verify will always succeed.
+func newLookupCovering(name string, m map[string]string) (Vindex, error) {
+	lc := &LookupCovering{
+		name:          name,
+		unknownParams: FindUnknownParams(m, lookupCoveringParams),
+	}
+
+	var err error
+	lc.writeOnly, err = boolFromMap(m, lookupParamWriteOnly)
+	if err != nil {
+		return nil, err
+	}
+	lc.noVerify, err = boolFromMap(m, lookupParamNoVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't allow upserts for unique vindexes.
+	if err := lc.lkp.Init(m, false /* autocommit */, false /* upsert */, false /* multiShardAutocommit */); err != nil {
+		return nil, err
+	}
+	for _, col := range strings.Split(m[lookupCoveringParamCovering], ",") {
+		if col = strings.TrimSpace(col); col != "" {
+			lc.lkp.CoveringColumns = append(lc.lkp.CoveringColumns, col)
+		}
+	}
+	if len(lc.lkp.CoveringColumns) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "lookup_covering: missing '%s' columns", lookupCoveringParamCovering)
+	}
+	return lc, nil
+}
+
+// String returns the name of the vindex.
+func (lc *LookupCovering) String() string {
+	return lc.name
+}
+
+// Cost returns the cost of this vindex as 10.
+func (lc *LookupCovering) Cost() int {
+	return 10
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (lc *LookupCovering) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (lc *LookupCovering) NeedsVCursor() bool {
+	return true
+}
+
+// PartialVindex returns true, since the leading from columns can be looked up alone.
+func (lc *LookupCovering) PartialVindex() bool {
+	return true
+}
+
+// Map can map rows of values of the from columns to key.ShardDestination objects.
+// The values of the covering columns, if given, are ignored.
+func (lc *LookupCovering) Map(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value) ([]key.ShardDestination, error) {
+	out := make([]key.ShardDestination, 0, len(rowsColValues))
+	if lc.writeOnly {
+		for range rowsColValues {
+			out = append(out, key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{}})
+		}
+		return out, nil
+	}
+
+	var results []*sqltypes.Result
+	var err error
+	if len(lc.lkp.FromColumns) == 1 {
+		results, err = lc.lkp.Lookup(ctx, vcursor, firstColsOnly(rowsColValues), vtgatepb.CommitOrder_NORMAL)
+	} else {
+		results, err = lc.lkp.LookupRows(ctx, vcursor, rowsColValues, vtgatepb.CommitOrder_NORMAL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range results {
+		if len(rowsColValues[i]) < len(lc.lkp.FromColumns) {
+			// only some of the from columns are given, so any number of rows can match
+			ksids := make([][]byte, 0, len(result.Rows))
+			for _, row := range result.Rows {
+				rowBytes, err := row[0].ToBytes()
+				if err != nil {
+					return nil, err
+				}
+				ksids = append(ksids, rowBytes)
+			}
+			out = append(out, key.DestinationKeyspaceIDs(ksids))
+			continue
+		}
+		switch len(result.Rows) {
+		case 0:
+			out = append(out, key.DestinationNone{})
+		case 1:
+			rowBytes, err := result.Rows[0][0].ToBytes()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, key.DestinationKeyspaceID(rowBytes))
+		default:
+			return nil, fmt.Errorf("LookupCovering.Map: unexpected multiple results from vindex %s: %v", lc.lkp.Table, rowsColValues[i])
+		}
+	}
+	return out, nil
+}
+
+// Verify returns true if the rows of values of the from columns map to ksids.
+func (lc *LookupCovering) Verify(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	if lc.writeOnly || lc.noVerify {
+		out := make([]bool, len(rowsColValues))
+		for i := range rowsColValues {
+			out[i] = true
+		}
+		return out, nil
+	}
+	if len(lc.lkp.FromColumns) == 1 {
+		return lc.lkp.Verify(ctx, vcursor, firstColsOnly(rowsColValues), ksidsToValues(ksids))
+	}
+	return lc.lkp.VerifyRows(ctx, vcursor, rowsColValues, ksidsToValues(ksids))
+}
+
+// Create reserves the id by inserting it into the vindex table, along with
+// the values of the covering columns.
+func (lc *LookupCovering) Create(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, ksids [][]byte, ignoreMode bool) error {
+	return lc.lkp.Create(ctx, vcursor, rowsColValues, ksidsToValues(ksids), ignoreMode)
+}
+
+// Update updates the entry in the vindex table. It's called when either the
+// from column or any of the covering columns change.
+func (lc *LookupCovering) Update(ctx context.Context, vcursor VCursor, oldValues []sqltypes.Value, ksid []byte, newValues []sqltypes.Value) error {
+	return lc.lkp.Update(ctx, vcursor, oldValues, ksid, sqltypes.MakeTrusted(sqltypes.VarBinary, ksid), newValues)
+}
+
+// Delete deletes the entry from the vindex table.
+func (lc *LookupCovering) Delete(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, ksid []byte) error {
+	return lc.lkp.Delete(ctx, vcursor, rowsColValues, sqltypes.MakeTrusted(sqltypes.VarBinary, ksid), vtgatepb.CommitOrder_NORMAL)
+}
+
+// MarshalJSON returns a JSON representation of LookupCovering.
+func (lc *LookupCovering) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lc.lkp)
+}
+
+// IsBackfilling implements the LookupBackfill interface
+func (lc *LookupCovering) IsBackfilling() bool {
+	return lc.writeOnly
+}
+
+// CoveringTable implements the Covering interface.
+func (lc *LookupCovering) CoveringTable() (table string, fromColumns, coveringColumns []string) {
+	return lc.lkp.Table, lc.lkp.FromColumns, lc.lkp.CoveringColumns
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (lc *LookupCovering) UnknownParams() []string {
+	return lc.unknownParams
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+func lookupCoveringCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "lookup_covering",
+		vindexName:   "lookup_covering",
+		vindexParams: vindexParams,
+
+		expectCost:          10,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  true,
+		expectString:        "lookup_covering",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestLookupCoveringCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		lookupCoveringCreateVindexTestCase(
+			"covering columns ok",
+			map[string]string{
+				"table":    "t",
+				"from":     "fromc",
+				"to":       "toc",
+				"covering": "c1, c2",
+			},
+			nil,
+			nil,
+		),
+		lookupCoveringCreateVindexTestCase(
+			"covering columns required",
+			map[string]string{
+				"table": "t",
+				"from":  "fromc",
+				"to":    "toc",
+			},
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "lookup_covering: missing 'covering' columns"),
+			nil,
+		),
+		lookupCoveringCreateVindexTestCase(
+			"multiple from columns ok",
+			map[string]string{
+				"table":    "t",
+				"from":     "fromc1, fromc2",
+				"to":       "toc",
+				"covering": "c1",
+			},
+			nil,
+			nil,
+		),
+		lookupCoveringCreateVindexTestCase(
+			"autocommit is not supported",
+			map[string]string{
+				"table":      "t",
+				"from":       "fromc",
+				"to":         "toc",
+				"covering":   "c1",
+				"autocommit": "true",
+			},
+			nil,
+			[]string{"autocommit"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func createLookupCovering(t *testing.T, from string) *LookupCovering {
+	t.Helper()
+	l, err := CreateVindex("lookup_covering", "lookup_covering", map[string]string{
+		"table":    "t",
+		"from":     from,
+		"to":       "toc",
+		"covering": "c1,c2",
+	})
+	require.NoError(t, err)
+	require.Empty(t, l.(ParamValidating).UnknownParams())
+	return l.(*LookupCovering)
+}
+
+func TestLookupCoveringMap(t *testing.T) {
+	lc := createLookupCovering(t, "fromc")
+	vc := &vcursor{numRows: 1}
+
+	got, err := lc.Map(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")}})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{key.DestinationKeyspaceID("1")}, got)
+
+	wantqueries := []*querypb.BoundQuery{{
+		Sql: "select fromc, toc from t where fromc in ::fromc",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc": sqltypes.TestBindVariable([]any{sqltypes.NewInt64(1)}),
+		},
+	}}
+	utils.MustMatch(t, wantqueries, vc.queries)
+
+	table, fromColumns, coveringColumns := lc.CoveringTable()
+	assert.Equal(t, "t", table)
+	assert.Equal(t, []string{"fromc"}, fromColumns)
+	assert.Equal(t, []string{"c1", "c2"}, coveringColumns)
+}
+
+func TestLookupCoveringMultiColumnMap(t *testing.T) {
+	lc := createLookupCovering(t, "fromc1, fromc2")
+	vc := &vcursor{numRows: 2}
+
+	got, err := lc.Map(context.Background(), vc, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1)},
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")},
+	})
+	require.EqualError(t, err, "LookupCovering.Map: unexpected multiple results from vindex t: [INT64(1) VARCHAR(\"a\")]")
+	assert.Nil(t, got)
+
+	vc = &vcursor{numRows: 1}
+	got, err = lc.Map(context.Background(), vc, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1)},
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationKeyspaceIDs([][]byte{[]byte("1")}),
+		key.DestinationKeyspaceID("1"),
+	}, got)
+
+	wantqueries := []*querypb.BoundQuery{{
+		Sql: "select toc from t where fromc1 = :fromc1",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc1": sqltypes.Int64BindVariable(1),
+		},
+	}, {
+		Sql: "select toc from t where fromc1 = :fromc1 and fromc2 = :fromc2",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc1": sqltypes.Int64BindVariable(1),
+			"fromc2": sqltypes.StringBindVariable("a"),
+		},
+	}}
+	utils.MustMatch(t, wantqueries, vc.queries)
+
+	vc = &vcursor{numRows: 0}
+	got, err = lc.Map(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")}})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{key.DestinationNone{}}, got)
+}
+
+func TestLookupCoveringMultiColumnVerify(t *testing.T) {
+	lc := createLookupCovering(t, "fromc1, fromc2")
+	vc := &vcursor{numRows: 1}
+
+	got, err := lc.Verify(context.Background(), vc, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NewInt64(10)},
+	}, [][]byte{[]byte("test")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true}, got)
+
+	wantqueries := []*querypb.BoundQuery{{
+		Sql: "select fromc1 from t where fromc1 = :fromc1 and fromc2 = :fromc2 and toc = :toc",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc1": sqltypes.Int64BindVariable(1),
+			"fromc2": sqltypes.StringBindVariable("a"),
+			"toc":    sqltypes.BytesBindVariable([]byte("test")),
+		},
+	}}
+	utils.MustMatch(t, wantqueries, vc.queries)
+
+	// All the from columns must be given.
+	_, err = lc.Verify(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, [][]byte{[]byte("test")})
+	assert.EqualError(t, err, "VT03030: lookup column count does not match value count with the row (columns, count): ([fromc1 fromc2], 1)")
+}
+
+func TestLookupCoveringCreate(t *testing.T) {
+	lc := createLookupCovering(t, "fromc")
+	vc := &vcursor{}
+
+	err := lc.Create(context.Background(), vc, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NULL},
+		{sqltypes.NewInt64(2), sqltypes.NewVarChar("b"), sqltypes.NewInt64(20)},
+	}, [][]byte{[]byte("test1"), []byte("test2")}, false /* ignoreMode */)
+	require.NoError(t, err)
+
+	wantqueries := []*querypb.BoundQuery{{
+		Sql: "insert into t(fromc, c1, c2, toc) values(:fromc_0, :c1_0, :c2_0, :toc_0), (:fromc_1, :c1_1, :c2_1, :toc_1)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc_0": sqltypes.Int64BindVariable(1),
+			"c1_0":    sqltypes.StringBindVariable("a"),
+			"c2_0":    sqltypes.NullBindVariable,
+			"toc_0":   sqltypes.BytesBindVariable([]byte("test1")),
+			"fromc_1": sqltypes.Int64BindVariable(2),
+			"c1_1":    sqltypes.StringBindVariable("b"),
+			"c2_1":    sqltypes.Int64BindVariable(20),
+			"toc_1":   sqltypes.BytesBindVariable([]byte("test2")),
+		},
+	}}
+	utils.MustMatch(t, wantqueries, vc.queries)
+
+	// The from column still can't be null.
+	err = lc.Create(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NULL, sqltypes.NewVarChar("a"), sqltypes.NewInt64(1)}}, [][]byte{[]byte("test1")}, false /* ignoreMode */)
+	assert.EqualError(t, err, "VT03028: Column 'fromc' cannot be null on row 0, col 0")
+
+	// The covering columns must be given.
+	err = lc.Create(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, [][]byte{[]byte("test1")}, false /* ignoreMode */)
+	assert.EqualError(t, err, "VT03030: lookup column count does not match value count with the row (columns, count): ([fromc c1 c2], 1)")
+}
+
+func TestLookupCoveringUpdate(t *testing.T) {
+	lc := createLookupCovering(t, "fromc")
+	vc := &vcursor{}
+
+	err := lc.Update(context.Background(), vc,
+		[]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NewInt64(10)},
+		[]byte("test"),
+		[]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("b"), sqltypes.NewInt64(10)})
+	require.NoError(t, err)
+
+	wantqueries := []*querypb.BoundQuery{{
+		Sql: "delete from t where fromc = :fromc and toc = :toc",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc": sqltypes.Int64BindVariable(1),
+			"toc":   sqltypes.BytesBindVariable([]byte("test")),
+		},
+	}, {
+		Sql: "insert into t(fromc, c1, c2, toc) values(:fromc_0, :c1_0, :c2_0, :toc_0)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"fromc_0": sqltypes.Int64BindVariable(1),
+			"c1_0":    sqltypes.StringBindVariable("b"),
+			"c2_0":    sqltypes.Int64BindVariable(10),
+			"toc_0":   sqltypes.BytesBindVariable([]byte("test")),
+		},
+	}}
+	utils.MustMatch(t, wantqueries, vc.queries)
+}
@@ -78,6 +78,7 @@ var (
 type lookupInternal struct {
 	Table                   string   `json:"table"`
 	FromColumns             []string `json:"from_columns"`
+	CoveringColumns         []string `json:"covering_columns,omitempty"`
 	To                      string   `json:"to"`
 	Autocommit              bool     `json:"autocommit,omitempty"`
 	MultiShardAutocommit    bool     `json:"multi_shard_autocommit,omitempty"`
@@ -124,15 +125,7 @@ func (lkp *lookupInternal) Init(lookupQueryParams map[string]string, autocommit,
 	// as part of face 2 of https://github.com/vitessio/vitess/issues/3481
 	// For now multi column behaves as a single column for Map and Verify operations
 	lkp.sel = fmt.Sprintf("select %s, %s from %s where %s in ::%s", lkp.FromColumns[0], lkp.To, lkp.Table, lkp.FromColumns[0], lkp.FromColumns[0])
-	if lkp.ReadLock != readLockNone {
-		lockExpr, ok := readLockExprs[lkp.ReadLock]
-		if !ok {
-			lockExpr = readLockExprs[readLockDefault]
-		}
-		lkp.selTxDml = fmt.Sprintf("%s %s", lkp.sel, lockExpr)
-	} else {
-		lkp.selTxDml = lkp.sel
-	}
+	lkp.selTxDml = lkp.withReadLock(lkp.sel)
 	lkp.ver = fmt.Sprintf("select %s from %s where %s = :%s and %s = :%s", lkp.FromColumns[0], lkp.Table, lkp.FromColumns[0], lkp.FromColumns[0], lkp.To, lkp.To)
 	lkp.del = lkp.initDelStmt()
 	return nil
@@ -228,6 +221,86 @@ func (lkp *lookupInternal) VerifyCustom(ctx context.Context, vcursor VCursor, id
 	return out, nil
 }
 
+// LookupRows performs a lookup for rows of values of the from columns. A row
+// that has fewer values than there are from columns is looked up on the leading
+// from columns only, and the values after the from columns are ignored.
+func (lkp *lookupInternal) LookupRows(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, co vtgatepb.CommitOrder) ([]*sqltypes.Result, error) {
+	if vcursor == nil {
+		return nil, vterrors.VT13001("cannot perform lookup: no vcursor provided")
+	}
+	if lkp.Autocommit {
+		co = vtgatepb.CommitOrder_AUTOCOMMIT
+	}
+	results := make([]*sqltypes.Result, 0, len(rowsColValues))
+	for _, row := range rowsColValues {
+		fromColumns := lkp.FromColumns[:min(len(row), len(lkp.FromColumns))]
+		sel := fmt.Sprintf("select %s from %s where %s", lkp.To, lkp.Table, whereColumns(fromColumns))
+		if vcursor.InTransactionAndIsDML() {
+			sel = lkp.withReadLock(sel)
+		}
+		bindVars := make(map[string]*querypb.BindVariable, len(fromColumns))
+		for i, col := range fromColumns {
+			bindVars[col] = sqltypes.ValueBindVariable(row[i])
+		}
+		result, err := vcursor.Execute(ctx, "VindexLookup", sel, bindVars, false /* rollbackOnError */, co)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "lookup.Map")
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// VerifyRows returns true if the rows of values of the from columns map to values.
+func (lkp *lookupInternal) VerifyRows(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, values []sqltypes.Value) ([]bool, error) {
+	co := vtgatepb.CommitOrder_NORMAL
+	if lkp.Autocommit {
+		co = vtgatepb.CommitOrder_AUTOCOMMIT
+	}
+	ver := fmt.Sprintf("select %s from %s where %s and %s = :%s", lkp.FromColumns[0], lkp.Table, whereColumns(lkp.FromColumns), lkp.To, lkp.To)
+	out := make([]bool, len(rowsColValues))
+	for i, row := range rowsColValues {
+		if len(row) < len(lkp.FromColumns) {
+			return nil, vterrors.VT03030(lkp.FromColumns, len(row))
+		}
+		bindVars := make(map[string]*querypb.BindVariable, len(lkp.FromColumns)+1)
+		for colIdx, col := range lkp.FromColumns {
+			bindVars[col] = sqltypes.ValueBindVariable(row[colIdx])
+		}
+		bindVars[lkp.To] = sqltypes.ValueBindVariable(values[i])
+		result, err := vcursor.Execute(ctx, "VindexVerify", ver, bindVars, false /* rollbackOnError */, co)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "lookup.Verify")
+		}
+		out[i] = (len(result.Rows) != 0)
+	}
+	return out, nil
+}
+
+// withReadLock returns the select with the read lock of the vindex, used within DML transactions.
+func (lkp *lookupInternal) withReadLock(sel string) string {
+	if lkp.ReadLock == readLockNone {
+		return sel
+	}
+	lockExpr, ok := readLockExprs[lkp.ReadLock]
+	if !ok {
+		lockExpr = readLockExprs[readLockDefault]
+	}
+	return fmt.Sprintf("%s %s", sel, lockExpr)
+}
+
+// whereColumns returns the predicate that matches every column with the bind variable of the same name.
+func whereColumns(columns []string) string {
+	var buf strings.Builder
+	for colIdx, column := range columns {
+		if colIdx != 0 {
+			buf.WriteString(" and ")
+		}
+		buf.WriteString(column + " = :" + column)
+	}
+	return buf.String()
+}
+
 type sorter struct {
 	rowsColValues [][]sqltypes.Value
 	toValues      []sqltypes.Value
@@ -286,7 +359,8 @@ func (lkp *lookupInternal) createCustom(ctx context.Context, vcursor VCursor, ro
 nextRow:
 	for i, row := range rowsColValues {
 		for j, col := range row {
-			if col.IsNull() {
+			// covering columns are stored as they are, only from columns can't be null
+			if col.IsNull() && j < len(lkp.FromColumns) {
 				if !lkp.IgnoreNulls {
 					cols := strings.Join(lkp.FromColumns, ",")
 					return vterrors.VT03028(cols, i, j)
@@ -302,8 +376,9 @@ nextRow:
 	}
 	// We only need to check the first row. Number of cols per row
 	// is guaranteed by the engine to be uniform.
-	if len(trimmedRowsCols[0]) != len(lkp.FromColumns) {
-		return vterrors.VT03030(lkp.FromColumns, len(trimmedRowsCols[0]))
+	columns := lkp.columns()
+	if len(trimmedRowsCols[0]) != len(columns) {
+		return vterrors.VT03030(columns, len(trimmedRowsCols[0]))
 	}
 	sort.Sort(&sorter{rowsColValues: trimmedRowsCols, toValues: trimmedToValues})
 
@@ -317,7 +392,7 @@ nextRow:
 	} else {
 		fmt.Fprintf(&buf, "%s into %s(", insStmt, lkp.Table)
 	}
-	for _, col := range lkp.FromColumns {
+	for _, col := range columns {
 		fmt.Fprintf(&buf, "%s, ", col)
 	}
 	fmt.Fprintf(&buf, "%s) values(", lkp.To)
@@ -329,7 +404,7 @@ nextRow:
 			buf.WriteString(", (")
 		}
 		for colIdx, colID := range colIds {
-			fromStr := lkp.FromColumns[colIdx] + "_" + strconv.Itoa(rowIdx)
+			fromStr := columns[colIdx] + "_" + strconv.Itoa(rowIdx)
 			bindVars[fromStr] = sqltypes.ValueBindVariable(colID)
 			buf.WriteString(":" + fromStr + ", ")
 		}
@@ -340,7 +415,7 @@ nextRow:
 
 	if lkp.Upsert {
 		fmt.Fprintf(&buf, " on duplicate key update ")
-		for _, col := range lkp.FromColumns {
+		for _, col := range columns {
 			fmt.Fprintf(&buf, "%s=values(%s), ", col, col)
 		}
 		fmt.Fprintf(&buf, "%s=values(%s)", lkp.To, lkp.To)
@@ -378,12 +453,13 @@ func (lkp *lookupInternal) Delete(ctx context.Context, vcursor VCursor, rowsColV
 	}
 	// We only need to check the first row. Number of cols per row
 	// is guaranteed by the engine to be uniform.
-	if len(rowsColValues[0]) != len(lkp.FromColumns) {
-		return vterrors.VT03030(lkp.FromColumns, len(rowsColValues[0]))
+	if columns := lkp.columns(); len(rowsColValues[0]) != len(columns) {
+		return vterrors.VT03030(columns, len(rowsColValues[0]))
 	}
 	for _, column := range rowsColValues {
 		bindVars := make(map[string]*querypb.BindVariable, len(rowsColValues))
-		for colIdx, columnValue := range column {
+		// the covering columns don't identify the row, so they're not part of the delete
+		for colIdx, columnValue := range column[:len(lkp.FromColumns)] {
 			bindVars[lkp.FromColumns[colIdx]] = sqltypes.ValueBindVariable(columnValue)
 		}
 		bindVars[lkp.To] = sqltypes.ValueBindVariable(value)
@@ -416,6 +492,15 @@ func (lkp *lookupInternal) initDelStmt() string {
 	return delBuffer.String()
 }
 
+// columns returns the columns of the lookup table that are populated from the
+// owner table: the from columns, followed by the covering columns.
+func (lkp *lookupInternal) columns() []string {
+	if len(lkp.CoveringColumns) == 0 {
+		return lkp.FromColumns
+	}
+	return append(append(make([]string, 0, len(lkp.FromColumns)+len(lkp.CoveringColumns)), lkp.FromColumns...), lkp.CoveringColumns...)
+}
+
 func (lkp *lookupInternal) query() (selQuery string, arguments []string) {
 	return lkp.sel, lkp.FromColumns
 }
@@ -18,13 +18,16 @@ package vindexes
 
 import (
 	"context"
+	"math"
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var numeric SingleColumn
@@ -146,3 +149,53 @@ func TestNumericReverseMapBadData(t *testing.T) {
 		t.Errorf("numeric.Map: %v, want %v", err, want)
 	}
 }
+
+func TestNumericRangeMap(t *testing.T) {
+	testCases := []struct {
+		start, end sqltypes.Value
+		want       string
+	}{
+		{sqltypes.NewInt64(1), sqltypes.NewInt64(16), "DestinationKeyRange(0000000000000001-0000000000000011)"},
+		{sqltypes.NewInt64(1), sqltypes.NULL, "DestinationKeyRange(0000000000000001-)"},
+		{sqltypes.NULL, sqltypes.NewInt64(16), "DestinationKeyRange(-0000000000000011)"},
+		{sqltypes.NULL, sqltypes.NULL, "DestinationKeyRange(-)"},
+		// the end is inclusive, also when it is the start of a shard
+		{sqltypes.NewInt64(1), sqltypes.NewUint64(0x8000000000000000), "DestinationKeyRange(0000000000000001-8000000000000001)"},
+		{sqltypes.NewInt64(1), sqltypes.NewUint64(math.MaxUint64), "DestinationKeyRange(0000000000000001-)"},
+		// bounds that cannot be converted target all the shards
+		{sqltypes.NewInt64(-1), sqltypes.NULL, "DestinationAllShards()"},
+		{sqltypes.NewFloat64(1.5), sqltypes.NULL, "DestinationAllShards()"},
+		{sqltypes.NULL, sqltypes.NewVarBinary("aa"), "DestinationAllShards()"},
+	}
+	for _, tc := range testCases {
+		got, err := numeric.(Sequential).RangeMap(context.Background(), nil, tc.start, tc.end)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got[0].String())
+	}
+}
+
+func TestNumericRangeMapShardBoundary(t *testing.T) {
+	shards := []*topodatapb.ShardReference{
+		{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+		{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+	}
+	testCases := []struct {
+		start, end sqltypes.Value
+		want       []string
+	}{
+		{sqltypes.NewInt64(1), sqltypes.NewUint64(0x7fffffffffffffff), []string{"-80"}},
+		{sqltypes.NewInt64(1), sqltypes.NewUint64(0x8000000000000000), []string{"-80", "80-"}},
+		{sqltypes.NewUint64(0x8000000000000000), sqltypes.NULL, []string{"80-"}},
+	}
+	for _, tc := range testCases {
+		got, err := numeric.(Sequential).RangeMap(context.Background(), nil, tc.start, tc.end)
+		require.NoError(t, err)
+		var resolved []string
+		err = got[0].Resolve(shards, func(shard string) error {
+			resolved = append(resolved, shard)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, resolved, "RangeMap(%v, %v)", tc.start, tc.end)
+	}
+}
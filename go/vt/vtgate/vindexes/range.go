@@ -0,0 +1,329 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	rangeParamSplitPoints = "split_points"
+	rangeParamType        = "type"
+
+	// rangeMaxTimeZoneOffset is the largest offset of a time zone from UTC. A
+	// datetime that is later than the current time by more than it is in the
+	// future in every time zone.
+	rangeMaxTimeZoneOffset = 14 * time.Hour
+)
+
+var (
+	_ SingleColumn    = (*Range)(nil)
+	_ Hashing         = (*Range)(nil)
+	_ ParamValidating = (*Range)(nil)
+	_ Sequential      = (*Range)(nil)
+
+	rangeParams = []string{
+		rangeParamSplitPoints,
+		rangeParamType,
+	}
+
+	// rangeTypes are the supported column types of a Range vindex. Each one of them
+	// converts a value into a key that sorts in the same order as the value.
+	rangeTypes = map[string]func(id sqltypes.Value) ([]byte, error){
+		"int":      rangeIntKey,
+		"uint":     rangeUintKey,
+		"datetime": rangeDateTimeKey,
+	}
+)
+
+// rangeSplitPoint is the start of a range of values, which are all mapped to
+// the same keyspace id.
+type rangeSplitPoint struct {
+	value string
+	key   []byte
+	ksid  []byte
+}
+
+// Range maps ordered values, such as timestamps, to the keyspace ids of the
+// ranges that contain them. The ranges are defined by a list of split points:
+// every value that is greater than or equal to a split point, and lower than the
+// next one, is mapped to the keyspace id of that split point. Since the keyspace
+// ids of the split points are ordered like the split points themselves, a range
+// of values always maps to a contiguous range of shards.
+// It's Unique.
+//
+// The split points are given with the `split_points` param as a comma-separated
+// list of `value=keyspace_id` pairs, where the keyspace id is hex-encoded like the
+// bounds of a shard, for example "2024-01-01=40,2024-07-01=80,2025-01-01=c0".
+// The `type` param defines how values are compared, and is either `int` (the
+// default), `uint` or `datetime`.
+type Range struct {
+	name          string
+	typ           string
+	keyFunc       func(id sqltypes.Value) ([]byte, error)
+	splitPoints   []rangeSplitPoint
+	unknownParams []string
+}
+
+// newRange creates a Range vindex.
+func newRange(name string, params map[string]string) (Vindex, error) {
+	typ := "int"
+	if t, ok := params[rangeParamType]; ok {
+		typ = strings.ToLower(t)
+	}
+	keyFunc, ok := rangeTypes[typ]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: unsupported type %q in vschema", typ)
+	}
+
+	points, ok := params[rangeParamSplitPoints]
+	if !ok || strings.TrimSpace(points) == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: Could not find `split_points` param in vschema")
+	}
+
+	var splitPoints []rangeSplitPoint
+	for _, point := range strings.Split(points, ",") {
+		value, ksid, ok := strings.Cut(point, "=")
+		if !ok {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: invalid split point %q, expected `value=keyspace_id`", strings.TrimSpace(point))
+		}
+		value = strings.TrimSpace(value)
+		k, err := keyFunc(sqltypes.NewVarChar(value))
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: invalid split point value %q: %v", value, err)
+		}
+		ksidBytes, err := hex.DecodeString(strings.TrimSpace(ksid))
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: invalid keyspace id %q for split point %q: %v", strings.TrimSpace(ksid), value, err)
+		}
+
+		if n := len(splitPoints); n > 0 {
+			if bytes.Compare(splitPoints[n-1].key, k) >= 0 {
+				return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: split points must be in increasing order, found %q after a greater or equal value", value)
+			}
+			if bytes.Compare(splitPoints[n-1].ksid, ksidBytes) >= 0 {
+				return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: keyspace ids must be in increasing order, found %q after a greater or equal keyspace id", strings.TrimSpace(ksid))
+			}
+		}
+		splitPoints = append(splitPoints, rangeSplitPoint{value: value, key: k, ksid: ksidBytes})
+	}
+
+	return &Range{
+		name:          name,
+		typ:           typ,
+		keyFunc:       keyFunc,
+		splitPoints:   splitPoints,
+		unknownParams: FindUnknownParams(params, rangeParams),
+	}, nil
+}
+
+// String returns the name of the vindex.
+func (vind *Range) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*Range) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (*Range) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (*Range) NeedsVCursor() bool {
+	return false
+}
+
+// Verify returns true if ids and ksids match.
+func (vind *Range) Verify(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, 0, len(ids))
+	for i, id := range ids {
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bytes.Equal(ksid, ksids[i]))
+	}
+	return out, nil
+}
+
+// Map can map ids to key.ShardDestination objects.
+func (vind *Range) Map(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]key.ShardDestination, error) {
+	out := make([]key.ShardDestination, 0, len(ids))
+	for _, id := range ids {
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		out = append(out, key.DestinationKeyspaceID(ksid))
+	}
+	return out, nil
+}
+
+// RangeMap implements Between. The resulting key range starts at the keyspace id
+// of the range that contains startId and ends at the keyspace id of the range that
+// follows the one containing endId. If a bound cannot be converted to the type of
+// the split points, all the shards are targeted.
+func (vind *Range) RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error) {
+	start, end := 0, len(vind.splitPoints)-1
+	var startKey, endKey []byte
+	var err error
+	if !startId.IsNull() {
+		if startKey, err = vind.keyFunc(startId); err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}, nil
+		}
+		start = max(vind.find(startKey), 0)
+	}
+	if !endId.IsNull() {
+		if endKey, err = vind.keyFunc(endId); err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}, nil
+		}
+		end = vind.find(endKey)
+	}
+	if end < 0 || (startKey != nil && endKey != nil && bytes.Compare(startKey, endKey) > 0) {
+		return []key.ShardDestination{key.DestinationNone{}}, nil
+	}
+
+	var endKsid []byte
+	if end+1 < len(vind.splitPoints) {
+		endKsid = vind.splitPoints[end+1].ksid
+	}
+	out := []key.ShardDestination{&key.DestinationKeyRange{KeyRange: key.NewKeyRange(vind.splitPoints[start].ksid, endKsid)}}
+	return out, nil
+}
+
+// CheckAppendedSplitPoints returns an error unless vind can replace prev without
+// changing the keyspace id of any existing row. Its split points must be the ones
+// of prev followed by new ones, and no existing row may have a value at or above
+// the first new split point: such a row would be mapped to the keyspace id of the
+// new split point instead of the one of the shard it is stored in, and could no
+// longer be found. Since the existing values cannot be known here, new split
+// points can only be added to a datetime vindex, and must be in the future in
+// every time zone at the given time.
+func (vind *Range) CheckAppendedSplitPoints(prev *Range, now time.Time) error {
+	if vind.typ != prev.typ || len(vind.splitPoints) < len(prev.splitPoints) {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: new split points can only be added after the last one")
+	}
+	for i, sp := range prev.splitPoints {
+		if !bytes.Equal(sp.key, vind.splitPoints[i].key) || !bytes.Equal(sp.ksid, vind.splitPoints[i].ksid) {
+			return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: new split points can only be added after the last one")
+		}
+	}
+	if len(vind.splitPoints) == len(prev.splitPoints) {
+		return nil
+	}
+
+	first := vind.splitPoints[len(prev.splitPoints)]
+	if vind.typ != "datetime" {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: cannot add split point %q to a vindex of type %s, as the existing rows at or above it would be mapped to a new keyspace id and could no longer be found", first.value, vind.typ)
+	}
+	future := now.UTC().Add(rangeMaxTimeZoneOffset)
+	futureKey, err := vind.keyFunc(sqltypes.NewVarChar(future.Format("2006-01-02 15:04:05.999999")))
+	if err != nil {
+		return err
+	}
+	if bytes.Compare(first.key, futureKey) <= 0 {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: cannot add split point %q, as it is not in the future in every time zone and the existing rows at or above it would be mapped to a new keyspace id and could no longer be found", first.value)
+	}
+	return nil
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (vind *Range) UnknownParams() []string {
+	return vind.unknownParams
+}
+
+// Hash returns the keyspace id of the range that contains id.
+func (vind *Range) Hash(id sqltypes.Value) ([]byte, error) {
+	k, err := vind.keyFunc(id)
+	if err != nil {
+		return nil, err
+	}
+	i := vind.find(k)
+	if i < 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: value %s is lower than the first split point", id.String())
+	}
+	return vind.splitPoints[i].ksid, nil
+}
+
+// find returns the index of the last split point that is lower than or equal to
+// the given key, or -1 if all the split points are greater.
+func (vind *Range) find(k []byte) int {
+	return sort.Search(len(vind.splitPoints), func(i int) bool {
+		return bytes.Compare(vind.splitPoints[i].key, k) > 0
+	}) - 1
+}
+
+func rangeIntKey(id sqltypes.Value) ([]byte, error) {
+	num, err := id.ToCastInt64()
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64(nil, uint64(num)^(1<<63)), nil
+}
+
+func rangeUintKey(id sqltypes.Value) ([]byte, error) {
+	num, err := id.ToCastUint64()
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64(nil, num), nil
+}
+
+func rangeDateTimeKey(id sqltypes.Value) ([]byte, error) {
+	var (
+		dt datetime.DateTime
+		ok bool
+	)
+	if id.IsIntegral() {
+		num, err := id.ToCastInt64()
+		if err != nil {
+			return nil, err
+		}
+		dt, ok = datetime.ParseDateTimeInt64(num)
+	} else {
+		str := id.ToString()
+		if dt, _, ok = datetime.ParseDateTime(str, -1); !ok {
+			dt.Date, ok = datetime.ParseDate(str)
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("cannot parse datetime from %q", id.ToString())
+	}
+	return dt.WeightString(nil), nil
+}
+
+func init() {
+	Register("range", newRange)
+}
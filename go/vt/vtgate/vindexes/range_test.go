@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+func rangeCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "range",
+		vindexName:   "range",
+		vindexParams: vindexParams,
+
+		expectCost:          1,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  false,
+		expectString:        "range",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestRangeCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		rangeCreateVindexTestCase(
+			"no params invalid, require split_points",
+			nil,
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: Could not find `split_points` param in vschema"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"split_points ok",
+			map[string]string{
+				"split_points": "0=, 100=40, 200=80",
+			},
+			nil,
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"datetime split_points ok",
+			map[string]string{
+				"split_points": "2024-01-01=40,2024-07-01 12:00:00=80",
+				"type":         "datetime",
+			},
+			nil,
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"type must be supported",
+			map[string]string{
+				"split_points": "0=",
+				"type":         "float",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: unsupported type \"float\" in vschema"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"split point must have a keyspace id",
+			map[string]string{
+				"split_points": "0=,100",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: invalid split point \"100\", expected `value=keyspace_id`"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"split point value must match the type",
+			map[string]string{
+				"split_points": "abc=40",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: invalid split point value \"abc\": cannot parse int64 from \"abc\""),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"keyspace id must be hex",
+			map[string]string{
+				"split_points": "0=zz",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: invalid keyspace id \"zz\" for split point \"0\": encoding/hex: invalid byte: U+007A 'z'"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"split points must be increasing",
+			map[string]string{
+				"split_points": "100=40,100=80",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: split points must be in increasing order, found \"100\" after a greater or equal value"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"keyspace ids must be increasing",
+			map[string]string{
+				"split_points": "100=80,200=40",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Range: keyspace ids must be in increasing order, found \"40\" after a greater or equal keyspace id"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"unknown params",
+			map[string]string{
+				"split_points": "0=",
+				"hello":        "world",
+			},
+			nil,
+			[]string{"hello"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func createRangeVindex(t *testing.T, params map[string]string) *Range {
+	vindex, err := CreateVindex("range", "range", params)
+	require.NoError(t, err)
+	return vindex.(*Range)
+}
+
+func TestRangeMap(t *testing.T) {
+	vind := createRangeVindex(t, map[string]string{"split_points": "-100=10,0=40,100=80,200=c0"})
+	got, err := vind.Map(context.Background(), nil, []sqltypes.Value{
+		sqltypes.NewInt64(-101),
+		sqltypes.NewInt64(-100),
+		sqltypes.NewInt64(-1),
+		sqltypes.NewInt64(0),
+		sqltypes.NewVarChar("99"),
+		sqltypes.NewUint64(100),
+		sqltypes.NewInt64(1000),
+		sqltypes.NewVarChar("abc"),
+		sqltypes.NULL,
+	})
+	require.NoError(t, err)
+	want := []key.ShardDestination{
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte("\x10")),
+		key.DestinationKeyspaceID([]byte("\x10")),
+		key.DestinationKeyspaceID([]byte("\x40")),
+		key.DestinationKeyspaceID([]byte("\x40")),
+		key.DestinationKeyspaceID([]byte("\x80")),
+		key.DestinationKeyspaceID([]byte("\xc0")),
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestRangeMapDateTime(t *testing.T) {
+	vind := createRangeVindex(t, map[string]string{
+		"split_points": "2024-01-01=,2024-04-01=40,2024-07-01=80,2024-10-01=c0",
+		"type":         "datetime",
+	})
+	got, err := vind.Map(context.Background(), nil, []sqltypes.Value{
+		sqltypes.NewDatetime("2023-12-31 23:59:59"),
+		sqltypes.NewDatetime("2024-01-01 00:00:00"),
+		sqltypes.NewVarChar("2024-03-31 23:59:59.999999"),
+		sqltypes.NewDate("2024-04-01"),
+		sqltypes.NewInt64(20240815120000),
+		sqltypes.NewVarChar("not a date"),
+	})
+	require.NoError(t, err)
+	want := []key.ShardDestination{
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte("\x40")),
+		key.DestinationKeyspaceID([]byte("\x80")),
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestRangeVerify(t *testing.T) {
+	vind := createRangeVindex(t, map[string]string{"split_points": "0=,100=80"})
+	got, err := vind.Verify(context.Background(), nil,
+		[]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(150), sqltypes.NewInt64(99)},
+		[][]byte{{}, []byte("\x80"), []byte("\x80")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, got)
+
+	_, err = vind.Verify(context.Background(), nil, []sqltypes.Value{sqltypes.NewInt64(-1)}, [][]byte{nil})
+	require.EqualError(t, err, "Range: value INT64(-1) is lower than the first split point")
+}
+
+func TestRangeCheckAppendedSplitPoints(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	prev := createRangeVindex(t, map[string]string{"split_points": "2024-01-01=,2024-07-01=80", "type": "datetime"})
+	testcases := []struct {
+		splitPoints string
+		err         string
+	}{{
+		splitPoints: "2024-01-01=,2024-07-01=80",
+	}, {
+		splitPoints: "2024-01-01=,2024-07-01=80,2025-04-01=c0",
+	}, {
+		splitPoints: "2024-01-01=,2024-07-01=80,2025-03-02 02:00:01=c0",
+	}, {
+		splitPoints: "2024-01-01=,2024-07-01=40,2025-04-01=80",
+		err:         "Range: new split points can only be added after the last one",
+	}, {
+		splitPoints: "2024-01-01=",
+		err:         "Range: new split points can only be added after the last one",
+	}, {
+		splitPoints: "2024-01-01=,2024-07-01=80,2025-01-01=c0",
+		err:         "Range: cannot add split point \"2025-01-01\", as it is not in the future in every time zone and the existing rows at or above it would be mapped to a new keyspace id and could no longer be found",
+	}, {
+		splitPoints: "2024-01-01=,2024-07-01=80,2025-03-02 02:00:00=c0",
+		err:         "Range: cannot add split point \"2025-03-02 02:00:00\", as it is not in the future in every time zone and the existing rows at or above it would be mapped to a new keyspace id and could no longer be found",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.splitPoints, func(t *testing.T) {
+			vind := createRangeVindex(t, map[string]string{"split_points": tc.splitPoints, "type": "datetime"})
+			err := vind.CheckAppendedSplitPoints(prev, now)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+
+	// The values of the other types are not known to be beyond the existing data.
+	prev = createRangeVindex(t, map[string]string{"split_points": "0=,100=80"})
+	vind := createRangeVindex(t, map[string]string{"split_points": "0=,100=80,200=c0"})
+	require.EqualError(t, vind.CheckAppendedSplitPoints(prev, now), "Range: cannot add split point \"200\" to a vindex of type int, as the existing rows at or above it would be mapped to a new keyspace id and could no longer be found")
+	require.NoError(t, prev.CheckAppendedSplitPoints(prev, now))
+}
+
+func TestRangeRangeMap(t *testing.T) {
+	vind := createRangeVindex(t, map[string]string{"split_points": "0=20,100=40,200=80,300=c0"})
+
+	testCases := []struct {
+		start, end sqltypes.Value
+		want       key.ShardDestination
+	}{
+		{
+			start: sqltypes.NewInt64(10), end: sqltypes.NewInt64(20),
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x20"), []byte("\x40"))},
+		},
+		{
+			start: sqltypes.NewInt64(50), end: sqltypes.NewInt64(250),
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x20"), []byte("\xc0"))},
+		},
+		{
+			start: sqltypes.NewInt64(100), end: sqltypes.NewInt64(199),
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x40"), []byte("\x80"))},
+		},
+		{
+			start: sqltypes.NewInt64(-50), end: sqltypes.NewInt64(100),
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x20"), []byte("\x80"))},
+		},
+		{
+			start: sqltypes.NewInt64(250), end: sqltypes.NewInt64(1000),
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x80"), nil)},
+		},
+		{
+			start: sqltypes.NewInt64(-50), end: sqltypes.NewInt64(-10),
+			want: key.DestinationNone{},
+		},
+		{
+			start: sqltypes.NewInt64(200), end: sqltypes.NewInt64(100),
+			want: key.DestinationNone{},
+		},
+		{
+			start: sqltypes.NewInt64(150), end: sqltypes.NULL,
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x40"), nil)},
+		},
+		{
+			start: sqltypes.NULL, end: sqltypes.NewInt64(150),
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x20"), []byte("\x80"))},
+		},
+		{
+			start: sqltypes.NULL, end: sqltypes.NewInt64(-1),
+			want: key.DestinationNone{},
+		},
+		{
+			start: sqltypes.NULL, end: sqltypes.NULL,
+			want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte("\x20"), nil)},
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := vind.RangeMap(context.Background(), nil, tc.start, tc.end)
+		require.NoError(t, err)
+		assert.Equal(t, []key.ShardDestination{tc.want}, got, "RangeMap(%v, %v)", tc.start, tc.end)
+	}
+
+	// a bound that cannot be converted targets all the shards
+	got, err := vind.RangeMap(context.Background(), nil, sqltypes.NewVarChar("abc"), sqltypes.NewInt64(1))
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{key.DestinationAllShards{}}, got)
+}
@@ -0,0 +1,465 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vindexdatapb "vitess.io/vitess/go/vt/proto/vindexdata"
+	vindexservicepb "vitess.io/vitess/go/vt/proto/vindexservice"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	remoteParamAddress       = "address"
+	remoteParamUnique        = "unique"
+	remoteParamBatchSize     = "batch_size"
+	remoteParamTimeout       = "timeout"
+	remoteParamCacheSize     = "cache_size"
+	remoteParamCacheTTL      = "cache_ttl"
+	remoteParamTLSCert       = "tls_cert"
+	remoteParamTLSKey        = "tls_key"
+	remoteParamTLSCA         = "tls_ca"
+	remoteParamTLSServerName = "tls_server_name"
+
+	remoteDefaultBatchSize = 1000
+	remoteDefaultTimeout   = 5 * time.Second
+	remoteDefaultCacheSize = 10000
+	remoteDefaultCacheTTL  = time.Minute
+)
+
+var (
+	_ SingleColumn    = (*Remote)(nil)
+	_ ParamValidating = (*Remote)(nil)
+
+	remoteParams = []string{
+		remoteParamAddress,
+		remoteParamUnique,
+		remoteParamBatchSize,
+		remoteParamTimeout,
+		remoteParamCacheSize,
+		remoteParamCacheTTL,
+		remoteParamTLSCert,
+		remoteParamTLSKey,
+		remoteParamTLSCA,
+		remoteParamTLSServerName,
+	}
+
+	// remoteConns holds the connections to the services, which are shared by
+	// all the Remote vindexes that use the same address, so that they are not
+	// dialed again every time the vschema changes. Since the vindexes are
+	// replaced without being closed, a connection is closed once it has not
+	// been used for remoteConnIdleTimeout, and dialed again when it is needed.
+	remoteConnsMu sync.Mutex
+	remoteConns   = make(map[remoteConnKey]*remoteConn)
+
+	remoteConnIdleTimeout = 10 * time.Minute
+)
+
+type remoteConnKey struct {
+	address, cert, key, ca, serverName string
+}
+
+// remoteConn is a connection, and the number of calls that use it.
+type remoteConn struct {
+	conn     *grpc.ClientConn
+	inUse    int
+	lastUsed time.Time
+	// idle closes the connection once it is idle for remoteConnIdleTimeout.
+	idle *time.Timer
+}
+
+// remoteCacheEntry is a cached result of the service for one id.
+type remoteCacheEntry struct {
+	ksids   [][]byte
+	expires time.Time
+}
+
+// remoteCache is the part of cache.LRUCache that is used by Remote.
+type remoteCache interface {
+	Get(key string) (remoteCacheEntry, bool)
+	Set(key string, value remoteCacheEntry) bool
+}
+
+// Remote is a vindex that delegates the mapping of ids to keyspace ids to an
+// external gRPC service, which implements the vindexservice.Vindex service. This
+// allows custom mappings, such as from tenants to shards, without compiling them
+// into Vitess.
+//
+// The `address` param is the address of the service. The vindex is unique unless
+// the `unique` param is set to false, in which case the service can map an id to
+// more than one keyspace id. Ids are sent to the service in batches of up to
+// `batch_size` ids, and every call times out after `timeout`. The results of Map
+// are cached for `cache_ttl` in a cache of `cache_size` ids, which can be set to
+// 0 to disable the cache. The `tls_cert`, `tls_key`, `tls_ca` and
+// `tls_server_name` params configure TLS for the connection to the service.
+// Ids that the service does not map to any keyspace id are not cached, so that
+// they are found as soon as the service knows them.
+type Remote struct {
+	name          string
+	connKey       remoteConnKey
+	unique        bool
+	batchSize     int
+	timeout       time.Duration
+	cacheTTL      time.Duration
+	cache         remoteCache
+	unknownParams []string
+}
+
+// newRemote creates a Remote vindex.
+func newRemote(name string, params map[string]string) (Vindex, error) {
+	address := params[remoteParamAddress]
+	if address == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: Could not find `address` param in vschema")
+	}
+
+	rv := &Remote{
+		name:          name,
+		unique:        true,
+		batchSize:     remoteDefaultBatchSize,
+		timeout:       remoteDefaultTimeout,
+		cacheTTL:      remoteDefaultCacheTTL,
+		unknownParams: FindUnknownParams(params, remoteParams),
+	}
+	var err error
+	if _, ok := params[remoteParamUnique]; ok {
+		if rv.unique, err = boolFromMap(params, remoteParamUnique); err != nil {
+			return nil, err
+		}
+	}
+	if rv.batchSize, err = remoteIntParam(params, remoteParamBatchSize, remoteDefaultBatchSize); err != nil {
+		return nil, err
+	}
+	if rv.batchSize <= 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: %s must be greater than 0", remoteParamBatchSize)
+	}
+	if rv.timeout, err = remoteDurationParam(params, remoteParamTimeout, remoteDefaultTimeout); err != nil {
+		return nil, err
+	}
+	if rv.cacheTTL, err = remoteDurationParam(params, remoteParamCacheTTL, remoteDefaultCacheTTL); err != nil {
+		return nil, err
+	}
+	cacheSize, err := remoteIntParam(params, remoteParamCacheSize, remoteDefaultCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	if cacheSize > 0 && rv.cacheTTL > 0 {
+		rv.cache = cache.NewLRUCache[remoteCacheEntry](int64(cacheSize))
+	}
+
+	rv.connKey = remoteConnKey{
+		address:    address,
+		cert:       params[remoteParamTLSCert],
+		key:        params[remoteParamTLSKey],
+		ca:         params[remoteParamTLSCA],
+		serverName: params[remoteParamTLSServerName],
+	}
+	// The connection is dialed right away, so that invalid params are reported
+	// when the vschema is loaded.
+	if _, err := acquireRemoteConn(rv.connKey); err != nil {
+		return nil, err
+	}
+	releaseRemoteConn(rv.connKey)
+	return rv, nil
+}
+
+// acquireRemoteConn returns the connection to the service at the given address,
+// and dials it if there is none yet. Every call must be matched by a call to
+// releaseRemoteConn once the connection is not used anymore.
+func acquireRemoteConn(k remoteConnKey) (*grpc.ClientConn, error) {
+	remoteConnsMu.Lock()
+	defer remoteConnsMu.Unlock()
+	if c, ok := remoteConns[k]; ok {
+		c.inUse++
+		return c.conn, nil
+	}
+	opt, err := grpcclient.SecureDialOption(k.cert, k.key, k.ca, "", k.serverName)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: invalid TLS params: %v", err)
+	}
+	conn, err := grpcclient.DialContext(context.Background(), k.address, grpcclient.FailFast(false), opt)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "Remote: could not dial %s: %v", k.address, err)
+	}
+	remoteConns[k] = &remoteConn{conn: conn, inUse: 1}
+	return conn, nil
+}
+
+// releaseRemoteConn releases the connection to the service at the given
+// address, and schedules its close for when it is idle.
+func releaseRemoteConn(k remoteConnKey) {
+	remoteConnsMu.Lock()
+	defer remoteConnsMu.Unlock()
+	c := remoteConns[k]
+	c.inUse--
+	c.lastUsed = time.Now()
+	if c.inUse > 0 {
+		return
+	}
+	if c.idle != nil {
+		c.idle.Reset(remoteConnIdleTimeout)
+		return
+	}
+	c.idle = time.AfterFunc(remoteConnIdleTimeout, func() {
+		closeIdleRemoteConn(k, c)
+	})
+}
+
+// closeIdleRemoteConn closes the connection if it has not been used since
+// remoteConnIdleTimeout.
+func closeIdleRemoteConn(k remoteConnKey, c *remoteConn) {
+	remoteConnsMu.Lock()
+	defer remoteConnsMu.Unlock()
+	if remoteConns[k] != c || c.inUse > 0 || time.Since(c.lastUsed) < remoteConnIdleTimeout {
+		return
+	}
+	delete(remoteConns, k)
+	c.conn.Close()
+}
+
+func remoteIntParam(params map[string]string, name string, def int) (int, error) {
+	val, ok := params[name]
+	if !ok {
+		return def, nil
+	}
+	i, err := strconv.Atoi(val)
+	if err != nil || i < 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: %s must be a non-negative integer: '%s'", name, val)
+	}
+	return i, nil
+}
+
+func remoteDurationParam(params map[string]string, name string, def time.Duration) (time.Duration, error) {
+	val, ok := params[name]
+	if !ok {
+		return def, nil
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil || d < 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: %s must be a non-negative duration: '%s'", name, val)
+	}
+	return d, nil
+}
+
+// String returns the name of the vindex.
+func (rv *Remote) String() string {
+	return rv.name
+}
+
+// Cost returns the cost of this vindex as 10 if unique, and 20 otherwise, like
+// for lookup vindexes, since every value that is not cached costs a remote call.
+func (rv *Remote) Cost() int {
+	if rv.unique {
+		return 10
+	}
+	return 20
+}
+
+// IsUnique returns true if the Vindex is unique.
+func (rv *Remote) IsUnique() bool {
+	return rv.unique
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (*Remote) NeedsVCursor() bool {
+	return false
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (rv *Remote) UnknownParams() []string {
+	return rv.unknownParams
+}
+
+// Map can map ids to key.ShardDestination objects.
+func (rv *Remote) Map(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]key.ShardDestination, error) {
+	results := make([][][]byte, len(ids))
+	// missing holds the index of the first id for each value that is not cached,
+	// and dups the indexes of the other ids with the same value.
+	var missing []int
+	dups := make(map[string][]int)
+	now := time.Now()
+	for i, id := range ids {
+		cacheKey := remoteCacheKey(id)
+		if rv.cache != nil {
+			if entry, ok := rv.cache.Get(cacheKey); ok && now.Before(entry.expires) {
+				results[i] = entry.ksids
+				continue
+			}
+		}
+		if _, ok := dups[cacheKey]; !ok {
+			missing = append(missing, i)
+		}
+		dups[cacheKey] = append(dups[cacheKey], i)
+	}
+
+	for start := 0; start < len(missing); start += rv.batchSize {
+		batch := missing[start:min(start+rv.batchSize, len(missing))]
+		req := &vindexdatapb.MapRequest{
+			Vindex: rv.name,
+			Ids:    make([]*querypb.Value, 0, len(batch)),
+		}
+		for _, i := range batch {
+			req.Ids = append(req.Ids, sqltypes.ValueToProto(ids[i]))
+		}
+		resp, err := rv.callMap(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Destinations) != len(batch) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Remote: %s returned %d destinations for %d ids", rv.name, len(resp.Destinations), len(batch))
+		}
+		expires := time.Now().Add(rv.cacheTTL)
+		for j, i := range batch {
+			ksids := resp.Destinations[j].GetKeyspaceIds()
+			if rv.unique && len(ksids) > 1 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Remote: %s is unique but returned %d keyspace ids for %s", rv.name, len(ksids), ids[i].String())
+			}
+			cacheKey := remoteCacheKey(ids[i])
+			for _, dup := range dups[cacheKey] {
+				results[dup] = ksids
+			}
+			// An id that is not mapped yet, e.g. a tenant that is being created,
+			// is not cached so that it can be routed as soon as it is mapped.
+			if rv.cache != nil && len(ksids) > 0 {
+				rv.cache.Set(cacheKey, remoteCacheEntry{ksids: ksids, expires: expires})
+			}
+		}
+	}
+
+	out := make([]key.ShardDestination, 0, len(ids))
+	for _, ksids := range results {
+		switch {
+		case len(ksids) == 0:
+			out = append(out, key.DestinationNone{})
+		case rv.unique:
+			out = append(out, key.DestinationKeyspaceID(ksids[0]))
+		default:
+			out = append(out, key.DestinationKeyspaceIDs(ksids))
+		}
+	}
+	return out, nil
+}
+
+// Verify returns true if ids maps to ksids.
+func (rv *Remote) Verify(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, 0, len(ids))
+	if rv.cache != nil {
+		// Answer from the cache if every id is in it.
+		now := time.Now()
+		for i, id := range ids {
+			entry, ok := rv.cache.Get(remoteCacheKey(id))
+			if !ok || !now.Before(entry.expires) {
+				out = nil
+				break
+			}
+			out = append(out, remoteContains(entry.ksids, ksids[i]))
+		}
+		if out != nil {
+			return out, nil
+		}
+		out = make([]bool, 0, len(ids))
+	}
+
+	for start := 0; start < len(ids); start += rv.batchSize {
+		end := min(start+rv.batchSize, len(ids))
+		req := &vindexdatapb.VerifyRequest{
+			Vindex:      rv.name,
+			Ids:         make([]*querypb.Value, 0, end-start),
+			KeyspaceIds: ksids[start:end],
+		}
+		for _, id := range ids[start:end] {
+			req.Ids = append(req.Ids, sqltypes.ValueToProto(id))
+		}
+		resp, err := rv.callVerify(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Matches) != end-start {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Remote: %s returned %d matches for %d ids", rv.name, len(resp.Matches), end-start)
+		}
+		out = append(out, resp.Matches...)
+	}
+	return out, nil
+}
+
+func (rv *Remote) callMap(ctx context.Context, req *vindexdatapb.MapRequest) (*vindexdatapb.MapResponse, error) {
+	if rv.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rv.timeout)
+		defer cancel()
+	}
+	conn, err := acquireRemoteConn(rv.connKey)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseRemoteConn(rv.connKey)
+	resp, err := vindexservicepb.NewVindexClient(conn).Map(ctx, req)
+	if err != nil {
+		return nil, vterrors.Wrapf(vterrors.FromGRPC(err), "Remote: %s could not map ids", rv.name)
+	}
+	return resp, nil
+}
+
+func (rv *Remote) callVerify(ctx context.Context, req *vindexdatapb.VerifyRequest) (*vindexdatapb.VerifyResponse, error) {
+	if rv.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rv.timeout)
+		defer cancel()
+	}
+	conn, err := acquireRemoteConn(rv.connKey)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseRemoteConn(rv.connKey)
+	resp, err := vindexservicepb.NewVindexClient(conn).Verify(ctx, req)
+	if err != nil {
+		return nil, vterrors.Wrapf(vterrors.FromGRPC(err), "Remote: %s could not verify ids", rv.name)
+	}
+	return resp, nil
+}
+
+// remoteCacheKey returns the key of an id in the cache, which is made of its
+// type and its raw bytes, so that ids of different types never share a key.
+func remoteCacheKey(id sqltypes.Value) string {
+	return strconv.Itoa(int(id.Type())) + ":" + string(id.Raw())
+}
+
+func remoteContains(ksids [][]byte, ksid []byte) bool {
+	for _, k := range ksids {
+		if bytes.Equal(k, ksid) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	Register("remote", newRemote)
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	vindexdatapb "vitess.io/vitess/go/vt/proto/vindexdata"
+	vindexservicepb "vitess.io/vitess/go/vt/proto/vindexservice"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// fakeVindexServer maps tenant ids to keyspace ids, and records the requests.
+type fakeVindexServer struct {
+	vindexservicepb.UnimplementedVindexServer
+
+	mu       sync.Mutex
+	tenants  map[string][][]byte
+	requests [][]string
+	err      error
+}
+
+func (s *fakeVindexServer) Map(ctx context.Context, req *vindexdatapb.MapRequest) (*vindexdatapb.MapResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	resp := &vindexdatapb.MapResponse{}
+	var ids []string
+	for _, id := range req.Ids {
+		ids = append(ids, string(id.Value))
+		resp.Destinations = append(resp.Destinations, &vindexdatapb.Destination{KeyspaceIds: s.tenants[string(id.Value)]})
+	}
+	s.requests = append(s.requests, ids)
+	return resp, nil
+}
+
+func (s *fakeVindexServer) Verify(ctx context.Context, req *vindexdatapb.VerifyRequest) (*vindexdatapb.VerifyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &vindexdatapb.VerifyResponse{}
+	var ids []string
+	for i, id := range req.Ids {
+		ids = append(ids, string(id.Value))
+		resp.Matches = append(resp.Matches, remoteContains(s.tenants[string(id.Value)], req.KeyspaceIds[i]))
+	}
+	s.requests = append(s.requests, ids)
+	return resp, nil
+}
+
+func (s *fakeVindexServer) takeRequests() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := s.requests
+	s.requests = nil
+	return requests
+}
+
+// startFakeVindexServer starts an in-process gRPC server, and returns its address.
+func startFakeVindexServer(t *testing.T, s *fakeVindexServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	vindexservicepb.RegisterVindexServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func remoteCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectCost int,
+	expectErr error,
+	expectIsUnique bool,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "remote",
+		vindexName:   "remote",
+		vindexParams: vindexParams,
+
+		expectCost:          expectCost,
+		expectErr:           expectErr,
+		expectIsUnique:      expectIsUnique,
+		expectNeedsVCursor:  false,
+		expectString:        "remote",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestRemoteCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		remoteCreateVindexTestCase(
+			"address required",
+			nil,
+			0,
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: Could not find `address` param in vschema"),
+			false,
+			nil,
+		),
+		remoteCreateVindexTestCase(
+			"address ok",
+			map[string]string{
+				"address": "localhost:15991",
+			},
+			10,
+			nil,
+			true,
+			nil,
+		),
+		remoteCreateVindexTestCase(
+			"non unique",
+			map[string]string{
+				"address":    "localhost:15991",
+				"unique":     "false",
+				"batch_size": "10",
+				"timeout":    "100ms",
+				"cache_size": "0",
+				"cache_ttl":  "10s",
+			},
+			20,
+			nil,
+			false,
+			nil,
+		),
+		remoteCreateVindexTestCase(
+			"invalid batch_size",
+			map[string]string{
+				"address":    "localhost:15991",
+				"batch_size": "0",
+			},
+			0,
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: batch_size must be greater than 0"),
+			false,
+			nil,
+		),
+		remoteCreateVindexTestCase(
+			"invalid cache_ttl",
+			map[string]string{
+				"address":   "localhost:15991",
+				"cache_ttl": "forever",
+			},
+			0,
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Remote: cache_ttl must be a non-negative duration: 'forever'"),
+			false,
+			nil,
+		),
+		remoteCreateVindexTestCase(
+			"unknown params",
+			map[string]string{
+				"address": "localhost:15991",
+				"hello":   "world",
+			},
+			10,
+			nil,
+			true,
+			[]string{"hello"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func createRemoteVindex(t *testing.T, params map[string]string) *Remote {
+	t.Helper()
+	vindex, err := CreateVindex("remote", "remote", params)
+	require.NoError(t, err)
+	return vindex.(*Remote)
+}
+
+func TestRemoteMap(t *testing.T) {
+	server := &fakeVindexServer{tenants: map[string][][]byte{
+		"t1": {[]byte("\x10")},
+		"t2": {[]byte("\x80")},
+		"t3": {[]byte("\xc0")},
+	}}
+	addr := startFakeVindexServer(t, server)
+	rv := createRemoteVindex(t, map[string]string{"address": addr, "batch_size": "2"})
+
+	ids := []sqltypes.Value{
+		sqltypes.NewVarChar("t1"),
+		sqltypes.NewVarChar("t2"),
+		sqltypes.NewVarChar("t1"),
+		sqltypes.NewVarChar("unknown"),
+		sqltypes.NewVarChar("t3"),
+	}
+	want := []key.ShardDestination{
+		key.DestinationKeyspaceID("\x10"),
+		key.DestinationKeyspaceID("\x80"),
+		key.DestinationKeyspaceID("\x10"),
+		key.DestinationNone{},
+		key.DestinationKeyspaceID("\xc0"),
+	}
+	got, err := rv.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	// Each id is only sent once, in batches of 2 ids.
+	assert.Equal(t, [][]string{{"t1", "t2"}, {"unknown", "t3"}}, server.takeRequests())
+
+	// The second time, the ids are mapped from the cache, except for the id that
+	// was not mapped to any keyspace id, which may have been mapped since.
+	server.mu.Lock()
+	server.tenants["unknown"] = [][]byte{[]byte("\x40")}
+	server.mu.Unlock()
+	want[3] = key.DestinationKeyspaceID("\x40")
+	got, err = rv.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, [][]string{{"unknown"}}, server.takeRequests())
+
+	// Verify is also answered from the cache if every id is in it.
+	matches, err := rv.Verify(context.Background(), nil, ids[:2], [][]byte{[]byte("\x10"), []byte("\x10")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, matches)
+	assert.Empty(t, server.takeRequests())
+}
+
+func TestRemoteMapNonUnique(t *testing.T) {
+	server := &fakeVindexServer{tenants: map[string][][]byte{
+		"t1": {[]byte("\x10"), []byte("\x80")},
+	}}
+	addr := startFakeVindexServer(t, server)
+	rv := createRemoteVindex(t, map[string]string{"address": addr, "unique": "false", "cache_size": "0"})
+
+	ids := []sqltypes.Value{sqltypes.NewVarChar("t1"), sqltypes.NewVarChar("t2")}
+	got, err := rv.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationKeyspaceIDs([][]byte{[]byte("\x10"), []byte("\x80")}),
+		key.DestinationNone{},
+	}, got)
+
+	// Without a cache, every call goes to the service.
+	_, err = rv.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"t1", "t2"}, {"t1", "t2"}}, server.takeRequests())
+
+	// A unique vindex can't map an id to more than one keyspace id.
+	rv = createRemoteVindex(t, map[string]string{"address": addr})
+	_, err = rv.Map(context.Background(), nil, ids)
+	require.EqualError(t, err, "Remote: remote is unique but returned 2 keyspace ids for VARCHAR(\"t1\")")
+}
+
+func TestRemoteVerify(t *testing.T) {
+	server := &fakeVindexServer{tenants: map[string][][]byte{
+		"t1": {[]byte("\x10")},
+		"t2": {[]byte("\x80")},
+	}}
+	addr := startFakeVindexServer(t, server)
+	rv := createRemoteVindex(t, map[string]string{"address": addr, "batch_size": "2", "cache_size": "0"})
+
+	got, err := rv.Verify(context.Background(), nil,
+		[]sqltypes.Value{sqltypes.NewVarChar("t1"), sqltypes.NewVarChar("t2"), sqltypes.NewVarChar("t3")},
+		[][]byte{[]byte("\x10"), []byte("\x10"), []byte("\x10")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, got)
+	assert.Equal(t, [][]string{{"t1", "t2"}, {"t3"}}, server.takeRequests())
+}
+
+func TestRemoteError(t *testing.T) {
+	server := &fakeVindexServer{err: status.Error(codes.ResourceExhausted, "too many requests")}
+	addr := startFakeVindexServer(t, server)
+	rv := createRemoteVindex(t, map[string]string{"address": addr})
+
+	_, err := rv.Map(context.Background(), nil, []sqltypes.Value{sqltypes.NewVarChar("t1")})
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "Remote: remote could not map ids")
+}
+
+func TestRemoteCacheKey(t *testing.T) {
+	// The ids of different types don't share a key, even if they have the same
+	// raw bytes.
+	keys := make(map[string]bool)
+	for _, id := range []sqltypes.Value{
+		sqltypes.NewInt64(1),
+		sqltypes.NewUint64(1),
+		sqltypes.NewVarChar("1"),
+		sqltypes.NewVarBinary("1"),
+		sqltypes.NewDecimal("1"),
+	} {
+		keys[remoteCacheKey(id)] = true
+	}
+	assert.Len(t, keys, 5)
+	assert.Equal(t, remoteCacheKey(sqltypes.NewVarChar("t1")), remoteCacheKey(sqltypes.NewVarChar("t1")))
+}
+
+func TestRemoteConnIdle(t *testing.T) {
+	defer func(timeout time.Duration) { remoteConnIdleTimeout = timeout }(remoteConnIdleTimeout)
+	remoteConnIdleTimeout = 50 * time.Millisecond
+
+	server := &fakeVindexServer{tenants: map[string][][]byte{"t1": {[]byte("\x10")}}}
+	addr := startFakeVindexServer(t, server)
+	connKey := remoteConnKey{address: addr}
+	conn := func() *grpc.ClientConn {
+		remoteConnsMu.Lock()
+		defer remoteConnsMu.Unlock()
+		if c, ok := remoteConns[connKey]; ok {
+			return c.conn
+		}
+		return nil
+	}
+
+	// The vindexes that use the same address share the connection.
+	rv1 := createRemoteVindex(t, map[string]string{"address": addr, "cache_size": "0"})
+	rv2 := createRemoteVindex(t, map[string]string{"address": addr, "cache_size": "0"})
+	shared := conn()
+	require.NotNil(t, shared)
+	ids := []sqltypes.Value{sqltypes.NewVarChar("t1")}
+	_, err := rv1.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	_, err = rv2.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	assert.Equal(t, shared, conn())
+
+	// The connection is closed once it is idle, even though the vindexes that
+	// use it are still referenced, e.g. by a vschema that was replaced.
+	require.Eventually(t, func() bool {
+		return conn() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, connectivity.Shutdown, shared.GetState())
+
+	// It is dialed again when it is needed.
+	got, err := rv1.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{key.DestinationKeyspaceID("\x10")}, got)
+	assert.NotNil(t, conn())
+}
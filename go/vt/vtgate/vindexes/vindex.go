@@ -132,7 +132,10 @@ type (
 
 	// A Sequential vindex is an optional interface one that maps to a keyspace range
 	// instead of a single keyspace id. It's being used to reduce the fan out for
-	// 'BETWEEN' expressions.
+	// 'BETWEEN' expressions and range comparisons. A NULL startId or endId means
+	// that the range is unbounded on that side, and endId is part of the range.
+	// A bound that cannot be mapped must not fail the query, the vindex targets
+	// all the shards instead.
 	Sequential interface {
 		RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error)
 	}
@@ -179,6 +182,16 @@ type (
 		IsBackfilling() bool
 	}
 
+	// A Covering vindex is a lookup vindex whose lookup table also stores other
+	// columns of the owner table, so that queries that only need those columns
+	// can be answered from the lookup table alone.
+	Covering interface {
+		// CoveringTable returns the lookup table, and the names of its columns
+		// that match the columns of the ColumnVindex, in the same order: the
+		// from columns, followed by the covering columns.
+		CoveringTable() (table string, fromColumns, coveringColumns []string)
+	}
+
 	// WantOwnerInfo defines the interface that a vindex must
 	// satisfy to request info about the owner table. This information can
 	// be used to query the owner's table for the owning row's presence.
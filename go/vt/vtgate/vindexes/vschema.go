@@ -138,6 +138,12 @@ type BaseTable struct {
 	Columns                 []Column               `json:"columns,omitempty"`
 	Pinned                  []byte                 `json:"pinned,omitempty"`
 	ColumnListAuthoritative bool                   `json:"column_list_authoritative,omitempty"`
+	// AllowPrimaryVindexUpdate allows updates to change the primary vindex columns.
+	// Rows that get a new keyspace id are moved to the shard of the new keyspace id.
+	AllowPrimaryVindexUpdate bool `json:"allow_primary_vindex_update,omitempty"`
+	// ResultCacheTTL is how long vtgate caches the results of the queries on the
+	// table. The result cache is not used for the table if it is zero.
+	ResultCacheTTL time.Duration `json:"result_cache_ttl,omitempty"`
 	// ReferencedBy is an inverse mapping of tables in other keyspaces that
 	// reference this table via Source.
 	//
@@ -226,6 +232,12 @@ type Column struct {
 	Nullable  bool  `json:"nullable,omitempty"`
 	// Values contains the list of values for enum and set types.
 	Values []string `json:"values,omitempty"`
+	// Generated marks a generated column, whose value is computed by MySQL
+	// and cannot be written.
+	Generated bool `json:"generated,omitempty"`
+	// OnUpdate is the value the column is set to when the row is updated,
+	// if the column has an ON UPDATE clause.
+	OnUpdate sqlparser.Expr `json:"on_update,omitempty"`
 }
 
 // MarshalJSON returns a JSON representation of Column.
@@ -239,6 +251,8 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 		Scale     int32    `json:"scale,omitempty"`
 		Nullable  bool     `json:"nullable,omitempty"`
 		Values    []string `json:"values,omitempty"`
+		Generated bool     `json:"generated,omitempty"`
+		OnUpdate  string   `json:"on_update,omitempty"`
 	}{
 		Name:      col.Name.String(),
 		Type:      querypb.Type_name[int32(col.Type)],
@@ -247,10 +261,14 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 		Scale:     col.Scale,
 		Nullable:  col.Nullable,
 		Values:    col.Values,
+		Generated: col.Generated,
 	}
 	if col.Default != nil {
 		cj.Default = sqlparser.String(col.Default)
 	}
+	if col.OnUpdate != nil {
+		cj.OnUpdate = sqlparser.String(col.OnUpdate)
+	}
 	return json.Marshal(cj)
 }
 
@@ -686,9 +704,10 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 	}
 	for tname, table := range ks.Tables {
 		t := &BaseTable{
-			Name:                    sqlparser.NewIdentifierCS(tname),
-			Keyspace:                keyspace,
-			ColumnListAuthoritative: table.ColumnListAuthoritative,
+			Name:                     sqlparser.NewIdentifierCS(tname),
+			Keyspace:                 keyspace,
+			ColumnListAuthoritative:  table.ColumnListAuthoritative,
+			AllowPrimaryVindexUpdate: table.AllowPrimaryVindexUpdate,
 		}
 		switch table.Type {
 		case "":
@@ -735,6 +754,18 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			}
 			t.Pinned = decoded
 		}
+		if table.ResultCacheTtl != "" {
+			ttl, err := time.ParseDuration(table.ResultCacheTtl)
+			if err != nil || ttl <= 0 {
+				return vterrors.Errorf(
+					vtrpcpb.Code_INVALID_ARGUMENT,
+					"invalid result_cache_ttl %q for table: %s",
+					table.ResultCacheTtl,
+					tname,
+				)
+			}
+			t.ResultCacheTTL = ttl
+		}
 
 		// If keyspace is sharded, then any table that's not a reference or pinned must have vindexes.
 		if keyspace.Sharded && t.Type != TypeReference && table.Pinned == "" && len(table.ColumnVindexes) == 0 {
@@ -871,7 +902,8 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			if !isMultiColumn {
 				continue
 			}
-			if i != 0 {
+			_, isCovering := vindex.(Covering)
+			if i != 0 && !isCovering {
 				return vterrors.Errorf(
 					vtrpcpb.Code_UNIMPLEMENTED,
 					"multi-column vindex %s should be a primary vindex for table %s",
@@ -884,6 +916,13 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 				// Do not create subset column vindex.
 				continue
 			}
+			// The from columns of a covering lookup vindex identify a row of the
+			// lookup table on their own, without the covering columns.
+			uniqueColumns := len(columns)
+			if covering, ok := vindex.(Covering); ok && vindex.IsUnique() {
+				_, fromColumns, _ := covering.CoveringTable()
+				uniqueColumns = len(fromColumns)
+			}
 			cost := vindex.Cost()
 			for i := len(columns) - 1; i > 0; i-- {
 				columnSubset := columns[:i]
@@ -894,6 +933,7 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 					Name:     ind.Name,
 					Owned:    owned,
 					Vindex:   vindex,
+					isUnique: i >= uniqueColumns,
 					cost:     cost,
 					partial:  true,
 					backfill: backfill,
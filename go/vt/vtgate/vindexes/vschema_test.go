@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1532,6 +1533,28 @@ func TestBuildVSchemaNoColumnVindexFail(t *testing.T) {
 	}
 }
 
+func TestBuildVSchemaResultCacheTTL(t *testing.T) {
+	srv := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"unsharded": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {ResultCacheTtl: "30s"},
+					"t2": {},
+				},
+			},
+		},
+	}
+	got := BuildVSchema(&srv, sqlparser.NewTestParser())
+	ks := got.Keyspaces["unsharded"]
+	require.NoError(t, ks.Error)
+	assert.Equal(t, 30*time.Second, ks.Tables["t1"].ResultCacheTTL)
+	assert.Zero(t, ks.Tables["t2"].ResultCacheTTL)
+
+	srv.Keyspaces["unsharded"].Tables["t2"].ResultCacheTtl = "forever"
+	got = BuildVSchema(&srv, sqlparser.NewTestParser())
+	require.EqualError(t, got.Keyspaces["unsharded"].Error, `invalid result_cache_ttl "forever" for table: t2`)
+}
+
 func TestBuildVSchemaDupSeq(t *testing.T) {
 	good := vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -3158,6 +3181,91 @@ func TestMultiColVindexPartialNotAllowed(t *testing.T) {
 	require.EqualValues(t, 1, table.ColumnVindexes[0].Cost())
 }
 
+func TestMultiColVindexCoveringLookup(t *testing.T) {
+	input := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ksa": {
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"hash": {
+						Type: "hash",
+					},
+					"covering_vdx": {
+						Type:  "lookup_covering",
+						Owner: "t1",
+						Params: map[string]string{
+							"table":    "t1_lookup",
+							"from":     "cola,colb",
+							"to":       "keyspace_id",
+							"covering": "colc",
+						},
+					},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						ColumnVindexes: []*vschemapb.ColumnVindex{
+							{
+								Column: "id",
+								Name:   "hash",
+							},
+							{
+								Columns: []string{"cola", "colb", "colc"},
+								Name:    "covering_vdx",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+	table, err := vschema.FindTable("ksa", "t1")
+	require.NoError(t, err)
+	require.Len(t, table.ColumnVindexes, 4)
+	require.Len(t, table.Owned, 1)
+	// the subset of the from columns is unique, the shorter one is not
+	require.Equal(t, []sqlparser.IdentifierCI{sqlparser.NewIdentifierCI("cola"), sqlparser.NewIdentifierCI("colb")}, table.ColumnVindexes[2].Columns)
+	require.True(t, table.ColumnVindexes[2].IsUnique())
+	require.True(t, table.ColumnVindexes[2].IsPartialVindex())
+	require.False(t, table.ColumnVindexes[3].IsUnique())
+	require.True(t, table.ColumnVindexes[3].IsPartialVindex())
+}
+
+func TestMultiColVindexSecondary(t *testing.T) {
+	input := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ksa": {
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"hash": {
+						Type: "hash",
+					},
+					"mc": {
+						Type: "mcfu",
+					},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						ColumnVindexes: []*vschemapb.ColumnVindex{
+							{
+								Column: "id",
+								Name:   "hash",
+							},
+							{
+								Columns: []string{"cola", "colb"},
+								Name:    "mc",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+	// only covering lookup vindexes can be multi-column secondary vindexes
+	require.EqualError(t, vschema.Keyspaces["ksa"].Error, "multi-column vindex mc should be a primary vindex for table t1")
+}
+
 func TestSourceTableHasReferencedBy(t *testing.T) {
 	input := vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
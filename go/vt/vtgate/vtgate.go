@@ -45,6 +45,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sidecardb"
@@ -166,6 +167,11 @@ var (
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	// resultCacheSize is the maximum number of query results held by the result cache.
+	resultCacheSize = 0
+	// resultCacheMaxRows is the maximum number of rows of a cached query result.
+	resultCacheMaxRows = 1000
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -203,6 +209,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	fs.IntVar(&resultCacheSize, "result-cache-size", resultCacheSize, "Maximum number of query results cached for the tables that have a result_cache_ttl in the vschema. The result cache is disabled if 0.")
+	fs.IntVar(&resultCacheMaxRows, "result-cache-max-rows", resultCacheMaxRows, "Maximum number of rows of a query result that is cached by the result cache.")
 
 	viperutil.BindFlags(fs,
 		enableOnlineDDL,
@@ -380,6 +388,9 @@ func Init(
 		WarmingReadsPercent: warmingReadsPercent,
 		QueryLogToFile:      queryLogToFile,
 	}
+	if resultCacheSize > 0 {
+		eConfig.ResultCache = NewResultCache(resultCacheSize, resultCacheMaxRows, vstreamResultCacheStreamer(vsm))
+	}
 
 	executor := NewExecutor(ctx, env, serv, cell, resolver, eConfig, warnShardedOnly, plans, si, pv, dynamicConfig)
 
@@ -657,6 +668,25 @@ func (vtg *VTGate) CloseSession(ctx context.Context, session *vtgatepb.Session)
 	return vtg.executor.CloseSession(ctx, econtext.NewSafeSession(session))
 }
 
+// MessageAck acks the messages of a message table, or changes their visibility
+// timeout if visibilityTimeout is set.
+func (vtg *VTGate) MessageAck(ctx context.Context, keyspace string, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
+	statsKey := []string{"MessageAck", keyspace, topoproto.TabletTypeLString(topodatapb.TabletType_PRIMARY)}
+	defer vtg.timings.Record(statsKey, time.Now())
+
+	count, err := vtg.executor.MessageAck(ctx, keyspace, name, ids, visibilityTimeout)
+	if err == nil {
+		vtg.rowsAffected.Add(statsKey, count)
+		return count, nil
+	}
+
+	request := map[string]any{
+		"Keyspace": keyspace,
+		"Name":     name,
+	}
+	return 0, recordAndAnnotateError(err, statsKey, request, vtg.logExecute, vtg.executor.vm.parser)
+}
+
 // Prepare supports non-streaming prepare statement query with multi shards
 func (vtg *VTGate) Prepare(ctx context.Context, session *vtgatepb.Session, sql string) (newSession *vtgatepb.Session, fld []*querypb.Field, paramsCount uint16, err error) {
 	// In this context, we don't care if we can't fully parse destination
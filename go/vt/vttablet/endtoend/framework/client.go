@@ -306,7 +306,7 @@ func (client *QueryClient) MessageAck(name string, ids []string) (int64, error)
 			Value: []byte(id),
 		})
 	}
-	return client.server.MessageAck(client.ctx, client.target, name, bids)
+	return client.server.MessageAck(client.ctx, client.target, name, bids, nil)
 }
 
 // ReserveExecute performs a ReserveExecute.
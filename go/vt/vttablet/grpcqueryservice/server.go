@@ -320,7 +320,7 @@ func (q *query) MessageAck(ctx context.Context, request *querypb.MessageAckReque
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
-	count, err := q.server.MessageAck(ctx, request.Target, request.Name, request.Ids)
+	count, err := q.server.MessageAck(ctx, request.Target, request.Name, request.Ids, request.VisibilityTimeout)
 	if err != nil {
 		return nil, vterrors.ToGRPC(err)
 	}
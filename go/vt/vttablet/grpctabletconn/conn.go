@@ -36,6 +36,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	queryservicepb "vitess.io/vitess/go/vt/proto/queryservice"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 const protocolName = "grpc"
@@ -619,7 +620,7 @@ func (conn *gRPCQueryClient) MessageStream(ctx context.Context, target *querypb.
 }
 
 // MessageAck acks messages.
-func (conn *gRPCQueryClient) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (int64, error) {
+func (conn *gRPCQueryClient) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (int64, error) {
 	conn.mu.RLock()
 	defer conn.mu.RUnlock()
 	if conn.cc == nil {
@@ -631,6 +632,7 @@ func (conn *gRPCQueryClient) MessageAck(ctx context.Context, target *querypb.Tar
 		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
 		Name:              name,
 		Ids:               ids,
+		VisibilityTimeout: visibilityTimeout,
 	}
 	reply, err := conn.c.MessageAck(ctx, req)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 // QueryService is the interface implemented by the tablet's query service.
@@ -93,7 +94,9 @@ type QueryService interface {
 
 	// Messaging methods.
 	MessageStream(ctx context.Context, target *querypb.Target, name string, callback func(*sqltypes.Result) error) error
-	MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error)
+	// MessageAck acks the messages. If visibilityTimeout is set, the messages
+	// are postponed instead, and are sent again once it expires.
+	MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (count int64, err error)
 
 	// VStream streams VReplication events based on the specified filter.
 	VStream(ctx context.Context, request *binlogdatapb.VStreamRequest, send func([]*binlogdatapb.VEvent) error) error
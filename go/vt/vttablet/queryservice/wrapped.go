@@ -25,6 +25,7 @@ import (
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 var _ QueryService = &wrappedService{}
@@ -242,10 +243,10 @@ func (ws *wrappedService) MessageStream(ctx context.Context, target *querypb.Tar
 	})
 }
 
-func (ws *wrappedService) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+func (ws *wrappedService) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (count int64, err error) {
 	err = ws.wrapper(ctx, target, ws.impl, "MessageAck", false, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
-		count, innerErr = conn.MessageAck(ctx, target, name, ids)
+		count, innerErr = conn.MessageAck(ctx, target, name, ids, visibilityTimeout)
 		return canRetry(ctx, innerErr), innerErr
 	})
 	return count, err
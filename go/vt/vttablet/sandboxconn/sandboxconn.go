@@ -32,6 +32,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
@@ -106,7 +107,8 @@ type SandboxConn struct {
 	// UnresolvedTransactionsResult is used for returning results for UnresolvedTransactions.
 	UnresolvedTransactionsResult []*querypb.TransactionMetadata
 
-	MessageIDs []*querypb.Value
+	MessageIDs               []*querypb.Value
+	MessageVisibilityTimeout *vttimepb.Duration
 
 	// vstream expectations.
 	StartPos      string
@@ -533,8 +535,9 @@ func (sbc *SandboxConn) MessageStream(ctx context.Context, target *querypb.Targe
 }
 
 // MessageAck is part of the QueryService interface.
-func (sbc *SandboxConn) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+func (sbc *SandboxConn) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (count int64, err error) {
 	sbc.MessageIDs = ids
+	sbc.MessageVisibilityTimeout = visibilityTimeout
 	return int64(len(ids)), nil
 }
 
@@ -34,6 +34,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 // FakeQueryService implements a programmable fake for the query service
@@ -623,6 +624,9 @@ var (
 		Type:  sqltypes.VarChar,
 		Value: []byte("1"),
 	}}
+
+	// MessageVisibilityTimeout is a test message visibility timeout.
+	MessageVisibilityTimeout = &vttimepb.Duration{Seconds: 30}
 )
 
 // MessageStream is part of the queryservice.QueryService interface
@@ -643,7 +647,7 @@ func (f *FakeQueryService) MessageStream(ctx context.Context, target *querypb.Ta
 }
 
 // MessageAck is part of the queryservice.QueryService interface
-func (f *FakeQueryService) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+func (f *FakeQueryService) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (count int64, err error) {
 	if f.HasError {
 		return 0, f.TabletError
 	}
@@ -656,6 +660,9 @@ func (f *FakeQueryService) MessageAck(ctx context.Context, target *querypb.Targe
 	if !sqltypes.Proto3ValuesEqual(ids, MessageIDs) {
 		f.t.Errorf("ids: %v, want %v", ids, MessageIDs)
 	}
+	if !proto.Equal(visibilityTimeout, MessageVisibilityTimeout) {
+		f.t.Errorf("visibilityTimeout: %v, want %v", visibilityTimeout, MessageVisibilityTimeout)
+	}
 	return 1, nil
 }
 
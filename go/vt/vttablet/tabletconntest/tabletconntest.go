@@ -878,7 +878,7 @@ func testMessageAck(t *testing.T, conn queryservice.QueryService, f *FakeQuerySe
 	t.Log("testMessageAck")
 	ctx := context.Background()
 	ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
-	count, err := conn.MessageAck(ctx, TestTarget, MessageName, MessageIDs)
+	count, err := conn.MessageAck(ctx, TestTarget, MessageName, MessageIDs, MessageVisibilityTimeout)
 	if err != nil {
 		t.Fatalf("MessageAck failed: %v", err)
 	}
@@ -892,7 +892,7 @@ func testMessageAckError(t *testing.T, conn queryservice.QueryService, f *FakeQu
 	f.HasError = true
 	testErrorHelper(t, f, "MessageAck", func(ctx context.Context) error {
 		ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
-		_, err := conn.MessageAck(ctx, TestTarget, MessageName, MessageIDs)
+		_, err := conn.MessageAck(ctx, TestTarget, MessageName, MessageIDs, MessageVisibilityTimeout)
 		return err
 	})
 	f.HasError = false
@@ -901,7 +901,7 @@ func testMessageAckError(t *testing.T, conn queryservice.QueryService, f *FakeQu
 func testMessageAckPanics(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
 	t.Log("testMessageAckPanics")
 	testPanicHelper(t, f, "MessageAck", func(ctx context.Context) error {
-		_, err := conn.MessageAck(ctx, TestTarget, MessageName, MessageIDs)
+		_, err := conn.MessageAck(ctx, TestTarget, MessageName, MessageIDs, MessageVisibilityTimeout)
 		return err
 	})
 }
@@ -194,6 +194,54 @@ func (ct *controller) start(ctx context.Context, dbClient binlogplayer.DBClient)
 	}
 	ct.workflowFilter = fmt.Sprintf("where workflow = %s and db_name = %s", encodeString(ct.workflow),
 		encodeString(ct.vde.dbName))
+	if ct.isLookupVindexDiff() {
+		ct.initLookupVindexSources()
+	} else if err := ct.initWorkflowSources(ctx, dbClient); err != nil {
+		return err
+	}
+
+	if err := ct.validate(); err != nil {
+		return err
+	}
+
+	wd, err := newWorkflowDiffer(ct, ct.options, ct.vde.collationEnv)
+	if err != nil {
+		return err
+	}
+	if err := ct.updateState(dbClient, StartedState, nil); err != nil {
+		return err
+	}
+	if err := wd.diff(ctx); err != nil {
+		log.Errorf("Encountered an error performing workflow diff for vdiff %s: %v", ct.uuid, err)
+		return err
+	}
+
+	return nil
+}
+
+// isLookupVindexDiff returns whether the vdiff compares the lookup table of a
+// Lookup Vindex to its owner table, using the streams that were generated from
+// the Vindex definition instead of the ones of a VReplication workflow.
+func (ct *controller) isLookupVindexDiff() bool {
+	return len(ct.options.GetCoreOptions().GetLookupVindexSources()) > 0
+}
+
+// initLookupVindexSources sets up the sources of a Lookup Vindex diff.
+func (ct *controller) initLookupVindexSources() {
+	bls := ct.options.CoreOptions.LookupVindexSources
+	log.Infof("Using %d lookup vindex streams for %s", len(bls), ct.workflow)
+	for _, source := range bls {
+		ms := newMigrationSource()
+		ms.shard = source.Shard
+		ct.sources[ms.shard] = ms
+	}
+	ct.sourceKeyspace = bls[0].Keyspace
+	ct.filter = bls[0].Filter
+	ct.workflowType = binlogdatapb.VReplicationWorkflowType_CreateLookupIndex
+}
+
+// initWorkflowSources sets up the sources from the streams of the workflow.
+func (ct *controller) initWorkflowSources(ctx context.Context, dbClient binlogplayer.DBClient) error {
 	query := sqlparser.BuildParsedQuery(sqlGetVReplicationEntry, ct.workflowFilter)
 	qr, err := dbClient.ExecuteFetch(query.Query, -1)
 	if err != nil {
@@ -239,23 +287,6 @@ func (ct *controller) start(ctx context.Context, dbClient binlogplayer.DBClient)
 		}
 		ct.workflowType = binlogdatapb.VReplicationWorkflowType(workflowType)
 	}
-
-	if err := ct.validate(); err != nil {
-		return err
-	}
-
-	wd, err := newWorkflowDiffer(ct, ct.options, ct.vde.collationEnv)
-	if err != nil {
-		return err
-	}
-	if err := ct.updateState(dbClient, StartedState, nil); err != nil {
-		return err
-	}
-	if err := wd.diff(ctx); err != nil {
-		log.Errorf("Encountered an error performing workflow diff for vdiff %s: %v", ct.uuid, err)
-		return err
-	}
-
 	return nil
 }
 
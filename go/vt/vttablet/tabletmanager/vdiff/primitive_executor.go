@@ -92,8 +92,8 @@ func (pe *primitiveExecutor) next() ([]sqltypes.Value, error) {
 }
 
 // drain fastforward's a shard to process (and ignore) everything from its results stream and return a count of the
-// discarded rows.
-func (pe *primitiveExecutor) drain(ctx context.Context) (int64, error) {
+// discarded rows. Each discarded row is passed to onRow, if it's not nil.
+func (pe *primitiveExecutor) drain(ctx context.Context, onRow func(row []sqltypes.Value)) (int64, error) {
 	var count int64
 	for {
 		row, err := pe.next()
@@ -103,6 +103,9 @@ func (pe *primitiveExecutor) drain(ctx context.Context) (int64, error) {
 		if row == nil {
 			return count, nil
 		}
+		if onRow != nil {
+			onRow(row)
+		}
 		count++
 	}
 }
@@ -0,0 +1,431 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// maxRowsToRepair is the maximum number of differing rows that are repaired for
+// a table on a shard by one vdiff. The remaining rows are repaired by running the
+// vdiff again.
+const maxRowsToRepair = 10000
+
+// tableRepairer collects the rows that differ between the owner table and the
+// lookup table of a Lookup Vindex while the lookup table is diffed, and then fixes
+// them on the lookup table.
+//
+// Each fix only applies if the lookup row still has the values that were seen
+// during the diff, so a row that was changed by vtgate since then is left as it
+// is. A missing row is only inserted if the owner table still has it, and an
+// extra row is only deleted if the owner table does not have it, see
+// applyChecked.
+type tableRepairer struct {
+	td *tableDiffer
+	// sourceRowExists returns whether the owner table has a row that the given
+	// row of the diff is selected from.
+	sourceRowExists func(ctx context.Context, row []sqltypes.Value) (bool, error)
+
+	extraSource [][]sqltypes.Value
+	extraTarget [][]sqltypes.Value
+	// mismatched holds pairs of source and target rows.
+	mismatched [][2][]sqltypes.Value
+}
+
+func newTableRepairer(td *tableDiffer) *tableRepairer {
+	tr := &tableRepairer{td: td}
+	tr.sourceRowExists = tr.sourcePrimariesHaveRow
+	return tr
+}
+
+// rowRepair is a statement that fixes one row of the target. If sourceRow is set,
+// the statement inserts the missing row for that source row. If orphanRow is set,
+// the statement deletes that extra target row.
+type rowRepair struct {
+	query     string
+	sourceRow []sqltypes.Value
+	orphanRow []sqltypes.Value
+}
+
+func (tr *tableRepairer) full() bool {
+	return len(tr.extraSource)+len(tr.extraTarget)+len(tr.mismatched) >= maxRowsToRepair
+}
+
+// addExtraSourceRow records a row that is missing on the target. It can be called
+// on a nil tableRepairer, in which case it does nothing.
+func (tr *tableRepairer) addExtraSourceRow(row []sqltypes.Value) {
+	if tr == nil || tr.full() {
+		return
+	}
+	tr.extraSource = append(tr.extraSource, row)
+}
+
+// addExtraTargetRow records a row that is missing on the source. It can be called
+// on a nil tableRepairer, in which case it does nothing.
+func (tr *tableRepairer) addExtraTargetRow(row []sqltypes.Value) {
+	if tr == nil || tr.full() {
+		return
+	}
+	tr.extraTarget = append(tr.extraTarget, row)
+}
+
+// addMismatchedRow records a row that has different values on the source and the
+// target. It can be called on a nil tableRepairer, in which case it does nothing.
+func (tr *tableRepairer) addMismatchedRow(source, target []sqltypes.Value) {
+	if tr == nil || tr.full() {
+		return
+	}
+	tr.mismatched = append(tr.mismatched, [2][]sqltypes.Value{source, target})
+}
+
+// repair fixes the differences on the target, and adds the number of rows that
+// were changed to the report. It must be called after the extra rows of the
+// report have been reconciled.
+func (tr *tableRepairer) repair(ctx context.Context, dbClient binlogplayer.DBClient, dr *DiffReport) error {
+	repairs, err := tr.repairs(dr)
+	if err != nil {
+		return err
+	}
+	for _, rr := range repairs {
+		var rowsAffected uint64
+		switch {
+		case rr.sourceRow != nil:
+			rowsAffected, err = tr.applyChecked(ctx, dbClient, rr.query, rr.sourceRow, true)
+		case rr.orphanRow != nil:
+			rowsAffected, err = tr.applyChecked(ctx, dbClient, rr.query, rr.orphanRow, false)
+		default:
+			var qr *sqltypes.Result
+			qr, err = dbClient.ExecuteFetch(rr.query, 0)
+			if qr != nil {
+				rowsAffected = qr.RowsAffected
+			}
+		}
+		if err != nil {
+			return err
+		}
+		dr.RepairedRows += int64(rowsAffected)
+	}
+	if len(repairs) > 0 {
+		log.Infof("Repaired %d rows of table %s for vdiff %s", dr.RepairedRows, dr.TableName, tr.td.wd.ct.uuid)
+	}
+	return nil
+}
+
+// applyChecked inserts a row that is missing on the lookup table or deletes an
+// extra one, and only commits the change if the owner table still has, or still
+// does not have, the given row, as wanted by wantExists. The owner table has been
+// written to since the diff, so the row may have been deleted or inserted in the
+// meantime along with its lookup row.
+//
+// The row is checked after the lookup row is changed: the change locks the lookup
+// row until the transaction ends, so vtgate waits for the commit before it
+// changes the same lookup row. The owner row of a consistent lookup is written
+// after its lookup row is committed though, so an extra lookup row whose owner row
+// was in the process of being inserted during the diff can still be deleted.
+func (tr *tableRepairer) applyChecked(ctx context.Context, dbClient binlogplayer.DBClient, query string, row []sqltypes.Value, wantExists bool) (uint64, error) {
+	if err := dbClient.Begin(); err != nil {
+		return 0, err
+	}
+	qr, err := dbClient.ExecuteFetch(query, 0)
+	if err != nil {
+		dbClient.Rollback()
+		return 0, err
+	}
+	if qr.RowsAffected > 0 {
+		exists, err := tr.sourceRowExists(ctx, row)
+		if err != nil || exists != wantExists {
+			dbClient.Rollback()
+			return 0, err
+		}
+	}
+	if err := dbClient.Commit(); err != nil {
+		return 0, err
+	}
+	return qr.RowsAffected, nil
+}
+
+// sourcePrimariesHaveRow returns whether the primary of one of the source shards
+// has a source row for the given row of the diff. Primaries are used because a
+// replica may lag behind the writes that vtgate made since the diff.
+func (tr *tableRepairer) sourcePrimariesHaveRow(ctx context.Context, row []sqltypes.Value) (bool, error) {
+	query, err := tr.sourceRowQuery(row)
+	if err != nil {
+		return false, err
+	}
+	ct := tr.td.wd.ct
+	ts, err := tr.td.wd.getSourceTopoServer()
+	if err != nil {
+		return false, err
+	}
+	var found atomic.Bool
+	err = tr.td.forEachSource(func(source *migrationSource) error {
+		si, err := ts.GetShard(ctx, ct.sourceKeyspace, source.shard)
+		if err != nil {
+			return err
+		}
+		if !si.HasPrimary() {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary", ct.sourceKeyspace, source.shard)
+		}
+		ti, err := ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return err
+		}
+		qr, err := ct.tmc.ExecuteFetchAsApp(ctx, ti.Tablet, false, &tabletmanagerdatapb.ExecuteFetchAsAppRequest{
+			Query:   []byte(query),
+			MaxRows: 1,
+		})
+		if err != nil {
+			return vterrors.Wrapf(err, "checking the source row on %s", topoproto.TabletAliasString(si.PrimaryAlias))
+		}
+		if len(qr.Rows) > 0 {
+			found.Store(true)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return found.Load(), nil
+}
+
+// sourceSelect returns the select of the lookup table rows on the owner table.
+func (tr *tableRepairer) sourceSelect() (*sqlparser.Select, error) {
+	stmt, err := tr.td.wd.ct.vde.parser.Parse(tr.td.tablePlan.sourceQuery)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, fmt.Errorf("unexpected: %v", sqlparser.String(stmt))
+	}
+	return sel, nil
+}
+
+// hasComputedPKColumns returns whether some of the primary key columns of the
+// target are computed, such as with keyspace_id(), instead of being copied from
+// source columns.
+func (tr *tableRepairer) hasComputedPKColumns() (bool, error) {
+	sel, err := tr.sourceSelect()
+	if err != nil {
+		return false, err
+	}
+	columns := sel.GetColumns()
+	for _, col := range tr.td.tablePlan.compareCols {
+		if !col.isPK {
+			continue
+		}
+		aliased, ok := columns[col.colIndex].(*sqlparser.AliasedExpr)
+		if !ok {
+			return true, nil
+		}
+		if _, ok := aliased.Expr.(*sqlparser.ColName); !ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// sourceRowQuery returns a query that selects a source row for the given row of
+// the diff, using the filter of the diff and the source columns of the primary
+// key. Computed columns of the primary key are skipped, so the query also matches
+// a source row that only has the same values for the other columns, see
+// hasComputedPKColumns.
+func (tr *tableRepairer) sourceRowQuery(row []sqltypes.Value) (string, error) {
+	sel, err := tr.sourceSelect()
+	if err != nil {
+		return "", err
+	}
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("select 1 from %v", sqlparser.TableExprs(sel.From))
+	separator := " where "
+	if where := copyNonKeyRangeExpressions(sel.Where); where != nil && where.Expr != nil {
+		buf.Myprintf("%s(%v)", separator, where.Expr)
+		separator = " and "
+	}
+	columns := sel.GetColumns()
+	for _, col := range tr.td.tablePlan.compareCols {
+		if !col.isPK {
+			continue
+		}
+		var colName *sqlparser.ColName
+		if aliased, ok := columns[col.colIndex].(*sqlparser.AliasedExpr); ok {
+			colName, _ = aliased.Expr.(*sqlparser.ColName)
+		}
+		if colName == nil {
+			continue
+		}
+		buf.Myprintf("%s%v = ", separator, colName)
+		row[col.colIndex].EncodeSQL(buf)
+		separator = " and "
+	}
+	buf.WriteString(" limit 1")
+	return buf.String(), nil
+}
+
+// repairs returns the statements that repair the target.
+func (tr *tableRepairer) repairs(dr *DiffReport) ([]rowRepair, error) {
+	extraSource, extraTarget := tr.extraSource, tr.extraTarget
+	if dr.ExtraRowsSource == 0 {
+		// The extra rows were all reconciled, e.g. for a reference table.
+		extraSource = nil
+	}
+	if dr.ExtraRowsTarget == 0 {
+		extraTarget = nil
+	}
+	extraSource, extraTarget, err := tr.reconcile(extraSource, extraTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extraSource) > 0 {
+		// The source row of a missing row is looked up on the source columns of the
+		// primary key before the row is inserted. A computed column, such as the
+		// keyspace id of a non-unique lookup table, can't be looked up, and a row with
+		// the other values may belong to another one, so these rows are not inserted.
+		computed, err := tr.hasComputedPKColumns()
+		if err != nil {
+			return nil, err
+		}
+		if computed {
+			log.Warningf("Not inserting %d rows missing from table %s for vdiff %s, as its primary key has computed columns",
+				len(extraSource), tr.td.tablePlan.table.Name, tr.td.wd.ct.uuid)
+			extraSource = nil
+		}
+	}
+
+	var repairs []rowRepair
+	for _, row := range extraSource {
+		repairs = append(repairs, rowRepair{query: tr.insertQuery(row), sourceRow: row})
+	}
+	for _, row := range extraTarget {
+		repairs = append(repairs, rowRepair{query: tr.deleteQuery(row), orphanRow: row})
+	}
+	for _, rows := range tr.mismatched {
+		repairs = append(repairs, rowRepair{query: tr.updateQuery(rows[0], rows[1])})
+	}
+	return repairs, nil
+}
+
+// reconcile removes the rows that are extra on both sides, the same way that
+// workflowDiffer.reconcileExtraRows does.
+func (tr *tableRepairer) reconcile(extraSource, extraTarget [][]sqltypes.Value) ([][]sqltypes.Value, [][]sqltypes.Value, error) {
+	if len(extraSource) == 0 || len(extraTarget) == 0 {
+		return extraSource, extraTarget, nil
+	}
+	var sourceRows [][]sqltypes.Value
+	targetRows := append([][]sqltypes.Value(nil), extraTarget...)
+	for _, sourceRow := range extraSource {
+		found := false
+		for j, targetRow := range targetRows {
+			c, err := tr.td.compare(sourceRow, targetRow, tr.td.tablePlan.compareCols, false)
+			if err != nil {
+				return nil, nil, err
+			}
+			if c == 0 {
+				targetRows = append(targetRows[:j], targetRows[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			sourceRows = append(sourceRows, sourceRow)
+		}
+	}
+	return sourceRows, targetRows, nil
+}
+
+func (tr *tableRepairer) tableName() sqlparser.TableName {
+	tp := tr.td.tablePlan
+	return sqlparser.NewTableNameWithQualifier(tp.table.Name, tp.dbName)
+}
+
+func (tr *tableRepairer) insertQuery(row []sqltypes.Value) string {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("insert ignore into %v(", tr.tableName())
+	for i, col := range tr.td.tablePlan.compareCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.Myprintf("%v", sqlparser.NewIdentifierCI(col.colName))
+	}
+	buf.WriteString(") values (")
+	for i, col := range tr.td.tablePlan.compareCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		row[col.colIndex].EncodeSQL(buf)
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+func (tr *tableRepairer) deleteQuery(row []sqltypes.Value) string {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("delete from %v", tr.tableName())
+	tr.writeWhere(buf, row)
+	return buf.String()
+}
+
+func (tr *tableRepairer) updateQuery(source, target []sqltypes.Value) string {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("update %v set ", tr.tableName())
+	first := true
+	for _, col := range tr.td.tablePlan.compareCols {
+		if col.isPK {
+			continue
+		}
+		if !first {
+			buf.WriteString(", ")
+		}
+		first = false
+		buf.Myprintf("%v = ", sqlparser.NewIdentifierCI(col.colName))
+		source[col.colIndex].EncodeSQL(buf)
+	}
+	tr.writeWhere(buf, target)
+	return buf.String()
+}
+
+// writeWhere writes a where clause that matches the given target row only if
+// none of its columns have changed.
+func (tr *tableRepairer) writeWhere(buf *sqlparser.TrackedBuffer, row []sqltypes.Value) {
+	for i, col := range tr.td.tablePlan.compareCols {
+		if i == 0 {
+			buf.WriteString(" where ")
+		} else {
+			buf.WriteString(" and ")
+		}
+		op := " <=> "
+		if col.isPK {
+			op = " = "
+		}
+		buf.Myprintf("%v", sqlparser.NewIdentifierCI(col.colName))
+		buf.WriteString(op)
+		row[col.colIndex].EncodeSQL(buf)
+	}
+}
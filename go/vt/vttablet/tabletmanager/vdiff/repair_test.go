@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func newTestTableRepairer() *tableRepairer {
+	td := &tableDiffer{
+		wd: &workflowDiffer{
+			ct: &controller{
+				uuid: "uuid",
+				vde:  &Engine{parser: sqlparser.NewTestParser()},
+			},
+			collationEnv: collations.MySQL8(),
+		},
+		tablePlan: &tablePlan{
+			sourceQuery: "select c1, c2 from t1 where in_keyrange(c1, 'hash', '-80') and c2 != 'z' order by c1 asc",
+			dbName:      "vt_ks",
+			table:       &tabletmanagerdatapb.TableDefinition{Name: "t1"},
+			compareCols: []compareColInfo{
+				{colIndex: 0, collation: collations.CollationBinaryID, isPK: true, colName: "c1"},
+				{colIndex: 1, collation: collations.CollationBinaryID, colName: "c2"},
+			},
+		},
+	}
+	return newTableRepairer(td)
+}
+
+func TestTableRepairerQueries(t *testing.T) {
+	row := func(c1 int64, c2 sqltypes.Value) []sqltypes.Value {
+		return []sqltypes.Value{sqltypes.NewInt64(c1), c2}
+	}
+
+	tr := newTestTableRepairer()
+	tr.addExtraSourceRow(row(1, sqltypes.NewVarChar("a")))
+	tr.addExtraSourceRow(row(5, sqltypes.NewVarChar("e")))
+	tr.addExtraTargetRow(row(5, sqltypes.NewVarChar("e")))
+	tr.addExtraTargetRow(row(3, sqltypes.NULL))
+	tr.addMismatchedRow(row(2, sqltypes.NewVarChar("b")), row(2, sqltypes.NewVarChar("x")))
+
+	dr := &DiffReport{TableName: "t1", ExtraRowsSource: 1, ExtraRowsTarget: 1, MismatchedRows: 1}
+	repairs, err := tr.repairs(dr)
+	require.NoError(t, err)
+	require.Equal(t, []rowRepair{
+		{query: "insert ignore into vt_ks.t1(c1, c2) values (1, 'a')", sourceRow: row(1, sqltypes.NewVarChar("a"))},
+		{query: "delete from vt_ks.t1 where c1 = 3 and c2 <=> null", orphanRow: row(3, sqltypes.NULL)},
+		{query: "update vt_ks.t1 set c2 = 'b' where c1 = 2 and c2 <=> 'x'"},
+	}, repairs)
+
+	var checked [][]sqltypes.Value
+	tr.sourceRowExists = func(ctx context.Context, row []sqltypes.Value) (bool, error) {
+		checked = append(checked, row)
+		id, err := row[0].ToInt64()
+		return id == 1, err
+	}
+	dbClient := binlogplayer.NewMockDBClient(t)
+	for _, rr := range repairs[:2] {
+		dbClient.ExpectRequest("begin", &sqltypes.Result{}, nil)
+		dbClient.ExpectRequest(rr.query, &sqltypes.Result{RowsAffected: 1}, nil)
+		dbClient.ExpectRequest("commit", &sqltypes.Result{}, nil)
+	}
+	dbClient.ExpectRequest(repairs[2].query, &sqltypes.Result{RowsAffected: 1}, nil)
+	require.NoError(t, tr.repair(context.Background(), dbClient, dr))
+	dbClient.Wait()
+	require.EqualValues(t, 3, dr.RepairedRows)
+	require.Equal(t, [][]sqltypes.Value{row(1, sqltypes.NewVarChar("a")), row(3, sqltypes.NULL)}, checked)
+
+	// The extra rows that were reconciled by the diff are not repaired.
+	dr = &DiffReport{TableName: "t1", MismatchedRows: 1}
+	repairs, err = tr.repairs(dr)
+	require.NoError(t, err)
+	require.Equal(t, []rowRepair{
+		{query: "update vt_ks.t1 set c2 = 'b' where c1 = 2 and c2 <=> 'x'"},
+	}, repairs)
+}
+
+func TestTableRepairerSourceRowDeleted(t *testing.T) {
+	tr := newTestTableRepairer()
+	tr.addExtraSourceRow([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")})
+	tr.addExtraSourceRow([]sqltypes.Value{sqltypes.NewInt64(2), sqltypes.NewVarChar("b")})
+
+	// The source row of the first missing row was deleted after the diff.
+	tr.sourceRowExists = func(ctx context.Context, row []sqltypes.Value) (bool, error) {
+		id, err := row[0].ToInt64()
+		return id != 1, err
+	}
+	dbClient := binlogplayer.NewMockDBClient(t)
+	dbClient.ExpectRequest("begin", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("insert ignore into vt_ks.t1(c1, c2) values (1, 'a')", &sqltypes.Result{RowsAffected: 1}, nil)
+	dbClient.ExpectRequest("rollback", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("begin", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("insert ignore into vt_ks.t1(c1, c2) values (2, 'b')", &sqltypes.Result{RowsAffected: 1}, nil)
+	dbClient.ExpectRequest("commit", &sqltypes.Result{}, nil)
+
+	dr := &DiffReport{TableName: "t1", ExtraRowsSource: 2}
+	require.NoError(t, tr.repair(context.Background(), dbClient, dr))
+	dbClient.Wait()
+	require.EqualValues(t, 1, dr.RepairedRows)
+}
+
+func TestTableRepairerOwnerRowInserted(t *testing.T) {
+	tr := newTestTableRepairer()
+	tr.addExtraTargetRow([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")})
+	tr.addExtraTargetRow([]sqltypes.Value{sqltypes.NewInt64(2), sqltypes.NewVarChar("b")})
+
+	// The owner row of the first extra row was inserted after the diff.
+	tr.sourceRowExists = func(ctx context.Context, row []sqltypes.Value) (bool, error) {
+		id, err := row[0].ToInt64()
+		return id == 1, err
+	}
+	dbClient := binlogplayer.NewMockDBClient(t)
+	dbClient.ExpectRequest("begin", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("delete from vt_ks.t1 where c1 = 1 and c2 <=> 'a'", &sqltypes.Result{RowsAffected: 1}, nil)
+	dbClient.ExpectRequest("rollback", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("begin", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("delete from vt_ks.t1 where c1 = 2 and c2 <=> 'b'", &sqltypes.Result{RowsAffected: 1}, nil)
+	dbClient.ExpectRequest("commit", &sqltypes.Result{}, nil)
+
+	dr := &DiffReport{TableName: "t1", ExtraRowsTarget: 2}
+	require.NoError(t, tr.repair(context.Background(), dbClient, dr))
+	dbClient.Wait()
+	require.EqualValues(t, 1, dr.RepairedRows)
+}
+
+func TestTableRepairerSourceRowQuery(t *testing.T) {
+	tr := newTestTableRepairer()
+	query, err := tr.sourceRowQuery([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")})
+	require.NoError(t, err)
+	require.Equal(t, "select 1 from t1 where (c2 != 'z') and c1 = 1 limit 1", query)
+}
+
+func TestTableRepairerComputedPK(t *testing.T) {
+	tr := newTestTableRepairer()
+	tr.td.tablePlan.sourceQuery = "select c2, keyspace_id() as c1 from t1 order by c2 asc"
+	tr.td.tablePlan.compareCols = []compareColInfo{
+		{colIndex: 0, collation: collations.CollationBinaryID, isPK: true, colName: "c2"},
+		{colIndex: 1, collation: collations.CollationBinaryID, isPK: true, colName: "c1"},
+	}
+	tr.addExtraSourceRow([]sqltypes.Value{sqltypes.NewVarChar("a"), sqltypes.NewVarBinary("\x16k@\xb4J\xbaK\xd6")})
+	tr.addExtraTargetRow([]sqltypes.Value{sqltypes.NewVarChar("b"), sqltypes.NewVarBinary("\x16k@\xb4J\xbaK\xd6")})
+
+	// The keyspace id of the missing row can't be checked on the source, so the
+	// row is not inserted. The extra row is still deleted if the source has no
+	// row with the other values.
+	dr := &DiffReport{TableName: "t1", ExtraRowsSource: 1, ExtraRowsTarget: 1}
+	repairs, err := tr.repairs(dr)
+	require.NoError(t, err)
+	require.Equal(t, []rowRepair{
+		{
+			query:     "delete from vt_ks.t1 where c2 = 'b' and c1 = _binary'\x16k@\xb4J\xbaK\xd6'",
+			orphanRow: tr.extraTarget[0],
+		},
+	}, repairs)
+	query, err := tr.sourceRowQuery(tr.extraTarget[0])
+	require.NoError(t, err)
+	require.Equal(t, "select 1 from t1 where c2 = 'b' limit 1", query)
+}
+
+func TestTableRepairerMaxRows(t *testing.T) {
+	var tr *tableRepairer
+	// Nothing is recorded when the vdiff does not repair the target.
+	tr.addExtraSourceRow([]sqltypes.Value{sqltypes.NewInt64(1)})
+
+	tr = newTestTableRepairer()
+	for i := 0; i < maxRowsToRepair+10; i++ {
+		tr.addExtraTargetRow([]sqltypes.Value{sqltypes.NewInt64(int64(i)), sqltypes.NULL})
+	}
+	require.Len(t, tr.extraTarget, maxRowsToRepair)
+}
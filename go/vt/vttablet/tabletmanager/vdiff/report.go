@@ -39,6 +39,7 @@ type DiffReport struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
+	RepairedRows    int64 `json:"RepairedRows,omitempty"`
 
 	// actual data for a few sample rows
 	ExtraRowsSourceDiffs []*RowDiff      `json:"ExtraRowsSourceSample,omitempty"`
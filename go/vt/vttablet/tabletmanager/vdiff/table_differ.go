@@ -99,6 +99,9 @@ type tableDiffer struct {
 	wgShardStreamers   sync.WaitGroup
 	shardStreamsCtx    context.Context
 	shardStreamsCancel context.CancelFunc
+
+	// repairer collects the rows that differ when the vdiff repairs the target.
+	repairer *tableRepairer
 }
 
 func newTableDiffer(wd *workflowDiffer, table *tabletmanagerdatapb.TableDefinition, sourceQuery string) *tableDiffer {
@@ -135,34 +138,44 @@ func (td *tableDiffer) initialize(ctx context.Context) error {
 		}
 	}()
 
-	if err := td.stopTargetVReplicationStreams(ctx, dbClient); err != nil {
-		return err
-	}
-	defer func() {
-		// We use a new context as we want to reset the state even
-		// when the parent context has timed out or been canceled.
-		log.Infof("Restarting the %q VReplication workflow on target tablets in keyspace %q",
-			td.wd.ct.workflow, targetKeyspace)
-		restartCtx, restartCancel := context.WithTimeout(context.Background(), BackgroundOperationTimeout)
-		defer restartCancel()
-		if err := td.restartTargetVReplicationStreams(restartCtx); err != nil {
-			log.Errorf("error restarting target streams: %v", err)
+	// The lookup table of a Lookup Vindex is maintained by vtgate rather than by a
+	// VReplication workflow, so there are no streams to stop and synchronize, and
+	// the snapshots of the owner and lookup tables are taken independently.
+	lookupVindexDiff := td.wd.ct.isLookupVindexDiff()
+	if !lookupVindexDiff {
+		if err := td.stopTargetVReplicationStreams(ctx, dbClient); err != nil {
+			return err
 		}
-	}()
+		defer func() {
+			// We use a new context as we want to reset the state even
+			// when the parent context has timed out or been canceled.
+			log.Infof("Restarting the %q VReplication workflow on target tablets in keyspace %q",
+				td.wd.ct.workflow, targetKeyspace)
+			restartCtx, restartCancel := context.WithTimeout(context.Background(), BackgroundOperationTimeout)
+			defer restartCancel()
+			if err := td.restartTargetVReplicationStreams(restartCtx); err != nil {
+				log.Errorf("error restarting target streams: %v", err)
+			}
+		}()
+	}
 
 	td.shardStreamsCtx, td.shardStreamsCancel = context.WithCancel(ctx)
 
 	if err := td.selectTablets(ctx); err != nil {
 		return err
 	}
-	if err := td.syncSourceStreams(ctx); err != nil {
-		return err
+	if !lookupVindexDiff {
+		if err := td.syncSourceStreams(ctx); err != nil {
+			return err
+		}
 	}
 	if err := td.startSourceDataStreams(td.shardStreamsCtx); err != nil {
 		return err
 	}
-	if err := td.syncTargetStreams(ctx); err != nil {
-		return err
+	if !lookupVindexDiff {
+		if err := td.syncTargetStreams(ctx); err != nil {
+			return err
+		}
 	}
 	if err := td.startTargetDataStream(td.shardStreamsCtx); err != nil {
 		return err
@@ -537,6 +550,10 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 		globalStats.RowsDiffedCount.Add(dr.ProcessedRows)
 	}()
 
+	if coreOpts.GetRepair() && td.repairer == nil {
+		td.repairer = newTableRepairer(td)
+	}
+
 	rowsToCompare := coreOpts.GetMaxRows()
 	maxExtraRowsToCompare := coreOpts.GetMaxExtraRowsToCompare()
 	maxReportSampleRows := reportOpts.GetMaxSampleRows()
@@ -595,9 +612,10 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 				return nil, vterrors.Wrap(err, "unexpected error generating diff")
 			}
 			dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
+			td.repairer.addExtraTargetRow(targetRow)
 
 			// Drain target, update count.
-			count, err := targetExecutor.drain(ctx)
+			count, err := targetExecutor.drain(ctx, td.repairer.addExtraTargetRow)
 			if err != nil {
 				return nil, err
 			}
@@ -613,7 +631,8 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 				return nil, vterrors.Wrap(err, "unexpected error generating diff")
 			}
 			dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
-			count, err := sourceExecutor.drain(ctx)
+			td.repairer.addExtraSourceRow(sourceRow)
+			count, err := sourceExecutor.drain(ctx, td.repairer.addExtraSourceRow)
 			if err != nil {
 				return nil, err
 			}
@@ -637,6 +656,7 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 				}
 				dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
 			}
+			td.repairer.addExtraSourceRow(sourceRow)
 			dr.ExtraRowsSource++
 			advanceTarget = false
 			continue
@@ -648,6 +668,7 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 				}
 				dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
 			}
+			td.repairer.addExtraTargetRow(targetRow)
 			dr.ExtraRowsTarget++
 			advanceSource = false
 			continue
@@ -672,6 +693,7 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 				}
 				dr.MismatchedRowsDiffs = append(dr.MismatchedRowsDiffs, &DiffMismatch{Source: sourceDiffRow, Target: targetDiffRow})
 			}
+			td.repairer.addMismatchedRow(sourceRow, targetRow)
 			dr.MismatchedRows++
 		default:
 			dr.MatchingRows++
@@ -177,6 +177,11 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		maxDiffRuntime = time.Duration(wd.ct.options.CoreOptions.MaxDiffSeconds) * time.Second
 	}
 
+	if wd.opts.CoreOptions.GetRepair() && !wd.ct.isLookupVindexDiff() {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot repair table %s: repair is only supported for a Lookup Vindex",
+			td.table.Name)
+	}
+
 	log.Infof("Starting differ on table %s for vdiff %s", td.table.Name, wd.ct.uuid)
 	if err := td.updateTableState(ctx, dbClient, StartedState); err != nil {
 		return err
@@ -227,6 +232,13 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		}
 	}
 
+	if td.repairer != nil {
+		if err := td.repairer.repair(ctx, dbClient, diffReport); err != nil {
+			log.Errorf("Encountered an error repairing rows found for table %s for vdiff %s: %v", td.table.Name, wd.ct.uuid, err)
+			return vterrors.Wrap(err, "failed to repair rows")
+		}
+	}
+
 	if diffReport.MismatchedRows > 0 || diffReport.ExtraRowsTarget > 0 || diffReport.ExtraRowsSource > 0 {
 		if err := updateTableMismatch(dbClient, wd.ct.id, td.table.Name); err != nil {
 			return err
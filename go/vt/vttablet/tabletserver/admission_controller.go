@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// admissionThrottler is the part of the tablet throttler that is used by
+// the admission controller.
+type admissionThrottler interface {
+	Check(ctx context.Context, appName string, metricNames base.MetricNames, flags *throttle.CheckFlags) *throttle.CheckResult
+}
+
+// admissionOverload is the outcome of a check of the throttler metrics.
+type admissionOverload struct {
+	checkedAt time.Time
+	// reason describes the metric that exceeds its threshold, and is empty
+	// if the tablet is not overloaded.
+	reason string
+}
+
+// admissionController sheds the queries that are not part of a transaction
+// while the self metrics of the tablet throttler exceed their thresholds.
+// Like the transaction throttler, a query is shed with a probability of its
+// priority divided by sqlparser.MaxPriorityValue, so that the queries with
+// priority 0 are never shed. The shed queries fail with UNAVAILABLE, which
+// vtgate retries on another tablet.
+type admissionController struct {
+	enabled     bool
+	dryRun      bool
+	interval    time.Duration
+	metricNames base.MetricNames
+	flags       *throttle.CheckFlags
+
+	// throttler is set by the tabletserver. If it is nil, every query is
+	// admitted.
+	throttler admissionThrottler
+
+	// overload is the outcome of the last check, which is refreshed by the
+	// first query that finds it older than the interval. The other queries
+	// use the outcome of the previous check meanwhile.
+	overload   atomic.Pointer[admissionOverload]
+	refreshing atomic.Bool
+
+	requests *stats.CountersWithSingleLabel
+	shed     *stats.CountersWithSingleLabel
+}
+
+func newAdmissionController(env tabletenv.Env) *admissionController {
+	config := env.Config().AdmissionControl
+	ac := &admissionController{
+		enabled:  config.Enable,
+		dryRun:   config.DryRun,
+		interval: config.CheckInterval,
+		requests: env.Exporter().NewCountersWithSingleLabel("AdmissionControlRequests", "admission control requests", "workload"),
+		shed:     env.Exporter().NewCountersWithSingleLabel("AdmissionControlShed", "admission control requests shed", "workload"),
+	}
+	for _, metric := range config.Metrics {
+		ac.metricNames = append(ac.metricNames, base.MetricName(metric))
+	}
+	ac.flags = &throttle.CheckFlags{
+		Scope: base.SelfScope,
+		// The heartbeats are only needed to keep the lag metric fresh.
+		SkipRequestHeartbeats: !ac.metricNames.Contains(base.LagMetricName),
+	}
+	return ac
+}
+
+// admit returns an error if the query with the given plan type and priority
+// must be shed. Only the reads and writes are subject to admission control.
+// The callers must not call it for the queries of transactions and reserved
+// connections, including the ones of BeginExecute: failing them would only
+// make the transaction hold its locks longer or roll back the work it already
+// did. The transactions are throttled when they begin by the transaction
+// throttler instead.
+func (ac *admissionController) admit(ctx context.Context, planID planbuilder.PlanType, priority int, workload string) error {
+	if !ac.enabled || ac.throttler == nil {
+		return nil
+	}
+	switch planID {
+	case planbuilder.PlanSelect, planbuilder.PlanSelectStream,
+		planbuilder.PlanInsert, planbuilder.PlanUpdate, planbuilder.PlanUpdateLimit,
+		planbuilder.PlanDelete, planbuilder.PlanDeleteLimit, planbuilder.PlanLoad:
+	default:
+		return nil
+	}
+
+	ac.requests.Add(workload, 1)
+	reason := ac.overloaded(ctx)
+	if reason == "" || rand.IntN(sqlparser.MaxPriorityValue) >= priority {
+		return nil
+	}
+	ac.shed.Add(workload, 1)
+	if ac.dryRun {
+		return nil
+	}
+	return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "query shed by admission control with priority %d: %s", priority, reason)
+}
+
+// overloaded returns why the tablet is overloaded, or an empty string if it
+// is not.
+func (ac *admissionController) overloaded(ctx context.Context) string {
+	overload := ac.overload.Load()
+	if overload != nil && time.Since(overload.checkedAt) < ac.interval {
+		return overload.reason
+	}
+	if !ac.refreshing.CompareAndSwap(false, true) {
+		if overload == nil {
+			return ""
+		}
+		return overload.reason
+	}
+	defer ac.refreshing.Store(false)
+
+	overload = &admissionOverload{checkedAt: time.Now()}
+	result := ac.throttler.Check(ctx, throttlerapp.AdmissionControlName.String(), ac.metricNames, ac.flags)
+	for name, metric := range result.Metrics {
+		if metric.ResponseCode == tabletmanagerdatapb.CheckThrottlerResponseCode_THRESHOLD_EXCEEDED {
+			overload.reason = fmt.Sprintf("%s/%s metric value %v exceeds threshold %v", metric.Scope, name, metric.Value, metric.Threshold)
+			break
+		}
+	}
+	ac.overload.Store(overload)
+	return overload.reason
+}
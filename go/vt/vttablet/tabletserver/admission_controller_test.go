@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+type fakeAdmissionThrottler struct {
+	checks      int
+	exceeded    bool
+	appName     string
+	metricNames base.MetricNames
+	flags       *throttle.CheckFlags
+}
+
+func (f *fakeAdmissionThrottler) Check(ctx context.Context, appName string, metricNames base.MetricNames, flags *throttle.CheckFlags) *throttle.CheckResult {
+	f.checks++
+	f.appName = appName
+	f.metricNames = metricNames
+	f.flags = flags
+	result := &throttle.CheckResult{
+		AppName: appName,
+		Metrics: map[string]*throttle.MetricResult{
+			"lag": {ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK, Scope: "self", Value: 0.5, Threshold: 5},
+		},
+	}
+	if f.exceeded {
+		result.Metrics["threads_running"] = &throttle.MetricResult{
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_THRESHOLD_EXCEEDED,
+			Scope:        "self",
+			Value:        120,
+			Threshold:    100,
+		}
+	}
+	return result
+}
+
+func newTestAdmissionController(t *testing.T, configure func(config *tabletenv.AdmissionControlConfig)) (*admissionController, *fakeAdmissionThrottler) {
+	t.Helper()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.AdmissionControl.Enable = true
+	cfg.AdmissionControl.CheckInterval = time.Hour
+	if configure != nil {
+		configure(&cfg.AdmissionControl)
+	}
+	require.NoError(t, cfg.Verify())
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TabletServerTest")
+	ac := newAdmissionController(env)
+	throttler := &fakeAdmissionThrottler{}
+	ac.throttler = throttler
+	return ac, throttler
+}
+
+func TestAdmissionControllerDisabled(t *testing.T) {
+	ac, throttler := newTestAdmissionController(t, func(config *tabletenv.AdmissionControlConfig) {
+		config.Enable = false
+	})
+	throttler.exceeded = true
+	require.NoError(t, ac.admit(context.Background(), planbuilder.PlanSelect, 100, "app"))
+	assert.Zero(t, throttler.checks)
+}
+
+func TestAdmissionControllerShed(t *testing.T) {
+	ac, throttler := newTestAdmissionController(t, nil)
+	requests, shed := ac.requests.Counts()["app"], ac.shed.Counts()["app"]
+
+	// The tablet is not overloaded.
+	require.NoError(t, ac.admit(context.Background(), planbuilder.PlanSelect, 100, "app"))
+	assert.Equal(t, 1, throttler.checks)
+	assert.Equal(t, throttlerapp.AdmissionControlName.String(), throttler.appName)
+	assert.Equal(t, base.MetricNames{"lag", "threads_running", "history_list_length"}, throttler.metricNames)
+	assert.Equal(t, base.SelfScope, throttler.flags.Scope)
+	assert.False(t, throttler.flags.SkipRequestHeartbeats)
+
+	// The outcome of the check is reused until the interval expires.
+	throttler.exceeded = true
+	require.NoError(t, ac.admit(context.Background(), planbuilder.PlanSelect, 100, "app"))
+	assert.Equal(t, 1, throttler.checks)
+
+	ac.overload.Store(nil)
+	err := ac.admit(context.Background(), planbuilder.PlanUpdate, 100, "app")
+	require.EqualError(t, err, "query shed by admission control with priority 100: self/threads_running metric value 120 exceeds threshold 100")
+	assert.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+	assert.Equal(t, 2, throttler.checks)
+
+	// The queries with priority 0 and the queries that are neither reads nor
+	// writes are never shed.
+	require.NoError(t, ac.admit(context.Background(), planbuilder.PlanSelect, 0, "app"))
+	require.NoError(t, ac.admit(context.Background(), planbuilder.PlanDDL, 100, "app"))
+
+	assert.Equal(t, requests+4, ac.requests.Counts()["app"])
+	assert.Equal(t, shed+1, ac.shed.Counts()["app"])
+}
+
+func TestAdmissionControllerDryRun(t *testing.T) {
+	ac, throttler := newTestAdmissionController(t, func(config *tabletenv.AdmissionControlConfig) {
+		config.DryRun = true
+		config.Metrics = []string{"threads_running"}
+	})
+	throttler.exceeded = true
+	shed := ac.shed.Counts()["app"]
+
+	require.NoError(t, ac.admit(context.Background(), planbuilder.PlanSelect, 100, "app"))
+	assert.Equal(t, shed+1, ac.shed.Counts()["app"])
+	assert.True(t, throttler.flags.SkipRequestHeartbeats)
+}
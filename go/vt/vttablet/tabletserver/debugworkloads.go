@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/safehtml/template"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logz"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/workload"
+)
+
+var (
+	workloadsHeader = []byte(`<thead>
+		<tr>
+			<th>Pool</th>
+			<th>Class</th>
+			<th>Weight</th>
+			<th>Reserved</th>
+			<th>In use</th>
+			<th>Waiting</th>
+		</tr>
+        </thead>
+	`)
+	workloadsTmpl = template.Must(template.New("workloads").Parse(`
+		<tr>
+			<td>{{.Pool}}</td>
+			<td>{{.Name}}</td>
+			<td>{{.Weight}}</td>
+			<td>{{.Reserved}}</td>
+			<td>{{.InUse}}</td>
+			<td>{{.Waiting}}</td>
+		</tr>
+	`))
+)
+
+// workloadsRow is the status of a workload class in a pool.
+type workloadsRow struct {
+	Pool string
+	workload.ClassStatus
+}
+
+// workloadsHandler shows the connections used and awaited by the workload
+// classes in every pool, as HTML or as JSON if format=json.
+func workloadsHandler(schedulers []*workload.Scheduler, w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+
+	var rows []workloadsRow
+	for _, scheduler := range schedulers {
+		for _, class := range scheduler.Classes() {
+			rows = append(rows, workloadsRow{Pool: scheduler.Name(), ClassStatus: class})
+		}
+	}
+
+	if r.FormValue("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rows); err != nil {
+			log.Errorf("workloads: couldn't encode json: %v", err)
+		}
+		return
+	}
+
+	logz.StartHTMLTable(w)
+	defer logz.EndHTMLTable(w)
+	w.Write(workloadsHeader)
+	for _, row := range rows {
+		if err := workloadsTmpl.Execute(w, row); err != nil {
+			log.Errorf("workloads: couldn't execute template: %v", err)
+		}
+	}
+}
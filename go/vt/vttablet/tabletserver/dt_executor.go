@@ -93,12 +93,13 @@ func (dte *DTExecutor) Prepare(transactionID int64, dtid string) error {
 
 	// Fail Prepare if any query rule disallows it.
 	// This could be due to ongoing cutover happening in vreplication workflow
-	// regarding OnlineDDL or MoveTables.
+	// regarding OnlineDDL or MoveTables. The rules that only throttle or delay
+	// the queries don't fail it.
 	for _, query := range queries {
 		qr := dte.qe.queryRuleSources.FilterByPlan(query.Sql, 0, query.Tables...)
 		if qr != nil {
 			act, _, _, _ := qr.GetAction("", "", nil, sqlparser.MarginComments{})
-			if act != rules.QRContinue {
+			if act == rules.QRFail || act == rules.QRFailRetry || act == rules.QRBuffer {
 				dte.te.txPool.RollbackAndRelease(dte.ctx, conn)
 				return vterrors.VT10002("cannot prepare the transaction due to query rule")
 			}
@@ -118,7 +119,7 @@ func (dte *DTExecutor) Prepare(transactionID int64, dtid string) error {
 		qr := dte.qe.queryRuleSources.FilterByPlan(query.Sql, 0, query.Tables...)
 		if qr != nil {
 			act, _, _, _ := qr.GetAction("", "", nil, sqlparser.MarginComments{})
-			if act != rules.QRContinue {
+			if act == rules.QRFail || act == rules.QRFailRetry || act == rules.QRBuffer {
 				dte.te.txPool.RollbackAndRelease(dte.ctx, conn)
 				dte.te.preparedPool.FetchForRollback(dtid)
 				return vterrors.VT10002("cannot prepare the transaction due to query rule")
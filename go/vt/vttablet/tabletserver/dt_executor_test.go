@@ -191,8 +191,12 @@ func TestExecutorPrepareRuleFailure(t *testing.T) {
 
 	alterRule := rules.NewQueryRule("disable update", "disable update", rules.QRBuffer)
 	alterRule.AddTableCond("test_table")
+	// A rule that only throttles the queries does not hide the buffering rule.
+	throttleRule := rules.NewQueryRule("throttle", "throttle", rules.QRThrottle)
+	require.NoError(t, throttleRule.SetThrottle(0, 10))
 
 	r := rules.New()
+	r.Add(throttleRule)
 	r.Add(alterRule)
 	txe.qe.queryRuleSources.RegisterSource("bufferQuery")
 	err := txe.qe.queryRuleSources.SetRules("bufferQuery", r)
@@ -186,6 +186,19 @@ func (mc *cache) Discard(ids []string) {
 	}
 }
 
+// DiscardQueued forgets the specified id if it's still
+// in the send queue. Unlike Discard, it keeps in-flight
+// messages, so that they can't be added back before
+// they're postponed.
+func (mc *cache) DiscardQueued(id string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mr := mc.inQueue[id]; mr != nil {
+		mr.defunct = true
+		delete(mc.inQueue, id)
+	}
+}
+
 // Size returns the max size of cache.
 func (mc *cache) Size() int {
 	mc.mu.Lock()
@@ -165,6 +165,44 @@ func TestMessagerCacheDiscard(t *testing.T) {
 	}
 }
 
+func TestMessagerCacheDiscardQueued(t *testing.T) {
+	mc := newCache(10)
+	for _, id := range []string{"row01", "row02"} {
+		if !mc.Add(&MessageRow{
+			TimeNext: 1,
+			Epoch:    0,
+			Row:      []sqltypes.Value{sqltypes.NewVarBinary(id)},
+		}) {
+			t.Fatal("Add returned false")
+		}
+	}
+	if row := mc.Pop(); row == nil || row.Row[0].ToString() != "row01" {
+		t.Errorf("Pop: want row01, got %v", row)
+	}
+	mc.DiscardQueued("row01")
+	mc.DiscardQueued("row02")
+	if row := mc.Pop(); row != nil {
+		t.Errorf("Pop: want nil, got %v", row.Row[0])
+	}
+
+	// row01 is still in flight, so Add is a no-op for it.
+	for _, id := range []string{"row01", "row02"} {
+		if !mc.Add(&MessageRow{
+			TimeNext: 1,
+			Epoch:    0,
+			Row:      []sqltypes.Value{sqltypes.NewVarBinary(id)},
+		}) {
+			t.Fatal("Add returned false")
+		}
+	}
+	if row := mc.Pop(); row == nil || row.Row[0].ToString() != "row02" {
+		t.Errorf("Pop: want row02, got %v", row)
+	}
+	if row := mc.Pop(); row != nil {
+		t.Errorf("Pop: want nil, got %v", row.Row[0])
+	}
+}
+
 func TestMessagerCacheFull(t *testing.T) {
 	mc := newCache(2)
 	if !mc.Add(&MessageRow{
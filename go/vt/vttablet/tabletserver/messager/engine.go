@@ -40,6 +40,7 @@ type TabletService interface {
 	tabletenv.Env
 	PostponeMessages(ctx context.Context, target *querypb.Target, querygen QueryGenerator, ids []string) (count int64, err error)
 	PurgeMessages(ctx context.Context, target *querypb.Target, querygen QueryGenerator, timeCutoff int64) (count int64, err error)
+	DeadLetterMessages(ctx context.Context, target *querypb.Target, querygen QueryGenerator, ids []string) (count int64, err error)
 }
 
 // VStreamer defines  the functions of VStreamer
@@ -171,6 +172,13 @@ func (me *Engine) schemaChanged(tables map[string]*schema.Table, created, altere
 			log.Errorf("Newly created table already exists in messages: %s", name)
 			continue
 		}
+		if t.MessageInfo.DeadLetterTableError != "" {
+			// The messages that reach the maximum number of attempts stay in
+			// the message table until they can be moved to the dead letter
+			// table, but the other messages are still sent.
+			me.tsv.Stats().InternalErrors.Add("Messages", 1)
+			log.Errorf("Messages of table %s cannot be dead-lettered until the dead letter table is fixed: %s", name, t.MessageInfo.DeadLetterTableError)
+		}
 		mm := newMessageManager(me.tsv, me.vs, t, me.postponeSema)
 		me.managers[name] = mm
 		log.Infof("Starting messager for table: %v", name)
@@ -22,6 +22,8 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -100,6 +102,27 @@ func TestEngineSchemaChanged(t *testing.T) {
 	}
 }
 
+func TestEngineSchemaChangedDeadLetterTableError(t *testing.T) {
+	engine := newTestEngine()
+	defer engine.Close()
+
+	mi := *newMMTable().MessageInfo
+	mi.MaxAttempts = 3
+	mi.DeadLetterTable = "t1_dead"
+	mi.DeadLetterTableError = "dead letter table t1_dead has no column message"
+	table := &schema.Table{
+		Name:        sqlparser.NewIdentifierCS("t1"),
+		Type:        schema.Message,
+		MessageInfo: &mi,
+	}
+	internalErrors := engine.tsv.Stats().InternalErrors.Counts()["Messages"]
+
+	// A bad dead letter table doesn't stop the delivery of the messages.
+	engine.schemaChanged(nil, []*schema.Table{table}, nil, nil, true)
+	assert.Equal(t, map[string]bool{"t1": true}, extractManagerNames(engine.managers))
+	assert.Equal(t, internalErrors+1, engine.tsv.Stats().InternalErrors.Counts()["Messages"])
+}
+
 func extractManagerNames(in map[string]*messageManager) map[string]bool {
 	out := make(map[string]bool)
 	for k := range in {
@@ -53,6 +53,8 @@ type QueryGenerator interface {
 	GenerateAckQuery(ids []string) (string, map[string]*querypb.BindVariable)
 	GeneratePostponeQuery(ids []string) (string, map[string]*querypb.BindVariable)
 	GeneratePurgeQuery(timeCutoff int64) (string, map[string]*querypb.BindVariable)
+	GenerateDeadLetterQueries(ids []string) ([]string, map[string]*querypb.BindVariable)
+	GenerateVisibilityQuery(ids []string, visibilityTimeout time.Duration) (string, map[string]*querypb.BindVariable)
 }
 
 type messageReceiver struct {
@@ -166,6 +168,12 @@ type receiverWithStatus struct {
 // The Purge thread
 // This thread is mostly independent. It wakes up periodically
 // to delete old rows that were successfully acked.
+//
+// Dead-lettering
+// If the table has a maximum number of attempts, the messages that
+// were already sent that many times are not sent again when they are
+// pulled out of the cache. Instead, they are moved to the dead letter
+// table of the message table, or discarded if it has none.
 type messageManager struct {
 	tsv TabletService
 	vs  VStreamer
@@ -177,6 +185,7 @@ type messageManager struct {
 	minBackoff   time.Duration
 	maxBackoff   time.Duration
 	batchSize    int
+	maxAttempts  int
 	pollerTicks  *timer.Timer
 	purgeTicks   *timer.Timer
 	postponeSema *semaphore.Weighted
@@ -236,6 +245,11 @@ type messageManager struct {
 	ackQuery                  *sqlparser.ParsedQuery
 	postponeQuery             *sqlparser.ParsedQuery
 	purgeQuery                *sqlparser.ParsedQuery
+	visibilityQuery           *sqlparser.ParsedQuery
+	// deadLetterQueries move the dead-lettered messages to the
+	// dead letter table, or discard them if there is none.
+	deadLetterQueries []*sqlparser.ParsedQuery
+	deadLetterStat    string
 
 	// idType is the type of the id column in the message table.
 	idType sqltypes.Type
@@ -257,6 +271,7 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 		minBackoff:      table.MessageInfo.MinBackoff,
 		maxBackoff:      table.MessageInfo.MaxBackoff,
 		batchSize:       table.MessageInfo.BatchSize,
+		maxAttempts:     table.MessageInfo.MaxAttempts,
 		cache:           newCache(table.MessageInfo.CacheSize),
 		pollerTicks:     timer.NewTimer(table.MessageInfo.PollInterval),
 		purgeTicks:      timer.NewTimer(table.MessageInfo.PollInterval),
@@ -286,6 +301,31 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 		"delete from %v where time_acked < %a limit 500", mm.name, ":time_acked")
 
 	mm.postponeQuery = buildPostponeQuery(mm.name, mm.minBackoff, mm.maxBackoff)
+	mm.visibilityQuery = sqlparser.BuildParsedQuery(
+		"update %v set time_next = %a where id in %a and time_acked is null",
+		mm.name, ":time_next", "::ids")
+
+	// The messages are only dead-lettered if they still have not been acked.
+	if table.MessageInfo.DeadLetterTable != "" {
+		allColumns := buildColumnList(table.Fields)
+		mm.deadLetterQueries = []*sqlparser.ParsedQuery{
+			sqlparser.BuildParsedQuery(
+				"insert into %v(%s) select %s from %v where id in %a and time_acked is null and epoch >= %a",
+				sqlparser.NewIdentifierCS(table.MessageInfo.DeadLetterTable), allColumns, allColumns, mm.name, "::ids", ":max_attempts"),
+			sqlparser.BuildParsedQuery(
+				"delete from %v where id in %a and time_acked is null and epoch >= %a",
+				mm.name, "::ids", ":max_attempts"),
+		}
+		mm.deadLetterStat = "DeadLettered"
+	} else {
+		// Discarded messages are acked, so that they get purged.
+		mm.deadLetterQueries = []*sqlparser.ParsedQuery{
+			sqlparser.BuildParsedQuery(
+				"update %v set time_acked = %a, time_next = null where id in %a and time_acked is null and epoch >= %a",
+				mm.name, ":time_acked", "::ids", ":max_attempts"),
+		}
+		mm.deadLetterStat = "Discarded"
+	}
 
 	return mm
 }
@@ -339,8 +379,13 @@ func buildPostponeQuery(name sqlparser.IdentifierCS, minBackoff, maxBackoff time
 // buildSelectColumnList is a convenience function that
 // builds a 'select' list for the user-defined columns.
 func buildSelectColumnList(t *schema.Table) string {
+	return buildColumnList(t.MessageInfo.Fields)
+}
+
+// buildColumnList builds a column list for the fields.
+func buildColumnList(fields []*querypb.Field) string {
 	buf := sqlparser.NewTrackedBuffer(nil)
-	for i, c := range t.MessageInfo.Fields {
+	for i, c := range fields {
 		// Column names may have to be escaped.
 		if i == 0 {
 			buf.Myprintf("%v", sqlparser.NewIdentifierCI(c.Name))
@@ -553,17 +598,26 @@ func (mm *messageManager) runSend() {
 
 			// Fetch rows from cache.
 			lateCount := int64(0)
+			var deadIDs []string
 			for i := 0; i < mm.batchSize; i++ {
 				mr := mm.cache.Pop()
 				if mr == nil {
 					break
 				}
+				if mm.maxAttempts > 0 && mr.Epoch >= int64(mm.maxAttempts) {
+					deadIDs = append(deadIDs, mr.Row[0].ToString())
+					continue
+				}
 				if mr.Epoch >= 1 {
 					lateCount++
 				}
 				rows = append(rows, mr.Row)
 			}
 			MessageStats.Add([]string{mm.name.String(), "Delayed"}, lateCount)
+			if deadIDs != nil {
+				mm.wg.Add(1)
+				go mm.deadLetter(deadIDs) // calls the offsetting mm.wg.Done()
+			}
 
 			// If we have rows to send, break out of this loop.
 			if rows != nil {
@@ -646,6 +700,40 @@ func (mm *messageManager) postpone(ctx context.Context, tsv TabletService, ackWa
 	return nil
 }
 
+// deadLetter moves the messages that reached the maximum number of
+// attempts to the dead letter table, or discards them.
+func (mm *messageManager) deadLetter(ids []string) {
+	defer func() {
+		mm.tsv.LogError()
+		mm.wg.Done()
+	}()
+
+	defer func() {
+		// Like in send, hold cacheManagementMu to prevent the poller from
+		// adding the messages back from an older snapshot.
+		mm.cacheManagementMu.Lock()
+		defer mm.cacheManagementMu.Unlock()
+		mm.cache.Discard(ids)
+	}()
+
+	// Use the semaphore to limit parallelism, like postpone.
+	if err := mm.postponeSema.Acquire(context.Background(), 1); err != nil {
+		return
+	}
+	defer mm.postponeSema.Release(1)
+	ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), mm.ackWaitTime)
+	defer cancel()
+	count, err := mm.tsv.DeadLetterMessages(ctx, nil, mm, ids)
+	if err != nil {
+		// The messages are dead-lettered again the next time
+		// they are pulled out of the cache.
+		MessageStats.Add([]string{mm.name.String(), "DeadLetterFailed"}, 1)
+		log.Errorf("messageManager (%v) - Unable to dead-letter messages: %v", mm.name, err)
+		return
+	}
+	MessageStats.Add([]string{mm.name.String(), mm.deadLetterStat}, count)
+}
+
 func (mm *messageManager) startVStream() {
 	if mm.streamCancel != nil {
 		return
@@ -681,7 +769,7 @@ func (mm *messageManager) runVStream(ctx context.Context) {
 // runOneVStream watches for any new rows or rows that have been modified.
 // Whether it's an insert or an update, if the new value of the
 // row indicates that the message is eligible to be sent, it's added to
-// the cache.
+// the cache. Otherwise, a copy that is still queued in the cache is dropped.
 // Deletes are ignored.
 // If the poller updates lastPollPosition, then all GTIDs up to that
 // point are deemed obsolete and are skipped.
@@ -767,6 +855,9 @@ func (mm *messageManager) processRowEvent(fields []*querypb.Field, rowEvent *bin
 			return err
 		}
 		if mr.TimeAcked != 0 || mr.TimeNext > now {
+			// The message was acked or postponed, like with a DML that
+			// sets its time_next, so an older copy must not be sent.
+			mm.cache.DiscardQueued(mr.Row[0].ToString())
 			continue
 		}
 		mm.Add(mr)
@@ -854,41 +945,20 @@ func (mm *messageManager) runPurge() {
 
 // GenerateAckQuery returns the query and bind vars for acking a message.
 func (mm *messageManager) GenerateAckQuery(ids []string) (string, map[string]*querypb.BindVariable) {
-	idbvs := &querypb.BindVariable{
-		Type:   querypb.Type_TUPLE,
-		Values: make([]*querypb.Value, 0, len(ids)),
-	}
-	for _, id := range ids {
-		idbvs.Values = append(idbvs.Values, &querypb.Value{
-			Type:  mm.idType,
-			Value: []byte(id),
-		})
-	}
 	return mm.ackQuery.Query, map[string]*querypb.BindVariable{
 		"time_acked": sqltypes.Int64BindVariable(time.Now().UnixNano()),
-		"ids":        idbvs,
+		"ids":        mm.idsBindVariable(ids),
 	}
 }
 
 // GeneratePostponeQuery returns the query and bind vars for postponing a message.
 func (mm *messageManager) GeneratePostponeQuery(ids []string) (string, map[string]*querypb.BindVariable) {
-	idbvs := &querypb.BindVariable{
-		Type:   querypb.Type_TUPLE,
-		Values: make([]*querypb.Value, 0, len(ids)),
-	}
-	for _, id := range ids {
-		idbvs.Values = append(idbvs.Values, &querypb.Value{
-			Type:  mm.idType,
-			Value: []byte(id),
-		})
-	}
-
 	bvs := map[string]*querypb.BindVariable{
 		"time_now":    sqltypes.Int64BindVariable(time.Now().UnixNano()),
 		"wait_time":   sqltypes.Int64BindVariable(int64(mm.ackWaitTime)),
 		"min_backoff": sqltypes.Int64BindVariable(int64(mm.minBackoff)),
 		"jitter":      sqltypes.Float64BindVariable(.666666 + rand.Float64()*.666666),
-		"ids":         idbvs,
+		"ids":         mm.idsBindVariable(ids),
 	}
 
 	if mm.maxBackoff > 0 {
@@ -905,6 +975,44 @@ func (mm *messageManager) GeneratePurgeQuery(timeCutoff int64) (string, map[stri
 	}
 }
 
+// GenerateDeadLetterQueries returns the queries and bind vars for moving
+// messages to the dead letter table, or for discarding them if there is
+// none. The queries must be executed in a single transaction.
+func (mm *messageManager) GenerateDeadLetterQueries(ids []string) ([]string, map[string]*querypb.BindVariable) {
+	queries := make([]string, 0, len(mm.deadLetterQueries))
+	for _, query := range mm.deadLetterQueries {
+		queries = append(queries, query.Query)
+	}
+	return queries, map[string]*querypb.BindVariable{
+		"time_acked":   sqltypes.Int64BindVariable(time.Now().UnixNano()),
+		"max_attempts": sqltypes.Int64BindVariable(int64(mm.maxAttempts)),
+		"ids":          mm.idsBindVariable(ids),
+	}
+}
+
+// GenerateVisibilityQuery returns the query and bind vars for sending
+// messages again once the visibility timeout expires.
+func (mm *messageManager) GenerateVisibilityQuery(ids []string, visibilityTimeout time.Duration) (string, map[string]*querypb.BindVariable) {
+	return mm.visibilityQuery.Query, map[string]*querypb.BindVariable{
+		"time_next": sqltypes.Int64BindVariable(time.Now().Add(visibilityTimeout).UnixNano()),
+		"ids":       mm.idsBindVariable(ids),
+	}
+}
+
+func (mm *messageManager) idsBindVariable(ids []string) *querypb.BindVariable {
+	idbvs := &querypb.BindVariable{
+		Type:   querypb.Type_TUPLE,
+		Values: make([]*querypb.Value, 0, len(ids)),
+	}
+	for _, id := range ids {
+		idbvs.Values = append(idbvs.Values, &querypb.Value{
+			Type:  mm.idType,
+			Value: []byte(id),
+		})
+	}
+	return idbvs
+}
+
 // BuildMessageRow builds a MessageRow from a db row.
 func BuildMessageRow(row []sqltypes.Value) (*MessageRow, error) {
 	mr := &MessageRow{Row: row[4:]}
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/semaphore"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -300,6 +301,92 @@ func TestMessageManagerSend(t *testing.T) {
 	<-r1.ch
 }
 
+func TestMessageManagerDeadLetter(t *testing.T) {
+	tsv := newFakeTabletServer()
+	ti := newMMTable()
+	ti.MessageInfo.MaxAttempts = 2
+	mm := newMessageManager(tsv, newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	mm.Open()
+	defer mm.Close()
+
+	r1 := newTestReceiver(1)
+	mm.Subscribe(context.Background(), r1.rcv)
+	<-r1.ch
+
+	ch := make(chan string, 20)
+	tsv.SetChannel(ch)
+	discarded := MessageStats.Counts()["foo.Discarded"]
+
+	// The message was already sent twice: it's not sent again.
+	mm.Add(&MessageRow{Epoch: 2, Row: []sqltypes.Value{sqltypes.NewVarBinary("1"), sqltypes.NULL}})
+	if got, want := <-ch, "deadletter"; got != want {
+		t.Errorf("DeadLetter: %s, want %v", got, want)
+	}
+	mm.Add(&MessageRow{Epoch: 1, Row: []sqltypes.Value{sqltypes.NewVarBinary("2"), sqltypes.NULL}})
+	want := &sqltypes.Result{
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewVarBinary("2"),
+			sqltypes.NULL,
+		}},
+	}
+	if got := <-r1.ch; !got.Equal(want) {
+		t.Errorf("Received: %v, want %v", got, want)
+	}
+	if got, want := <-ch, "postpone"; got != want {
+		t.Errorf("Postpone: %s, want %v", got, want)
+	}
+	assert.EqualValues(t, 1, tsv.deadLetterCount.Load())
+	assert.Eventually(t, func() bool {
+		return MessageStats.Counts()["foo.Discarded"] == discarded+1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The dead-lettered message is removed from the cache.
+	mm.cache.mu.Lock()
+	defer mm.cache.mu.Unlock()
+	assert.NotContains(t, mm.cache.inQueue, "1")
+	assert.NotContains(t, mm.cache.inFlight, "1")
+}
+
+func TestMessageManagerDeadLetterRetry(t *testing.T) {
+	tsv := newFakeTabletServer()
+	ti := newMMTable()
+	ti.MessageInfo.MaxAttempts = 2
+	ti.MessageInfo.DeadLetterTable = "foo_dead"
+	mm := newMessageManager(tsv, newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	mm.Open()
+	defer mm.Close()
+
+	r1 := newTestReceiver(1)
+	mm.Subscribe(context.Background(), r1.rcv)
+	<-r1.ch
+
+	ch := make(chan string, 20)
+	tsv.SetChannel(ch)
+	failed := MessageStats.Counts()["foo.DeadLetterFailed"]
+	deadLettered := MessageStats.Counts()["foo.DeadLettered"]
+
+	// The dead letter table does not exist yet: the message stays in the
+	// message table, and is not sent again.
+	tsv.SetDeadLetterError(errors.New("table foo_dead does not exist"))
+	mm.Add(&MessageRow{Epoch: 2, Row: []sqltypes.Value{sqltypes.NewVarBinary("1"), sqltypes.NULL}})
+	assert.Equal(t, "deadletter", <-ch)
+	assert.Eventually(t, func() bool {
+		return MessageStats.Counts()["foo.DeadLetterFailed"] == failed+1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Once it's created, the message is dead-lettered the next time it's read.
+	tsv.SetDeadLetterError(nil)
+	assert.Eventually(t, func() bool {
+		return mm.Add(&MessageRow{Epoch: 2, Row: []sqltypes.Value{sqltypes.NewVarBinary("1"), sqltypes.NULL}})
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "deadletter", <-ch)
+	assert.Eventually(t, func() bool {
+		return MessageStats.Counts()["foo.DeadLettered"] == deadLettered+1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 2, tsv.deadLetterCount.Load())
+	assert.EqualValues(t, 0, tsv.postponeCount.Load())
+}
+
 func TestMessageManagerPostponeThrottle(t *testing.T) {
 	tsv := newFakeTabletServer()
 	mm := newMessageManager(tsv, newFakeVStreamer(), newMMTable(), semaphore.NewWeighted(1))
@@ -488,6 +575,29 @@ func TestMessageManagerStreamerSimple(t *testing.T) {
 	}
 }
 
+func TestMessageManagerStreamerPostponed(t *testing.T) {
+	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), newMMTable(), semaphore.NewWeighted(1))
+	row, err := BuildMessageRow(sqltypes.MakeRowTrusted(testDBFields, newMMRow(1)))
+	require.NoError(t, err)
+	require.True(t, mm.cache.Add(row))
+
+	// The message is postponed, like with a DML that sets its time_next.
+	postponed := sqltypes.RowToProto3([]sqltypes.Value{
+		sqltypes.NewInt64(1),
+		sqltypes.NewInt64(time.Now().Add(time.Hour).UnixNano()),
+		sqltypes.NewInt64(0),
+		sqltypes.NULL,
+		sqltypes.NewInt64(1),
+		sqltypes.NewVarBinary("1"),
+	})
+	err = mm.processRowEvent(testDBFields, &binlogdatapb.RowEvent{
+		TableName:  "foo",
+		RowChanges: []*binlogdatapb.RowChange{{After: postponed}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, mm.cache.Pop())
+}
+
 func TestMessageManagerStreamerAndPoller(t *testing.T) {
 	fvs := newFakeVStreamer()
 	fvs.setPollerResponse([]*binlogdatapb.VStreamResultsResponse{{
@@ -789,6 +899,42 @@ func TestMMGenerate(t *testing.T) {
 	}
 }
 
+func TestMMGenerateDeadLetter(t *testing.T) {
+	ti := newMMTable()
+	ti.MessageInfo.MaxAttempts = 3
+	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	queries, bv := mm.GenerateDeadLetterQueries([]string{"1", "2"})
+	wantQueries := []string{
+		"update foo set time_acked = :time_acked, time_next = null where id in ::ids and time_acked is null and epoch >= :max_attempts",
+	}
+	assert.Equal(t, wantQueries, queries)
+	// time_acked cannot be compared.
+	delete(bv, "time_acked")
+	wantids := sqltypes.TestBindVariable([]any{[]byte{'1'}, []byte{'2'}})
+	wantbv := map[string]*querypb.BindVariable{
+		"max_attempts": sqltypes.Int64BindVariable(3),
+		"ids":          wantids,
+	}
+	utils.MustMatch(t, wantbv, bv, "did not match")
+
+	ti.MessageInfo.DeadLetterTable = "foo_dead"
+	ti.Fields = []*querypb.Field{{Name: "id"}, {Name: "time_next"}, {Name: "epoch"}, {Name: "time_acked"}, {Name: "message"}}
+	mm = newMessageManager(newFakeTabletServer(), newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	queries, _ = mm.GenerateDeadLetterQueries([]string{"1", "2"})
+	wantQueries = []string{
+		"insert into foo_dead(id, time_next, epoch, time_acked, message) select id, time_next, epoch, time_acked, message from foo where id in ::ids and time_acked is null and epoch >= :max_attempts",
+		"delete from foo where id in ::ids and time_acked is null and epoch >= :max_attempts",
+	}
+	assert.Equal(t, wantQueries, queries)
+
+	query, bv := mm.GenerateVisibilityQuery([]string{"1", "2"}, time.Minute)
+	assert.Equal(t, "update foo set time_next = :time_next where id in ::ids and time_acked is null", query)
+	bvv, _ := sqltypes.BindVariableToValue(bv["time_next"])
+	gotNext, _ := bvv.ToCastInt64()
+	assert.InDelta(t, time.Now().Add(time.Minute).UnixNano(), gotNext, 10e9)
+	utils.MustMatch(t, wantids, bv["ids"], "did not match")
+}
+
 func TestMMGenerateWithBackoff(t *testing.T) {
 	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), newMMTableWithBackoff(), semaphore.NewWeighted(1))
 	mm.Open()
@@ -826,11 +972,13 @@ func TestMMGenerateWithBackoff(t *testing.T) {
 
 type fakeTabletServer struct {
 	tabletenv.Env
-	postponeCount atomic.Int64
-	purgeCount    atomic.Int64
+	postponeCount   atomic.Int64
+	purgeCount      atomic.Int64
+	deadLetterCount atomic.Int64
 
-	mu sync.Mutex
-	ch chan string
+	mu            sync.Mutex
+	ch            chan string
+	deadLetterErr error
 }
 
 func newFakeTabletServer() *fakeTabletServer {
@@ -870,6 +1018,27 @@ func (fts *fakeTabletServer) PurgeMessages(ctx context.Context, target *querypb.
 	return 0, nil
 }
 
+func (fts *fakeTabletServer) SetDeadLetterError(err error) {
+	fts.mu.Lock()
+	fts.deadLetterErr = err
+	fts.mu.Unlock()
+}
+
+func (fts *fakeTabletServer) DeadLetterMessages(ctx context.Context, target *querypb.Target, gen QueryGenerator, ids []string) (count int64, err error) {
+	fts.deadLetterCount.Add(1)
+	fts.mu.Lock()
+	ch := fts.ch
+	err = fts.deadLetterErr
+	fts.mu.Unlock()
+	if ch != nil {
+		ch <- "deadletter"
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
 type fakeVStreamer struct {
 	streamInvocations atomic.Int64
 	mu                sync.Mutex
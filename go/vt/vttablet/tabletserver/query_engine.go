@@ -51,6 +51,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txserializer"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/workload"
 )
 
 // _______________________________________________
@@ -69,8 +70,25 @@ type TabletPlan struct {
 	RowsAffected uint64
 	RowsReturned uint64
 	ErrorCount   uint64
+
+	// rowEstimate caches the rows that MySQL estimates the last bound query
+	// of the plan examines, for the query rules that have a condition on it.
+	rowEstimate atomic.Pointer[rowEstimate]
+}
+
+// rowEstimate is the number of rows that MySQL estimated a query examines.
+// The query is the one bound with the bind variables it was executed with,
+// since the estimate depends on their values.
+type rowEstimate struct {
+	query   string
+	rows    int64
+	expires time.Time
 }
 
+// rowEstimateTTL is how long the row estimate of a plan is used before its
+// query is explained again.
+const rowEstimateTTL = 10 * time.Second
+
 // AddStats updates the stats for the current TabletPlan.
 func (ep *TabletPlan) AddStats(queryCount uint64, duration, mysqlTime time.Duration, rowsAffected, rowsReturned, errorCount uint64) {
 	atomic.AddUint64(&ep.QueryCount, queryCount)
@@ -158,6 +176,11 @@ type QueryEngine struct {
 	// Pools
 	conns       *connpool.Pool
 	streamConns *connpool.Pool
+	// workloads and streamWorkloads schedule the workload classes on the pools.
+	workloads       *workload.Scheduler
+	streamWorkloads *workload.Scheduler
+	// admission sheds the low priority queries while the tablet is overloaded.
+	admission *admissionController
 
 	// Services
 	consolidator       sync2.Consolidator
@@ -187,9 +210,9 @@ type QueryEngine struct {
 
 	// stats
 	// Note: queryErrorCountsWithCode is similar to queryErrorCounts except it contains error code as an additional dimension
-	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned, queryTextCharsProcessed *stats.CountersWithMultiLabels
-	queryEnginePlanCacheHits, queryEnginePlanCacheMisses                                                                                                          *stats.CounterFunc
-	queryCacheHitsDeprecated, queryCacheMissesDeprecated                                                                                                          *stats.CounterFunc
+	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned, queryTextCharsProcessed, queryRuleHits *stats.CountersWithMultiLabels
+	queryEnginePlanCacheHits, queryEnginePlanCacheMisses                                                                                                                         *stats.CounterFunc
+	queryCacheHitsDeprecated, queryCacheMissesDeprecated                                                                                                                         *stats.CounterFunc
 
 	// stats flags
 	enablePerWorkloadTableMetrics bool
@@ -231,6 +254,9 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 
 	qe.conns = connpool.NewPool(env, "ConnPool", config.OltpReadPool)
 	qe.streamConns = connpool.NewPool(env, "StreamConnPool", config.OlapReadPool)
+	qe.workloads = workload.NewScheduler(env, "ConnPool", config.OltpReadPool)
+	qe.streamWorkloads = workload.NewScheduler(env, "StreamConnPool", config.OlapReadPool)
+	qe.admission = newAdmissionController(env)
 	qe.consolidatorMode.Store(config.Consolidator)
 	qe.consolidator = sync2.NewConsolidator()
 	if config.ConsolidatorStreamTotalSize > 0 && config.ConsolidatorStreamQuerySize > 0 {
@@ -334,6 +360,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	qe.queryTextCharsProcessed = env.Exporter().NewCountersWithMultiLabels("QueryTextCharactersProcessed", "query text characters processed", labels)
 	qe.queryErrorCounts = env.Exporter().NewCountersWithMultiLabels("QueryErrorCounts", "query error counts", labels)
 	qe.queryErrorCountsWithCode = env.Exporter().NewCountersWithMultiLabels("QueryErrorCountsWithCode", "query error counts with error code", []string{"Table", "Plan", "Code"})
+	qe.queryRuleHits = env.Exporter().NewCountersWithMultiLabels("QueryRuleHits", "Number of queries that triggered each query rule", []string{"Rule", "Action"})
 
 	env.Exporter().HandleFunc("/debug/hotrows", qe.txSerializer.ServeHTTP)
 	env.Exporter().HandleFunc("/debug/tablet_plans", qe.handleHTTPQueryPlans)
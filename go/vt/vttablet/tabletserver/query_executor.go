@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"strings"
 	"sync"
 	"time"
@@ -152,9 +153,11 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		qre.tsv.Stats().ResultHistogram.Add(int64(len(reply.Rows)))
 	}(time.Now())
 
-	if err = qre.checkPermissions(); err != nil {
+	release, err := qre.checkPermissions()
+	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	if qre.plan.PlanID == p.PlanNextval {
 		return qre.execNextval()
@@ -339,9 +342,11 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		qre.recordUserQuery("Stream", int64(time.Since(start)))
 	}(time.Now())
 
-	if err := qre.checkPermissions(); err != nil {
+	release, err := qre.checkPermissions()
+	if err != nil {
 		return err
 	}
+	defer release()
 
 	switch qre.plan.PlanID {
 	case p.PlanSelectStream:
@@ -429,9 +434,11 @@ func (qre *QueryExecutor) MessageStream(callback StreamCallback) error {
 		qre.recordUserQuery("MessageStream", int64(time.Since(start)))
 	}(time.Now())
 
-	if err := qre.checkPermissions(); err != nil {
+	release, err := qre.checkPermissions()
+	if err != nil {
 		return err
 	}
+	defer release()
 
 	done, err := qre.tsv.messager.Subscribe(qre.ctx, qre.plan.TableName().String(), func(r *sqltypes.Result) error {
 		select {
@@ -449,11 +456,13 @@ func (qre *QueryExecutor) MessageStream(callback StreamCallback) error {
 }
 
 // checkPermissions returns an error if the query does not pass all checks
-// (denied query, table ACL).
-func (qre *QueryExecutor) checkPermissions() error {
+// (denied query, table ACL). If the query passes, the returned function must
+// be called once the query is done, to release the throttling rule that
+// it may hold.
+func (qre *QueryExecutor) checkPermissions() (release func(), err error) {
 	// Skip permissions check if the context is local.
 	if tabletenv.IsLocalContext(qre.ctx) {
-		return nil
+		return func() {}, nil
 	}
 
 	// Check if the query relates to a table that is in the denylist.
@@ -465,18 +474,55 @@ func (qre *QueryExecutor) checkPermissions() error {
 		username = ci.Username()
 	}
 
-	action, ruleCancelCtx, timeout, desc := qre.plan.Rules.GetAction(remoteAddr, username, qre.bindVars, qre.marginComments)
+	// The ACL is checked first, so that the rules that need a row estimate
+	// don't explain the queries that the user is not allowed to run.
+	if err := qre.checkACL(username); err != nil {
+		return nil, err
+	}
+	return qre.applyRules(qre.plan.Rules.FindRules(remoteAddr, username, qre.bindVars, qre.marginComments, qre.estimateRows, qre.tsv.qe.queryRuleHits))
+}
 
-	bufferingTimeoutCtx, cancel := context.WithTimeout(qre.ctx, timeout) // aborts buffering at given timeout
-	defer cancel()
+// applyRules applies the actions of the query rules that the query triggered,
+// in order. The returned function releases the rules once the query is done.
+func (qre *QueryExecutor) applyRules(qrs []*rules.Rule) (release func(), err error) {
+	releases := make([]func(), 0, len(qrs))
+	release = func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+	for _, qr := range qrs {
+		ruleRelease, err := qre.applyRule(qr)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, ruleRelease)
+	}
+	return release, nil
+}
 
-	switch action {
+// applyRule applies the action of the query rule that the query triggered,
+// if any. The returned function releases the rule once the query is done.
+func (qre *QueryExecutor) applyRule(qr *rules.Rule) (release func(), err error) {
+	release = func() {}
+	if qr == nil {
+		// no rules against this query. Good to proceed
+		return release, nil
+	}
+	desc := qr.Description
+
+	switch qr.Action() {
 	case rules.QRFail:
-		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "disallowed due to rule: %s", desc)
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "disallowed due to rule: %s", desc)
 	case rules.QRFailRetry:
-		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: %s", desc)
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: %s", desc)
 	case rules.QRBuffer:
+		ruleCancelCtx, timeout := qr.Buffering()
 		if ruleCancelCtx != nil {
+			bufferingTimeoutCtx, cancel := context.WithTimeout(qre.ctx, timeout) // aborts buffering at given timeout
+			defer cancel()
+
 			// We buffer up to some timeout. The timeout is determined by ctx.Done().
 			// If we're not at timeout yet, we fail the query
 			select {
@@ -484,12 +530,90 @@ func (qre *QueryExecutor) checkPermissions() error {
 				// good! We have buffered the query, and buffering is completed
 			case <-bufferingTimeoutCtx.Done():
 				// Sorry, timeout while waiting for buffering to complete
-				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "buffer timeout after %v in rule: %s", timeout, desc)
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "buffer timeout after %v in rule: %s", timeout, desc)
 			}
 		}
+	case rules.QRDelay:
+		timer := time.NewTimer(qr.Delay())
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-qre.ctx.Done():
+			return nil, vterrors.Errorf(vtrpcpb.Code_DEADLINE_EXCEEDED, "context expired while delayed by rule: %s", desc)
+		}
+	case rules.QRThrottle:
+		release, err = qr.Throttle(qre.ctx)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "throttled due to rule: %s: %v", desc, err)
+		}
+	}
+	return release, nil
+}
+
+// estimateRows returns the number of rows that MySQL estimates the query
+// examines, which is the largest estimate of the steps of its EXPLAIN. The
+// estimate of the query bound with its bind variables is cached in the plan
+// for rowEstimateTTL, so that the query is not explained every time it is
+// executed with the same bind variables.
+func (qre *QueryExecutor) estimateRows() (int64, error) {
+	switch qre.plan.PlanID {
+	case p.PlanSelect, p.PlanSelectStream, p.PlanUpdate, p.PlanUpdateLimit, p.PlanDelete, p.PlanDeleteLimit:
 	default:
-		// no rules against this query. Good to proceed
+		return 0, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot estimate the rows of a %s query", qre.plan.PlanID)
+	}
+	if qre.plan.FullQuery == nil {
+		return 0, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot estimate the rows of a %s query", qre.plan.PlanID)
+	}
+
+	// The limit of the query is only set once it is executed.
+	bindVars := maps.Clone(qre.bindVars)
+	if bindVars == nil {
+		bindVars = make(map[string]*querypb.BindVariable)
 	}
+	if _, ok := bindVars["#maxLimit"]; !ok {
+		bindVars["#maxLimit"] = sqltypes.Int64BindVariable(qre.getSelectLimit() + 1)
+	}
+	query, err := qre.plan.FullQuery.GenerateQuery(bindVars, nil)
+	if err != nil {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s", err)
+	}
+	if est := qre.plan.rowEstimate.Load(); est != nil && est.query == query && time.Now().Before(est.expires) {
+		return est.rows, nil
+	}
+
+	qr, err := qre.execExplain("explain " + query)
+	if err != nil {
+		return 0, err
+	}
+
+	col := -1
+	for i, field := range qr.Fields {
+		if strings.EqualFold(field.Name, "rows") {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "no rows column in the explain of the query")
+	}
+	var rows int64
+	for _, row := range qr.Rows {
+		if row[col].IsNull() {
+			continue
+		}
+		n, err := row[col].ToInt64()
+		if err != nil {
+			return 0, err
+		}
+		rows = max(rows, n)
+	}
+	qre.plan.rowEstimate.Store(&rowEstimate{query: query, rows: rows, expires: time.Now().Add(rowEstimateTTL)})
+	return rows, nil
+}
+
+// checkACL returns an error if the caller is not allowed to run the query
+// by the table ACL.
+func (qre *QueryExecutor) checkACL(username string) error {
 	// Skip ACL check for queries against the dummy dual table
 	if qre.plan.TableName().String() == "dual" {
 		return nil
@@ -782,6 +906,26 @@ func (qre *QueryExecutor) execOther() (*sqltypes.Result, error) {
 	return qre.execDBConn(conn.Conn, qre.query, true)
 }
 
+// execExplain runs the explain of the query on the connection of its
+// transaction or reservation, if it has one, so that it sees the same data
+// and settings as the query, and doesn't take a second connection.
+func (qre *QueryExecutor) execExplain(explain string) (*sqltypes.Result, error) {
+	if qre.connID != 0 {
+		conn, err := qre.tsv.te.txPool.GetAndLock(qre.connID, "for explain")
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Unlock()
+		return conn.Exec(qre.ctx, explain, 1000, true)
+	}
+	conn, err := qre.getConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	return conn.Conn.Exec(qre.ctx, explain, 1000, true)
+}
+
 func (qre *QueryExecutor) getConn() (*connpool.PooledConn, error) {
 	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.getConn")
 	defer span.Finish()
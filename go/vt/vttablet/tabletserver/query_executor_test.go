@@ -1255,6 +1255,319 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorQRThrottle(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	expected := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", expected)
+
+	throttleRule := rules.NewQueryRule("throttle selects", "throttle selects", rules.QRThrottle)
+	require.NoError(t, throttleRule.SetThrottle(0, 1))
+	throttleRule.AddTableCond("test_table")
+
+	rulesName := "throttleRules"
+	rules := rules.New()
+	rules.Add(throttleRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	// The query can't run while another query holds the only slot of the rule.
+	release, err := throttleRule.Throttle(ctx)
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	qre := newTestQueryExecutor(timeoutCtx, tsv, query, 0)
+	_, err = qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+
+	// Once the slot is released, the query runs and releases it in turn.
+	release()
+	for range 2 {
+		qre = newTestQueryExecutor(ctx, tsv, query, 0)
+		_, err = qre.Execute()
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 3, tsv.qe.queryRuleHits.Counts()["throttle selects.THROTTLE"])
+}
+
+func TestQueryExecutorQRTwoThrottles(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	expected := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", expected)
+
+	tableRule := rules.NewQueryRule("throttle table", "throttle table", rules.QRThrottle)
+	require.NoError(t, tableRule.SetThrottle(0, 2))
+	tableRule.AddTableCond("test_table")
+	selectRule := rules.NewQueryRule("throttle selects", "throttle selects", rules.QRThrottle)
+	require.NoError(t, selectRule.SetThrottle(0, 1))
+	selectRule.AddPlanCond(planbuilder.PlanSelect)
+
+	rulesName := "throttleRules"
+	rules := rules.New()
+	rules.Add(tableRule)
+	rules.Add(selectRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	// Both rules match the query, so it is held by the second rule even though
+	// the first one has a free slot.
+	release, err := selectRule.Throttle(ctx)
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	qre := newTestQueryExecutor(timeoutCtx, tsv, query, 0)
+	_, err = qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	release()
+
+	// The slots of both rules are released once the queries are done.
+	for range 2 {
+		qre = newTestQueryExecutor(ctx, tsv, query, 0)
+		_, err = qre.Execute()
+		require.NoError(t, err)
+	}
+	timeoutCtx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	for range 2 {
+		release, err := tableRule.Throttle(timeoutCtx)
+		require.NoError(t, err)
+		defer release()
+	}
+}
+
+func TestQueryExecutorQRDelay(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	expected := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", expected)
+
+	delayRule := rules.NewQueryRule("delay selects", "delay selects", rules.QRDelay)
+	require.NoError(t, delayRule.SetDelay(50*time.Millisecond))
+	delayRule.AddTableCond("test_table")
+
+	rulesName := "delayRules"
+	rules := rules.New()
+	rules.Add(delayRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	start := time.Now()
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err := qre.Execute()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// The query fails if its deadline expires while it is delayed.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	qre = newTestQueryExecutor(timeoutCtx, tsv, query, 0)
+	_, err = qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_DEADLINE_EXCEEDED, vterrors.Code(err))
+}
+
+func TestQueryExecutorQRMinEstimatedRows(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	expected := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", expected)
+	explainFields := sqltypes.MakeTestFields("id|table|rows", "int64|varchar|int64")
+	db.AddQuery("explain select * from test_table where `name` = 1 limit 1000", sqltypes.MakeTestResult(explainFields, "1|test_table|5000", "1|other|null"))
+
+	bigRule := rules.NewQueryRule("big selects", "big selects", rules.QRFail)
+	require.NoError(t, bigRule.SetMinEstimatedRowsCond(1000))
+	bigRule.AddTableCond("test_table")
+
+	rulesName := "bigRules"
+	rules := rules.New()
+	rules.Add(bigRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err := qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+
+	// The estimate is cached in the plan.
+	plan := qre.plan
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.plan = plan
+	_, err = qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	assert.Equal(t, 1, db.GetQueryCalledNum("explain select * from test_table where `name` = 1 limit 1000"))
+
+	// Until it expires.
+	db.AddQuery("explain select * from test_table where `name` = 1 limit 1000", sqltypes.MakeTestResult(explainFields, "1|test_table|10"))
+	plan.rowEstimate.Load().expires = time.Now()
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.plan = plan
+	_, err = qre.Execute()
+	require.NoError(t, err)
+}
+
+func TestQueryExecutorQRMinEstimatedRowsBindVars(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = :v limit 1000"
+	expected := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", expected)
+	db.AddQuery("select * from test_table where `name` = 2 limit 1000", expected)
+	explainFields := sqltypes.MakeTestFields("id|table|rows", "int64|varchar|int64")
+	db.AddQuery("explain select * from test_table where `name` = 1 limit 1000", sqltypes.MakeTestResult(explainFields, "1|test_table|5000"))
+	db.AddQuery("explain select * from test_table where `name` = 2 limit 1000", sqltypes.MakeTestResult(explainFields, "1|test_table|10"))
+
+	bigRule := rules.NewQueryRule("big selects", "big selects", rules.QRFail)
+	require.NoError(t, bigRule.SetMinEstimatedRowsCond(1000))
+	bigRule.AddTableCond("test_table")
+
+	rulesName := "bigRules"
+	rules := rules.New()
+	rules.Add(bigRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	// The estimate depends on the values of the bind variables, and only the
+	// one of the last values the plan was executed with is cached.
+	execute := func(v int64) error {
+		qre := newTestQueryExecutor(ctx, tsv, query, 0)
+		qre.bindVars["v"] = sqltypes.Int64BindVariable(v)
+		_, err := qre.Execute()
+		return err
+	}
+	for range 2 {
+		assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(execute(1)))
+		assert.NoError(t, execute(2))
+	}
+	assert.NoError(t, execute(2))
+	assert.Equal(t, 2, db.GetQueryCalledNum("explain select * from test_table where `name` = 1 limit 1000"))
+	assert.Equal(t, 2, db.GetQueryCalledNum("explain select * from test_table where `name` = 2 limit 1000"))
+}
+
+func TestQueryExecutorQRMinEstimatedRowsACL(t *testing.T) {
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int64())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	explainFields := sqltypes.MakeTestFields("id|table|rows", "int64|varchar|int64")
+	db.AddQuery("explain select * from test_table where `name` = 1 limit 1000", sqltypes.MakeTestResult(explainFields, "1|test_table|5000"))
+
+	bigRule := rules.NewQueryRule("big selects", "big selects", rules.QRFail)
+	require.NoError(t, bigRule.SetMinEstimatedRowsCond(1000))
+	bigRule.AddTableCond("test_table")
+
+	rulesName := "bigRules"
+	rules := rules.New()
+	rules.Add(bigRule)
+
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "group02",
+			TableNamesOrPrefixes: []string{"test_table"},
+			Readers:              []string{"superuser"},
+		}},
+	}
+	require.NoError(t, tableacl.InitFromProto(config))
+
+	ctx := callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{Username: "u2"})
+	tsv := newTestTabletServer(ctx, enableStrictTableACL, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	// The query is denied by the ACL before it is explained for the rule.
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err := qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+	assert.Zero(t, db.GetQueryCalledNum("explain select * from test_table where `name` = 1 limit 1000"))
+}
+
+func TestQueryExecutorQRMinEstimatedRowsInTransaction(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	explainFields := sqltypes.MakeTestFields("id|table|rows", "int64|varchar|int64")
+	db.AddQuery("explain select * from test_table where `name` = 1 limit 1000", sqltypes.MakeTestResult(explainFields, "1|test_table|10"))
+
+	bigRule := rules.NewQueryRule("big selects", "big selects", rules.QRFail)
+	require.NoError(t, bigRule.SetMinEstimatedRowsCond(1000))
+	bigRule.AddTableCond("test_table")
+
+	rulesName := "bigRules"
+	rules := rules.New()
+	rules.Add(bigRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	// In a transaction, the query is explained on the connection of the
+	// transaction, and not on one of the query pool.
+	txID := newTransaction(tsv, nil)
+	gets := tsv.qe.conns.Metrics.GetCount()
+	qre := newTestQueryExecutor(ctx, tsv, query, txID)
+	_, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, 1, db.GetQueryCalledNum("explain select * from test_table where `name` = 1 limit 1000"))
+	assert.Equal(t, gets, tsv.qe.conns.Metrics.GetCount())
+	_, err = tsv.Rollback(ctx, &querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}, txID)
+	require.NoError(t, err)
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
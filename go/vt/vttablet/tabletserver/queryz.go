@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logz"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 )
 
@@ -34,6 +35,7 @@ var (
 	queryzHeader = []byte(`<thead>
 		<tr>
 			<th>Query</th>
+			<th>Digest</th>
 			<th>Table</th>
 			<th>Plan</th>
 			<th>Count</th>
@@ -53,6 +55,7 @@ var (
 	queryzTmpl = template.Must(template.New("example").Parse(`
 		<tr class="{{.Color}}">
 			<td>{{.Query}}</td>
+			<td>{{.Digest}}</td>
 			<td>{{.Table}}</td>
 			<td>{{.Plan}}</td>
 			<td>{{.Count}}</td>
@@ -74,6 +77,7 @@ var (
 // using go's template.
 type queryzRow struct {
 	Query        string
+	Digest       string
 	Table        string
 	Plan         planbuilder.PlanType
 	Count        uint64
@@ -156,9 +160,10 @@ func queryzHandler(qe *QueryEngine, w http.ResponseWriter, r *http.Request) {
 			return true
 		}
 		Value := &queryzRow{
-			Query: logz.Wrappable(qe.env.Environment().Parser().TruncateForUI(plan.Original)),
-			Table: plan.TableName().String(),
-			Plan:  plan.PlanID,
+			Query:  logz.Wrappable(qe.env.Environment().Parser().TruncateForUI(plan.Original)),
+			Digest: sqlparser.QueryDigest(plan.Original),
+			Table:  plan.TableName().String(),
+			Plan:   plan.PlanID,
 		}
 		Value.Count, Value.tm, Value.mysqlTime, Value.RowsAffected, Value.RowsReturned, Value.Errors = plan.Stats()
 		var timepq time.Duration
@@ -94,6 +94,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern1 := []string{
 		`<tr class="high">`,
 		`<td>select name from test_table</td>`,
+		`<td>[0-9a-f]{16}</td>`,
 		`<td>test_table</td>`,
 		`<td>Select</td>`,
 		`<td>10</td>`,
@@ -112,6 +113,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern2 := []string{
 		`<tr class="low">`,
 		`<td>insert into test_table values 1</td>`,
+		`<td>[0-9a-f]{16}</td>`,
 		`<td>test_table</td>`,
 		`<td>DDL</td>`,
 		`<td>1</td>`,
@@ -130,6 +132,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern3 := []string{
 		`<tr class="medium">`,
 		`<td>show tables</td>`,
+		`<td>[0-9a-f]{16}</td>`,
 		`<td></td>`,
 		`<td>OtherRead</td>`,
 		`<td>1</td>`,
@@ -148,6 +151,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern4 := []string{
 		`<tr class="low">`,
 		`<td>insert into test_table values .* \[TRUNCATED\][^<]*</td>`,
+		`<td>[0-9a-f]{16}</td>`,
 		`<td></td>`,
 		`<td>OtherRead</td>`,
 		`<td>1</td>`,
@@ -39,13 +39,15 @@ func (cached *BindVarCond) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+
+//go:nocheckptr
 func (cached *Rule) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(320)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -72,6 +74,8 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += hack.RuntimeAllocSize(int64(len(elem)))
 		}
 	}
+	// field queryDigest string
+	size += hack.RuntimeAllocSize(int64(len(cached.queryDigest)))
 	// field bindVarConds []vitess.io/vitess/go/vt/vttablet/tabletserver/rules.BindVarCond
 	{
 		size += hack.RuntimeAllocSize(int64(cap(cached.bindVarConds)) * int64(48))
@@ -79,6 +83,16 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field commentTags map[string]string
+	if cached.commentTags != nil {
+		size += hack.RuntimeMapSize(cached.commentTags)
+		for k, v := range cached.commentTags {
+			size += hack.RuntimeAllocSize(int64(len(k)))
+			size += hack.RuntimeAllocSize(int64(len(v)))
+		}
+	}
+	// field throttle *vitess.io/vitess/go/vt/vttablet/tabletserver/rules.ruleThrottle
+	size += cached.throttle.CachedSize(true)
 	return size
 }
 func (cached *Rules) CachedSize(alloc bool) int64 {
@@ -130,3 +144,23 @@ func (cached *namedRegexp) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *ruleThrottle) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field limiter *golang.org/x/time/rate.Limiter
+	if cached.limiter != nil {
+		// WARNING: size of external type golang.org/x/time/rate.Limiter cannot be fully calculated
+		size += hack.RuntimeAllocSize(int64(80))
+	}
+	// field slots *golang.org/x/sync/semaphore.Weighted
+	if cached.slots != nil {
+		// WARNING: size of external type golang.org/x/sync/semaphore.Weighted cannot be fully calculated
+		size += hack.RuntimeAllocSize(int64(72))
+	}
+	return size
+}
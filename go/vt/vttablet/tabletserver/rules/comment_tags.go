@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"net/url"
+	"strings"
+)
+
+// parseCommentTags returns the tags of the sqlcommenter comments found in
+// the given margin comments, e.g. /*application='shop',route='%2Forders'*/.
+// The keys and values of the tags are URL decoded. The comments that are not
+// in the sqlcommenter format are ignored.
+func parseCommentTags(comments string) map[string]string {
+	var tags map[string]string
+	for {
+		start := strings.Index(comments, "/*")
+		if start < 0 {
+			return tags
+		}
+		end := strings.Index(comments[start+2:], "*/")
+		if end < 0 {
+			return tags
+		}
+		body := strings.TrimSpace(comments[start+2 : start+2+end])
+		comments = comments[start+2+end+2:]
+
+		commentTags, ok := parseSQLCommenter(body)
+		if !ok {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string, len(commentTags))
+		}
+		for k, v := range commentTags {
+			tags[k] = v
+		}
+	}
+}
+
+// parseSQLCommenter parses the body of a sqlcommenter comment, which is a
+// list of key='value' pairs separated by commas.
+func parseSQLCommenter(body string) (map[string]string, bool) {
+	if body == "" {
+		return nil, false
+	}
+	tags := make(map[string]string)
+	for body != "" {
+		eq := strings.IndexByte(body, '=')
+		if eq <= 0 || eq+1 >= len(body) || body[eq+1] != '\'' {
+			return nil, false
+		}
+		key, err := url.PathUnescape(strings.TrimSpace(body[:eq]))
+		if err != nil {
+			return nil, false
+		}
+
+		// The quotes inside the values are escaped with a backslash.
+		var value strings.Builder
+		i := eq + 2
+		for ; i < len(body) && body[i] != '\''; i++ {
+			if body[i] == '\\' && i+1 < len(body) {
+				i++
+			}
+			value.WriteByte(body[i])
+		}
+		if i >= len(body) {
+			return nil, false
+		}
+		tags[key], err = url.PathUnescape(value.String())
+		if err != nil {
+			return nil, false
+		}
+
+		body = strings.TrimSpace(body[i+1:])
+		if body == "" {
+			break
+		}
+		if body[0] != ',' {
+			return nil, false
+		}
+		body = strings.TrimSpace(body[1:])
+	}
+	return tags, true
+}
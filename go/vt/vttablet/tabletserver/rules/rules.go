@@ -19,14 +19,18 @@ package rules
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
 	"regexp"
 	"strconv"
 	"time"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -40,6 +44,11 @@ const (
 	bufferedTableRuleName = "buffered_table"
 )
 
+var (
+	logOnlyLogger      = logutil.NewThrottledLogger("QueryRuleLogOnly", 5*time.Second)
+	estimateRowsLogger = logutil.NewThrottledLogger("QueryRuleEstimateRows", 5*time.Second)
+)
+
 // Rules is used to store and execute rules for the tabletserver.
 type Rules struct {
 	rules []*Rule
@@ -170,6 +179,8 @@ func (qrs *Rules) FilterByPlan(query string, planid planbuilder.PlanType, tableN
 }
 
 // GetAction runs the input against the rules engine and returns the action to be performed.
+// Unlike FindRules, it neither counts the rule hits nor logs the LOG_ONLY rules, and the
+// rules with a condition on the estimated rows are not triggered.
 func (qrs *Rules) GetAction(
 	ip,
 	user string,
@@ -180,12 +191,98 @@ func (qrs *Rules) GetAction(
 	cancelCtx context.Context,
 	timeout time.Duration,
 	desc string) {
+	if triggered := qrs.findRules(ip, user, bindVars, marginComments, nil, nil, false); len(triggered) > 0 {
+		qr := triggered[0]
+		return qr.act, qr.cancelCtx, qr.timeout, qr.Description
+	}
+	return QRContinue, nil, 0, ""
+}
+
+// RowEstimator returns the number of rows that MySQL estimates a query examines.
+type RowEstimator func() (int64, error)
+
+// FindRules runs the input against the rules engine and returns the rules that
+// are triggered, or nil if the query can proceed. The first rule that fails or
+// buffers the query is returned alone, before any rule that only throttles or
+// delays it, so that the order of the rules sources cannot hide it. Otherwise,
+// every THROTTLE and DELAY rule that is triggered is returned, in order, and
+// all of them apply to the query. The LOG_ONLY rules that are triggered are
+// logged, and the evaluation continues with the next rules.
+//
+// The rows of the query are only estimated if a rule that matches all its
+// other conditions has a condition on the estimated rows. Such rules are not
+// triggered if estimateRows is nil or fails.
+//
+// The triggered rules are counted in hits by rule name and action, unless
+// hits is nil.
+func (qrs *Rules) FindRules(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+	estimateRows RowEstimator,
+	hits *stats.CountersWithMultiLabels,
+) []*Rule {
+	return qrs.findRules(ip, user, bindVars, marginComments, estimateRows, hits, true)
+}
+
+// findRules implements FindRules. The LOG_ONLY rules are only logged if log
+// is set.
+func (qrs *Rules) findRules(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+	estimateRows RowEstimator,
+	hits *stats.CountersWithMultiLabels,
+	log bool,
+) []*Rule {
+	countHit := func(qr *Rule) {
+		if hits != nil {
+			hits.Add([]string{qr.Name, qr.act.String()}, 1)
+		}
+	}
+	var estimatedRows int64
+	var estimated bool
+	var nonBlocking []*Rule
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
-			return act, qr.cancelCtx, qr.timeout, qr.Description
+		act := qr.GetAction(ip, user, bindVars, marginComments)
+		if act == QRContinue {
+			continue
+		}
+		if qr.minEstimatedRows > 0 {
+			if estimateRows == nil {
+				continue
+			}
+			if !estimated {
+				var err error
+				if estimatedRows, err = estimateRows(); err != nil {
+					estimateRowsLogger.Warningf("cannot estimate the rows of the query for rule %s: %v", qr.Name, err)
+					estimatedRows = -1
+				}
+				estimated = true
+			}
+			if estimatedRows < qr.minEstimatedRows {
+				continue
+			}
+		}
+		switch act {
+		case QRLogOnly:
+			countHit(qr)
+			if log {
+				logOnlyLogger.Infof("query rule %s was triggered: %s", qr.Name, qr.Description)
+			}
+		case QRThrottle, QRDelay:
+			nonBlocking = append(nonBlocking, qr)
+		default:
+			countHit(qr)
+			return []*Rule{qr}
 		}
 	}
-	return QRContinue, nil, 0, ""
+	for _, qr := range nonBlocking {
+		countHit(qr)
+	}
+	return nonBlocking
 }
 
 // -----------------------------------------------
@@ -213,9 +310,22 @@ type Rule struct {
 	// Any matched tableNames will make this condition true (OR)
 	tableNames []string
 
+	// The digest of the normalized query, as returned by sqlparser.QueryDigest.
+	// Empty conditions are ignored (TRUE).
+	queryDigest string
+
 	// All BindVar conditions have to be fulfilled to make this true (AND)
 	bindVarConds []BindVarCond
 
+	// All the tags of the sqlcommenter comments of the query have to
+	// match to make this true (AND)
+	commentTags map[string]string
+
+	// The number of rows that MySQL estimates the query examines has to be
+	// at least minEstimatedRows to make this true. Zero conditions are
+	// ignored (TRUE).
+	minEstimatedRows int64
+
 	// Action to be performed on trigger
 	act Action
 
@@ -224,6 +334,13 @@ type Rule struct {
 
 	// a rule can timeout.
 	timeout time.Duration
+
+	// delay is how long a DELAY rule delays the queries.
+	delay time.Duration
+
+	// throttle limits the queries of a THROTTLE rule. It is shared
+	// by the copies of the rule.
+	throttle *ruleThrottle
 }
 
 type namedRegexp struct {
@@ -268,7 +385,12 @@ func (qr *Rule) Equal(other *Rule) bool {
 		qr.query.Equal(other.query) &&
 		qr.leadingComment.Equal(other.leadingComment) &&
 		qr.trailingComment.Equal(other.trailingComment) &&
+		qr.queryDigest == other.queryDigest &&
+		maps.Equal(qr.commentTags, other.commentTags) &&
+		qr.minEstimatedRows == other.minEstimatedRows &&
 		qr.timeout == other.timeout &&
+		qr.delay == other.delay &&
+		qr.throttle.equal(other.throttle) &&
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
@@ -278,16 +400,21 @@ func (qr *Rule) Equal(other *Rule) bool {
 // Copy performs a deep copy of a Rule.
 func (qr *Rule) Copy() (newqr *Rule) {
 	newqr = &Rule{
-		Description:     qr.Description,
-		Name:            qr.Name,
-		requestIP:       qr.requestIP,
-		user:            qr.user,
-		query:           qr.query,
-		leadingComment:  qr.leadingComment,
-		trailingComment: qr.trailingComment,
-		act:             qr.act,
-		cancelCtx:       qr.cancelCtx,
-		timeout:         qr.timeout,
+		Description:      qr.Description,
+		Name:             qr.Name,
+		requestIP:        qr.requestIP,
+		user:             qr.user,
+		query:            qr.query,
+		leadingComment:   qr.leadingComment,
+		trailingComment:  qr.trailingComment,
+		queryDigest:      qr.queryDigest,
+		commentTags:      maps.Clone(qr.commentTags),
+		minEstimatedRows: qr.minEstimatedRows,
+		act:              qr.act,
+		cancelCtx:        qr.cancelCtx,
+		timeout:          qr.timeout,
+		delay:            qr.delay,
+		throttle:         qr.throttle,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -330,19 +457,85 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.tableNames != nil {
 		safeEncode(b, `,"TableNames":`, qr.tableNames)
 	}
+	if qr.queryDigest != "" {
+		safeEncode(b, `,"QueryDigest":`, qr.queryDigest)
+	}
 	if qr.bindVarConds != nil {
 		safeEncode(b, `,"BindVarConds":`, qr.bindVarConds)
 	}
+	if qr.commentTags != nil {
+		safeEncode(b, `,"CommentTags":`, qr.commentTags)
+	}
+	if qr.minEstimatedRows != 0 {
+		safeEncode(b, `,"MinEstimatedRows":`, qr.minEstimatedRows)
+	}
 	if qr.act != QRContinue {
 		safeEncode(b, `,"Action":`, qr.act)
 	}
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.delay != 0 {
+		safeEncode(b, `,"Delay":`, qr.delay.String())
+	}
+	if qr.throttle != nil {
+		if qr.throttle.maxQPS != 0 {
+			safeEncode(b, `,"MaxQPS":`, qr.throttle.maxQPS)
+		}
+		if qr.throttle.maxConcurrency != 0 {
+			safeEncode(b, `,"MaxConcurrency":`, qr.throttle.maxConcurrency)
+		}
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
 
+// Action returns the action of the rule.
+func (qr *Rule) Action() Action {
+	return qr.act
+}
+
+// Buffering returns the context that ends the buffering of a BUFFER rule,
+// and how long the queries are buffered at most.
+func (qr *Rule) Buffering() (cancelCtx context.Context, timeout time.Duration) {
+	return qr.cancelCtx, qr.timeout
+}
+
+// SetDelay sets how long a DELAY rule delays the queries.
+func (qr *Rule) SetDelay(delay time.Duration) error {
+	if delay <= 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "delay must be positive: %v", delay)
+	}
+	qr.delay = delay
+	return nil
+}
+
+// Delay returns how long a DELAY rule delays the queries.
+func (qr *Rule) Delay() time.Duration {
+	return qr.delay
+}
+
+// SetThrottle sets the limits of a THROTTLE rule. The queries are limited to
+// maxQPS queries per second, and to maxConcurrency concurrent queries.
+// A zero limit is not enforced, but at least one limit must be set.
+func (qr *Rule) SetThrottle(maxQPS float64, maxConcurrency int) error {
+	if maxQPS < 0 || maxConcurrency < 0 || (maxQPS == 0 && maxConcurrency == 0) {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid throttle limits: MaxQPS %v, MaxConcurrency %v", maxQPS, maxConcurrency)
+	}
+	qr.throttle = newRuleThrottle(maxQPS, maxConcurrency)
+	return nil
+}
+
+// Throttle waits until the query is allowed by the limits of a THROTTLE rule,
+// or until the context is done. The returned function must be called once the
+// query is done.
+func (qr *Rule) Throttle(ctx context.Context) (release func(), err error) {
+	if qr.throttle == nil {
+		return func() {}, nil
+	}
+	return qr.throttle.acquire(ctx)
+}
+
 // SetIPCond adds a regular expression condition for the client IP.
 // It has to be a full match (not substring).
 func (qr *Rule) SetIPCond(pattern string) (err error) {
@@ -380,6 +573,36 @@ func (qr *Rule) SetQueryCond(pattern string) (err error) {
 	return
 }
 
+// SetQueryDigestCond adds a condition on the digest of the normalized query,
+// as returned by sqlparser.QueryDigest and shown in queryz.
+func (qr *Rule) SetQueryDigestCond(digest string) error {
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != 8 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid query digest: %s", digest)
+	}
+	qr.queryDigest = digest
+	return nil
+}
+
+// AddCommentTagCond adds a condition on a tag of the sqlcommenter comments of
+// the query, e.g. application='shop'. The value is compared after it is URL
+// decoded.
+func (qr *Rule) AddCommentTagCond(key, value string) {
+	if qr.commentTags == nil {
+		qr.commentTags = make(map[string]string)
+	}
+	qr.commentTags[key] = value
+}
+
+// SetMinEstimatedRowsCond adds a condition on the number of rows that MySQL
+// estimates the query examines, from the EXPLAIN of the query.
+func (qr *Rule) SetMinEstimatedRowsCond(rows int64) error {
+	if rows <= 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "min estimated rows must be positive: %d", rows)
+	}
+	qr.minEstimatedRows = rows
+	return nil
+}
+
 // SetLeadingCommentCond adds a regular expression condition for a leading query comment.
 func (qr *Rule) SetLeadingCommentCond(pattern string) (err error) {
 	qr.leadingComment.name = pattern
@@ -471,8 +694,12 @@ func (qr *Rule) FilterByPlan(query string, planid planbuilder.PlanType, tableNam
 	if !tableMatch(qr.tableNames, tableNames) {
 		return nil
 	}
+	if qr.queryDigest != "" && qr.queryDigest != sqlparser.QueryDigest(query) {
+		return nil
+	}
 	newqr = qr.Copy()
 	newqr.query = namedRegexp{}
+	newqr.queryDigest = ""
 	// Note we explicitly don't remove the leading/trailing comments as they
 	// must be evaluated at execution time.
 	newqr.plans = nil
@@ -480,7 +707,8 @@ func (qr *Rule) FilterByPlan(query string, planid planbuilder.PlanType, tableNam
 	return newqr
 }
 
-// GetAction returns the action for a single rule.
+// GetAction returns the action for a single rule. The condition on the
+// estimated rows is not evaluated, see Rules.FindRules.
 func (qr *Rule) GetAction(
 	ip,
 	user string,
@@ -514,6 +742,9 @@ func (qr *Rule) GetAction(
 			return QRContinue
 		}
 	}
+	if !commentTagsMatch(qr.commentTags, marginComments) {
+		return QRContinue
+	}
 	return qr.act
 }
 
@@ -549,6 +780,19 @@ func tableMatch(tableNames []string, otherNames []string) bool {
 	return false
 }
 
+func commentTagsMatch(commentTags map[string]string, marginComments sqlparser.MarginComments) bool {
+	if len(commentTags) == 0 {
+		return true
+	}
+	tags := parseCommentTags(marginComments.Leading + marginComments.Trailing)
+	for k, v := range commentTags {
+		if tv, ok := tags[k]; !ok || tv != v {
+			return false
+		}
+	}
+	return true
+}
+
 func bvMatch(bvcond BindVarCond, bindVars map[string]*querypb.BindVariable) bool {
 	bv, ok := bindVars[bvcond.name]
 	if !ok {
@@ -573,20 +817,38 @@ const (
 	QRFail
 	QRFailRetry
 	QRBuffer
+	QRThrottle
+	QRDelay
+	// QRLogOnly only logs the queries, and the next rules are evaluated.
+	QRLogOnly
 )
 
-// MarshalJSON marshals to JSON.
-func (act Action) MarshalJSON() ([]byte, error) {
-	// If we add more actions, we'll need to use a map.
-	var str string
+// String returns the name of the action, as used in the JSON representation.
+func (act Action) String() string {
 	switch act {
+	case QRContinue:
+		return "CONTINUE"
 	case QRFail:
-		str = "FAIL"
+		return "FAIL"
 	case QRFailRetry:
-		str = "FAIL_RETRY"
+		return "FAIL_RETRY"
 	case QRBuffer:
-		str = "BUFFER"
+		return "BUFFER"
+	case QRThrottle:
+		return "THROTTLE"
+	case QRDelay:
+		return "DELAY"
+	case QRLogOnly:
+		return "LOG_ONLY"
 	default:
+		return "INVALID"
+	}
+}
+
+// MarshalJSON marshals to JSON.
+func (act Action) MarshalJSON() ([]byte, error) {
+	str := act.String()
+	if act == QRContinue {
 		str = "INVALID"
 	}
 	return json.Marshal(str)
@@ -871,12 +1133,17 @@ func MapStrOperator(strop string) (op Operator, err error) {
 // BuildQueryRule builds a query rule from a ruleInfo.
 func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 	qr = NewQueryRule("", "", QRFail)
+	var maxQPS float64
+	var maxConcurrency int64
+	var delay time.Duration
 	for k, v := range ruleInfo {
 		var sv string
 		var lv []any
+		var mv map[string]any
+		var nv json.Number
 		var ok bool
 		switch k {
-		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment":
+		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment", "Delay", "QueryDigest":
 			sv, ok = v.(string)
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for %s", k)
@@ -886,6 +1153,16 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "CommentTags":
+			mv, ok = v.(map[string]any)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want json object for %s", k)
+			}
+		case "MaxQPS", "MaxConcurrency", "MinEstimatedRows":
+			nv, ok = v.(json.Number)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want number for %s", k)
+			}
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -958,9 +1235,74 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				qr.act = QRFailRetry
 			case "BUFFER":
 				qr.act = QRBuffer
+			case "THROTTLE":
+				qr.act = QRThrottle
+			case "DELAY":
+				qr.act = QRDelay
+			case "LOG_ONLY":
+				qr.act = QRLogOnly
 			default:
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
+		case "QueryDigest":
+			if err = qr.SetQueryDigestCond(sv); err != nil {
+				return nil, err
+			}
+		case "CommentTags":
+			for key, value := range mv {
+				tv, ok := value.(string)
+				if !ok {
+					return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for CommentTags value of %s", key)
+				}
+				qr.AddCommentTagCond(key, tv)
+			}
+		case "MinEstimatedRows":
+			rows, err := nv.Int64()
+			if err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want integer for MinEstimatedRows: %v", nv)
+			}
+			if err = qr.SetMinEstimatedRowsCond(rows); err != nil {
+				return nil, err
+			}
+		case "MaxQPS":
+			maxQPS, err = nv.Float64()
+			if err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want number for MaxQPS: %v", nv)
+			}
+		case "MaxConcurrency":
+			maxConcurrency, err = nv.Int64()
+			if err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want integer for MaxConcurrency: %v", nv)
+			}
+		case "Delay":
+			delay, err = time.ParseDuration(sv)
+			if err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Delay: %v", sv)
+			}
+		}
+	}
+
+	switch qr.act {
+	case QRThrottle:
+		if delay != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Delay is only valid with the DELAY action")
+		}
+		if err := qr.SetThrottle(maxQPS, int(maxConcurrency)); err != nil {
+			return nil, err
+		}
+	case QRDelay:
+		if maxQPS != 0 || maxConcurrency != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "MaxQPS and MaxConcurrency are only valid with the THROTTLE action")
+		}
+		if err := qr.SetDelay(delay); err != nil {
+			return nil, err
+		}
+	default:
+		if maxQPS != 0 || maxConcurrency != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "MaxQPS and MaxConcurrency are only valid with the THROTTLE action")
+		}
+		if delay != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Delay is only valid with the DELAY action")
 		}
 	}
 	return qr, nil
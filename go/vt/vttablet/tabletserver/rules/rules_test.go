@@ -18,7 +18,9 @@ package rules
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"regexp"
 	"strings"
@@ -26,7 +28,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -734,6 +738,24 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"QueryDigest": 1 }]`, "want string for QueryDigest"},
+	{`[{"QueryDigest": "abc" }]`, "invalid query digest: abc"},
+	{`[{"CommentTags": "application" }]`, "want json object for CommentTags"},
+	{`[{"CommentTags": {"application": 1} }]`, "want string for CommentTags value of application"},
+	{`[{"MinEstimatedRows": "1" }]`, "want number for MinEstimatedRows"},
+	{`[{"MinEstimatedRows": 1.5 }]`, "want integer for MinEstimatedRows: 1.5"},
+	{`[{"MinEstimatedRows": 0 }]`, "min estimated rows must be positive: 0"},
+	{`[{"MaxQPS": "1" }]`, "want number for MaxQPS"},
+	{`[{"MaxConcurrency": 1.5, "Action": "THROTTLE" }]`, "want integer for MaxConcurrency: 1.5"},
+	{`[{"Delay": 1 }]`, "want string for Delay"},
+	{`[{"Delay": "foo", "Action": "DELAY" }]`, "invalid Delay: foo"},
+	{`[{"Action": "THROTTLE" }]`, "invalid throttle limits: MaxQPS 0, MaxConcurrency 0"},
+	{`[{"Action": "THROTTLE", "MaxQPS": -1 }]`, "invalid throttle limits: MaxQPS -1, MaxConcurrency 0"},
+	{`[{"Action": "THROTTLE", "MaxQPS": 1, "Delay": "1s" }]`, "Delay is only valid with the DELAY action"},
+	{`[{"Action": "DELAY" }]`, "delay must be positive: 0s"},
+	{`[{"Action": "DELAY", "Delay": "1s", "MaxQPS": 1 }]`, "MaxQPS and MaxConcurrency are only valid with the THROTTLE action"},
+	{`[{"Action": "FAIL", "MaxConcurrency": 1 }]`, "MaxQPS and MaxConcurrency are only valid with the THROTTLE action"},
+	{`[{"Action": "FAIL", "Delay": "1s" }]`, "Delay is only valid with the DELAY action"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -812,3 +834,276 @@ func marshalled(in any) string {
 	}
 	return string(b)
 }
+
+func TestBuildQueryRuleThrottleAndDelay(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[{
+		"Name": "r1",
+		"Action": "THROTTLE",
+		"MaxQPS": 2.5,
+		"MaxConcurrency": 3
+	}, {
+		"Name": "r2",
+		"Action": "DELAY",
+		"Delay": "150ms"
+	}, {
+		"Name": "r3",
+		"Action": "LOG_ONLY"
+	}]`))
+	require.NoError(t, err)
+
+	throttle := NewQueryRule("", "r1", QRThrottle)
+	require.NoError(t, throttle.SetThrottle(2.5, 3))
+	delay := NewQueryRule("", "r2", QRDelay)
+	require.NoError(t, delay.SetDelay(150*time.Millisecond))
+	want := New()
+	want.Add(throttle)
+	want.Add(delay)
+	want.Add(NewQueryRule("", "r3", QRLogOnly))
+	assert.True(t, qrs.Equal(want))
+	assert.Equal(t, 150*time.Millisecond, qrs.Find("r2").Delay())
+
+	// The rules survive a round trip through JSON.
+	b, err := json.Marshal(qrs)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"Description":"","Name":"r1","Action":"THROTTLE","MaxQPS":2.5,"MaxConcurrency":3},`+
+		`{"Description":"","Name":"r2","Action":"DELAY","Delay":"150ms"},`+
+		`{"Description":"","Name":"r3","Action":"LOG_ONLY"}]`, string(b))
+	qrs2 := New()
+	require.NoError(t, qrs2.UnmarshalJSON(b))
+	assert.True(t, qrs2.Equal(qrs))
+
+	// The limits are part of the rule.
+	other := NewQueryRule("", "r1", QRThrottle)
+	require.NoError(t, other.SetThrottle(2.5, 4))
+	assert.False(t, throttle.Equal(other))
+}
+
+func TestFindRuleLogOnly(t *testing.T) {
+	logOnly := NewQueryRule("log", "log", QRLogOnly)
+	fail := NewQueryRule("fail", "fail", QRFail)
+	require.NoError(t, fail.SetUserCond("bad"))
+	qrs := New()
+	qrs.Add(logOnly)
+	qrs.Add(fail)
+
+	hits := stats.NewCountersWithMultiLabels("", "", []string{"Rule", "Action"})
+
+	// The LOG_ONLY rule does not stop the evaluation of the next rules.
+	assert.Nil(t, qrs.FindRules("", "good", nil, sqlparser.MarginComments{}, nil, hits))
+	assert.Equal(t, []*Rule{fail}, qrs.FindRules("", "bad", nil, sqlparser.MarginComments{}, nil, hits))
+	act, _, _, desc := qrs.GetAction("", "bad", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRFail, act)
+	assert.Equal(t, "fail", desc)
+
+	// GetAction does not count the hits.
+	assert.Equal(t, map[string]int64{"log.LOG_ONLY": 2, "fail.FAIL": 1}, hits.Counts())
+}
+
+func TestFindRuleBlockingFirst(t *testing.T) {
+	throttle := NewQueryRule("throttle", "throttle", QRThrottle)
+	require.NoError(t, throttle.SetThrottle(0, 1))
+	delay := NewQueryRule("delay", "delay", QRDelay)
+	require.NoError(t, delay.SetDelay(time.Second))
+	fail := NewQueryRule("fail", "fail", QRFail)
+	require.NoError(t, fail.SetUserCond("bad"))
+	qrs := New()
+	qrs.Add(throttle)
+	qrs.Add(delay)
+	qrs.Add(fail)
+
+	hits := stats.NewCountersWithMultiLabels("", "", []string{"Rule", "Action"})
+
+	// A rule that fails the query is applied even if a throttling rule comes first.
+	assert.Equal(t, []*Rule{fail}, qrs.FindRules("", "bad", nil, sqlparser.MarginComments{}, nil, hits))
+	act, _, _, _ := qrs.GetAction("", "bad", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRFail, act)
+
+	// Otherwise every throttling or delaying rule is applied.
+	assert.Equal(t, []*Rule{throttle, delay}, qrs.FindRules("", "good", nil, sqlparser.MarginComments{}, nil, hits))
+	assert.Equal(t, map[string]int64{"fail.FAIL": 1, "throttle.THROTTLE": 1, "delay.DELAY": 1}, hits.Counts())
+}
+
+func TestFindRulesTwoThrottles(t *testing.T) {
+	table := NewQueryRule("table", "table", QRThrottle)
+	require.NoError(t, table.SetThrottle(0, 1))
+	user := NewQueryRule("user", "user", QRThrottle)
+	require.NoError(t, user.SetThrottle(0, 2))
+	require.NoError(t, user.SetUserCond("batch"))
+	qrs := New()
+	qrs.Add(table)
+	qrs.Add(user)
+
+	// Both rules match the query, so both limits apply to it.
+	assert.Equal(t, []*Rule{table, user}, qrs.FindRules("", "batch", nil, sqlparser.MarginComments{}, nil, nil))
+	assert.Equal(t, []*Rule{table}, qrs.FindRules("", "web", nil, sqlparser.MarginComments{}, nil, nil))
+}
+
+func TestRuleThrottle(t *testing.T) {
+	qr := NewQueryRule("", "r1", QRThrottle)
+	require.NoError(t, qr.SetThrottle(0, 2))
+
+	// The copies of the rule share its limits.
+	release1, err := qr.Throttle(context.Background())
+	require.NoError(t, err)
+	release2, err := qr.Copy().Throttle(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = qr.Throttle(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release1()
+	release3, err := qr.Throttle(context.Background())
+	require.NoError(t, err)
+	release2()
+	release3()
+
+	// The rate is limited by a token bucket of one second.
+	require.NoError(t, qr.SetThrottle(2, 0))
+	for range 2 {
+		release, err := qr.Throttle(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = qr.Throttle(ctx)
+	assert.Error(t, err)
+}
+
+func TestQueryDigestCond(t *testing.T) {
+	query := "select * from a where id = :id"
+	digest := sqlparser.QueryDigest(query)
+
+	qr := NewQueryRule("digest", "r1", QRFail)
+	require.NoError(t, qr.SetQueryDigestCond(digest))
+	qrs := New()
+	qrs.Add(qr)
+
+	b, err := json.Marshal(qrs)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"Description":"digest","Name":"r1","QueryDigest":"`+digest+`","Action":"FAIL"}]`, string(b))
+	qrs2 := New()
+	require.NoError(t, qrs2.UnmarshalJSON(b))
+	assert.True(t, qrs2.Equal(qrs))
+
+	// The digest is matched when the plan is built, and is removed from the
+	// filtered rule.
+	b, err = json.Marshal(qrs.FilterByPlan("select * from a where id > :id", planbuilder.PlanSelect, "a"))
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(b))
+	filtered := qrs.FilterByPlan(query, planbuilder.PlanSelect, "a")
+	b, err = json.Marshal(filtered)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"Description":"digest","Name":"r1","Action":"FAIL"}]`, string(b))
+}
+
+func TestCommentTagsCond(t *testing.T) {
+	qr := NewQueryRule("orders", "r1", QRFail)
+	qr.AddCommentTagCond("application", "shop")
+	qr.AddCommentTagCond("route", "/orders/{id}")
+	qrs := New()
+	qrs.Add(qr)
+
+	b, err := json.Marshal(qrs)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"Description":"orders","Name":"r1","CommentTags":{"application":"shop","route":"/orders/{id}"},"Action":"FAIL"}]`, string(b))
+	qrs2 := New()
+	require.NoError(t, qrs2.UnmarshalJSON(b))
+	assert.True(t, qrs2.Equal(qrs))
+
+	testcases := []struct {
+		comments sqlparser.MarginComments
+		want     Action
+	}{{
+		comments: sqlparser.MarginComments{Trailing: " /*application='shop',route='%2Forders%2F%7Bid%7D'*/"},
+		want:     QRFail,
+	}, {
+		comments: sqlparser.MarginComments{Leading: "/* application='shop', framework='django', route='%2Forders%2F%7Bid%7D' */ "},
+		want:     QRFail,
+	}, {
+		comments: sqlparser.MarginComments{Leading: "/* route='%2Forders%2F%7Bid%7D' */ ", Trailing: " /*application='shop'*/"},
+		want:     QRFail,
+	}, {
+		comments: sqlparser.MarginComments{Trailing: " /*application='shop',route='%2Fcart'*/"},
+		want:     QRContinue,
+	}, {
+		comments: sqlparser.MarginComments{Trailing: " /*application='shop'*/"},
+		want:     QRContinue,
+	}, {
+		comments: sqlparser.MarginComments{Trailing: " /* application=shop route=/orders/{id} */"},
+		want:     QRContinue,
+	}, {
+		comments: sqlparser.MarginComments{},
+		want:     QRContinue,
+	}}
+	for _, tcase := range testcases {
+		act, _, _, _ := qrs.GetAction("", "", nil, tcase.comments)
+		assert.Equal(t, tcase.want, act, "%+v", tcase.comments)
+	}
+}
+
+func TestParseCommentTags(t *testing.T) {
+	testcases := []struct {
+		comments string
+		want     map[string]string
+	}{{
+		comments: "/*action='%2Fparam*d',controller='index,framework='spring'*/",
+		want:     nil,
+	}, {
+		comments: `/*db_driver='django.db.backends.postgresql',route='%5Epolls%2F%24',traceparent='00-5bd66ef5095369c7b0d1f8f4bd33716a-c532cb4098ac3dd2-01'*/`,
+		want: map[string]string{
+			"db_driver":   "django.db.backends.postgresql",
+			"route":       "^polls/$",
+			"traceparent": "00-5bd66ef5095369c7b0d1f8f4bd33716a-c532cb4098ac3dd2-01",
+		},
+	}, {
+		comments: `/* a='it\'s' */ /* not a tag */ /*b='1'*/`,
+		want:     map[string]string{"a": "it's", "b": "1"},
+	}, {
+		comments: "/* just a comment */",
+		want:     nil,
+	}}
+	for _, tcase := range testcases {
+		assert.Equal(t, tcase.want, parseCommentTags(tcase.comments), tcase.comments)
+	}
+}
+
+func TestMinEstimatedRowsCond(t *testing.T) {
+	big := NewQueryRule("big", "big", QRFail)
+	require.NoError(t, big.SetMinEstimatedRowsCond(1000))
+	bigger := NewQueryRule("bigger", "bigger", QRFailRetry)
+	require.NoError(t, bigger.SetMinEstimatedRowsCond(10))
+	qrs := New()
+	qrs.Add(big)
+	qrs.Add(bigger)
+
+	b, err := json.Marshal(qrs)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"Description":"big","Name":"big","MinEstimatedRows":1000,"Action":"FAIL"},{"Description":"bigger","Name":"bigger","MinEstimatedRows":10,"Action":"FAIL_RETRY"}]`, string(b))
+	qrs2 := New()
+	require.NoError(t, qrs2.UnmarshalJSON(b))
+	assert.True(t, qrs2.Equal(qrs))
+
+	var calls int
+	estimator := func(rows int64, err error) RowEstimator {
+		return func() (int64, error) {
+			calls++
+			return rows, err
+		}
+	}
+	find := func(estimateRows RowEstimator) []*Rule {
+		return qrs.FindRules("", "", nil, sqlparser.MarginComments{}, estimateRows, nil)
+	}
+	assert.Equal(t, []*Rule{big}, find(estimator(5000, nil)))
+	assert.Equal(t, []*Rule{bigger}, find(estimator(100, nil)))
+	assert.Nil(t, find(estimator(5, nil)))
+	// The rows are only estimated once per query.
+	assert.Equal(t, 3, calls)
+
+	// The rules don't fire if the rows can't be estimated.
+	assert.Nil(t, find(estimator(0, errors.New("explain failed"))))
+	assert.Nil(t, find(nil))
+}
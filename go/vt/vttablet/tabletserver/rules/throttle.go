@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"context"
+	"math"
+
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// ruleThrottle limits the rate and the concurrency of the queries that
+// trigger a THROTTLE rule. The rate is limited with a token bucket that
+// holds up to one second of queries.
+type ruleThrottle struct {
+	maxQPS         float64
+	maxConcurrency int
+
+	limiter *rate.Limiter
+	slots   *semaphore.Weighted
+}
+
+func newRuleThrottle(maxQPS float64, maxConcurrency int) *ruleThrottle {
+	t := &ruleThrottle{maxQPS: maxQPS, maxConcurrency: maxConcurrency}
+	if maxQPS > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(maxQPS), max(1, int(math.Ceil(maxQPS))))
+	}
+	if maxConcurrency > 0 {
+		t.slots = semaphore.NewWeighted(int64(maxConcurrency))
+	}
+	return t
+}
+
+// equal returns true if the throttles have the same limits.
+func (t *ruleThrottle) equal(other *ruleThrottle) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.maxQPS == other.maxQPS && t.maxConcurrency == other.maxConcurrency
+}
+
+// acquire waits until the query is allowed by the limits of the throttle, or
+// until the context is done. The returned function must be called once the
+// query is done.
+func (t *ruleThrottle) acquire(ctx context.Context) (release func(), err error) {
+	if t.limiter != nil {
+		// Wait fails right away if the context expires before a token is available.
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if t.slots == nil {
+		return func() {}, nil
+	}
+	if err := t.slots.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { t.slots.Release(1) }, nil
+}
@@ -48,6 +48,7 @@ func LoadTable(conn *connpool.PooledConn, databaseName, tableName, tableType str
 		if err := loadMessageInfo(ta, comment, collationEnv); err != nil {
 			return nil, err
 		}
+		checkDeadLetterTable(ta, conn, databaseName)
 		ta.Type = Message
 	case strings.Contains(tableType, tmutils.TableView):
 		ta.Type = View
@@ -68,6 +69,29 @@ func fetchColumns(ta *Table, conn *connpool.PooledConn, databaseName, sqlTableNa
 	return nil
 }
 
+// checkDeadLetterTable checks that the dead letter table of the message table
+// exists and has all of its columns, since the messages are moved to it with
+// an insert ... select of those columns. A bad dead letter table doesn't fail
+// the load, so that it doesn't prevent the rest of the schema from loading.
+// Instead, it is recorded in DeadLetterTableError.
+func checkDeadLetterTable(ta *Table, conn *connpool.PooledConn, databaseName string) {
+	mi := ta.MessageInfo
+	if mi.DeadLetterTable == "" {
+		return
+	}
+	dlq := NewTable(mi.DeadLetterTable, NoType)
+	if err := fetchColumns(dlq, conn, databaseName, sqlparser.String(dlq.Name)); err != nil {
+		mi.DeadLetterTableError = fmt.Sprintf("cannot read dead letter table %s: %v", mi.DeadLetterTable, err)
+		return
+	}
+	for _, field := range ta.Fields {
+		if dlq.FindColumn(sqlparser.NewIdentifierCI(field.Name)) < 0 {
+			mi.DeadLetterTableError = fmt.Sprintf("dead letter table %s has no column %s", mi.DeadLetterTable, field.Name)
+			return
+		}
+	}
+}
+
 func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environment) error {
 	ta.MessageInfo = &MessageInfo{}
 	// Extract keyvalues.
@@ -107,6 +131,27 @@ func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environ
 
 	ta.MessageInfo.MaxBackoff, _ = getDuration(keyvals, "vt_max_backoff")
 
+	// vt_max_attempts and vt_dead_letter_table are optional, but they must be
+	// valid if specified. Without vt_max_attempts, messages are sent until
+	// they're acked, so a dead letter table would never be used.
+	if keyvals["vt_max_attempts"] != "" {
+		if ta.MessageInfo.MaxAttempts, err = getNum(keyvals, "vt_max_attempts"); err != nil {
+			return err
+		}
+		if ta.MessageInfo.MaxAttempts <= 0 {
+			return fmt.Errorf("vt_max_attempts must be positive: %s", ta.Name.String())
+		}
+	}
+	ta.MessageInfo.DeadLetterTable = strings.TrimSpace(keyvals["vt_dead_letter_table"])
+	if ta.MessageInfo.DeadLetterTable != "" {
+		if ta.MessageInfo.MaxAttempts == 0 {
+			return fmt.Errorf("vt_dead_letter_table requires vt_max_attempts: %s", ta.Name.String())
+		}
+		if ta.MessageInfo.DeadLetterTable == ta.Name.String() {
+			return fmt.Errorf("vt_dead_letter_table must be a different table: %s", ta.Name.String())
+		}
+	}
+
 	// these columns are required for message manager to function properly, but only
 	// id is required to be streamed to subscribers
 	requiredCols := []string{
@@ -161,6 +161,58 @@ func TestLoadTableMessage(t *testing.T) {
 	want.MessageInfo.MaxBackoff = 100 * time.Second
 	assert.Equal(t, want, table)
 
+	// Test loading max attempts and dead letter table
+	dlqFields := []*querypb.Field{{
+		Name: "id",
+		Type: sqltypes.Int64,
+	}, {
+		Name: "priority",
+		Type: sqltypes.Int64,
+	}, {
+		Name: "time_next",
+		Type: sqltypes.Int64,
+	}, {
+		Name: "epoch",
+		Type: sqltypes.Int64,
+	}, {
+		Name: "time_acked",
+		Type: sqltypes.Int64,
+	}, {
+		Name: "message",
+		Type: sqltypes.VarBinary,
+	}, {
+		Name: "time_dead_lettered",
+		Type: sqltypes.Int64,
+	}}
+	db.MockQueriesForTable("dead_letters", &sqltypes.Result{Fields: dlqFields})
+	table, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_min_backoff=10,vt_max_backoff=100,vt_max_attempts=5,vt_dead_letter_table=dead_letters", db)
+	require.NoError(t, err)
+	want.MessageInfo.MaxAttempts = 5
+	want.MessageInfo.DeadLetterTable = "dead_letters"
+	assert.Equal(t, want, table)
+
+	// A dead letter table that can't hold the messages is flagged,
+	// but doesn't fail the load.
+	db.MockQueriesForTable("dead_letters", &sqltypes.Result{Fields: dlqFields[:5]})
+	table, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_min_backoff=10,vt_max_backoff=100,vt_max_attempts=5,vt_dead_letter_table=dead_letters", db)
+	require.NoError(t, err)
+	assert.Equal(t, "dead letter table dead_letters has no column message", table.MessageInfo.DeadLetterTableError)
+
+	table, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_min_backoff=10,vt_max_backoff=100,vt_max_attempts=5,vt_dead_letter_table=missing_letters", db)
+	require.NoError(t, err)
+	assert.Contains(t, table.MessageInfo.DeadLetterTableError, "cannot read dead letter table missing_letters")
+	want.MessageInfo.MaxAttempts = 0
+	want.MessageInfo.DeadLetterTable = ""
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=0", db)
+	require.EqualError(t, err, "vt_max_attempts must be positive: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_dead_letter_table=test_table_dlq", db)
+	require.EqualError(t, err, "vt_dead_letter_table requires vt_max_attempts: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=5,vt_dead_letter_table=test_table", db)
+	require.EqualError(t, err, "vt_dead_letter_table must be a different table: test_table")
+
 	//
 	// multiple tests for vt_message_cols
 	//
@@ -126,12 +126,29 @@ type MessageInfo struct {
 	// should wait before rescheduling a message
 	MaxBackoff time.Duration
 
+	// MaxAttempts specifies how many times a message is sent
+	// without being acked before it's dead-lettered. Zero
+	// means that messages are sent until they're acked.
+	MaxAttempts int
+
+	// DeadLetterTable specifies the table that dead-lettered
+	// messages are moved to. If empty, dead-lettered messages
+	// are discarded.
+	DeadLetterTable string
+
+	// DeadLetterTableError is set if the dead letter table
+	// does not exist, or lacks some of the columns of the
+	// message table, when the message table is loaded. The
+	// messages are still sent, and moving them to the dead
+	// letter table is retried until it succeeds.
+	DeadLetterTableError string
+
 	// IDType specifies the type of the ID column
 	IDType sqltypes.Type
 }
 
 func (mi *MessageInfo) String() string {
-	return fmt.Sprintf("MessageInfo: AckWaitDuration: %v, PurgeAfterDuration: %v, BatchSize: %v, CacheSize: %v, PollInterval: %v, MinBackoff: %v, MaxBackoff: %v, IDType: %v", mi.AckWaitDuration, mi.PurgeAfterDuration, mi.BatchSize, mi.CacheSize, mi.PollInterval, mi.MinBackoff, mi.MaxBackoff, mi.IDType)
+	return fmt.Sprintf("MessageInfo: AckWaitDuration: %v, PurgeAfterDuration: %v, BatchSize: %v, CacheSize: %v, PollInterval: %v, MinBackoff: %v, MaxBackoff: %v, MaxAttempts: %v, DeadLetterTable: %v, DeadLetterTableError: %v, IDType: %v", mi.AckWaitDuration, mi.PurgeAfterDuration, mi.BatchSize, mi.CacheSize, mi.PollInterval, mi.MinBackoff, mi.MaxBackoff, mi.MaxAttempts, mi.DeadLetterTable, mi.DeadLetterTableError, mi.IDType)
 }
 
 // NewTable creates a new Table.
@@ -50,6 +50,10 @@ type StatefulConnection struct {
 	enforceTimeout bool
 	timeout        time.Duration
 	expiryTime     time.Time
+	// releaseWorkload releases the slot of the workload class of the
+	// transaction when the connection is released. It can be called more
+	// than once.
+	releaseWorkload func()
 }
 
 // Properties contains meta information about the connection
@@ -171,6 +175,9 @@ func (sc *StatefulConnection) Releasef(reasonFormat string, a ...any) {
 // ReleaseString is used when the connection will not be used ever again.
 // The underlying dbConn is removed so that this connection cannot be used by mistake.
 func (sc *StatefulConnection) ReleaseString(reason string) {
+	if sc.releaseWorkload != nil {
+		sc.releaseWorkload()
+	}
 	if sc.dbConn == nil {
 		return
 	}
@@ -112,6 +112,30 @@ func (t *TxThrottlerConfigFlag) Set(arg string) error {
 
 func (t *TxThrottlerConfigFlag) Type() string { return "string" }
 
+// workloadClassesFlag parses the workload classes from a JSON list.
+type workloadClassesFlag struct {
+	classes *[]WorkloadClassConfig
+}
+
+func (f workloadClassesFlag) String() string {
+	if f.classes == nil || len(*f.classes) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(*f.classes)
+	return string(data)
+}
+
+func (f workloadClassesFlag) Set(arg string) error {
+	var classes []WorkloadClassConfig
+	if err := json.Unmarshal([]byte(arg), &classes); err != nil {
+		return err
+	}
+	*f.classes = classes
+	return nil
+}
+
+func (f workloadClassesFlag) Type() string { return "string" }
+
 // RegisterTabletEnvFlags is a public API to register tabletenv flags for use by test cases that expect
 // some flags to be set with default values
 func RegisterTabletEnvFlags(fs *pflag.FlagSet) {
@@ -148,6 +172,7 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&currentConfig.OlapReadPool.MaxIdleCount, "queryserver-config-stream-pool-max-idle-count", defaultConfig.OlapReadPool.MaxIdleCount, "query server stream pool - maximum number of idle connections to retain in the pool. Use this to balance between faster response times during traffic bursts and resource efficiency during low-traffic periods.")
 	fs.IntVar(&currentConfig.TxPool.MaxIdleCount, "queryserver-config-txpool-max-idle-count", defaultConfig.TxPool.MaxIdleCount, "query server transaction pool - maximum number of idle connections to retain in the pool. Use this to balance between faster response times during traffic bursts and resource efficiency during low-traffic periods.")
 	fs.DurationVar(&currentConfig.OltpReadPool.IdleTimeout, "queryserver-config-idle-timeout", defaultConfig.OltpReadPool.IdleTimeout, "query server idle timeout, vttablet manages various mysql connection pools. This config means if a connection has not been used in given idle timeout, this connection will be removed from pool. This effectively manages number of connection objects and optimize the pool performance.")
+	fs.Var(workloadClassesFlag{&currentConfig.WorkloadClasses}, "queryserver-config-workload-classes", `query server workload classes, a JSON list of classes like [{"name":"batch","weight":1,"reserved":0.1,"users":["etl"],"workloadNames":["nightly"],"workloads":["OLAP"]}]. The requests of a class are selected by their caller ID, their WORKLOAD_NAME query directive or the workload of their session. Every class can only use its reserved fraction of the query, stream and transaction pools, plus the unreserved connections that it shares with the other classes according to its weight.`)
 	fs.DurationVar(&currentConfig.OltpReadPool.MaxLifetime, "queryserver-config-pool-conn-max-lifetime", defaultConfig.OltpReadPool.MaxLifetime, "query server connection max lifetime, vttablet manages various mysql connection pools. This config means if a connection has lived at least this long, it connection will be removed from pool upon the next time it is returned to the pool.")
 
 	// tableacl related configurations.
@@ -175,6 +200,12 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&currentConfig.TxThrottlerDryRun, "tx-throttler-dry-run", defaultConfig.TxThrottlerDryRun, "If present, the transaction throttler only records metrics about requests received and throttled, but does not actually throttle any requests.")
 	fs.DurationVar(&currentConfig.TxThrottlerTopoRefreshInterval, "tx-throttler-topo-refresh-interval", time.Minute*5, "The rate that the transaction throttler will refresh the topology to find cells.")
 
+	// Admission control config
+	fs.BoolVar(&currentConfig.AdmissionControl.Enable, "enable-admission-control", defaultConfig.AdmissionControl.Enable, "If true, the queries that are not part of a transaction are shed while the tablet throttler metrics of the tablet exceed their thresholds. The queries are shed with a probability of their priority divided by 100, so that queries with priority 0 are never shed. The priority of the queries that lack priority information is --tx-throttler-default-priority.")
+	fs.BoolVar(&currentConfig.AdmissionControl.DryRun, "admission-control-dry-run", defaultConfig.AdmissionControl.DryRun, "If present, the admission control only records metrics about the queries that it would shed, but does not actually shed any queries.")
+	flagutil.StringListVar(fs, &currentConfig.AdmissionControl.Metrics, "admission-control-metrics", defaultConfig.AdmissionControl.Metrics, "A comma-separated list of the tablet throttler metrics of the tablet that the admission control checks against their thresholds.")
+	fs.DurationVar(&currentConfig.AdmissionControl.CheckInterval, "admission-control-check-interval", defaultConfig.AdmissionControl.CheckInterval, "How often the admission control checks the tablet throttler metrics.")
+
 	fs.BoolVar(&enableHotRowProtection, "enable_hot_row_protection", false, "If true, incoming transactions for the same row (range) will be queued and cannot consume all txpool slots.")
 	fs.BoolVar(&enableHotRowProtectionDryRun, "enable_hot_row_protection_dry_run", false, "If true, hot row protection is not enforced but logs if transactions would have been queued.")
 	fs.IntVar(&currentConfig.HotRowProtection.MaxQueueSize, "hot_row_protection_max_queue_size", defaultConfig.HotRowProtection.MaxQueueSize, "Maximum number of BeginExecute RPCs which will be queued for the same row (range).")
@@ -324,6 +355,8 @@ type TabletConfig struct {
 
 	ReplicationTracker ReplicationTrackerConfig `json:"replicationTracker,omitempty"`
 
+	WorkloadClasses []WorkloadClassConfig `json:"workloadClasses,omitempty"`
+
 	// Consolidator can be enable, disable, or notOnPrimary. Default is enable.
 	Consolidator                string        `json:"consolidator,omitempty"`
 	PassthroughDML              bool          `json:"passthroughDML,omitempty"`
@@ -360,6 +393,8 @@ type TabletConfig struct {
 	TxThrottlerTopoRefreshInterval time.Duration                 `json:"-"`
 	TxThrottlerDryRun              bool                          `json:"-"`
 
+	AdmissionControl AdmissionControlConfig `json:"-"`
+
 	EnableTableGC bool `json:"-"` // can be turned off programmatically by tests
 
 	TransactionLimitConfig `json:"-"`
@@ -839,6 +874,39 @@ type TransactionLimitConfig struct {
 	TransactionLimitBySubcomponent bool
 }
 
+// AdmissionControlConfig contains the config of the admission control, which
+// sheds the low priority queries while the tablet is overloaded.
+type AdmissionControlConfig struct {
+	Enable bool
+	DryRun bool
+	// Metrics are the tablet throttler metrics that are checked against their
+	// thresholds, in the self scope.
+	Metrics       []string
+	CheckInterval time.Duration
+}
+
+// DefaultWorkloadClass is the name of the workload class of the requests
+// that are not selected by any of the configured classes. It can be
+// configured like the other classes to change its weight and reservation.
+const DefaultWorkloadClass = "default"
+
+// WorkloadClassConfig contains the config of a workload class. The requests
+// of a class are selected by the username of their immediate caller ID or the
+// principal of their effective caller ID, then by their WORKLOAD_NAME query
+// directive, and then by the workload of their session.
+type WorkloadClassConfig struct {
+	Name string `json:"name"`
+	// Weight is the share of the unreserved connections of the class,
+	// relative to the other classes, when the pools are contended.
+	Weight int `json:"weight,omitempty"`
+	// Reserved is the fraction of every pool that only the class can use.
+	Reserved float64 `json:"reserved,omitempty"`
+
+	Users         []string `json:"users,omitempty"`
+	WorkloadNames []string `json:"workloadNames,omitempty"`
+	Workloads     []string `json:"workloads,omitempty"`
+}
+
 // RowStreamerConfig contains configuration parameters for a vstreamer (source) that is
 // copying the contents of a table to a target
 type RowStreamerConfig struct {
@@ -901,6 +969,12 @@ func (c *TabletConfig) Verify() error {
 	if err := c.verifyTxThrottlerConfig(); err != nil {
 		return err
 	}
+	if err := c.verifyWorkloadClasses(); err != nil {
+		return err
+	}
+	if err := c.verifyAdmissionControlConfig(); err != nil {
+		return err
+	}
 	if v := c.HotRowProtection.MaxQueueSize; v <= 0 {
 		return fmt.Errorf("--hot_row_protection_max_queue_size must be > 0 (specified value: %v)", v)
 	}
@@ -990,6 +1064,70 @@ func (c *TabletConfig) verifyTransactionLimitConfig() error {
 	return nil
 }
 
+// verifyWorkloadClasses checks the workload classes for sanity.
+func (c *TabletConfig) verifyWorkloadClasses() error {
+	names := make(map[string]bool)
+	selectors := make(map[string]string)
+	reserved := 0.0
+	for _, class := range c.WorkloadClasses {
+		if class.Name == "" {
+			return errors.New("--queryserver-config-workload-classes: every workload class must have a name")
+		}
+		if names[class.Name] {
+			return fmt.Errorf("--queryserver-config-workload-classes: duplicate workload class %s", class.Name)
+		}
+		names[class.Name] = true
+		if class.Weight < 0 {
+			return fmt.Errorf("--queryserver-config-workload-classes: weight of workload class %s must be >= 0 (specified value: %v)", class.Name, class.Weight)
+		}
+		if class.Reserved < 0 || class.Reserved >= 1 {
+			return fmt.Errorf("--queryserver-config-workload-classes: reserved fraction of workload class %s should be within range [0, 1) (specified value: %v)", class.Name, class.Reserved)
+		}
+		reserved += class.Reserved
+
+		var classSelectors []string
+		for _, user := range class.Users {
+			classSelectors = append(classSelectors, "user "+user)
+		}
+		for _, name := range class.WorkloadNames {
+			classSelectors = append(classSelectors, "workload name "+name)
+		}
+		for _, workload := range class.Workloads {
+			if _, ok := querypb.ExecuteOptions_Workload_value[workload]; !ok {
+				return fmt.Errorf("--queryserver-config-workload-classes: invalid workload %s of workload class %s", workload, class.Name)
+			}
+			classSelectors = append(classSelectors, "workload "+workload)
+		}
+		for _, selector := range classSelectors {
+			if other, ok := selectors[selector]; ok {
+				return fmt.Errorf("--queryserver-config-workload-classes: %s selects both workload classes %s and %s", selector, other, class.Name)
+			}
+			selectors[selector] = class.Name
+		}
+	}
+	if reserved >= 1 {
+		return fmt.Errorf("--queryserver-config-workload-classes: the reserved fractions of the workload classes must add up to less than 1 (total: %v)", reserved)
+	}
+	return nil
+}
+
+// verifyAdmissionControlConfig checks the admission control config for sanity.
+func (c *TabletConfig) verifyAdmissionControlConfig() error {
+	if !c.AdmissionControl.Enable {
+		return nil
+	}
+	if len(c.AdmissionControl.Metrics) == 0 {
+		return errors.New("--admission-control-metrics must be defined when admission control is enabled")
+	}
+	if v := c.AdmissionControl.CheckInterval; v <= 0 {
+		return fmt.Errorf("--admission-control-check-interval must be > 0 (specified value: %v)", v)
+	}
+	if v := c.TxThrottlerDefaultPriority; v > sqlparser.MaxPriorityValue || v < 0 {
+		return fmt.Errorf("--tx-throttler-default-priority must be >= 0 and <= %d (specified value: %d)", sqlparser.MaxPriorityValue, v)
+	}
+	return nil
+}
+
 // verifyTxThrottlerConfig checks the TxThrottler related config for sanity.
 func (c *TabletConfig) verifyTxThrottlerConfig() error {
 	if !c.EnableTxThrottler {
@@ -1099,6 +1237,11 @@ var defaultConfig = TabletConfig{
 	TxThrottlerDryRun:              false,
 	TxThrottlerTopoRefreshInterval: time.Minute * 5,
 
+	AdmissionControl: AdmissionControlConfig{
+		Metrics:       []string{"lag", "threads_running", "history_list_length"},
+		CheckInterval: 250 * time.Millisecond,
+	},
+
 	TransactionLimitConfig: defaultTransactionLimitConfig(),
 
 	EnforceStrictTransTables: true,
@@ -358,6 +358,116 @@ func TestTxThrottlerConfigFlag(t *testing.T) {
 	}
 }
 
+func TestWorkloadClassesFlag(t *testing.T) {
+	var classes []WorkloadClassConfig
+	f := workloadClassesFlag{&classes}
+	assert.Equal(t, "", f.String())
+	assert.Equal(t, "string", f.Type())
+
+	require.NoError(t, f.Set(`[{"name":"batch","weight":2,"reserved":0.25,"users":["etl"],"workloadNames":["nightly"],"workloads":["OLAP"]}]`))
+	assert.Equal(t, []WorkloadClassConfig{{
+		Name:          "batch",
+		Weight:        2,
+		Reserved:      0.25,
+		Users:         []string{"etl"},
+		WorkloadNames: []string{"nightly"},
+		Workloads:     []string{"OLAP"},
+	}}, classes)
+	assert.Equal(t, `[{"name":"batch","weight":2,"reserved":0.25,"users":["etl"],"workloadNames":["nightly"],"workloads":["OLAP"]}]`, f.String())
+
+	assert.Error(t, f.Set("should not parse"))
+}
+
+func TestVerifyWorkloadClasses(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []WorkloadClassConfig
+		wantErr string
+	}{{
+		name: "none",
+	}, {
+		name: "valid",
+		classes: []WorkloadClassConfig{
+			{Name: "batch", Weight: 1, Reserved: 0.2, Users: []string{"etl"}, Workloads: []string{"OLAP"}},
+			{Name: "default", Weight: 4, Reserved: 0.5},
+		},
+	}, {
+		name:    "missing name",
+		classes: []WorkloadClassConfig{{Weight: 1}},
+		wantErr: "every workload class must have a name",
+	}, {
+		name:    "duplicate name",
+		classes: []WorkloadClassConfig{{Name: "a"}, {Name: "a"}},
+		wantErr: "duplicate workload class a",
+	}, {
+		name:    "negative weight",
+		classes: []WorkloadClassConfig{{Name: "a", Weight: -1}},
+		wantErr: "weight of workload class a must be >= 0",
+	}, {
+		name:    "invalid reserved",
+		classes: []WorkloadClassConfig{{Name: "a", Reserved: 1}},
+		wantErr: "reserved fraction of workload class a should be within range [0, 1)",
+	}, {
+		name:    "too much reserved",
+		classes: []WorkloadClassConfig{{Name: "a", Reserved: 0.5}, {Name: "b", Reserved: 0.5}},
+		wantErr: "the reserved fractions of the workload classes must add up to less than 1",
+	}, {
+		name:    "invalid workload",
+		classes: []WorkloadClassConfig{{Name: "a", Workloads: []string{"batch"}}},
+		wantErr: "invalid workload batch of workload class a",
+	}, {
+		name:    "ambiguous selector",
+		classes: []WorkloadClassConfig{{Name: "a", Users: []string{"etl"}}, {Name: "b", Users: []string{"etl"}}},
+		wantErr: "user etl selects both workload classes a and b",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := defaultConfig
+			config.WorkloadClasses = test.classes
+			err := config.verifyWorkloadClasses()
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyAdmissionControlConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AdmissionControlConfig
+		wantErr string
+	}{{
+		name:   "disabled",
+		config: AdmissionControlConfig{},
+	}, {
+		name:   "enabled",
+		config: AdmissionControlConfig{Enable: true, Metrics: []string{"lag"}, CheckInterval: time.Second},
+	}, {
+		name:    "no metrics",
+		config:  AdmissionControlConfig{Enable: true, CheckInterval: time.Second},
+		wantErr: "--admission-control-metrics must be defined when admission control is enabled",
+	}, {
+		name:    "invalid interval",
+		config:  AdmissionControlConfig{Enable: true, Metrics: []string{"lag"}},
+		wantErr: "--admission-control-check-interval must be > 0 (specified value: 0s)",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := defaultConfig
+			config.AdmissionControl = test.config
+			err := config.verifyAdmissionControlConfig()
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
 func TestVerifyTxThrottlerConfig(t *testing.T) {
 	defaultMaxReplicationLagModuleConfig := throttler.DefaultMaxReplicationLagModuleConfig().Configuration
 	invalidMaxReplicationLagModuleConfig := throttler.DefaultMaxReplicationLagModuleConfig().Configuration
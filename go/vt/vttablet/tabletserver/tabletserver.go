@@ -35,6 +35,7 @@ import (
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/pools/smartconnpool"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/streamlog"
@@ -50,6 +51,7 @@ import (
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
@@ -73,6 +75,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txserializer"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txthrottler"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/workload"
 )
 
 // logPoolFull is for throttling transaction / query pool full messages in the log.
@@ -182,6 +185,7 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	tsv.tracker = schema.NewTracker(tsv, tsv.vstreamer, tsv.se)
 	tsv.watcher = NewBinlogWatcher(tsv, tsv.vstreamer, tsv.config)
 	tsv.qe = NewQueryEngine(tsv, tsv.se)
+	tsv.qe.admission.throttler = tsv.lagThrottler
 	tsv.txThrottler = txthrottler.NewTxThrottler(tsv, topoServer)
 	tsv.te = NewTxEngine(tsv, tsv.hs.sendUnresolvedTransactionSignal)
 	tsv.messager = messager.NewEngine(tsv, tsv.se, tsv.vstreamer)
@@ -232,6 +236,7 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	tsv.registerTwopczHandler()
 	tsv.registerThrottlerHandlers()
 	tsv.registerDebugEnvHandler()
+	tsv.registerWorkloadsHandler()
 
 	return tsv
 }
@@ -916,6 +921,18 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 			}
 			logStats.ReservedID = reservedID
 			logStats.TransactionID = transactionID
+			if connID == 0 {
+				if err := tsv.qe.admission.admit(ctx, plan.PlanID, tsv.getPriorityFromOptions(options), options.GetWorkloadName()); err != nil {
+					return err
+				}
+				if usesWorkloadSlot(plan.PlanID) {
+					release, err := acquireWorkload(ctx, tsv.qe.workloads, options, logStats)
+					if err != nil {
+						return err
+					}
+					defer release()
+				}
+			}
 
 			var connSetting *smartconnpool.Setting
 			if len(settings) > 0 {
@@ -966,6 +983,28 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 	return result, err
 }
 
+// acquireWorkload waits until the workload class of the request can use a
+// connection of the pool of the scheduler. The wait is recorded in the log stats.
+func acquireWorkload(ctx context.Context, scheduler *workload.Scheduler, options *querypb.ExecuteOptions, logStats *tabletenv.LogStats) (func(), error) {
+	defer func(start time.Time) {
+		logStats.WaitingForConnection += time.Since(start)
+	}(time.Now())
+	return scheduler.Acquire(ctx, options)
+}
+
+// usesWorkloadSlot returns whether a query of the plan that is not part of a
+// transaction waits for a slot of its workload class in the query or stream pool.
+// The autocommitted DMLs take their slot in the transaction pool instead, and
+// the plans that only change the session or read metadata don't need one, so
+// that every query takes at most one slot.
+func usesWorkloadSlot(planID planbuilder.PlanType) bool {
+	switch planID {
+	case planbuilder.PlanSelect, planbuilder.PlanSelectStream, planbuilder.PlanCallProc:
+		return true
+	}
+	return false
+}
+
 // smallerTimeout returns the smaller of the two timeouts.
 // 0 is treated as infinity.
 func smallerTimeout(t1, t2 time.Duration) time.Duration {
@@ -1022,6 +1061,18 @@ func (tsv *TabletServer) streamExecute(ctx context.Context, target *querypb.Targ
 			}
 			logStats.ReservedID = reservedID
 			logStats.TransactionID = transactionID
+			if connID == 0 {
+				if err := tsv.qe.admission.admit(ctx, plan.PlanID, tsv.getPriorityFromOptions(options), options.GetWorkloadName()); err != nil {
+					return err
+				}
+				if usesWorkloadSlot(plan.PlanID) {
+					release, err := acquireWorkload(ctx, tsv.qe.streamWorkloads, options, logStats)
+					if err != nil {
+						return err
+					}
+					defer release()
+				}
+			}
 
 			var connSetting *smartconnpool.Setting
 			if len(settings) > 0 {
@@ -1194,7 +1245,10 @@ func (tsv *TabletServer) MessageStream(ctx context.Context, target *querypb.Targ
 
 // MessageAck acks the list of messages for a given message table.
 // It returns the number of messages successfully acked.
-func (tsv *TabletServer) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+// If visibilityTimeout is set, the messages are not acked. Instead,
+// they are sent again once the timeout expires, and the number of
+// messages successfully postponed is returned.
+func (tsv *TabletServer) MessageAck(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value, visibilityTimeout *vttimepb.Duration) (count int64, err error) {
 	sids := make([]string, 0, len(ids))
 	for _, val := range ids {
 		sids = append(sids, sqltypes.ProtoToValue(val).ToString())
@@ -1203,9 +1257,27 @@ func (tsv *TabletServer) MessageAck(ctx context.Context, target *querypb.Target,
 	if err != nil {
 		return 0, err
 	}
-	count, err = tsv.execDML(ctx, target, func() (string, map[string]*querypb.BindVariable, error) {
+	if visibilityTimeout != nil {
+		timeout, _, err := protoutil.DurationFromProto(visibilityTimeout)
+		if err != nil {
+			return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid visibility timeout: %v", err)
+		}
+		if timeout < 0 {
+			return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "visibility timeout must not be negative: %v", timeout)
+		}
+		count, err = tsv.execDML(ctx, target, func() ([]string, map[string]*querypb.BindVariable, error) {
+			query, bv := querygen.GenerateVisibilityQuery(sids, timeout)
+			return []string{query}, bv, nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		messager.MessageStats.Add([]string{name, "VisibilityChanged"}, count)
+		return count, nil
+	}
+	count, err = tsv.execDML(ctx, target, func() ([]string, map[string]*querypb.BindVariable, error) {
 		query, bv := querygen.GenerateAckQuery(sids)
-		return query, bv, nil
+		return []string{query}, bv, nil
 	})
 	if err != nil {
 		return 0, err
@@ -1217,29 +1289,42 @@ func (tsv *TabletServer) MessageAck(ctx context.Context, target *querypb.Target,
 // PostponeMessages postpones the list of messages for a given message table.
 // It returns the number of messages successfully postponed.
 func (tsv *TabletServer) PostponeMessages(ctx context.Context, target *querypb.Target, querygen messager.QueryGenerator, ids []string) (count int64, err error) {
-	return tsv.execDML(ctx, target, func() (string, map[string]*querypb.BindVariable, error) {
+	return tsv.execDML(ctx, target, func() ([]string, map[string]*querypb.BindVariable, error) {
 		query, bv := querygen.GeneratePostponeQuery(ids)
-		return query, bv, nil
+		return []string{query}, bv, nil
 	})
 }
 
 // PurgeMessages purges messages older than specified time in Unix Nanoseconds.
 // It purges at most 500 messages. It returns the number of messages successfully purged.
 func (tsv *TabletServer) PurgeMessages(ctx context.Context, target *querypb.Target, querygen messager.QueryGenerator, timeCutoff int64) (count int64, err error) {
-	return tsv.execDML(ctx, target, func() (string, map[string]*querypb.BindVariable, error) {
+	return tsv.execDML(ctx, target, func() ([]string, map[string]*querypb.BindVariable, error) {
 		query, bv := querygen.GeneratePurgeQuery(timeCutoff)
-		return query, bv, nil
+		return []string{query}, bv, nil
+	})
+}
+
+// DeadLetterMessages moves the list of messages that reached the maximum
+// number of attempts to the dead letter table, or discards them if the
+// message table has none. It returns the number of messages successfully
+// dead-lettered.
+func (tsv *TabletServer) DeadLetterMessages(ctx context.Context, target *querypb.Target, querygen messager.QueryGenerator, ids []string) (count int64, err error) {
+	return tsv.execDML(ctx, target, func() ([]string, map[string]*querypb.BindVariable, error) {
+		queries, bv := querygen.GenerateDeadLetterQueries(ids)
+		return queries, bv, nil
 	})
 }
 
-func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, queryGenerator func() (string, map[string]*querypb.BindVariable, error)) (count int64, err error) {
+// execDML executes the generated queries in a single transaction. It returns
+// the number of rows affected by the last query.
+func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, queryGenerator func() ([]string, map[string]*querypb.BindVariable, error)) (count int64, err error) {
 	if err = tsv.sm.StartRequest(ctx, target, false /* allowOnShutdown */); err != nil {
 		return 0, err
 	}
 	defer tsv.sm.EndRequest()
 	defer tsv.handlePanicAndSendLogStats("ack", nil, nil)
 
-	query, bv, err := queryGenerator()
+	queries, bv, err := queryGenerator()
 	if err != nil {
 		return 0, err
 	}
@@ -1255,16 +1340,19 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 			tsv.Rollback(ctx, target, state.TransactionID)
 		}
 	}()
-	qr, err := tsv.Execute(ctx, target, query, bv, state.TransactionID, 0, nil)
-	if err != nil {
-		return 0, err
+	for _, query := range queries {
+		qr, err := tsv.Execute(ctx, target, query, bv, state.TransactionID, 0, nil)
+		if err != nil {
+			return 0, err
+		}
+		count = int64(qr.RowsAffected)
 	}
 	if _, err = tsv.Commit(ctx, target, state.TransactionID); err != nil {
 		state.TransactionID = 0
 		return 0, err
 	}
 	state.TransactionID = 0
-	return int64(qr.RowsAffected), nil
+	return count, nil
 }
 
 // VStream streams VReplication events.
@@ -1956,6 +2044,12 @@ func (tsv *TabletServer) registerThrottlerHandlers() {
 	tsv.registerThrottlerCheckHandlers()
 }
 
+func (tsv *TabletServer) registerWorkloadsHandler() {
+	tsv.exporter.HandleFunc("/debug/workloads", func(w http.ResponseWriter, r *http.Request) {
+		workloadsHandler([]*workload.Scheduler{tsv.qe.workloads, tsv.qe.streamWorkloads, tsv.te.txPool.workloads}, w, r)
+	})
+}
+
 func (tsv *TabletServer) registerDebugEnvHandler() {
 	tsv.exporter.HandleFunc("/debug/env", func(w http.ResponseWriter, r *http.Request) {
 		debugEnvHandler(tsv, w, r)
@@ -1985,7 +2079,11 @@ func (tsv *TabletServer) SetPoolSize(ctx context.Context, val int) error {
 	if val <= 0 {
 		return nil
 	}
-	return tsv.qe.conns.SetCapacity(ctx, int64(val))
+	if err := tsv.qe.conns.SetCapacity(ctx, int64(val)); err != nil {
+		return err
+	}
+	tsv.qe.workloads.SetCapacity(val)
+	return nil
 }
 
 // PoolSize returns the pool size.
@@ -1995,7 +2093,11 @@ func (tsv *TabletServer) PoolSize() int {
 
 // SetStreamPoolSize changes the pool size to the specified value.
 func (tsv *TabletServer) SetStreamPoolSize(ctx context.Context, val int) error {
-	return tsv.qe.streamConns.SetCapacity(ctx, int64(val))
+	if err := tsv.qe.streamConns.SetCapacity(ctx, int64(val)); err != nil {
+		return err
+	}
+	tsv.qe.streamWorkloads.SetCapacity(val)
+	return nil
 }
 
 // SetStreamConsolidationBlocking sets whether the stream consolidator should wait for slow clients
@@ -2014,7 +2116,11 @@ func (tsv *TabletServer) SetTxPoolSize(ctx context.Context, val int) error {
 	if err := tsv.te.txPool.scp.conns.SetCapacity(ctx, int64(val)); err != nil {
 		return err
 	}
-	return tsv.te.txPool.scp.foundRowsPool.SetCapacity(ctx, int64(val))
+	if err := tsv.te.txPool.scp.foundRowsPool.SetCapacity(ctx, int64(val)); err != nil {
+		return err
+	}
+	tsv.te.txPool.workloads.SetCapacity(val)
+	return nil
 }
 
 // TxPoolSize returns the tx pool size.
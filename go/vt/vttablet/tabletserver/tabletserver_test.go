@@ -18,6 +18,7 @@ package tabletserver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -55,10 +56,12 @@ import (
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/workload"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 func TestTabletServerHealthz(t *testing.T) {
@@ -478,6 +481,126 @@ func TestTabletServerBeginFail(t *testing.T) {
 	require.EqualError(t, err, "transaction pool aborting request due to already expired context", "Begin err")
 }
 
+func TestTabletServerWorkloadClasses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.TxPool.Size = 2
+	cfg.TxPool.Timeout = 10 * time.Millisecond
+	cfg.WorkloadClasses = []tabletenv.WorkloadClassConfig{
+		{Name: "batch", Users: []string{"batch"}},
+		{Name: "interactive", Reserved: 0.5, Users: []string{"web"}},
+	}
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	batchCtx := callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("batch"))
+	webCtx := callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("web"))
+
+	// The batch class can only use the unreserved connection.
+	state, err := tsv.Begin(batchCtx, &target, nil)
+	require.NoError(t, err)
+	_, err = tsv.Begin(batchCtx, &target, nil)
+	require.ErrorContains(t, err, "TransactionPool: workload class batch timed out waiting for a connection")
+
+	// The interactive class still gets its reserved connection.
+	webState, err := tsv.Begin(webCtx, &target, nil)
+	require.NoError(t, err)
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/debug/workloads?format=json", nil)
+	workloadsHandler([]*workload.Scheduler{tsv.te.txPool.workloads}, resp, req)
+	var rows []workloadsRow
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &rows))
+	assert.Equal(t, []workloadsRow{
+		{Pool: "TransactionPool", ClassStatus: workload.ClassStatus{Name: "batch", Weight: 1, InUse: 1}},
+		{Pool: "TransactionPool", ClassStatus: workload.ClassStatus{Name: "interactive", Weight: 1, Reserved: 1, InUse: 1}},
+		{Pool: "TransactionPool", ClassStatus: workload.ClassStatus{Name: "default", Weight: 1}},
+	}, rows)
+
+	// The connection of the batch class is released with its transaction.
+	_, err = tsv.Commit(ctx, &target, state.TransactionID)
+	require.NoError(t, err)
+	state, err = tsv.Begin(batchCtx, &target, nil)
+	require.NoError(t, err)
+	_, err = tsv.Rollback(ctx, &target, state.TransactionID)
+	require.NoError(t, err)
+	_, err = tsv.Rollback(ctx, &target, webState.TransactionID)
+	require.NoError(t, err)
+}
+
+func TestTabletServerWorkloadSlots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.OltpReadPool.Size = 1
+	cfg.OltpReadPool.Timeout = 10 * time.Millisecond
+	cfg.WorkloadClasses = []tabletenv.WorkloadClassConfig{{Name: "batch", Users: []string{"batch"}}}
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	updateSQL := "update test_table set `name` = 2 where pk = 1"
+	db.AddQuery(updateSQL+" limit 10001", &sqltypes.Result{})
+	showSQL := "show tables"
+	db.AddQuery(showSQL, &sqltypes.Result{})
+	selectSQL := "select * from test_table limit 10001"
+	db.AddQuery(selectSQL, &sqltypes.Result{})
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	batchCtx := callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("batch"))
+
+	// The only slot of the query pool is in use.
+	release, err := tsv.qe.workloads.Acquire(batchCtx, nil)
+	require.NoError(t, err)
+
+	// An autocommitted DML only takes a slot of the transaction pool, and the
+	// reads of metadata don't take any.
+	_, err = tsv.Execute(batchCtx, &target, updateSQL, nil, 0, 0, nil)
+	require.NoError(t, err)
+	_, err = tsv.Execute(batchCtx, &target, showSQL, nil, 0, 0, nil)
+	require.NoError(t, err)
+	_, err = tsv.Execute(batchCtx, &target, selectSQL, nil, 0, 0, nil)
+	require.ErrorContains(t, err, "ConnPool: workload class batch timed out waiting for a connection")
+	assert.Equal(t, 0, tsv.te.txPool.workloads.Classes()[0].InUse)
+
+	release()
+	_, err = tsv.Execute(batchCtx, &target, selectSQL, nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, tsv.qe.workloads.Classes()[0].InUse)
+}
+
+func TestTabletServerAdmissionControl(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.AdmissionControl.Enable = true
+	cfg.AdmissionControl.CheckInterval = time.Hour
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+	tsv.qe.admission.throttler = &fakeAdmissionThrottler{exceeded: true}
+
+	executeSQL := "select * from test_table limit 1000"
+	db.AddQuery(executeSQL, &sqltypes.Result{})
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	options := &querypb.ExecuteOptions{Priority: "100"}
+
+	// The queries that are not part of a transaction are shed.
+	_, err := tsv.Execute(ctx, &target, executeSQL, nil, 0, 0, options)
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+
+	// The queries of a transaction are not, including the one that begins it.
+	state, _, err := tsv.BeginExecute(ctx, &target, nil, executeSQL, nil, 0, options)
+	require.NoError(t, err)
+	_, err = tsv.Execute(ctx, &target, executeSQL, nil, state.TransactionID, 0, options)
+	require.NoError(t, err)
+	_, err = tsv.Rollback(ctx, &target, state.TransactionID)
+	require.NoError(t, err)
+}
+
 func TestTabletServerCommitTransaction(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1590,18 +1713,40 @@ func TestMessageAck(t *testing.T) {
 		Type:  sqltypes.VarChar,
 		Value: []byte("2"),
 	}}
-	_, err := tsv.MessageAck(ctx, &target, "nonmsg", ids)
+	_, err := tsv.MessageAck(ctx, &target, "nonmsg", ids, nil)
 	want := "message table nonmsg not found in schema"
 	require.Error(t, err)
 	require.Contains(t, err.Error(), want)
 
-	_, err = tsv.MessageAck(ctx, &target, "msg", ids)
+	_, err = tsv.MessageAck(ctx, &target, "msg", ids, nil)
 	want = "query: 'update msg set time_acked"
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), want)
 
 	db.AddQueryPattern("update msg set time_acked = .*", &sqltypes.Result{RowsAffected: 1})
-	count, err := tsv.MessageAck(ctx, &target, "msg", ids)
+	count, err := tsv.MessageAck(ctx, &target, "msg", ids, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+}
+
+func TestMessageAckVisibilityTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+
+	ids := []*querypb.Value{{
+		Type:  sqltypes.VarChar,
+		Value: []byte("1"),
+	}}
+	_, err := tsv.MessageAck(ctx, &target, "msg", ids, &vttimepb.Duration{Seconds: -1})
+	require.EqualError(t, err, "visibility timeout must not be negative: -1s")
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+
+	// The messages are postponed instead of acked.
+	db.AddQueryPattern("update msg set time_next = .* where id in .* and time_acked is null.*", &sqltypes.Result{RowsAffected: 1})
+	count, err := tsv.MessageAck(ctx, &target, "msg", ids, &vttimepb.Duration{Seconds: 30})
 	require.NoError(t, err)
 	require.EqualValues(t, 1, count)
 }
@@ -1657,6 +1802,27 @@ func TestPurgeMessages(t *testing.T) {
 	require.EqualValues(t, 1, count)
 }
 
+func TestDeadLetterMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+
+	gen, err := tsv.messager.GetGenerator("msg")
+	require.NoError(t, err)
+
+	// The message table has no dead letter table, so the messages are discarded.
+	_, err = tsv.DeadLetterMessages(ctx, &target, gen, []string{"1", "2"})
+	want := "query: 'update msg set time_acked"
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), want)
+	db.AddQueryPattern("update msg set time_acked = .*, time_next = null where id in .* and time_acked is null and epoch >= .*", &sqltypes.Result{RowsAffected: 2})
+	count, err := tsv.DeadLetterMessages(ctx, &target, gen, []string{"1", "2"})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
+
 func TestHandleExecUnknownError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -77,6 +77,8 @@ const (
 	MessagerName      Name = "messager"
 	SchemaTrackerName Name = "schema-tracker"
 
+	AdmissionControlName Name = "admission-control"
+
 	TestingName                Name = "test"
 	TestingAlwaysThrottledName Name = "always-throttled-app"
 )
@@ -34,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txlimiter"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/workload"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -68,6 +69,8 @@ type (
 		scp     *StatefulConnectionPool
 		ticks   *timer.Timer
 		limiter txlimiter.TxLimiter
+		// workloads schedules the workload classes on the pool.
+		workloads *workload.Scheduler
 
 		logMu   sync.Mutex
 		lastLog time.Time
@@ -79,11 +82,12 @@ type (
 func NewTxPool(env tabletenv.Env, limiter txlimiter.TxLimiter) *TxPool {
 	config := env.Config()
 	axp := &TxPool{
-		env:     env,
-		scp:     NewStatefulConnPool(env),
-		ticks:   timer.NewTimer(txKillerTimeoutInterval(config)),
-		limiter: limiter,
-		txStats: env.Exporter().NewTimings("Transactions", "Transaction stats", "operation"),
+		env:       env,
+		scp:       NewStatefulConnPool(env),
+		ticks:     timer.NewTimer(txKillerTimeoutInterval(config)),
+		limiter:   limiter,
+		workloads: workload.NewScheduler(env, "TransactionPool", config.TxPool),
+		txStats:   env.Exporter().NewTimings("Transactions", "Transaction stats", "operation"),
 	}
 	// Careful: conns also exports name+"xxx" vars,
 	// but we know it doesn't export Timeout.
@@ -249,14 +253,24 @@ func (tp *TxPool) Begin(ctx context.Context, options *querypb.ExecuteOptions, re
 		if !tp.limiter.Get(immediateCaller, effectiveCaller) {
 			return nil, "", "", vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "per-user transaction pool connection limit exceeded")
 		}
+		var releaseWorkload func()
+		releaseWorkload, err = tp.workloads.Acquire(ctx, options)
+		if err != nil {
+			tp.limiter.Release(immediateCaller, effectiveCaller)
+			return nil, "", "", err
+		}
 		conn, err = tp.createConn(ctx, options, setting)
 		defer func() {
 			if err != nil {
 				// The transaction limiter frees transactions on rollback or commit. If we fail to create the transaction,
 				// release immediately since there will be no rollback or commit.
 				tp.limiter.Release(immediateCaller, effectiveCaller)
+				releaseWorkload()
 			}
 		}()
+		if err == nil {
+			conn.releaseWorkload = releaseWorkload
+		}
 	}
 	if err != nil {
 		return nil, "", "", err
@@ -31,6 +31,7 @@ import (
 
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/fakesqldb"
@@ -409,6 +410,28 @@ func TestTxPoolCloseKillsStrayTransactions(t *testing.T) {
 	require.Equal(t, 0, txPool.scp.Capacity())
 }
 
+func TestTxPoolCloseReleasesWorkloadSlots(t *testing.T) {
+	env := newEnv("TabletServerTest")
+	env.Config().WorkloadClasses = []tabletenv.WorkloadClassConfig{
+		{Name: "batch", Users: []string{"batch"}},
+	}
+	_, txPool, _, closer := setupWithEnv(t, env)
+
+	ctx := callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID("batch"))
+	conn, _, _, err := txPool.Begin(ctx, &querypb.ExecuteOptions{}, false, 0, nil)
+	require.NoError(t, err)
+	conn.Unlock()
+	classes := txPool.workloads.Classes()
+	require.Equal(t, "batch", classes[0].Name)
+	require.Equal(t, 1, classes[0].InUse)
+
+	// Closing the pool kills the open transaction, and releases its slot.
+	closer()
+	for _, class := range txPool.workloads.Classes() {
+		assert.Equal(t, 0, class.InUse, class.Name)
+	}
+}
+
 func TestTxTimeoutKillsTransactions(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
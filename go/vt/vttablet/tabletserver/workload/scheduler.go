@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workload schedules the requests of the workload classes on the
+// connection pools of the tabletserver.
+package workload
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Scheduler admits the requests of the workload classes to a connection pool.
+//
+// Every class can use the connections that it reserves, plus the unreserved
+// connections of the pool, which are shared by all the classes. When the
+// pool is contended, the waiting requests are admitted by start-time fair
+// queueing: every class has a virtual time that is advanced by the inverse of
+// its weight for every admitted request, and the class with the lowest
+// virtual time that can use a connection goes first. The requests of a class
+// are admitted in order.
+//
+// If no workload classes are configured, the scheduler admits every request.
+type Scheduler struct {
+	name    string
+	timeout time.Duration
+
+	mu       sync.Mutex
+	capacity int
+	inUse    int
+	// vtime is the virtual time of the scheduler, which is the start time of
+	// the last admitted request. The classes that start waiting are moved up
+	// to it, so that the idle classes do not accumulate credit.
+	vtime float64
+
+	classes        []*class
+	defaultClass   *class
+	byUser         map[string]*class
+	byWorkloadName map[string]*class
+	byWorkload     map[string]*class
+
+	acquires *stats.CountersWithSingleLabel
+	timeouts *stats.CountersWithSingleLabel
+	waits    *servenv.TimingsWrapper
+}
+
+type class struct {
+	name     string
+	weight   float64
+	reserved float64
+
+	// reservedSlots is the number of connections reserved for the class,
+	// which depends on the capacity of the pool.
+	reservedSlots int
+	inUse         int
+	vtime         float64
+	waiters       []*waiter
+}
+
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// ClassStatus is the status of a workload class of a scheduler.
+type ClassStatus struct {
+	Name     string
+	Weight   int
+	Reserved int
+	InUse    int
+	Waiting  int
+}
+
+// NewScheduler creates a scheduler for the pool with the given name and
+// config. The requests wait for a connection up to the timeout of the pool,
+// if it has one, or until their context is done.
+func NewScheduler(env tabletenv.Env, name string, pool tabletenv.ConnPoolConfig) *Scheduler {
+	config := env.Config()
+	s := &Scheduler{
+		name:     name,
+		timeout:  pool.Timeout,
+		capacity: pool.Size,
+	}
+	if len(config.WorkloadClasses) == 0 {
+		return s
+	}
+
+	s.byUser = make(map[string]*class)
+	s.byWorkloadName = make(map[string]*class)
+	s.byWorkload = make(map[string]*class)
+	for _, cfg := range config.WorkloadClasses {
+		c := &class{name: cfg.Name, weight: float64(max(cfg.Weight, 1)), reserved: cfg.Reserved}
+		s.classes = append(s.classes, c)
+		if cfg.Name == tabletenv.DefaultWorkloadClass {
+			s.defaultClass = c
+		}
+		for _, user := range cfg.Users {
+			s.byUser[user] = c
+		}
+		for _, name := range cfg.WorkloadNames {
+			s.byWorkloadName[name] = c
+		}
+		for _, workload := range cfg.Workloads {
+			s.byWorkload[workload] = c
+		}
+	}
+	if s.defaultClass == nil {
+		s.defaultClass = &class{name: tabletenv.DefaultWorkloadClass, weight: 1}
+		s.classes = append(s.classes, s.defaultClass)
+	}
+	s.setReservedSlotsLocked()
+
+	s.acquires = env.Exporter().NewCountersWithSingleLabel(name+"WorkloadAcquires", "Connections acquired by workload class", "Class")
+	s.timeouts = env.Exporter().NewCountersWithSingleLabel(name+"WorkloadTimeouts", "Requests that timed out waiting for a connection by workload class", "Class")
+	s.waits = env.Exporter().NewTimings(name+"WorkloadWaits", "Time spent waiting for a connection by workload class", "Class")
+	env.Exporter().NewGaugesFuncWithMultiLabels(name+"WorkloadInUse", "Connections in use by workload class", []string{"Class"}, func() map[string]int64 {
+		return s.gauge(func(c *class) int { return c.inUse })
+	})
+	env.Exporter().NewGaugesFuncWithMultiLabels(name+"WorkloadWaiting", "Requests waiting for a connection by workload class", []string{"Class"}, func() map[string]int64 {
+		return s.gauge(func(c *class) int { return len(c.waiters) })
+	})
+	return s
+}
+
+// Name returns the name of the pool of the scheduler.
+func (s *Scheduler) Name() string {
+	return s.name
+}
+
+// Enabled returns true if workload classes are configured.
+func (s *Scheduler) Enabled() bool {
+	return len(s.classes) > 0
+}
+
+// Acquire waits until the workload class of the request can use a connection
+// of the pool. The class is selected by the caller ID of the context, and by
+// the workload name and the workload of the options. The returned function
+// must be called once the request no longer uses the connection.
+func (s *Scheduler) Acquire(ctx context.Context, options *querypb.ExecuteOptions) (release func(), err error) {
+	if !s.Enabled() {
+		return func() {}, nil
+	}
+	c := s.classify(ctx, options)
+	start := time.Now()
+
+	s.mu.Lock()
+	if len(c.waiters) == 0 && s.canGrantLocked(c) {
+		s.grantLocked(c)
+		s.mu.Unlock()
+		return s.admitted(c, start), nil
+	}
+	w := &waiter{ready: make(chan struct{})}
+	if len(c.waiters) == 0 {
+		c.vtime = max(c.vtime, s.vtime)
+	}
+	c.waiters = append(c.waiters, w)
+	s.mu.Unlock()
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		s.mu.Lock()
+		granted := w.granted
+		if !granted {
+			c.waiters = slices.DeleteFunc(c.waiters, func(other *waiter) bool { return other == w })
+		}
+		s.mu.Unlock()
+		if !granted {
+			s.timeouts.Add(c.name, 1)
+			return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "%s: workload class %s timed out waiting for a connection: %v", s.name, c.name, ctx.Err())
+		}
+	}
+	return s.admitted(c, start), nil
+}
+
+// SetCapacity changes the capacity of the pool, and the number of connections
+// reserved for every class accordingly.
+func (s *Scheduler) SetCapacity(capacity int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.capacity = capacity
+	s.setReservedSlotsLocked()
+	s.dispatchLocked()
+}
+
+// Classes returns the status of the workload classes.
+func (s *Scheduler) Classes() []ClassStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]ClassStatus, 0, len(s.classes))
+	for _, c := range s.classes {
+		statuses = append(statuses, ClassStatus{
+			Name:     c.name,
+			Weight:   int(c.weight),
+			Reserved: c.reservedSlots,
+			InUse:    c.inUse,
+			Waiting:  len(c.waiters),
+		})
+	}
+	return statuses
+}
+
+// classify returns the workload class of the request.
+func (s *Scheduler) classify(ctx context.Context, options *querypb.ExecuteOptions) *class {
+	if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil {
+		if c, ok := s.byUser[callerid.GetUsername(im)]; ok {
+			return c
+		}
+	}
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil {
+		if c, ok := s.byUser[callerid.GetPrincipal(ef)]; ok {
+			return c
+		}
+	}
+	if options != nil {
+		if c, ok := s.byWorkloadName[options.WorkloadName]; ok {
+			return c
+		}
+		if c, ok := s.byWorkload[options.Workload.String()]; ok {
+			return c
+		}
+	}
+	return s.defaultClass
+}
+
+// admitted records the stats of an admitted request, and returns the function
+// that releases its connection.
+func (s *Scheduler) admitted(c *class, start time.Time) func() {
+	s.acquires.Add(c.name, 1)
+	s.waits.Record(c.name, start)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			c.inUse--
+			s.inUse--
+			s.dispatchLocked()
+		})
+	}
+}
+
+// canGrantLocked returns true if the class can use one more connection,
+// either one of its reserved connections or a shared one.
+func (s *Scheduler) canGrantLocked(c *class) bool {
+	if s.inUse >= s.capacity {
+		return false
+	}
+	if c.inUse < c.reservedSlots {
+		return true
+	}
+	shared, sharedInUse := s.capacity, 0
+	for _, other := range s.classes {
+		shared -= other.reservedSlots
+		sharedInUse += max(other.inUse-other.reservedSlots, 0)
+	}
+	return sharedInUse < shared
+}
+
+func (s *Scheduler) grantLocked(c *class) {
+	start := max(c.vtime, s.vtime)
+	c.vtime = start + 1/c.weight
+	s.vtime = start
+	c.inUse++
+	s.inUse++
+}
+
+// dispatchLocked admits the waiting requests while there are connections for
+// them, in the order of the virtual times of their classes.
+func (s *Scheduler) dispatchLocked() {
+	for {
+		var next *class
+		for _, c := range s.classes {
+			if len(c.waiters) == 0 || !s.canGrantLocked(c) {
+				continue
+			}
+			if next == nil || max(c.vtime, s.vtime) < max(next.vtime, s.vtime) {
+				next = c
+			}
+		}
+		if next == nil {
+			return
+		}
+		w := next.waiters[0]
+		next.waiters = next.waiters[1:]
+		s.grantLocked(next)
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+func (s *Scheduler) setReservedSlotsLocked() {
+	for _, c := range s.classes {
+		c.reservedSlots = int(c.reserved * float64(s.capacity))
+	}
+}
+
+func (s *Scheduler) gauge(f func(c *class) int) map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make(map[string]int64, len(s.classes))
+	for _, c := range s.classes {
+		values[c.name] = int64(f(c))
+	}
+	return values
+}
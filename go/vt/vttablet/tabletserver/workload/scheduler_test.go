@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func newTestScheduler(t *testing.T, size int, classes ...tabletenv.WorkloadClassConfig) *Scheduler {
+	t.Helper()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.WorkloadClasses = classes
+	require.NoError(t, cfg.Verify())
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "WorkloadSchedulerTest")
+	return NewScheduler(env, "ConnPool", tabletenv.ConnPoolConfig{Size: size})
+}
+
+func userContext(user string) context.Context {
+	return callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID(user))
+}
+
+// acquireAsync acquires a connection in the background, and returns the
+// channel that receives its release function.
+func acquireAsync(t *testing.T, s *Scheduler, ctx context.Context, options *querypb.ExecuteOptions) chan func() {
+	queued := func() int {
+		n := 0
+		for _, c := range s.Classes() {
+			n += c.Waiting
+		}
+		return n
+	}
+	before := queued()
+	ch := make(chan func(), 1)
+	go func() {
+		release, err := s.Acquire(ctx, options)
+		if err != nil {
+			close(ch)
+			return
+		}
+		ch <- release
+	}()
+	// Wait until the request is queued.
+	require.Eventually(t, func() bool {
+		return queued() > before
+	}, 5*time.Second, time.Millisecond)
+	return ch
+}
+
+func waiting(s *Scheduler) map[string]int {
+	counts := make(map[string]int)
+	for _, c := range s.Classes() {
+		counts[c.Name] = c.Waiting
+	}
+	return counts
+}
+
+func TestSchedulerDisabled(t *testing.T) {
+	s := newTestScheduler(t, 1)
+	assert.False(t, s.Enabled())
+	for range 3 {
+		_, err := s.Acquire(context.Background(), nil)
+		require.NoError(t, err)
+	}
+	assert.Empty(t, s.Classes())
+}
+
+func TestSchedulerClassify(t *testing.T) {
+	s := newTestScheduler(t, 10,
+		tabletenv.WorkloadClassConfig{Name: "etl", Users: []string{"etl-user"}},
+		tabletenv.WorkloadClassConfig{Name: "reports", WorkloadNames: []string{"nightly"}},
+		tabletenv.WorkloadClassConfig{Name: "olap", Workloads: []string{"OLAP"}},
+	)
+	for _, tcase := range []struct {
+		ctx     context.Context
+		options *querypb.ExecuteOptions
+		want    string
+	}{{
+		ctx:  userContext("etl-user"),
+		want: "etl",
+	}, {
+		ctx:  callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("etl-user", "", ""), nil),
+		want: "etl",
+	}, {
+		// The caller ID takes precedence over the options.
+		ctx:     userContext("etl-user"),
+		options: &querypb.ExecuteOptions{WorkloadName: "nightly", Workload: querypb.ExecuteOptions_OLAP},
+		want:    "etl",
+	}, {
+		ctx:     userContext("app"),
+		options: &querypb.ExecuteOptions{WorkloadName: "nightly", Workload: querypb.ExecuteOptions_OLAP},
+		want:    "reports",
+	}, {
+		ctx:     userContext("app"),
+		options: &querypb.ExecuteOptions{Workload: querypb.ExecuteOptions_OLAP},
+		want:    "olap",
+	}, {
+		ctx:     userContext("app"),
+		options: &querypb.ExecuteOptions{WorkloadName: "other"},
+		want:    tabletenv.DefaultWorkloadClass,
+	}, {
+		ctx:  context.Background(),
+		want: tabletenv.DefaultWorkloadClass,
+	}} {
+		assert.Equal(t, tcase.want, s.classify(tcase.ctx, tcase.options).name)
+	}
+}
+
+func TestSchedulerReservation(t *testing.T) {
+	s := newTestScheduler(t, 4,
+		tabletenv.WorkloadClassConfig{Name: "interactive", Reserved: 0.5, Users: []string{"web"}},
+	)
+
+	// The default class can only use the unreserved connections.
+	for range 2 {
+		_, err := s.Acquire(userContext("batch"), nil)
+		require.NoError(t, err)
+	}
+	ch := acquireAsync(t, s, userContext("batch"), nil)
+	assert.Equal(t, map[string]int{"interactive": 0, "default": 1}, waiting(s))
+
+	// The reserved connections are still available to the interactive class.
+	var releases []func()
+	for range 2 {
+		release, err := s.Acquire(userContext("web"), nil)
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+
+	// A released reserved connection goes back to its class.
+	ch2 := acquireAsync(t, s, userContext("web"), nil)
+	releases[0]()
+	release := <-ch2
+	require.NotNil(t, release)
+	assert.Equal(t, map[string]int{"interactive": 0, "default": 1}, waiting(s))
+	// Releasing twice has no effect.
+	releases[0]()
+	assert.Equal(t, map[string]int{"interactive": 0, "default": 1}, waiting(s))
+
+	// Once the capacity is increased, the default class gets a connection.
+	s.SetCapacity(6)
+	require.NotNil(t, <-ch)
+}
+
+func TestSchedulerWeights(t *testing.T) {
+	s := newTestScheduler(t, 1,
+		tabletenv.WorkloadClassConfig{Name: "heavy", Weight: 3, Users: []string{"heavy"}},
+		tabletenv.WorkloadClassConfig{Name: "light", Weight: 1, Users: []string{"light"}},
+	)
+	release, err := s.Acquire(userContext("heavy"), nil)
+	require.NoError(t, err)
+
+	var heavy, light []chan func()
+	for range 6 {
+		heavy = append(heavy, acquireAsync(t, s, userContext("heavy"), nil))
+	}
+	for range 6 {
+		light = append(light, acquireAsync(t, s, userContext("light"), nil))
+	}
+
+	// The heavy class gets three connections for every connection of the light
+	// class while both are waiting.
+	var order []string
+	for range 8 {
+		release()
+		select {
+		case release = <-heavy[0]:
+			heavy = heavy[1:]
+			order = append(order, "heavy")
+		case release = <-light[0]:
+			light = light[1:]
+			order = append(order, "light")
+		}
+	}
+	assert.Equal(t, []string{"light", "heavy", "heavy", "heavy", "light", "heavy", "heavy", "heavy"}, order)
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.WorkloadClasses = []tabletenv.WorkloadClassConfig{{Name: "batch", Users: []string{"batch"}}}
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "WorkloadSchedulerTest")
+	s := NewScheduler(env, "ConnPool", tabletenv.ConnPoolConfig{Size: 1, Timeout: 10 * time.Millisecond})
+	timeouts := s.timeouts.Counts()["batch"]
+
+	release, err := s.Acquire(userContext("batch"), nil)
+	require.NoError(t, err)
+	_, err = s.Acquire(userContext("batch"), nil)
+	require.ErrorContains(t, err, "ConnPool: workload class batch timed out waiting for a connection")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.Equal(t, timeouts+1, s.timeouts.Counts()["batch"])
+
+	// The context of the request also limits how long it waits.
+	ctx, cancel := context.WithCancel(userContext("batch"))
+	cancel()
+	_, err = s.Acquire(ctx, nil)
+	require.ErrorContains(t, err, "context canceled")
+	assert.Equal(t, map[string]int{"batch": 0, "default": 0}, waiting(s))
+
+	release()
+	release, err = s.Acquire(userContext("batch"), nil)
+	require.NoError(t, err)
+	release()
+}